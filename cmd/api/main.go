@@ -1,9 +1,39 @@
 package main
 
 import (
+	"context"
 	"log"
 	"github.com/nihar-hegde/valtro-backend/internal/database"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	alertRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/alert"
+	anomalyRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/anomaly"
+	archiveRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/archive"
+	exportRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/export"
+	incidentRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/incident"
+	legalHoldRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/legalhold"
+	monitorRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/monitor"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	purgeRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/purge"
+	savedSearchRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/savedsearch"
+	silenceRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/silence"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
 	"github.com/nihar-hegde/valtro-backend/internal/server"
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/services/anomaly"
+	"github.com/nihar-hegde/valtro-backend/internal/services/archive"
+	"github.com/nihar-hegde/valtro-backend/internal/services/export"
+	"github.com/nihar-hegde/valtro-backend/internal/services/incident"
+	"github.com/nihar-hegde/valtro-backend/internal/services/legalhold"
+	"github.com/nihar-hegde/valtro-backend/internal/services/monitor"
+	"github.com/nihar-hegde/valtro-backend/internal/services/notification"
+	"github.com/nihar-hegde/valtro-backend/internal/services/purge"
+	"github.com/nihar-hegde/valtro-backend/internal/services/retention"
+	"github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/services/search"
+	"github.com/nihar-hegde/valtro-backend/internal/services/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
 
 	"github.com/joho/godotenv"
 )
@@ -21,8 +51,69 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Configure the object store for release artifacts
+	// A misconfigured store only disables the features that need it, not the whole API
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		log.Printf("Object storage unavailable, artifact uploads and symbolication are disabled: %v", err)
+		store = storage.NewUnavailableStore(err)
+	}
+
+	// Configure where ingested log events are stored
+	logStore, err := logstore.NewFromEnv(db)
+	if err != nil {
+		log.Fatalf("Failed to open log store: %v", err)
+	}
+
+	// Start the background job that permanently deletes expired soft-deleted records
+	purgeService := purge.NewService(purgeRepo.NewRepository(db), store, logStore, purge.ConfigFromEnv())
+	go purgeService.Start(context.Background())
+
+	// Start the worker that loads archived log events back for searching and removes them once they expire
+	archiveRepository := archiveRepo.NewRepository(db)
+	archiveService := archive.NewService(archiveRepository, logStore, store, archive.ConfigFromEnv())
+	go archive.NewWorker(archiveService, archiveRepository).Start(context.Background())
+
+	// Start the background job that deletes log events past their project's retention
+	// Expired events are archived first; ranges under an active legal hold are kept
+	legalHoldService := legalhold.NewService(legalHoldRepo.NewRepository(db))
+	go retention.NewService(projectRepo.NewRepository(db), legalHoldService, archiveService, logStore, retention.ConfigFromEnv()).Start(context.Background())
+
+	// Start the dispatcher that sends and retries alert notifications
+	notificationRepository := notificationRepo.NewRepository(db)
+	notificationService := notification.NewService(notificationRepository, notification.ConfigFromEnv())
+	go notification.NewDispatcher(notificationService, notificationRepository).Start(context.Background())
+
+	// Start the scheduler that evaluates alert rules
+	// State changes are recorded on incidents, then notified unless a silence mutes them
+	alertRepository := alertRepo.NewRepository(db)
+	savedSearchService := savedsearch.NewService(savedSearchRepo.NewRepository(db))
+	alertService := alert.NewService(alertRepository, notificationRepository, savedSearchService, alert.NewLogStoreSource(logStore))
+	// Notifications held back by a silence are sent once it ends if the rule is still firing
+	silenceNotifier := silence.NewNotifier(silence.NewService(silenceRepo.NewRepository(db)), notificationService, silence.NotifierConfigFromEnv())
+	go silenceNotifier.Start(context.Background())
+	alertNotifier := incident.NewNotifier(incident.NewService(incidentRepo.NewRepository(db), savedSearchService), silenceNotifier)
+	alertScheduler := alert.NewScheduler(alertService, alertRepository, alertNotifier, alert.SchedulerConfigFromEnv())
+	go alertScheduler.Start(context.Background())
+
+	// Start the detector that learns event volume baselines and raises anomalies
+	anomalyDetector := anomaly.NewDetector(anomalyRepo.NewRepository(db), usageRepo.NewRepository(db), anomaly.DetectorConfigFromEnv())
+	go anomalyDetector.Start(context.Background())
+
+	// Start the scheduler that records missed and timed out cron job and heartbeat runs
+	// Monitor notifications go through silences like alert notifications do
+	monitorRepository := monitorRepo.NewRepository(db)
+	monitorService := monitor.NewService(monitorRepository, notificationRepository, silenceNotifier)
+	go monitor.NewScheduler(monitorService, monitorRepository, monitor.SchedulerConfigFromEnv()).Start(context.Background())
+
+	// Start the worker that runs background log exports and deletes expired export files
+	exportRepository := exportRepo.NewRepository(db)
+	searchService := search.NewService(logStore, orgRepo.NewRepository(db), search.ConfigFromEnv())
+	exportService := export.NewService(logStore, searchService, exportRepository, store, export.ConfigFromEnv())
+	go export.NewWorker(exportService, exportRepository).Start(context.Background())
+
 	// Create and start the server
-	s := server.NewServer(db)
+	s := server.NewServer(db, store, logStore)
 	if err := s.Start(); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
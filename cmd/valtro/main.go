@@ -0,0 +1,194 @@
+// Command valtro records releases and deploys from CI pipelines and deploy scripts
+//
+// Usage:
+//
+//	valtro releases new <version> [-commit <sha>]
+//	valtro releases deploy <version> -env <environment> [-deployed-at <RFC3339 time>]
+//
+// The project is identified by its API key, read from VALTRO_API_KEY. The API is reached at
+// VALTRO_URL, http://localhost:8080 by default.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+)
+
+// defaultURL is where the API is reached when VALTRO_URL is not set
+const defaultURL = "http://localhost:8080"
+
+const usage = `Usage:
+  valtro releases new <version> [-commit <sha>]
+  valtro releases deploy <version> -env <environment> [-deployed-at <RFC3339 time>]
+
+Environment:
+  VALTRO_API_KEY  the project's API key (required)
+  VALTRO_URL      the API's base URL (default: http://localhost:8080)
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "valtro:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches a command line to its command
+func run(args []string) error {
+	if len(args) < 2 || args[0] != "releases" {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[1] {
+	case "new":
+		return createRelease(client, args[2:])
+	case "deploy":
+		return createDeploy(client, args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", "releases "+args[1])
+	}
+}
+
+// createRelease runs "valtro releases new"
+func createRelease(client *client, args []string) error {
+	flags := flag.NewFlagSet("releases new", flag.ContinueOnError)
+	commit := flags.String("commit", "", "commit SHA the release was built from")
+	version, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CreateReleaseRequest{Version: version, CommitSHA: *commit}
+	if err := client.post("/api/v1/releases", req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created release %s\n", version)
+	return nil
+}
+
+// createDeploy runs "valtro releases deploy"
+func createDeploy(client *client, args []string) error {
+	flags := flag.NewFlagSet("releases deploy", flag.ContinueOnError)
+	environment := flags.String("env", "", "environment the release was deployed to, e.g. production")
+	deployedAt := flags.String("deployed-at", "", "when the deploy finished, as an RFC3339 time (default: now)")
+	version, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if *environment == "" {
+		return fmt.Errorf("-env is required")
+	}
+
+	req := dto.CreateDeployRequest{Environment: *environment}
+	if *deployedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, *deployedAt)
+		if err != nil {
+			return fmt.Errorf("invalid -deployed-at: %w", err)
+		}
+		req.DeployedAt = &parsed
+	}
+
+	if err := client.post("/api/v1/releases/"+url.PathEscape(version)+"/deploys", req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Recorded deploy of %s to %s\n", version, *environment)
+	return nil
+}
+
+// parseArgs reads a command's version argument and flags, in either order
+func parseArgs(flags *flag.FlagSet, args []string) (string, error) {
+	var version string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		version, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if version == "" && flags.NArg() > 0 {
+		version = flags.Arg(0)
+	}
+	if strings.TrimSpace(version) == "" {
+		return "", fmt.Errorf("a release version is required")
+	}
+	return version, nil
+}
+
+// client calls the API with the project API key
+type client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// newClient reads the API key and URL from the environment
+func newClient() (*client, error) {
+	apiKey := os.Getenv("VALTRO_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("VALTRO_API_KEY is not set")
+	}
+
+	baseURL := os.Getenv("VALTRO_URL")
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+
+	return &client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// post sends body as JSON, returning the API's error message when the request fails
+func (c *client) post(path string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var apiError dto.ErrorResponse
+	if json.Unmarshal(respBody, &apiError) == nil && apiError.Message != "" {
+		if apiError.Error != "" {
+			return fmt.Errorf("%s: %s (HTTP %d)", apiError.Message, apiError.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("%s (HTTP %d)", apiError.Message, resp.StatusCode)
+	}
+	return fmt.Errorf("request failed with HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
@@ -5,6 +5,10 @@ const (
 	APIKeyMaxAttempts = 10
 	APIKeyByteSize    = 32
 	APIKeyPrefix      = "vltro_"
+
+	// Share Token Generation Constants
+	ShareTokenMaxAttempts = 10
+	ShareTokenByteSize    = 9
 	
 	// Validation Constants
 	MinOrganizationNameLength = 2
@@ -13,6 +17,247 @@ const (
 	MaxProjectNameLength      = 255
 	MinUserNameLength         = 2
 	MaxUserNameLength         = 100
+	MinSavedSearchNameLength  = 2
+	MaxSavedSearchNameLength  = 255
+	
+	// Log Retention Constants (in days)
+	DefaultRetentionDays = 30
+	MinRetentionDays     = 1
+	MaxRetentionDays     = 3650
+
+	// Organization Plans
+	// Each plan caps how far back a single log search may reach
+	PlanFree                           = "free"
+	PlanTeam                           = "team"
+	PlanEnterprise                     = "enterprise"
+	MaxLogSearchRangeSecondsFree       = 7 * 24 * 60 * 60
+	MaxLogSearchRangeSecondsTeam       = 30 * 24 * 60 * 60
+	MaxLogSearchRangeSecondsEnterprise = 90 * 24 * 60 * 60
+
+	// Log Levels
+	LogLevelTrace = "trace"
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+	LogLevelFatal = "fatal"
+	
+	// Legal Hold Audit Actions
+	LegalHoldActionCreated  = "created"
+	LegalHoldActionUpdated  = "updated"
+	LegalHoldActionReleased = "released"
+	
+	// Usage Report Constants
+	UsageGranularityDay   = "day"
+	UsageGranularityMonth = "month"
+	DefaultUsageRangeDays = 30
+	MaxUsageRangeDays     = 731
+	
+	// Log Pattern Constants
+	PatternSortLastSeen  = "last_seen"
+	PatternSortFirstSeen = "first_seen"
+	PatternSortCount     = "count"
+	DefaultPatternLimit  = 100
+	MaxPatternLimit      = 1000
+	
+	// Issue Constants
+	IssueSortRecency           = "recency"
+	IssueSortFrequency         = "frequency"
+	IssueSortNew               = "new"
+	DefaultIssueLimit          = 100
+	MaxIssueLimit              = 1000
+	MaxIssueHosts              = 100
+	MaxIssueSamples            = 10
+	MaxIssueTitleLength        = 200
+	FingerprintDefaultTemplate = "{{ default }}"
+	
+	// Issue Status Constants
+	IssueStatusUnresolved            = "unresolved"
+	IssueStatusResolved              = "resolved"
+	IssueStatusResolvedInNextRelease = "resolved_in_next_release"
+	IssueStatusIgnored               = "ignored"
+	
+	// Issue Activity Actions
+	IssueActivityStatusChanged = "status_changed"
+	IssueActivityAssigned      = "assigned"
+	IssueActivityUnassigned    = "unassigned"
+	IssueActivityRegressed     = "regressed"
+	IssueActivityUnignored     = "unignored"
+	
+	// Release Constants
+	DefaultReleaseLimit        = 50
+	MaxReleaseLimit            = 500
+	MaxEnvironmentLength       = 64
+	
+	// Alert Rule Constants
+	AlertAggregationCount     = "count"
+	AlertAggregationRate      = "rate"
+	AlertComparisonGT         = "gt"
+	AlertComparisonGTE        = "gte"
+	AlertComparisonLT         = "lt"
+	AlertComparisonLTE        = "lte"
+	AlertStateOK              = "ok"
+	AlertStateFiring          = "firing"
+	MaxAlertRuleNameLength    = 255
+	MinAlertIntervalSeconds   = 60
+	MaxAlertIntervalSeconds   = 24 * 60 * 60
+	MaxAlertWindowSeconds     = 7 * 24 * 60 * 60
+	DefaultEvaluationLimit    = 100
+	MaxEvaluationLimit        = 1000
+	MaxAlertRuleLabels        = 20
+	MaxAlertLabelKeyLength    = 63
+	MaxAlertLabelValueLength  = 255
+	
+	// Notification Channel Constants
+	NotificationChannelWebhook        = "webhook"
+	NotificationChannelSlack          = "slack"
+	NotificationChannelEmail          = "email"
+	NotificationEventAlertFiring      = "alert.firing"
+	NotificationEventAlertResolved    = "alert.resolved"
+	NotificationEventMonitorMissed    = "monitor.missed"
+	NotificationEventMonitorFailed    = "monitor.failed"
+	NotificationEventMonitorRecovered = "monitor.recovered"
+	NotificationEventTest             = "test"
+	DeliveryStatusPending             = "pending"
+	DeliveryStatusSucceeded           = "succeeded"
+	DeliveryStatusFailed              = "failed"
+	MaxNotificationChannelNameLength  = 255
+	MaxNotificationTemplateLength     = 10000
+	MaxEmailRecipients                = 20
+	MaxAlertRuleChannels              = 10
+	DefaultDeliveryLimit              = 100
+	MaxDeliveryLimit                  = 1000
+	
+	// Silence Constants
+	SilenceStateScheduled    = "scheduled"
+	SilenceStateActive       = "active"
+	SilenceStateExpired      = "expired"
+	SilenceActionCreated     = "created"
+	SilenceActionUpdated     = "updated"
+	SilenceActionExpired     = "expired"
+	SilenceActionSuppressed  = "suppressed"
+	MaxSilenceCommentLength  = 1000
+	MaxSilenceMatchers       = 10
+	MaxSilenceWindowSeconds  = 7 * 24 * 60 * 60
+	DefaultSilenceAuditLimit = 100
+	MaxSilenceAuditLimit     = 1000
+	
+	// Incident Constants
+	IncidentStatusOpen          = "open"
+	IncidentStatusAcknowledged  = "acknowledged"
+	IncidentStatusResolved      = "resolved"
+	IncidentEventOpened         = "opened"
+	IncidentEventAlertFiring    = "alert_firing"
+	IncidentEventAlertResolved  = "alert_resolved"
+	IncidentEventAcknowledged   = "acknowledged"
+	IncidentEventResolved       = "resolved"
+	IncidentEventUpdated        = "updated"
+	IncidentEventNote           = "note"
+	IncidentEventIssueLinked    = "issue_linked"
+	IncidentEventIssueUnlinked  = "issue_unlinked"
+	IncidentExportMarkdown      = "markdown"
+	IncidentExportJSON          = "json"
+	MaxIncidentTitleLength      = 255
+	MaxIncidentNoteLength       = 10000
+	MaxIncidentLinkedIssues     = 10
+	DefaultIncidentLimit        = 50
+	MaxIncidentLimit            = 500
+	
+	// Monitor Constants
+	MonitorScheduleCron         = "cron"
+	MonitorScheduleInterval     = "interval"
+	MonitorStatusPending        = "pending"
+	MonitorStatusOK             = "ok"
+	MonitorStatusMissed         = "missed"
+	MonitorStatusFailed         = "failed"
+	CheckInStatusInProgress     = "in_progress"
+	CheckInStatusOK             = "ok"
+	CheckInStatusError          = "error"
+	CheckInStatusMissed         = "missed"
+	CheckInStatusTimeout        = "timeout"
+	MaxMonitorNameLength        = 255
+	MaxMonitorSlugLength        = 64
+	MinMonitorIntervalSeconds   = 60
+	MaxMonitorIntervalSeconds   = 30 * 24 * 60 * 60
+	DefaultMonitorMarginSeconds = 5 * 60
+	MaxMonitorMarginSeconds     = 24 * 60 * 60
+	MaxMonitorRuntimeSeconds    = 7 * 24 * 60 * 60
+	MaxMonitorChannels          = 10
+	MaxMonitorLabels            = 20
+	MaxCheckInMessageLength     = 1000
+	DefaultCheckInLimit         = 50
+	MaxCheckInLimit             = 500
+	
+	// Anomaly Detection Constants
+	AnomalyKindSpike            = "spike"
+	AnomalyKindDrop             = "drop"
+	AnomalyKindSilence          = "silence"
+	AnomalyStatusOpen           = "open"
+	AnomalyStatusResolved       = "resolved"
+	DefaultAnomalySensitivity   = 3.0
+	MinAnomalySensitivity       = 1.0
+	MaxAnomalySensitivity       = 10.0
+	DefaultAnomalyMinimumEvents = 10
+	DefaultAnomalySilenceEvents = 20
+	AnomalyBaselineSmoothing    = 0.2
+	AnomalyMinBaselineSamples   = 3
+	AnomalyMinHourFraction      = 0.25
+	AnomalySilenceHourFraction  = 0.5
+	AnomalyResolveRatio         = 0.5
+	DefaultAnomalyLimit         = 100
+	MaxAnomalyLimit             = 1000
+	
+	// Release Artifact Constants
+	MaxArtifactSize            = 50 << 20 // 50 MB
+	MaxArtifactNameLength      = 500
+	MaxReleaseLength           = 255
+	SourceMapExtension         = ".js.map"
+	SourceContextLines         = 5
+	MaxCachedSourceMaps        = 100
+	
+	// Log Ingest and Search Constants
+	MaxIngestBodySize            = 5 << 20 // 5 MB
+	MaxIngestBatchSize           = 1000
+	MaxLogMessageLength          = 32 << 10 // 32 KB
+	MaxLogFieldLength            = 255
+	MaxLogAttributes             = 50
+	MaxIndexedAttributes         = 10
+	MaxLogEventAgeSeconds        = 7 * 24 * 60 * 60
+	MaxLogEventSkewSeconds       = 60 * 60
+	DefaultLogSearchRangeSeconds = 24 * 60 * 60
+	DefaultLogSearchLimit        = 100
+	MaxLogSearchLimit            = 1000
+	DefaultLogContextLines       = 50
+	MaxLogContextLines           = 500
+	LogContextWindowSeconds      = 60 * 60 // How far either side of an event its context is looked for
+	
+	// Log Export Constants
+	ExportFormatCSV         = "csv"
+	ExportFormatNDJSON      = "ndjson"
+	ExportFormatParquet     = "parquet"
+	ExportStatusPending     = "pending"
+	ExportStatusRunning     = "running"
+	ExportStatusSucceeded   = "succeeded"
+	ExportStatusFailed      = "failed"
+	ExportStatusExpired     = "expired"
+	DefaultExportRows       = 100000
+	MaxExportRows           = 1000000
+	MaxExportJobRows        = 10000000
+	MaxExportJobAttempts    = 3
+	DefaultExportJobLimit   = 50
+	MaxExportJobLimit       = 500
+	
+	// Log Archive Constants
+	RehydrationStatusPending   = "pending"
+	RehydrationStatusRunning   = "running"
+	RehydrationStatusReady     = "ready"
+	RehydrationStatusFailed    = "failed"
+	RehydrationStatusExpired   = "expired"
+	MaxRehydrationRangeSeconds = 31 * 24 * 60 * 60
+	MaxRehydratedEvents        = 5000000
+	MaxRehydrationAttempts     = 3
+	DefaultRehydrationLimit    = 50
+	MaxRehydrationLimit        = 500
 	
 	// HTTP Status Messages
 	UserIDRequired          = "User ID required"
@@ -3,12 +3,19 @@ package database
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// defaultStatementTimeout caps how long a single SQL statement may run when DB_STATEMENT_TIMEOUT is not set
+const defaultStatementTimeout = 30 * time.Second
+
 // InitDB initializes and returns a GORM database connection.
 func InitDB() (*gorm.DB, error) {
 	dsn := os.Getenv("DATABASE_URL")
@@ -16,7 +23,17 @@ func InitDB() (*gorm.DB, error) {
 		log.Fatal("DATABASE_URL environment variable not set")
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply a server-side statement timeout to every connection in the pool as a backstop:
+	// searches cancel their own statements sooner, but any other runaway query is still
+	// cancelled by Postgres even if the caller never gives up
+	config.RuntimeParams["statement_timeout"] = strconv.FormatInt(statementTimeout().Milliseconds(), 10)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*config)}), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	if err != nil {
@@ -26,3 +43,19 @@ func InitDB() (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// statementTimeout reads DB_STATEMENT_TIMEOUT (e.g. "30s", "2m"), falling back to the default
+func statementTimeout() time.Duration {
+	value := os.Getenv("DB_STATEMENT_TIMEOUT")
+	if value == "" {
+		return defaultStatementTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		log.Printf("Invalid DB_STATEMENT_TIMEOUT %q, using default of %s", value, defaultStatementTimeout)
+		return defaultStatementTimeout
+	}
+
+	return timeout
+}
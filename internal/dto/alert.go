@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAlertRuleRequest represents the request payload for creating an alert rule
+// Window and EvaluationInterval are durations such as "5m" or "1h"
+//
+// Rules are evaluated against the log store. The query starts with optional level filters joined
+// by OR, followed by text searched for as in log search, e.g. "level:error OR level:fatal timeout".
+// The window must be whole minutes, and each evaluation covers the completed minutes before it
+//
+// Set SavedSearchID instead of Query to use the query of a saved search of the project's organization;
+// the saved search's current query is used at every evaluation
+type CreateAlertRuleRequest struct {
+	Name               string            `json:"name" validate:"required,max=255"`
+	Query              string            `json:"query,omitempty"`
+	SavedSearchID      *uuid.UUID        `json:"saved_search_id,omitempty"`
+	Aggregation        string            `json:"aggregation" validate:"required"`
+	Comparison         string            `json:"comparison" validate:"required"`
+	Threshold          float64           `json:"threshold"`
+	ResolveThreshold   *float64          `json:"resolve_threshold,omitempty"`
+	Window             string            `json:"window" validate:"required"`
+	EvaluationInterval string            `json:"evaluation_interval" validate:"required"`
+	ChannelIDs         []uuid.UUID       `json:"channel_ids,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	Enabled            *bool             `json:"enabled,omitempty"`
+}
+
+// UpdateAlertRuleRequest represents the request payload for updating an alert rule
+// Set ClearResolveThreshold to go back to resolving at the threshold, ChannelIDs
+// to an empty list to stop notifying, and Labels to an empty object to remove all labels.
+// Setting SavedSearchID switches the rule to that saved search's query; setting Query unlinks the saved search
+type UpdateAlertRuleRequest struct {
+	Name                  *string           `json:"name,omitempty" validate:"omitempty,max=255"`
+	Query                 *string           `json:"query,omitempty"`
+	SavedSearchID         *uuid.UUID        `json:"saved_search_id,omitempty"`
+	Aggregation           *string           `json:"aggregation,omitempty"`
+	Comparison            *string           `json:"comparison,omitempty"`
+	Threshold             *float64          `json:"threshold,omitempty"`
+	ResolveThreshold      *float64          `json:"resolve_threshold,omitempty"`
+	ClearResolveThreshold bool              `json:"clear_resolve_threshold,omitempty"`
+	Window                *string           `json:"window,omitempty"`
+	EvaluationInterval    *string           `json:"evaluation_interval,omitempty"`
+	ChannelIDs            *[]uuid.UUID      `json:"channel_ids,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	Enabled               *bool             `json:"enabled,omitempty"`
+}
+
+// AlertRuleResponse represents the response structure for alert rule data
+// Query is empty for rules that use a saved search (see SavedSearchID)
+type AlertRuleResponse struct {
+	ID                 uuid.UUID         `json:"id"`
+	ProjectID          uuid.UUID         `json:"project_id"`
+	Name               string            `json:"name"`
+	Query              string            `json:"query"`
+	SavedSearchID      *uuid.UUID        `json:"saved_search_id,omitempty"`
+	Aggregation        string            `json:"aggregation"`
+	Comparison         string            `json:"comparison"`
+	Threshold          float64           `json:"threshold"`
+	ResolveThreshold   *float64          `json:"resolve_threshold,omitempty"`
+	Window             string            `json:"window"`
+	EvaluationInterval string            `json:"evaluation_interval"`
+	ChannelIDs         []uuid.UUID       `json:"channel_ids"`
+	Labels             map[string]string `json:"labels"`
+	Enabled            bool              `json:"enabled"`
+	State              string            `json:"state"`
+	StateChangedAt     *time.Time        `json:"state_changed_at,omitempty"`
+	LastEvaluatedAt    *time.Time        `json:"last_evaluated_at,omitempty"`
+	LastValue          *float64          `json:"last_value,omitempty"`
+	NextEvaluationAt   time.Time         `json:"next_evaluation_at"`
+	CreatedByID        uuid.UUID         `json:"created_by_id"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// AlertEvaluationResponse represents a single entry of an alert rule's evaluation history
+type AlertEvaluationResponse struct {
+	ID            uuid.UUID `json:"id"`
+	AlertRuleID   uuid.UUID `json:"alert_rule_id"`
+	EvaluatedAt   time.Time `json:"evaluated_at"`
+	WindowStart   time.Time `json:"window_start"`
+	Value         *float64  `json:"value,omitempty"`
+	PreviousState string    `json:"previous_state"`
+	State         string    `json:"state"`
+	Error         string    `json:"error,omitempty"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateAnomalySettingsRequest represents the request payload for changing a project's anomaly detection settings
+type UpdateAnomalySettingsRequest struct {
+	Enabled       *bool    `json:"enabled,omitempty"`
+	Sensitivity   *float64 `json:"sensitivity,omitempty"`
+	MinimumEvents *int     `json:"minimum_events,omitempty"`
+	DetectSilence *bool    `json:"detect_silence,omitempty"`
+}
+
+// AnomalySettingsResponse represents a project's anomaly detection settings
+// UpdatedAt is omitted while the project uses the defaults
+type AnomalySettingsResponse struct {
+	ProjectID     uuid.UUID  `json:"project_id"`
+	Enabled       bool       `json:"enabled"`
+	Sensitivity   float64    `json:"sensitivity"`
+	MinimumEvents int        `json:"minimum_events"`
+	DetectSilence bool       `json:"detect_silence"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// VolumeAnomalyResponse represents the response structure for anomaly data
+type VolumeAnomalyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ProjectID  uuid.UUID  `json:"project_id"`
+	Level      string     `json:"level,omitempty"`
+	Kind       string     `json:"kind"`
+	Status     string     `json:"status"`
+	Observed   int64      `json:"observed"`
+	Expected   float64    `json:"expected"`
+	Score      float64    `json:"score"`
+	StartedAt  time.Time  `json:"started_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// VolumeBaselineResponse represents the learned event volume of one level and hour of the week
+type VolumeBaselineResponse struct {
+	Level      string    `json:"level"`
+	HourOfWeek int       `json:"hour_of_week"`
+	Mean       float64   `json:"mean"`
+	StdDev     float64   `json:"std_dev"`
+	Samples    int       `json:"samples"`
+	Ready      bool      `json:"ready"`
+	LastHour   time.Time `json:"last_hour"`
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncidentLogQuery represents the log query pinned to an incident
+// To is nil while the incident's alert is still firing
+// SavedSearchID is set when the query was copied from a saved search
+type IncidentLogQuery struct {
+	Query         string     `json:"query"`
+	SavedSearchID *uuid.UUID `json:"saved_search_id,omitempty"`
+	From          time.Time  `json:"from"`
+	To            *time.Time `json:"to,omitempty"`
+}
+
+// CreateIncidentRequest represents the request payload for opening an incident by hand
+// StartedAt and LogQueryFrom default to now
+// Set SavedSearchID instead of LogQuery to pin the query of a saved search of the project's organization
+type CreateIncidentRequest struct {
+	Title         string      `json:"title" validate:"required,max=255"`
+	StartedAt     *time.Time  `json:"started_at,omitempty"`
+	LogQuery      string      `json:"log_query,omitempty"`
+	SavedSearchID *uuid.UUID  `json:"saved_search_id,omitempty"`
+	LogQueryFrom  *time.Time  `json:"log_query_from,omitempty"`
+	LogQueryTo    *time.Time  `json:"log_query_to,omitempty"`
+	IssueIDs      []uuid.UUID `json:"issue_ids,omitempty"`
+}
+
+// UpdateIncidentRequest represents the request payload for updating an incident's title or pinned log query
+// Set ClearLogQueryTo to pin a query that runs up to the present.
+// Setting SavedSearchID pins the saved search's query; setting LogQuery unlinks the saved search
+type UpdateIncidentRequest struct {
+	Title           *string    `json:"title,omitempty" validate:"omitempty,max=255"`
+	LogQuery        *string    `json:"log_query,omitempty"`
+	SavedSearchID   *uuid.UUID `json:"saved_search_id,omitempty"`
+	LogQueryFrom    *time.Time `json:"log_query_from,omitempty"`
+	LogQueryTo      *time.Time `json:"log_query_to,omitempty"`
+	ClearLogQueryTo bool       `json:"clear_log_query_to,omitempty"`
+}
+
+// AddIncidentNoteRequest represents the request payload for adding a note to an incident's timeline
+type AddIncidentNoteRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// LinkIncidentIssueRequest represents the request payload for linking an issue to an incident
+type LinkIncidentIssueRequest struct {
+	IssueID uuid.UUID `json:"issue_id" validate:"required"`
+}
+
+// IncidentResponse represents the response structure for incident data
+type IncidentResponse struct {
+	ID               uuid.UUID        `json:"id"`
+	ProjectID        uuid.UUID        `json:"project_id"`
+	AlertRuleID      *uuid.UUID       `json:"alert_rule_id"`
+	Title            string           `json:"title"`
+	Status           string           `json:"status"`
+	LogQuery         IncidentLogQuery `json:"log_query"`
+	StartedAt        time.Time        `json:"started_at"`
+	AcknowledgedAt   *time.Time       `json:"acknowledged_at"`
+	AcknowledgedByID *uuid.UUID       `json:"acknowledged_by_id"`
+	ResolvedAt       *time.Time       `json:"resolved_at"`
+	ResolvedByID     *uuid.UUID       `json:"resolved_by_id"`
+	CreatedByID      *uuid.UUID       `json:"created_by_id"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	// Details, set when a single incident is retrieved or exported
+	Evaluations []IncidentEvaluationResponse    `json:"evaluations,omitempty"`
+	Issues      []IncidentIssueResponse         `json:"issues,omitempty"`
+	Timeline    []IncidentTimelineEntryResponse `json:"timeline,omitempty"`
+}
+
+// IncidentEvaluationResponse represents an alert evaluation recorded on an incident
+type IncidentEvaluationResponse struct {
+	AlertEvaluationID uuid.UUID `json:"alert_evaluation_id"`
+	EvaluatedAt       time.Time `json:"evaluated_at"`
+	WindowStart       time.Time `json:"window_start"`
+	Value             float64   `json:"value"`
+	State             string    `json:"state"`
+	Aggregation       string    `json:"aggregation"`
+	Comparison        string    `json:"comparison"`
+	Threshold         float64   `json:"threshold"`
+}
+
+// IncidentIssueResponse represents an issue linked to an incident
+type IncidentIssueResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Level      string     `json:"level"`
+	Status     string     `json:"status"`
+	EventCount int64      `json:"event_count"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	LinkedByID *uuid.UUID `json:"linked_by_id"`
+	LinkedAt   time.Time  `json:"linked_at"`
+}
+
+// IncidentTimelineEntryResponse represents a single entry of an incident's timeline
+type IncidentTimelineEntryResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Kind      string     `json:"kind"`
+	ActorID   *uuid.UUID `json:"actor_id"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StackFrame represents a single stack frame sent by an SDK, outermost first
+type StackFrame struct {
+	Function string `json:"function,omitempty"`
+	Module   string `json:"module,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	InApp    bool   `json:"in_app"`
+
+	// Source context around Line, filled in when the frame is symbolicated with a source map
+	PreContext   []string `json:"pre_context,omitempty"`
+	ContextLine  string   `json:"context_line,omitempty"`
+	PostContext  []string `json:"post_context,omitempty"`
+	Symbolicated bool     `json:"symbolicated,omitempty"`
+}
+
+// Exception represents an exception attached to an error event
+type Exception struct {
+	Type   string       `json:"type"`
+	Value  string       `json:"value,omitempty"`
+	Frames []StackFrame `json:"frames,omitempty"`
+}
+
+// ErrorEvent represents an error-level event as received from an SDK
+// Fingerprint overrides default grouping; the entry "{{ default }}" expands to the default components
+type ErrorEvent struct {
+	EventID     string     `json:"event_id,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+	Level       string     `json:"level"`
+	Host        string     `json:"host,omitempty"`
+	Release     string     `json:"release,omitempty"`
+	Message     string     `json:"message"`
+	Exception   *Exception `json:"exception,omitempty"`
+	Fingerprint []string   `json:"fingerprint,omitempty"`
+}
+
+// IssueSampleResponse represents a sample event stored on an issue
+type IssueSampleResponse struct {
+	EventID   string     `json:"event_id,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+	Host      string     `json:"host,omitempty"`
+	Release   string     `json:"release,omitempty"`
+	Message   string     `json:"message"`
+	Exception *Exception `json:"exception,omitempty"`
+}
+
+// IssueResponse represents the response structure for issue data
+// SampleEvents is only filled in when a single issue is requested
+type IssueResponse struct {
+	ID           uuid.UUID             `json:"id"`
+	ProjectID    uuid.UUID             `json:"project_id"`
+	Fingerprint  string                `json:"fingerprint"`
+	Title        string                `json:"title"`
+	Culprit      string                `json:"culprit,omitempty"`
+	Level        string                `json:"level"`
+	EventCount   int64                 `json:"event_count"`
+	Hosts        []string              `json:"hosts"`
+	FirstSeenAt  time.Time             `json:"first_seen_at"`
+	LastSeenAt   time.Time             `json:"last_seen_at"`
+	FirstRelease string                `json:"first_release,omitempty"`
+	LastRelease  string                `json:"last_release,omitempty"`
+	Status       string                `json:"status"`
+	AssigneeID   *uuid.UUID            `json:"assignee_id,omitempty"`
+	ResolvedAt   *time.Time            `json:"resolved_at,omitempty"`
+	IgnoreCount  *int64                `json:"ignore_until_count,omitempty"`
+	IgnoreUntil  *time.Time            `json:"ignore_until,omitempty"`
+	SampleEvents []IssueSampleResponse `json:"sample_events,omitempty"`
+}
+
+// UpdateIssueStatusRequest represents the request payload for changing an issue's status
+// For "ignored", IgnoreCount (more events) and IgnoreUntil end the ignore, whichever comes first
+type UpdateIssueStatusRequest struct {
+	Status      string     `json:"status" validate:"required"`
+	IgnoreCount *int64     `json:"ignore_count,omitempty"`
+	IgnoreUntil *time.Time `json:"ignore_until,omitempty"`
+}
+
+// AssignIssueRequest represents the request payload for assigning an issue
+// A null assignee_id unassigns the issue
+type AssignIssueRequest struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+// IssueActivityResponse represents a single entry of an issue's activity log
+type IssueActivityResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	IssueID    uuid.UUID  `json:"issue_id"`
+	Action     string     `json:"action"`
+	ActorID    *uuid.UUID `json:"actor_id,omitempty"`
+	FromStatus string     `json:"from_status,omitempty"`
+	ToStatus   string     `json:"to_status,omitempty"`
+	Details    string     `json:"details"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateLegalHoldRequest represents the request payload for placing a legal hold
+type CreateLegalHoldRequest struct {
+	Reason     string     `json:"reason" validate:"required"`
+	RangeStart *time.Time `json:"range_start,omitempty"`
+	RangeEnd   *time.Time `json:"range_end,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// UpdateLegalHoldRequest represents the request payload for updating a legal hold
+type UpdateLegalHoldRequest struct {
+	Reason    *string    `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// LegalHoldResponse represents the response structure for legal hold data
+type LegalHoldResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ProjectID    uuid.UUID  `json:"project_id"`
+	Reason       string     `json:"reason"`
+	RangeStart   *time.Time `json:"range_start"`
+	RangeEnd     *time.Time `json:"range_end"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	Active       bool       `json:"active"`
+	CreatedByID  uuid.UUID  `json:"created_by_id"`
+	ReleasedAt   *time.Time `json:"released_at"`
+	ReleasedByID *uuid.UUID `json:"released_by_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// LegalHoldAuditEntryResponse represents a single entry of the legal hold audit trail
+type LegalHoldAuditEntryResponse struct {
+	ID          uuid.UUID `json:"id"`
+	LegalHoldID uuid.UUID `json:"legal_hold_id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	Action      string    `json:"action"`
+	ActorID     uuid.UUID `json:"actor_id"`
+	Details     string    `json:"details"`
+	CreatedAt   time.Time `json:"created_at"`
+}
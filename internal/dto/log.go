@@ -0,0 +1,148 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IngestLogsRequest represents a batch of log events sent by an SDK
+type IngestLogsRequest struct {
+	Events []LogEvent `json:"events"`
+}
+
+// LogEvent represents a single log event as received from an SDK
+// Error and fatal events may carry an exception and a custom fingerprint for issue grouping
+type LogEvent struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Message     string            `json:"message"`
+	Host        string            `json:"host,omitempty"`
+	Service     string            `json:"service,omitempty"`
+	Stream      string            `json:"stream,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Exception   *Exception        `json:"exception,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+}
+
+// IngestLogsResponse represents the response structure for an accepted batch
+// EventIDs are in the order the events were sent
+type IngestLogsResponse struct {
+	Accepted int         `json:"accepted"`
+	EventIDs []uuid.UUID `json:"event_ids"`
+}
+
+// LogSearchParams represents the filters of a log search
+type LogSearchParams struct {
+	Query      string            `json:"q,omitempty"`
+	From       *time.Time        `json:"from,omitempty"`
+	To         *time.Time        `json:"to,omitempty"`
+	Levels     []string          `json:"levels,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	PatternID  *uuid.UUID        `json:"pattern_id,omitempty"`
+	Cursor     string            `json:"cursor,omitempty"`
+	Descending bool              `json:"descending,omitempty"`
+	Limit      int               `json:"limit,omitempty"`
+}
+
+// LogEventResponse represents the response structure for a stored log event
+// ProjectName is set in organization-wide searches, which mix events of several projects
+type LogEventResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	ProjectID   uuid.UUID         `json:"project_id"`
+	ProjectName string            `json:"project_name,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+	ReceivedAt  time.Time         `json:"received_at"`
+	Level       string            `json:"level"`
+	Message     string            `json:"message"`
+	Host        string            `json:"host,omitempty"`
+	Service     string            `json:"service,omitempty"`
+	Stream      string            `json:"stream,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	PatternID   *uuid.UUID        `json:"pattern_id,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// LogSearchResponse represents a page of log search results
+// NextCursor is set when more results may follow; pass it as the cursor parameter to continue.
+// Warning is set when the search was expensive enough that narrowing it is advised
+type LogSearchResponse struct {
+	Events     []*LogEventResponse `json:"events"`
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	Warning    string              `json:"warning,omitempty"`
+}
+
+// LogContextParams represents a request for the events around one event
+// GroupBy lists the fields neighbours must share with the event: "host", "service", "stream",
+// "release" or "attr.<name>". Nil means host, service and stream; empty means no grouping
+type LogContextParams struct {
+	Before  int      `json:"before"`
+	After   int      `json:"after"`
+	GroupBy []string `json:"group_by"`
+}
+
+// LogContextResponse represents an event with the events logged just before and after it
+// Both lists are oldest first. GroupBy holds the values neighbours were matched on; fields the
+// event has no value for are not matched
+type LogContextResponse struct {
+	Event   *LogEventResponse   `json:"event"`
+	Before  []*LogEventResponse `json:"before"`
+	After   []*LogEventResponse `json:"after"`
+	GroupBy map[string]string   `json:"group_by"`
+}
+
+// ExportRequest represents an export of log search results
+// Columns defaults to timestamp, level, host, service and message. Limit caps the number of
+// exported events; exports are not paged, so Cursor only sets where the export starts
+type ExportRequest struct {
+	Format  string   `json:"format"`
+	Columns []string `json:"columns,omitempty"`
+	LogSearchParams
+}
+
+// ExportJobResponse represents the response structure for a background export
+// DownloadURL is set once the file is ready and until it expires
+type ExportJobResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	ProjectID   uuid.UUID       `json:"project_id"`
+	CreatedByID *uuid.UUID      `json:"created_by_id,omitempty"`
+	Format      string          `json:"format"`
+	Columns     []string        `json:"columns"`
+	Filter      LogSearchParams `json:"filter"`
+	MaxRows     int             `json:"max_rows"`
+	Status      string          `json:"status"`
+	RowCount    int64           `json:"row_count"`
+	SizeBytes   int64           `json:"size_bytes"`
+	Error       string          `json:"error,omitempty"`
+	DownloadURL string          `json:"download_url,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// CreateRehydrationRequest represents a request to load archived log events back for searching
+// From is inclusive and To exclusive
+type CreateRehydrationRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// RehydrationResponse represents the response structure for a rehydration
+// SearchURL is set once the events are loaded and until they expire
+type RehydrationResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	CreatedByID *uuid.UUID `json:"created_by_id,omitempty"`
+	From        time.Time  `json:"from"`
+	To          time.Time  `json:"to"`
+	Status      string     `json:"status"`
+	EventCount  int64      `json:"event_count"`
+	Error       string     `json:"error,omitempty"`
+	SearchURL   string     `json:"search_url,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
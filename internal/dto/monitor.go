@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateMonitorRequest represents the request payload for creating a monitor
+// ScheduleType "cron" takes CronExpression and an optional Timezone (default "UTC");
+// "interval" takes Interval. Interval, Margin and MaxRuntime are durations such as "5m" or "1h".
+// Slug defaults to one derived from the name
+type CreateMonitorRequest struct {
+	Name           string            `json:"name" validate:"required,max=255"`
+	Slug           string            `json:"slug,omitempty" validate:"omitempty,max=64"`
+	ScheduleType   string            `json:"schedule_type" validate:"required"`
+	CronExpression string            `json:"cron_expression,omitempty"`
+	Interval       string            `json:"interval,omitempty"`
+	Timezone       string            `json:"timezone,omitempty"`
+	Margin         string            `json:"margin,omitempty"`
+	MaxRuntime     string            `json:"max_runtime,omitempty"`
+	ChannelIDs     []uuid.UUID       `json:"channel_ids,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Enabled        *bool             `json:"enabled,omitempty"`
+}
+
+// UpdateMonitorRequest represents the request payload for updating a monitor
+// Set ClearMaxRuntime to let runs take any time, ChannelIDs to an empty list to stop notifying,
+// and Labels to an empty object to remove all labels
+type UpdateMonitorRequest struct {
+	Name            *string           `json:"name,omitempty" validate:"omitempty,max=255"`
+	Slug            *string           `json:"slug,omitempty" validate:"omitempty,max=64"`
+	ScheduleType    *string           `json:"schedule_type,omitempty"`
+	CronExpression  *string           `json:"cron_expression,omitempty"`
+	Interval        *string           `json:"interval,omitempty"`
+	Timezone        *string           `json:"timezone,omitempty"`
+	Margin          *string           `json:"margin,omitempty"`
+	MaxRuntime      *string           `json:"max_runtime,omitempty"`
+	ClearMaxRuntime bool              `json:"clear_max_runtime,omitempty"`
+	ChannelIDs      *[]uuid.UUID      `json:"channel_ids,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Enabled         *bool             `json:"enabled,omitempty"`
+}
+
+// MonitorResponse represents the response structure for monitor data
+type MonitorResponse struct {
+	ID              uuid.UUID         `json:"id"`
+	ProjectID       uuid.UUID         `json:"project_id"`
+	Name            string            `json:"name"`
+	Slug            string            `json:"slug"`
+	ScheduleType    string            `json:"schedule_type"`
+	CronExpression  string            `json:"cron_expression,omitempty"`
+	Interval        string            `json:"interval,omitempty"`
+	Timezone        string            `json:"timezone"`
+	Margin          string            `json:"margin"`
+	MaxRuntime      string            `json:"max_runtime,omitempty"`
+	ChannelIDs      []uuid.UUID       `json:"channel_ids"`
+	Labels          map[string]string `json:"labels"`
+	Enabled         bool              `json:"enabled"`
+	Status          string            `json:"status"`
+	StatusChangedAt *time.Time        `json:"status_changed_at,omitempty"`
+	LastCheckInAt   *time.Time        `json:"last_check_in_at,omitempty"`
+	NextCheckInAt   *time.Time        `json:"next_check_in_at,omitempty"`
+	DeadlineAt      *time.Time        `json:"deadline_at,omitempty"`
+	CreatedByID     uuid.UUID         `json:"created_by_id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// CheckInRequest represents the payload a job sends to check in
+// Status is "in_progress" when the run starts, then "ok" or "error" when it ends (default "ok")
+// CheckInID closes the run with that ID; without it the latest run in progress is closed,
+// or a finished run is recorded in one go. DurationMs overrides the measured duration
+type CheckInRequest struct {
+	Status     string     `json:"status,omitempty"`
+	CheckInID  *uuid.UUID `json:"check_in_id,omitempty"`
+	DurationMs *int64     `json:"duration_ms,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+// MonitorCheckInResponse represents a single run of a monitor
+type MonitorCheckInResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	MonitorID  uuid.UUID  `json:"monitor_id"`
+	Status     string     `json:"status"`
+	ExpectedAt *time.Time `json:"expected_at,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs *int64     `json:"duration_ms,omitempty"`
+	TimeoutAt  *time.Time `json:"timeout_at,omitempty"`
+	Message    string     `json:"message,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateNotificationChannelRequest represents the request payload for creating a notification channel
+// URL is required for webhook and slack channels, Recipients for email channels
+type CreateNotificationChannelRequest struct {
+	Name            string   `json:"name" validate:"required,max=255"`
+	Type            string   `json:"type" validate:"required"`
+	URL             string   `json:"url,omitempty"`
+	Recipients      []string `json:"recipients,omitempty"`
+	SubjectTemplate string   `json:"subject_template,omitempty"`
+	BodyTemplate    string   `json:"body_template,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateNotificationChannelRequest represents the request payload for updating a notification channel
+// The type cannot be changed; set RotateSecret to issue a new webhook signing secret
+type UpdateNotificationChannelRequest struct {
+	Name            *string  `json:"name,omitempty" validate:"omitempty,max=255"`
+	URL             *string  `json:"url,omitempty"`
+	Recipients      []string `json:"recipients,omitempty"`
+	SubjectTemplate *string  `json:"subject_template,omitempty"`
+	BodyTemplate    *string  `json:"body_template,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+	RotateSecret    bool     `json:"rotate_secret,omitempty"`
+}
+
+// NotificationChannelResponse represents the response structure for notification channel data
+// Secret is only included when it is issued, on creation or rotation
+type NotificationChannelResponse struct {
+	ID              uuid.UUID `json:"id"`
+	OrganizationID  uuid.UUID `json:"organization_id"`
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`
+	URL             string    `json:"url,omitempty"`
+	Secret          string    `json:"secret,omitempty"`
+	Recipients      []string  `json:"recipients,omitempty"`
+	SubjectTemplate string    `json:"subject_template,omitempty"`
+	BodyTemplate    string    `json:"body_template,omitempty"`
+	Enabled         bool      `json:"enabled"`
+	CreatedByID     uuid.UUID `json:"created_by_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// NotificationDeliveryResponse represents a single entry of a channel's delivery log
+type NotificationDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ChannelID      uuid.UUID  `json:"channel_id"`
+	AlertRuleID    *uuid.UUID `json:"alert_rule_id,omitempty"`
+	MonitorID      *uuid.UUID `json:"monitor_id,omitempty"`
+	Event          string     `json:"event"`
+	Subject        string     `json:"subject,omitempty"`
+	Body           string     `json:"body"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// NotificationWebhookPayload is the JSON body posted to webhook channels
+// The same delivery ID is sent on every retry so receivers can deduplicate
+// Alert is set for "alert.*" and test events, Monitor for "monitor.*" events
+type NotificationWebhookPayload struct {
+	ID      uuid.UUID            `json:"id"`
+	Event   string               `json:"event"`
+	Test    bool                 `json:"test,omitempty"`
+	Message string               `json:"message"`
+	Alert   *NotificationAlert   `json:"alert,omitempty"`
+	Monitor *NotificationMonitor `json:"monitor,omitempty"`
+}
+
+// NotificationAlert describes the alert rule state change a notification is about
+type NotificationAlert struct {
+	RuleID      uuid.UUID `json:"rule_id"`
+	RuleName    string    `json:"rule_name"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	State       string    `json:"state"`
+	Value       float64   `json:"value"`
+	Aggregation string    `json:"aggregation"`
+	Comparison  string    `json:"comparison"`
+	Threshold   float64   `json:"threshold"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
+
+// SlackMessage is the JSON body posted to Slack-compatible incoming webhooks
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+// NotificationMonitor describes the monitor status change a notification is about
+type NotificationMonitor struct {
+	MonitorID   uuid.UUID  `json:"monitor_id"`
+	MonitorName string     `json:"monitor_name"`
+	MonitorSlug string     `json:"monitor_slug"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	Status      string     `json:"status"`
+	CheckInID   uuid.UUID  `json:"check_in_id"`
+	ExpectedAt  *time.Time `json:"expected_at,omitempty"`
+	Message     string     `json:"message,omitempty"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+}
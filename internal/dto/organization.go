@@ -13,26 +13,38 @@ type CreateOrganizationRequest struct {
 
 // UpdateOrganizationRequest represents the request payload for updating an organization
 type UpdateOrganizationRequest struct {
+	Name             *string          `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	DefaultRetention *RetentionPolicy `json:"default_retention,omitempty"`
+}
+
+// RestoreOrganizationRequest represents the optional payload for restoring a deleted organization
+// Name is only needed when another organization has taken the original name since deletion
+type RestoreOrganizationRequest struct {
 	Name *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
 }
 
 // OrganizationResponse represents the response structure for organization data
 type OrganizationResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	OwnerID   uuid.UUID `json:"owner_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uuid.UUID       `json:"id"`
+	Name             string          `json:"name"`
+	OwnerID          uuid.UUID       `json:"owner_id"`
+	DefaultRetention RetentionPolicy `json:"default_retention"`
+	Plan             string          `json:"plan"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	DeletedAt        *time.Time      `json:"deleted_at,omitempty"`
 }
 
 // OrganizationWithProjectsResponse represents organization data with its projects
 type OrganizationWithProjectsResponse struct {
-	ID        uuid.UUID         `json:"id"`
-	Name      string            `json:"name"`
-	OwnerID   uuid.UUID         `json:"owner_id"`
-	Projects  []ProjectResponse `json:"projects"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID               uuid.UUID         `json:"id"`
+	Name             string            `json:"name"`
+	OwnerID          uuid.UUID         `json:"owner_id"`
+	DefaultRetention RetentionPolicy   `json:"default_retention"`
+	Plan             string            `json:"plan"`
+	Projects         []ProjectResponse `json:"projects"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
 }
 
 // UserOrganizationCheckResponse represents the response for checking user's organization membership
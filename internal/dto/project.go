@@ -14,17 +14,30 @@ type CreateProjectRequest struct {
 
 // UpdateProjectRequest represents the request payload for updating a project
 type UpdateProjectRequest struct {
+	Name      *string          `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+
+	// IndexedAttributes replaces the attributes whose values are full-text indexed
+	IndexedAttributes *[]string `json:"indexed_attributes,omitempty"`
+}
+
+// RestoreProjectRequest represents the optional payload for restoring a deleted project
+// Name is only needed when another project has taken the original name since deletion
+type RestoreProjectRequest struct {
 	Name *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
 }
 
 // ProjectResponse represents the response structure for project data
 type ProjectResponse struct {
-	ID             uuid.UUID `json:"id"`
-	OrganizationID uuid.UUID `json:"organization_id"`
-	Name           string    `json:"name"`
-	APIKey         string    `json:"api_key"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID                uuid.UUID                `json:"id"`
+	OrganizationID    uuid.UUID                `json:"organization_id"`
+	Name              string                   `json:"name"`
+	APIKey            string                   `json:"api_key"`
+	Retention         ProjectRetentionResponse `json:"retention"`
+	IndexedAttributes []string                 `json:"indexed_attributes"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+	DeletedAt         *time.Time               `json:"deleted_at,omitempty"`
 }
 
 // OnboardingRequest represents the request payload for the complete onboarding flow
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateReleaseRequest represents the request payload for creating a release
+type CreateReleaseRequest struct {
+	Version   string `json:"version" validate:"required"`
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+// CreateDeployRequest represents the request payload for recording a deploy
+// DeployedAt defaults to the time of the request
+type CreateDeployRequest struct {
+	Environment string     `json:"environment" validate:"required"`
+	DeployedAt  *time.Time `json:"deployed_at,omitempty"`
+}
+
+// DeployResponse represents the response structure for deploy data
+type DeployResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ReleaseID   uuid.UUID  `json:"release_id"`
+	Environment string     `json:"environment"`
+	DeployedAt  time.Time  `json:"deployed_at"`
+	CreatedByID *uuid.UUID `json:"created_by_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ReleaseStats summarizes a release's health compared to the release before it
+// ErrorRate is the fraction of the release's events at error or fatal level
+// ErrorRateDelta is ErrorRate minus the previous release's rate, and is only set when both have events
+type ReleaseStats struct {
+	EventCount        int64    `json:"event_count"`
+	ErrorCount        int64    `json:"error_count"`
+	ErrorRate         float64  `json:"error_rate"`
+	NewIssues         int64    `json:"new_issues"`
+	PreviousVersion   string   `json:"previous_version,omitempty"`
+	PreviousErrorRate *float64 `json:"previous_error_rate,omitempty"`
+	ErrorRateDelta    *float64 `json:"error_rate_delta,omitempty"`
+}
+
+// ReleaseResponse represents the response structure for release data
+// Deploys is only filled in when a single release is requested
+type ReleaseResponse struct {
+	ID           uuid.UUID        `json:"id"`
+	ProjectID    uuid.UUID        `json:"project_id"`
+	Version      string           `json:"version"`
+	CommitSHA    string           `json:"commit_sha,omitempty"`
+	FirstEventAt *time.Time       `json:"first_event_at,omitempty"`
+	LastEventAt  *time.Time       `json:"last_event_at,omitempty"`
+	Stats        ReleaseStats     `json:"stats"`
+	LastDeploy   *DeployResponse  `json:"last_deploy,omitempty"`
+	Deploys      []DeployResponse `json:"deploys,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
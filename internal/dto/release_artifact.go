@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReleaseArtifactResponse represents the response structure for an uploaded release artifact
+type ReleaseArtifactResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	Release     string    `json:"release"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	CreatedByID uuid.UUID `json:"created_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
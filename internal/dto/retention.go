@@ -0,0 +1,14 @@
+package dto
+
+// RetentionPolicy represents log retention durations in days, optionally broken down per log level
+type RetentionPolicy struct {
+	DefaultDays int            `json:"default_days"`
+	LevelDays   map[string]int `json:"level_days,omitempty"`
+}
+
+// ProjectRetentionResponse represents a project's effective retention policy
+type ProjectRetentionResponse struct {
+	DefaultDays int            `json:"default_days"`
+	LevelDays   map[string]int `json:"level_days,omitempty"`
+	Inherited   bool           `json:"inherited"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSavedSearchRequest represents the request payload for creating a saved search
+type CreateSavedSearchRequest struct {
+	Name      string   `json:"name" validate:"required,min=2,max=255"`
+	Query     string   `json:"query" validate:"required"`
+	TimeRange string   `json:"time_range" validate:"required"`
+	Columns   []string `json:"columns,omitempty"`
+}
+
+// UpdateSavedSearchRequest represents the request payload for updating a saved search
+type UpdateSavedSearchRequest struct {
+	Name      *string  `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Query     *string  `json:"query,omitempty"`
+	TimeRange *string  `json:"time_range,omitempty"`
+	Columns   []string `json:"columns,omitempty"`
+}
+
+// SavedSearchResponse represents the response structure for saved search data
+type SavedSearchResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           string    `json:"name"`
+	Query          string    `json:"query"`
+	TimeRange      string    `json:"time_range"`
+	Columns        []string  `json:"columns"`
+	CreatedByID    uuid.UUID `json:"created_by_id"`
+	ShareToken     *string   `json:"share_token"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
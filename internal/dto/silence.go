@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SilenceRecurrence describes a weekly maintenance window
+// Days are weekday names such as "saturday", StartTime is "HH:MM" in Timezone and
+// Duration is how long each window lasts, such as "2h"
+type SilenceRecurrence struct {
+	Days      []string `json:"days"`
+	StartTime string   `json:"start_time"`
+	Duration  string   `json:"duration"`
+	Timezone  string   `json:"timezone"`
+}
+
+// CreateSilenceRequest represents the request payload for creating a silence
+// Leave ProjectID empty to cover every project of the organization and Matchers empty to
+// cover every alert rule and monitor in scope. StartsAt defaults to now; EndsAt is required unless the
+// silence recurs
+type CreateSilenceRequest struct {
+	ProjectID  *uuid.UUID         `json:"project_id,omitempty"`
+	Matchers   map[string]string  `json:"matchers,omitempty"`
+	Comment    string             `json:"comment" validate:"required"`
+	StartsAt   *time.Time         `json:"starts_at,omitempty"`
+	EndsAt     *time.Time         `json:"ends_at,omitempty"`
+	Recurrence *SilenceRecurrence `json:"recurrence,omitempty"`
+}
+
+// UpdateSilenceRequest represents the request payload for updating a silence
+// Set Matchers to an empty object to cover every rule and monitor in scope, ClearEndsAt to let a recurring
+// silence repeat until expired, and ClearRecurrence to turn it back into a one-off silence
+type UpdateSilenceRequest struct {
+	Matchers        map[string]string  `json:"matchers,omitempty"`
+	Comment         *string            `json:"comment,omitempty"`
+	StartsAt        *time.Time         `json:"starts_at,omitempty"`
+	EndsAt          *time.Time         `json:"ends_at,omitempty"`
+	ClearEndsAt     bool               `json:"clear_ends_at,omitempty"`
+	Recurrence      *SilenceRecurrence `json:"recurrence,omitempty"`
+	ClearRecurrence bool               `json:"clear_recurrence,omitempty"`
+}
+
+// SilenceResponse represents the response structure for silence data
+// State is "scheduled", "active" or "expired"; InEffect tells whether notifications are muted
+// right now, which for recurring silences is only during a window
+type SilenceResponse struct {
+	ID             uuid.UUID          `json:"id"`
+	OrganizationID uuid.UUID          `json:"organization_id"`
+	ProjectID      *uuid.UUID         `json:"project_id"`
+	Matchers       map[string]string  `json:"matchers"`
+	Comment        string             `json:"comment"`
+	StartsAt       time.Time          `json:"starts_at"`
+	EndsAt         *time.Time         `json:"ends_at"`
+	Recurrence     *SilenceRecurrence `json:"recurrence,omitempty"`
+	State          string             `json:"state"`
+	InEffect       bool               `json:"in_effect"`
+	CreatedByID    uuid.UUID          `json:"created_by_id"`
+	ExpiredAt      *time.Time         `json:"expired_at"`
+	ExpiredByID    *uuid.UUID         `json:"expired_by_id"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// SilenceAuditEntryResponse represents a single entry of the silence audit trail
+type SilenceAuditEntryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	SilenceID      uuid.UUID  `json:"silence_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Action         string     `json:"action"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	AlertRuleID    *uuid.UUID `json:"alert_rule_id,omitempty"`
+	MonitorID      *uuid.UUID `json:"monitor_id,omitempty"`
+	Details        string     `json:"details"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
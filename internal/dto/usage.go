@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageTotals represents rolled-up ingest and storage counters
+type UsageTotals struct {
+	Events      int64 `json:"events"`
+	RawBytes    int64 `json:"raw_bytes"`
+	StoredBytes int64 `json:"stored_bytes"`
+}
+
+// ProjectUsage represents a project's usage within a period, broken down by log level
+type ProjectUsage struct {
+	ProjectID   uuid.UUID              `json:"project_id"`
+	ProjectName string                 `json:"project_name"`
+	Totals      UsageTotals            `json:"totals"`
+	Levels      map[string]UsageTotals `json:"levels"`
+}
+
+// UsagePeriod represents usage for a single day or month
+type UsagePeriod struct {
+	Start    time.Time       `json:"start"`
+	Totals   UsageTotals     `json:"totals"`
+	Projects []*ProjectUsage `json:"projects"`
+}
+
+// OrganizationUsageResponse represents the usage report for an organization
+type OrganizationUsageResponse struct {
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	Granularity    string         `json:"granularity"`
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	Totals         UsageTotals    `json:"totals"`
+	Periods        []*UsagePeriod `json:"periods"`
+}
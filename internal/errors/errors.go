@@ -17,6 +17,7 @@ const (
 	ErrorTypeInternal       ErrorType = "INTERNAL_ERROR"
 	ErrorTypeBadRequest     ErrorType = "BAD_REQUEST"
 	ErrorTypeServiceUnavailable ErrorType = "SERVICE_UNAVAILABLE"
+	ErrorTypeGone           ErrorType = "GONE"
 )
 
 // AppError represents a structured application error
@@ -49,6 +50,8 @@ func (e *AppError) HTTPStatus() int {
 		return http.StatusConflict
 	case ErrorTypeServiceUnavailable:
 		return http.StatusServiceUnavailable
+	case ErrorTypeGone:
+		return http.StatusGone
 	case ErrorTypeInternal:
 		return http.StatusInternalServerError
 	default:
@@ -156,6 +159,20 @@ func NewBadRequestError(message string, details ...string) *AppError {
 	}
 }
 
+// NewGoneError creates an error for a resource that existed but can no longer be retrieved
+func NewGoneError(message string, details ...string) *AppError {
+	var detail string
+	if len(details) > 0 {
+		detail = details[0]
+	}
+	return &AppError{
+		Type:    ErrorTypeGone,
+		Message: message,
+		Details: detail,
+		Code:    "GONE_001",
+	}
+}
+
 // Helper functions to check error types
 
 // IsValidationError checks if error is a validation error
@@ -189,3 +206,11 @@ func IsConflictError(err error) bool {
 	}
 	return false
 }
+
+// IsGoneError checks if error is a gone error
+func IsGoneError(err error) bool {
+	if appErr, ok := err.(*AppError); ok {
+		return appErr.Type == ErrorTypeGone
+	}
+	return false
+}
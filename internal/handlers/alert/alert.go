@@ -0,0 +1,309 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	alertRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/alert"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	savedSearchRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/savedsearch"
+	alertService "github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	savedSearchService "github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles alert rule-related HTTP requests
+type Handler struct {
+	alertService   *alertService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new alert rule handler
+// Rules are checked against the log store, as the scheduler evaluates them
+func NewHandler(db *gorm.DB, logStore logstore.LogStore) *Handler {
+	alertRepository := alertRepo.NewRepository(db)
+	alertSource := alertService.NewLogStoreSource(logStore)
+	alertSvc := alertService.NewService(alertRepository, notificationRepo.NewRepository(db), savedSearchService.NewService(savedSearchRepo.NewRepository(db)), alertSource)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		alertService:   alertSvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndAlertIDs parses the project and alert rule IDs from the URL
+func (h *Handler) parseProjectAndAlertIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	alertID, err := uuid.Parse(chi.URLParam(r, "alertId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid alert rule ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, alertID, true
+}
+
+// Create handles POST /api/v1/projects/{id}/alerts
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateAlertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create alert rule through service
+	rule, err := h.alertService.CreateAlertRule(r.Context(), projectID, req, currentUserID)
+	if err != nil {
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to create alert rule", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to create alert rule", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Alert rule created successfully", rule)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/alerts
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get alert rules for project
+	rules, err := h.alertService.GetAlertRulesByProject(r.Context(), projectID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve alert rules: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Alert rules retrieved successfully", rules)
+}
+
+// GetByID handles GET /api/v1/projects/{id}/alerts/{alertId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, alertID, ok := h.parseProjectAndAlertIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get alert rule through service
+	rule, err := h.alertService.GetAlertRuleByID(r.Context(), alertID, projectID)
+	if err != nil {
+		response.SendNotFound(w, "Alert rule")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Alert rule retrieved successfully", rule)
+}
+
+// Update handles PUT /api/v1/projects/{id}/alerts/{alertId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, alertID, ok := h.parseProjectAndAlertIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateAlertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update alert rule through service
+	rule, err := h.alertService.UpdateAlertRule(r.Context(), alertID, req, projectID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Alert rule")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to update alert rule", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to update alert rule", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Alert rule updated successfully", rule)
+}
+
+// Delete handles DELETE /api/v1/projects/{id}/alerts/{alertId}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, alertID, ok := h.parseProjectAndAlertIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Delete alert rule through service
+	if err := h.alertService.DeleteAlertRule(r.Context(), alertID, projectID); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Alert rule")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to delete alert rule", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Alert rule deleted successfully", nil)
+}
+
+// GetEvaluations handles GET /api/v1/projects/{id}/alerts/{alertId}/evaluations
+// Query parameters: limit
+func (h *Handler) GetEvaluations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, alertID, ok := h.parseProjectAndAlertIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get evaluation history through service
+	evaluations, err := h.alertService.GetAlertEvaluations(r.Context(), alertID, projectID, limit)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Alert rule")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve alert evaluations", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Alert evaluations retrieved successfully", evaluations)
+}
@@ -0,0 +1,197 @@
+package anomaly
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	anomalyRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/anomaly"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	anomalyService "github.com/nihar-hegde/valtro-backend/internal/services/anomaly"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles anomaly detection-related HTTP requests
+type Handler struct {
+	anomalyService *anomalyService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new anomaly handler
+func NewHandler(db *gorm.DB) *Handler {
+	anomalyRepository := anomalyRepo.NewRepository(db)
+	anomalySvc := anomalyService.NewService(anomalyRepository)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		anomalyService: anomalySvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectID parses the project ID from the URL and verifies ownership
+func (h *Handler) parseProjectID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	if _, valid := h.validateProjectOwnership(w, r, projectID); !valid {
+		return uuid.Nil, false
+	}
+
+	return projectID, true
+}
+
+// GetAll handles GET /api/v1/projects/{id}/anomalies
+// Query parameters: status ("open" or "resolved"), limit
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := h.parseProjectID(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get anomalies through service
+	anomalies, err := h.anomalyService.GetAnomalies(r.Context(), projectID, r.URL.Query().Get("status"), limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve anomalies", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Anomalies retrieved successfully", anomalies)
+}
+
+// GetSettings handles GET /api/v1/projects/{id}/anomalies/settings
+func (h *Handler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := h.parseProjectID(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Get settings through service
+	settings, err := h.anomalyService.GetSettings(r.Context(), projectID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve anomaly settings: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Anomaly settings retrieved successfully", settings)
+}
+
+// UpdateSettings handles PUT /api/v1/projects/{id}/anomalies/settings
+func (h *Handler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := h.parseProjectID(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateAnomalySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update settings through service
+	settings, err := h.anomalyService.UpdateSettings(r.Context(), projectID, req)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to update anomaly settings", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Anomaly settings updated successfully", settings)
+}
+
+// GetBaselines handles GET /api/v1/projects/{id}/anomalies/baselines
+// Query parameters: level
+func (h *Handler) GetBaselines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := h.parseProjectID(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Get baselines through service
+	baselines, err := h.anomalyService.GetBaselines(r.Context(), projectID, r.URL.Query().Get("level"))
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve volume baselines: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Volume baselines retrieved successfully", baselines)
+}
@@ -0,0 +1,227 @@
+package artifact
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	artifactService "github.com/nihar-hegde/valtro-backend/internal/services/artifact"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// multipartOverhead allows for form fields and boundaries around the uploaded file
+const multipartOverhead = 1 << 20
+
+// Handler handles release artifact-related HTTP requests
+type Handler struct {
+	artifactService *artifactService.Service
+	projectService  *projectService.Service
+	orgService      *orgService.Service
+}
+
+// NewHandler creates a new release artifact handler
+// The artifact service is shared with the other handlers so its source map cache is built once
+func NewHandler(db *gorm.DB, artifactSvc *artifactService.Service) *Handler {
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		artifactService: artifactSvc,
+		projectService:  projectSvc,
+		orgService:      orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndRelease parses the project ID and release from the URL
+func (h *Handler) parseProjectAndRelease(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, "", false
+	}
+
+	// Releases often contain characters such as "@" or "+" that clients percent-encode
+	release, err := url.PathUnescape(chi.URLParam(r, "release"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid release: "+err.Error())
+		return uuid.Nil, "", false
+	}
+
+	return projectID, release, true
+}
+
+// Upload handles POST /api/v1/projects/{id}/releases/{release}/artifacts
+// Expects a multipart form with a "file" part and an optional "name" field
+// (e.g. "~/static/js/app.js.map"); the name defaults to "~/" plus the file name
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, release, ok := h.parseProjectAndRelease(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse multipart form, capping the request size
+	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxArtifactSize+multipartOverhead)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.SendError(w, http.StatusRequestEntityTooLarge, "Artifact too large", fmt.Sprintf("Artifacts must be at most %d bytes", constants.MaxArtifactSize))
+			return
+		}
+		response.SendValidationError(w, "Invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.SendValidationError(w, "Missing 'file' part: "+err.Error())
+		return
+	}
+	defer file.Close()
+
+	if header.Size > constants.MaxArtifactSize {
+		response.SendError(w, http.StatusRequestEntityTooLarge, "Artifact too large", fmt.Sprintf("Artifacts must be at most %d bytes", constants.MaxArtifactSize))
+		return
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		response.SendValidationError(w, "Failed to read uploaded file: "+err.Error())
+		return
+	}
+
+	name := r.FormValue("name")
+	if name == "" {
+		name = "~/" + header.Filename
+	}
+
+	// Upload artifact through service
+	artifact, err := h.artifactService.UploadArtifact(r.Context(), projectID, release, name, content, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to upload release artifact", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Release artifact uploaded successfully", artifact)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/releases/{release}/artifacts
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, release, ok := h.parseProjectAndRelease(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get artifacts for release
+	artifacts, err := h.artifactService.GetArtifactsByRelease(r.Context(), projectID, release)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve release artifacts: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Release artifacts retrieved successfully", artifacts)
+}
+
+// Delete handles DELETE /api/v1/projects/{id}/releases/{release}/artifacts/{artifactId}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, release, ok := h.parseProjectAndRelease(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	artifactID, err := uuid.Parse(chi.URLParam(r, "artifactId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid artifact ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Delete artifact through service
+	if err := h.artifactService.DeleteArtifact(r.Context(), artifactID, projectID, release); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Release artifact")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to delete release artifact", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Release artifact deleted successfully", nil)
+}
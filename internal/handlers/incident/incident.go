@@ -0,0 +1,456 @@
+package incident
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	incidentRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/incident"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	savedSearchRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/savedsearch"
+	incidentService "github.com/nihar-hegde/valtro-backend/internal/services/incident"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	savedSearchService "github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles incident-related HTTP requests
+type Handler struct {
+	incidentService *incidentService.Service
+	projectService  *projectService.Service
+	orgService      *orgService.Service
+}
+
+// NewHandler creates a new incident handler
+func NewHandler(db *gorm.DB) *Handler {
+	incidentRepository := incidentRepo.NewRepository(db)
+	incidentSvc := incidentService.NewService(incidentRepository, savedSearchService.NewService(savedSearchRepo.NewRepository(db)))
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		incidentService: incidentSvc,
+		projectService:  projectSvc,
+		orgService:      orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndIncidentIDs parses the project and incident IDs from the URL
+func (h *Handler) parseProjectAndIncidentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	incidentID, err := uuid.Parse(chi.URLParam(r, "incidentId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid incident ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, incidentID, true
+}
+
+// Create handles POST /api/v1/projects/{id}/incidents
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create incident through service
+	incident, err := h.incidentService.CreateIncident(r.Context(), projectID, req, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to create incident", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Incident created successfully", incident)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/incidents
+// Query parameters: status ("open", "acknowledged" or "resolved"), limit
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get incidents for project
+	incidents, err := h.incidentService.GetIncidentsByProject(r.Context(), projectID, r.URL.Query().Get("status"), limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve incidents", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Incidents retrieved successfully", incidents)
+}
+
+// GetByID handles GET /api/v1/projects/{id}/incidents/{incidentId}
+// The response includes the incident's alert evaluations, linked issues and timeline
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get incident through service
+	incident, err := h.incidentService.GetIncident(r.Context(), incidentID, projectID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		response.SendInternalError(w, "Failed to retrieve incident: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Incident retrieved successfully", incident)
+}
+
+// Update handles PUT /api/v1/projects/{id}/incidents/{incidentId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update incident through service
+	incident, err := h.incidentService.UpdateIncident(r.Context(), incidentID, req, projectID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to update incident", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Incident updated successfully", incident)
+}
+
+// Acknowledge handles POST /api/v1/projects/{id}/incidents/{incidentId}/acknowledge
+func (h *Handler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Acknowledge incident through service
+	incident, err := h.incidentService.AcknowledgeIncident(r.Context(), incidentID, projectID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to acknowledge incident", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to acknowledge incident", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Incident acknowledged successfully", incident)
+}
+
+// Resolve handles POST /api/v1/projects/{id}/incidents/{incidentId}/resolve
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Resolve incident through service
+	incident, err := h.incidentService.ResolveIncident(r.Context(), incidentID, projectID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to resolve incident", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to resolve incident", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Incident resolved successfully", incident)
+}
+
+// AddNote handles POST /api/v1/projects/{id}/incidents/{incidentId}/notes
+func (h *Handler) AddNote(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.AddIncidentNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Add note through service
+	entry, err := h.incidentService.AddNote(r.Context(), incidentID, req, projectID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to add note", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Note added successfully", entry)
+}
+
+// LinkIssue handles POST /api/v1/projects/{id}/incidents/{incidentId}/issues
+func (h *Handler) LinkIssue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.LinkIncidentIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Link issue through service
+	if err := h.incidentService.LinkIssue(r.Context(), incidentID, req, projectID, currentUserID); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendError(w, http.StatusNotFound, "Failed to link issue", err.Error())
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to link issue", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to link issue", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Issue linked successfully", nil)
+}
+
+// UnlinkIssue handles DELETE /api/v1/projects/{id}/incidents/{incidentId}/issues/{issueId}
+func (h *Handler) UnlinkIssue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	issueID, err := uuid.Parse(chi.URLParam(r, "issueId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid issue ID: "+err.Error())
+		return
+	}
+
+	// Unlink issue through service
+	if err := h.incidentService.UnlinkIssue(r.Context(), incidentID, issueID, projectID, currentUserID); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendError(w, http.StatusNotFound, "Failed to unlink issue", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to unlink issue", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issue unlinked successfully", nil)
+}
+
+// Export handles GET /api/v1/projects/{id}/incidents/{incidentId}/export
+// Query parameters: format ("markdown" or "json")
+// The incident and its timeline are sent as a file download for post-mortems
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	projectID, incidentID, ok := h.parseProjectAndIncidentIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Export incident through service
+	export, err := h.incidentService.ExportIncident(r.Context(), incidentID, projectID, r.URL.Query().Get("format"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Incident")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to export incident", err.Error())
+		return
+	}
+
+	// Send the export as a download
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Content)
+}
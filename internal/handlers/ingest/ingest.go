@@ -0,0 +1,84 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	issueRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/issue"
+	patternRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/pattern"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	releaseRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/release"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
+	artifactService "github.com/nihar-hegde/valtro-backend/internal/services/artifact"
+	ingestService "github.com/nihar-hegde/valtro-backend/internal/services/ingest"
+	issueService "github.com/nihar-hegde/valtro-backend/internal/services/issue"
+	patternService "github.com/nihar-hegde/valtro-backend/internal/services/pattern"
+	releaseService "github.com/nihar-hegde/valtro-backend/internal/services/release"
+	usageService "github.com/nihar-hegde/valtro-backend/internal/services/usage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles log ingestion HTTP requests
+type Handler struct {
+	ingestService *ingestService.Service
+}
+
+// NewHandler creates a new ingest handler
+// The artifact service symbolicates error events with their release's source maps before grouping
+func NewHandler(db *gorm.DB, logStore logstore.LogStore, artifactSvc *artifactService.Service) *Handler {
+	patternSvc := patternService.NewService(patternRepo.NewRepository(db))
+	usageSvc := usageService.NewService(usageRepo.NewRepository(db))
+	releaseSvc := releaseService.NewService(releaseRepo.NewRepository(db))
+	issueSvc := issueService.NewService(issueRepo.NewRepository(db), projectRepo.NewRepository(db))
+
+	return &Handler{
+		ingestService: ingestService.NewService(logStore, projectRepo.NewRepository(db), patternSvc, usageSvc, releaseSvc, issueSvc, artifactSvc),
+	}
+}
+
+// IngestLogs handles POST /api/v1/ingest/logs
+func (h *Handler) IngestLogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from API key middleware
+	projectID, ok := middleware.GetProjectIDFromContext(r.Context())
+	if !ok {
+		response.SendUnauthorized(w, "Project API key required")
+		return
+	}
+
+	// Parse request body, capped so one request cannot exhaust memory
+	var req dto.IngestLogsRequest
+	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxIngestBodySize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.SendError(w, http.StatusRequestEntityTooLarge, "Batch too large", fmt.Sprintf("Request bodies must be at most %d bytes", constants.MaxIngestBodySize))
+			return
+		}
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Store events through service
+	result, err := h.ingestService.IngestLogs(r.Context(), projectID, req)
+	if err != nil {
+		if appErrors.IsValidationError(err) {
+			response.SendError(w, http.StatusBadRequest, "Failed to ingest logs", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusInternalServerError, "Failed to ingest logs", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusAccepted, "Log events accepted", result)
+}
@@ -0,0 +1,266 @@
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	issueRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/issue"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	artifactService "github.com/nihar-hegde/valtro-backend/internal/services/artifact"
+	issueService "github.com/nihar-hegde/valtro-backend/internal/services/issue"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles issue-related HTTP requests
+type Handler struct {
+	issueService    *issueService.Service
+	artifactService *artifactService.Service
+	projectService  *projectService.Service
+	orgService      *orgService.Service
+}
+
+// NewHandler creates a new issue handler
+// The artifact service is shared with the other handlers so its source map cache is built once
+func NewHandler(db *gorm.DB, artifactSvc *artifactService.Service) *Handler {
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	issueRepository := issueRepo.NewRepository(db)
+	issueSvc := issueService.NewService(issueRepository, projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		issueService:    issueSvc,
+		artifactService: artifactSvc,
+		projectService:  projectSvc,
+		orgService:      orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndIssueIDs parses the project and issue IDs from the URL
+func (h *Handler) parseProjectAndIssueIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	issueID, err := uuid.Parse(chi.URLParam(r, "issueId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid issue ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, issueID, true
+}
+
+// GetAll handles GET /api/v1/projects/{id}/issues
+// Query parameters: status, sort (recency|frequency|new) and limit
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get issues for project
+	issues, err := h.issueService.GetIssuesByProject(r.Context(), projectID, query.Get("status"), query.Get("sort"), limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve issues", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issues retrieved successfully", issues)
+}
+
+// GetByID handles GET /api/v1/projects/{id}/issues/{issueId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, issueID, ok := h.parseProjectAndIssueIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get issue through service
+	issue, err := h.issueService.GetIssueByID(r.Context(), issueID, projectID)
+	if err != nil {
+		response.SendNotFound(w, "Issue")
+		return
+	}
+
+	// Symbolicate samples recorded before their release's source maps were uploaded
+	for _, sample := range issue.SampleEvents {
+		h.artifactService.SymbolicateException(r.Context(), projectID, sample.Release, sample.Exception)
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issue retrieved successfully", issue)
+}
+
+// UpdateStatus handles PUT /api/v1/projects/{id}/issues/{issueId}/status
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, issueID, ok := h.parseProjectAndIssueIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateIssueStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update status through service
+	issue, err := h.issueService.UpdateIssueStatus(r.Context(), issueID, req, projectID, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to update issue status", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issue status updated successfully", issue)
+}
+
+// Assign handles PUT /api/v1/projects/{id}/issues/{issueId}/assignee
+func (h *Handler) Assign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, issueID, ok := h.parseProjectAndIssueIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.AssignIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Assign issue through service
+	issue, err := h.issueService.AssignIssue(r.Context(), issueID, req, projectID, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to assign issue", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issue assignment updated successfully", issue)
+}
+
+// GetActivity handles GET /api/v1/projects/{id}/issues/{issueId}/activity
+func (h *Handler) GetActivity(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, issueID, ok := h.parseProjectAndIssueIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get activity log through service
+	activity, err := h.issueService.GetIssueActivity(r.Context(), issueID, projectID)
+	if err != nil {
+		response.SendNotFound(w, "Issue")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Issue activity retrieved successfully", activity)
+}
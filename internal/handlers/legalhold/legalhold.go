@@ -0,0 +1,248 @@
+package legalhold
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	legalHoldRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/legalhold"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	legalHoldService "github.com/nihar-hegde/valtro-backend/internal/services/legalhold"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles legal hold-related HTTP requests
+type Handler struct {
+	legalHoldService *legalHoldService.Service
+	projectService   *projectService.Service
+	orgService       *orgService.Service
+}
+
+// NewHandler creates a new legal hold handler
+func NewHandler(db *gorm.DB) *Handler {
+	legalHoldRepository := legalHoldRepo.NewRepository(db)
+	legalHoldSvc := legalHoldService.NewService(legalHoldRepository)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		legalHoldService: legalHoldSvc,
+		projectService:   projectSvc,
+		orgService:       orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+// Only organization owners may place, change or release legal holds
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "Only the organization owner can manage legal holds")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndHoldIDs parses the project and legal hold IDs from the URL
+func (h *Handler) parseProjectAndHoldIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	holdID, err := uuid.Parse(chi.URLParam(r, "holdId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid legal hold ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, holdID, true
+}
+
+// Create handles POST /api/v1/projects/{id}/legal-holds
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateLegalHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Place legal hold through service
+	hold, err := h.legalHoldService.PlaceLegalHold(r.Context(), projectID, req, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to place legal hold", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Legal hold placed successfully", hold)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/legal-holds
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get legal holds for project
+	holds, err := h.legalHoldService.GetLegalHoldsByProject(r.Context(), projectID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve legal holds: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Legal holds retrieved successfully", holds)
+}
+
+// GetAuditTrail handles GET /api/v1/projects/{id}/legal-holds/audit
+func (h *Handler) GetAuditTrail(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get audit trail for project
+	entries, err := h.legalHoldService.GetAuditTrailByProject(r.Context(), projectID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve legal hold audit trail: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Legal hold audit trail retrieved successfully", entries)
+}
+
+// Update handles PUT /api/v1/projects/{id}/legal-holds/{holdId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, holdID, ok := h.parseProjectAndHoldIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateLegalHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update legal hold through service
+	hold, err := h.legalHoldService.UpdateLegalHold(r.Context(), holdID, req, projectID, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to update legal hold", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Legal hold updated successfully", hold)
+}
+
+// Release handles DELETE /api/v1/projects/{id}/legal-holds/{holdId}
+func (h *Handler) Release(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, holdID, ok := h.parseProjectAndHoldIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Release legal hold through service
+	hold, err := h.legalHoldService.ReleaseLegalHold(r.Context(), holdID, projectID, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to release legal hold", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Legal hold released successfully", hold)
+}
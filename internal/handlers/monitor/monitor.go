@@ -0,0 +1,354 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	monitorRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/monitor"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	silenceRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/silence"
+	monitorService "github.com/nihar-hegde/valtro-backend/internal/services/monitor"
+	notificationService "github.com/nihar-hegde/valtro-backend/internal/services/notification"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	silenceService "github.com/nihar-hegde/valtro-backend/internal/services/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles monitor and check-in HTTP requests
+type Handler struct {
+	monitorService *monitorService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new monitor handler
+func NewHandler(db *gorm.DB) *Handler {
+	// Check-ins notify through silences, like the scheduler does
+	notificationRepository := notificationRepo.NewRepository(db)
+	sender := notificationService.NewService(notificationRepository, notificationService.ConfigFromEnv())
+	notifier := silenceService.NewNotifier(silenceService.NewService(silenceRepo.NewRepository(db)), sender, silenceService.NotifierConfigFromEnv())
+	monitorSvc := monitorService.NewService(monitorRepo.NewRepository(db), notificationRepository, notifier)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		monitorService: monitorSvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndMonitorIDs parses the project and monitor IDs from the URL
+func (h *Handler) parseProjectAndMonitorIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	monitorID, err := uuid.Parse(chi.URLParam(r, "monitorId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid monitor ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, monitorID, true
+}
+
+// Create handles POST /api/v1/projects/{id}/monitors
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateMonitorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create monitor through service
+	monitor, err := h.monitorService.CreateMonitor(r.Context(), projectID, req, currentUserID)
+	if err != nil {
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to create monitor", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to create monitor", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Monitor created successfully", monitor)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/monitors
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get monitors for project
+	monitors, err := h.monitorService.GetMonitorsByProject(r.Context(), projectID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve monitors: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Monitors retrieved successfully", monitors)
+}
+
+// GetByID handles GET /api/v1/projects/{id}/monitors/{monitorId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, monitorID, ok := h.parseProjectAndMonitorIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get monitor through service
+	monitor, err := h.monitorService.GetMonitorByID(r.Context(), monitorID, projectID)
+	if err != nil {
+		response.SendNotFound(w, "Monitor")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Monitor retrieved successfully", monitor)
+}
+
+// Update handles PUT /api/v1/projects/{id}/monitors/{monitorId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, monitorID, ok := h.parseProjectAndMonitorIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateMonitorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update monitor through service
+	monitor, err := h.monitorService.UpdateMonitor(r.Context(), monitorID, req, projectID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Monitor")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to update monitor", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to update monitor", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Monitor updated successfully", monitor)
+}
+
+// Delete handles DELETE /api/v1/projects/{id}/monitors/{monitorId}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, monitorID, ok := h.parseProjectAndMonitorIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Delete monitor through service
+	if err := h.monitorService.DeleteMonitor(r.Context(), monitorID, projectID); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Monitor")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to delete monitor", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Monitor deleted successfully", nil)
+}
+
+// GetCheckIns handles GET /api/v1/projects/{id}/monitors/{monitorId}/check-ins
+// Query parameters: status ("in_progress", "ok", "error", "missed" or "timeout"), limit
+func (h *Handler) GetCheckIns(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, monitorID, ok := h.parseProjectAndMonitorIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get run history through service
+	checkIns, err := h.monitorService.GetCheckIns(r.Context(), monitorID, projectID, r.URL.Query().Get("status"), limit)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Monitor")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve check-ins", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Check-ins retrieved successfully", checkIns)
+}
+
+// CheckIn handles POST /api/v1/monitors/{slug}/check-ins
+// Authenticated with the project API key; the body is optional and defaults to an "ok" check-in,
+// so a heartbeat can be as simple as:
+//
+//	curl -X POST -H "Authorization: Bearer $VALTRO_API_KEY" https://<host>/api/v1/monitors/nightly-backup/check-ins
+func (h *Handler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from API key middleware
+	projectID, ok := middleware.GetProjectIDFromContext(r.Context())
+	if !ok {
+		response.SendUnauthorized(w, "Project API key required")
+		return
+	}
+
+	// Parse request body, which may be empty
+	var req dto.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Record check-in through service
+	checkIn, err := h.monitorService.CheckIn(r.Context(), projectID, chi.URLParam(r, "slug"), req)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendError(w, http.StatusNotFound, "Failed to record check-in", err.Error())
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to record check-in", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to record check-in", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Check-in recorded successfully", checkIn)
+}
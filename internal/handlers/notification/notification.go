@@ -0,0 +1,326 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	notificationService "github.com/nihar-hegde/valtro-backend/internal/services/notification"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles notification channel-related HTTP requests
+type Handler struct {
+	notificationService *notificationService.Service
+	orgService          *orgService.Service
+}
+
+// NewHandler creates a new notification channel handler
+func NewHandler(db *gorm.DB) *Handler {
+	notificationRepository := notificationRepo.NewRepository(db)
+	notificationSvc := notificationService.NewService(notificationRepository, notificationService.ConfigFromEnv())
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		notificationService: notificationSvc,
+		orgService:          orgSvc,
+	}
+}
+
+// validateOrganizationOwnership is a DRY helper function to validate if user owns the organization
+func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseOrganizationAndChannelIDs parses the organization and notification channel IDs from the URL
+func (h *Handler) parseOrganizationAndChannelIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	channelID, err := uuid.Parse(chi.URLParam(r, "channelId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid notification channel ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, channelID, true
+}
+
+// Create handles POST /api/v1/organizations/{id}/notification-channels
+// The response of a webhook channel includes its signing secret, which is not shown again
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	currentUserID, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateNotificationChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create notification channel through service
+	channel, err := h.notificationService.CreateChannel(r.Context(), orgID, req, currentUserID)
+	if err != nil {
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to create notification channel", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to create notification channel", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Notification channel created successfully", channel)
+}
+
+// GetAll handles GET /api/v1/organizations/{id}/notification-channels
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get notification channels for organization
+	channels, err := h.notificationService.GetChannelsByOrganization(r.Context(), orgID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve notification channels: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Notification channels retrieved successfully", channels)
+}
+
+// GetByID handles GET /api/v1/organizations/{id}/notification-channels/{channelId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, channelID, ok := h.parseOrganizationAndChannelIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get notification channel through service
+	channel, err := h.notificationService.GetChannelByID(r.Context(), channelID, orgID)
+	if err != nil {
+		response.SendNotFound(w, "Notification channel")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Notification channel retrieved successfully", channel)
+}
+
+// Update handles PUT /api/v1/organizations/{id}/notification-channels/{channelId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, channelID, ok := h.parseOrganizationAndChannelIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateNotificationChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update notification channel through service
+	channel, err := h.notificationService.UpdateChannel(r.Context(), channelID, req, orgID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Notification channel")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to update notification channel", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to update notification channel", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Notification channel updated successfully", channel)
+}
+
+// Delete handles DELETE /api/v1/organizations/{id}/notification-channels/{channelId}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, channelID, ok := h.parseOrganizationAndChannelIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Delete notification channel through service
+	if err := h.notificationService.DeleteChannel(r.Context(), channelID, orgID); err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Notification channel")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to delete notification channel", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Notification channel deleted successfully", nil)
+}
+
+// Test handles POST /api/v1/organizations/{id}/notification-channels/{channelId}/test
+// The delivery is returned whether or not it succeeded; its status and last_error tell which
+func (h *Handler) Test(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, channelID, ok := h.parseOrganizationAndChannelIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Send test notification through service
+	delivery, err := h.notificationService.SendTest(r.Context(), channelID, orgID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Notification channel")
+			return
+		}
+		response.SendInternalError(w, "Failed to send test notification: "+err.Error())
+		return
+	}
+
+	// Send success response
+	message := "Test notification delivered successfully"
+	if delivery.Status != constants.DeliveryStatusSucceeded {
+		message = "Test notification could not be delivered"
+	}
+	response.SendSuccess(w, http.StatusOK, message, delivery)
+}
+
+// GetDeliveries handles GET /api/v1/organizations/{id}/notification-channels/{channelId}/deliveries
+// Query parameters: limit
+func (h *Handler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, channelID, ok := h.parseOrganizationAndChannelIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get delivery log through service
+	deliveries, err := h.notificationService.GetDeliveries(r.Context(), channelID, orgID, limit)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Notification channel")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve notification deliveries", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Notification deliveries retrieved successfully", deliveries)
+}
@@ -62,7 +62,7 @@ func (h *Handler) CompleteOnboarding(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Start database transaction
-	tx := h.db.Begin()
+	tx := h.db.WithContext(r.Context()).Begin()
 	if tx.Error != nil {
 		response.SendInternalError(w, "Failed to start transaction: "+tx.Error.Error())
 		return
@@ -87,7 +87,7 @@ func (h *Handler) CompleteOnboarding(w http.ResponseWriter, r *http.Request) {
 		Name: req.OrganizationName,
 	}
 
-	organization, err := orgTxService.CreateOrganization(orgRequest, userID)
+	organization, err := orgTxService.CreateOrganization(r.Context(), orgRequest, userID)
 	if err != nil {
 		tx.Rollback()
 		response.SendError(w, http.StatusBadRequest, "Failed to create organization", err.Error())
@@ -100,7 +100,7 @@ func (h *Handler) CompleteOnboarding(w http.ResponseWriter, r *http.Request) {
 		Name:           req.ProjectName,
 	}
 
-	project, err := projectTxService.CreateProject(projectRequest)
+	project, err := projectTxService.CreateProject(r.Context(), projectRequest)
 	if err != nil {
 		tx.Rollback()
 		response.SendError(w, http.StatusBadRequest, "Failed to create project", err.Error())
@@ -2,6 +2,7 @@ package organization
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,6 +10,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
 	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
 	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
 	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
@@ -64,7 +66,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	req.Name = validator.SanitizeString(req.Name)
 
 	// Create organization through service
-	organization, err := h.orgService.CreateOrganization(req, userID)
+	organization, err := h.orgService.CreateOrganization(r.Context(), req, userID)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to create organization", err.Error())
 		return
@@ -92,7 +94,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get organizations for user
-	organizations, err := h.orgService.GetOrganizationsByOwner(userID)
+	organizations, err := h.orgService.GetOrganizationsByOwner(r.Context(), userID)
 	if err != nil {
 		response.SendInternalError(w, "Failed to retrieve organizations: "+err.Error())
 		return
@@ -115,7 +117,7 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get organization through service
-	organization, err := h.orgService.GetOrganizationByID(orgID)
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
 	if err != nil {
 		response.SendNotFound(w, "Organization")
 		return
@@ -143,7 +145,7 @@ func (h *Handler) GetWithProjects(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get organization with projects
-	organization, err := h.orgService.GetOrganizationWithProjects(userID)
+	organization, err := h.orgService.GetOrganizationWithProjects(r.Context(), userID)
 	if err != nil {
 		response.SendNotFound(w, "Organization")
 		return
@@ -197,7 +199,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update organization through service
-	organization, err := h.orgService.UpdateOrganization(orgID, req, userID)
+	organization, err := h.orgService.UpdateOrganization(r.Context(), orgID, req, userID)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to update organization", err.Error())
 		return
@@ -233,7 +235,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Delete organization through service
-	if err := h.orgService.DeleteOrganization(orgID, userID); err != nil {
+	if err := h.orgService.DeleteOrganization(r.Context(), orgID, userID); err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to delete organization", err.Error())
 		return
 	}
@@ -242,6 +244,87 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.SendSuccess(w, http.StatusOK, "Organization deleted successfully", nil)
 }
 
+// GetDeleted handles GET /api/v1/organizations/deleted
+func (h *Handler) GetDeleted(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get user ID from header
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid user ID: "+err.Error())
+		return
+	}
+
+	// Get deleted organizations for user
+	organizations, err := h.orgService.GetDeletedOrganizationsByOwner(r.Context(), userID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve deleted organizations: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Deleted organizations retrieved successfully", organizations)
+}
+
+// Restore handles POST /api/v1/organizations/{id}/restore
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get user ID from header
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid user ID: "+err.Error())
+		return
+	}
+
+	// Get organization ID from URL
+	orgIDStr := chi.URLParam(r, "id")
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Parse optional request body
+	var req dto.RestoreOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Restore organization through service
+	organization, err := h.orgService.RestoreOrganization(r.Context(), orgID, req, userID)
+	if err != nil {
+		// Name collisions get a 409 so clients know to retry with a new name
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to restore organization", err.Error())
+			return
+		}
+		// Organizations past their grace period get a 410 so clients know they are gone for good
+		if appErrors.IsGoneError(err) {
+			response.SendError(w, http.StatusGone, "Failed to restore organization", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to restore organization", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Organization restored successfully", organization)
+}
+
 // CheckUserOrganization handles GET /api/v1/organizations/check
 func (h *Handler) CheckUserOrganization(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -260,7 +343,7 @@ func (h *Handler) CheckUserOrganization(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Check user organization
-	result, err := h.orgService.CheckUserOrganization(userID)
+	result, err := h.orgService.CheckUserOrganization(r.Context(), userID)
 	if err != nil {
 		response.SendInternalError(w, "Failed to check user organization: "+err.Error())
 		return
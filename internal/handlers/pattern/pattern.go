@@ -0,0 +1,131 @@
+package pattern
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	patternRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/pattern"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	patternService "github.com/nihar-hegde/valtro-backend/internal/services/pattern"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles log pattern-related HTTP requests
+type Handler struct {
+	patternService *patternService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new log pattern handler
+func NewHandler(db *gorm.DB) *Handler {
+	patternRepository := patternRepo.NewRepository(db)
+	patternSvc := patternService.NewService(patternRepository)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		patternService: patternSvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// GetAll handles GET /api/v1/projects/{id}/patterns
+// Query parameters: sort (last_seen|first_seen|count), since (RFC3339) and limit
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+
+	var since *time.Time
+	if value := query.Get("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'since' parameter, expected RFC3339 timestamp: "+err.Error())
+			return
+		}
+		since = &parsed
+	}
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get patterns for project
+	patterns, err := h.patternService.GetPatternsByProject(r.Context(), projectID, query.Get("sort"), since, limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve log patterns", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Log patterns retrieved successfully", patterns)
+}
@@ -2,11 +2,13 @@ package project
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
 	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
 	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
 	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
@@ -51,14 +53,14 @@ func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Get project to find its organization
-	project, err := h.projectService.GetProjectByID(projectID)
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return uuid.Nil, false
 	}
 
 	// Verify user owns the organization
-	organization, err := h.orgService.GetOrganizationByID(project.OrganizationID)
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
 	if err != nil {
 		response.SendNotFound(w, "Organization")
 		return uuid.Nil, false
@@ -88,7 +90,7 @@ func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.R
 	}
 
 	// Verify user owns the organization
-	organization, err := h.orgService.GetOrganizationByID(orgID)
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
 	if err != nil {
 		response.SendNotFound(w, "Organization")
 		return uuid.Nil, false
@@ -120,7 +122,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create project through service
-	project, err := h.projectService.CreateProject(req)
+	project, err := h.projectService.CreateProject(r.Context(), req)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to create project", err.Error())
 		return
@@ -149,7 +151,7 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get project through service (we know it exists from validation)
-	project, err := h.projectService.GetProjectByID(projectID)
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return
@@ -171,7 +173,7 @@ func (h *Handler) GetByAPIKey(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get project through service
-	project, err := h.projectService.GetProjectByAPIKey(apiKey)
+	project, err := h.projectService.GetProjectByAPIKey(r.Context(), apiKey)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return
@@ -206,7 +208,7 @@ func (h *Handler) GetByOrganization(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get projects for organization
-	projects, err := h.projectService.GetProjectsByOrganization(orgID)
+	projects, err := h.projectService.GetProjectsByOrganization(r.Context(), orgID)
 	if err != nil {
 		response.SendInternalError(w, "Failed to retrieve projects: "+err.Error())
 		return
@@ -242,14 +244,14 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get project to find organization ID for service call
-	project, err := h.projectService.GetProjectByID(projectID)
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return
 	}
 
 	// Update project through service
-	updatedProject, err := h.projectService.UpdateProject(projectID, req, project.OrganizationID)
+	updatedProject, err := h.projectService.UpdateProject(r.Context(), projectID, req, project.OrganizationID)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to update project", err.Error())
 		return
@@ -278,14 +280,14 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get project to find organization ID for service call
-	project, err := h.projectService.GetProjectByID(projectID)
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return
 	}
 
 	// Delete project through service
-	if err := h.projectService.DeleteProject(projectID, project.OrganizationID); err != nil {
+	if err := h.projectService.DeleteProject(r.Context(), projectID, project.OrganizationID); err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to delete project", err.Error())
 		return
 	}
@@ -294,6 +296,93 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.SendSuccess(w, http.StatusOK, "Project deleted successfully", nil)
 }
 
+// GetDeletedByOrganization handles GET /api/v1/projects/organization/{organizationId}/deleted
+func (h *Handler) GetDeletedByOrganization(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgIDStr := chi.URLParam(r, "organizationId")
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get deleted projects for organization
+	projects, err := h.projectService.GetDeletedProjectsByOrganization(r.Context(), orgID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve deleted projects: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Deleted projects retrieved successfully", projects)
+}
+
+// Restore handles POST /api/v1/projects/{id}/restore
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectIDStr := chi.URLParam(r, "id")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Get deleted project to find its organization
+	project, err := h.projectService.GetDeletedProjectByID(r.Context(), projectID)
+	if err != nil {
+		// Projects past their grace period get a 410 so clients know they are gone for good
+		if appErrors.IsGoneError(err) {
+			response.SendError(w, http.StatusGone, "Failed to restore project", err.Error())
+			return
+		}
+		response.SendNotFound(w, "Deleted project")
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	// A project in a deleted organization is restored by restoring the organization
+	_, valid := h.validateOrganizationOwnership(w, r, project.OrganizationID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse optional request body
+	var req dto.RestoreProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Restore project through service
+	restoredProject, err := h.projectService.RestoreProject(r.Context(), projectID, req, project.OrganizationID)
+	if err != nil {
+		// Name collisions get a 409 so clients know to retry with a new name
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to restore project", err.Error())
+			return
+		}
+		if appErrors.IsGoneError(err) {
+			response.SendError(w, http.StatusGone, "Failed to restore project", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to restore project", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Project restored successfully", restoredProject)
+}
+
 // RegenerateAPIKey handles POST /api/v1/projects/{id}/regenerate-api-key
 func (h *Handler) RegenerateAPIKey(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -313,14 +402,14 @@ func (h *Handler) RegenerateAPIKey(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get project to find organization ID for service call
-	project, err := h.projectService.GetProjectByID(projectID)
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
 	if err != nil {
 		response.SendNotFound(w, "Project")
 		return
 	}
 
 	// Regenerate API key through service
-	updatedProject, err := h.projectService.RegenerateAPIKey(projectID, project.OrganizationID)
+	updatedProject, err := h.projectService.RegenerateAPIKey(r.Context(), projectID, project.OrganizationID)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to regenerate API key", err.Error())
 		return
@@ -0,0 +1,351 @@
+package release
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	releaseRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/release"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	releaseService "github.com/nihar-hegde/valtro-backend/internal/services/release"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles release and deploy-related HTTP requests
+type Handler struct {
+	releaseService *releaseService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new release handler
+func NewHandler(db *gorm.DB) *Handler {
+	releaseRepository := releaseRepo.NewRepository(db)
+	releaseSvc := releaseService.NewService(releaseRepository)
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		releaseService: releaseSvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseProjectAndVersion parses the project ID and release version from the URL
+func (h *Handler) parseProjectAndVersion(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, "", false
+	}
+
+	// Versions often contain characters such as "@" or "+" that clients percent-encode
+	version, err := url.PathUnescape(chi.URLParam(r, "release"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid release version: "+err.Error())
+		return uuid.Nil, "", false
+	}
+
+	return projectID, version, true
+}
+
+// Create handles POST /api/v1/projects/{id}/releases
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create release through service
+	release, err := h.releaseService.CreateRelease(r.Context(), projectID, req)
+	if err != nil {
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to create release", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to create release", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Release created successfully", release)
+}
+
+// GetAll handles GET /api/v1/projects/{id}/releases
+// Query parameters: limit
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get releases for project
+	releases, err := h.releaseService.GetReleasesByProject(r.Context(), projectID, limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve releases", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Releases retrieved successfully", releases)
+}
+
+// GetByVersion handles GET /api/v1/projects/{id}/releases/{release}
+func (h *Handler) GetByVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, version, ok := h.parseProjectAndVersion(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get release through service
+	release, err := h.releaseService.GetRelease(r.Context(), projectID, version)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Release")
+			return
+		}
+		response.SendInternalError(w, "Failed to retrieve release: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Release retrieved successfully", release)
+}
+
+// CreateDeploy handles POST /api/v1/projects/{id}/releases/{release}/deploys
+func (h *Handler) CreateDeploy(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, version, ok := h.parseProjectAndVersion(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateDeployRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Record deploy through service
+	deploy, err := h.releaseService.CreateDeploy(r.Context(), projectID, version, req, &currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Release")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to record deploy", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Deploy recorded successfully", deploy)
+}
+
+// CreateWithAPIKey handles POST /api/v1/releases
+// Used by the CLI and CI pipelines, authenticated with the project API key instead of a user session
+func (h *Handler) CreateWithAPIKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from API key middleware
+	projectID, ok := middleware.GetProjectIDFromContext(r.Context())
+	if !ok {
+		response.SendUnauthorized(w, "Project API key required")
+		return
+	}
+
+	// Parse request body
+	var req dto.CreateReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create release through service
+	release, err := h.releaseService.CreateRelease(r.Context(), projectID, req)
+	if err != nil {
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to create release", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to create release", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Release created successfully", release)
+}
+
+// CreateDeployWithAPIKey handles POST /api/v1/releases/{release}/deploys
+// Used by the CLI and CI pipelines; the deploy is recorded without a user
+func (h *Handler) CreateDeployWithAPIKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from API key middleware
+	projectID, ok := middleware.GetProjectIDFromContext(r.Context())
+	if !ok {
+		response.SendUnauthorized(w, "Project API key required")
+		return
+	}
+
+	// Versions often contain characters such as "@" or "+" that clients percent-encode
+	version, err := url.PathUnescape(chi.URLParam(r, "release"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid release version: "+err.Error())
+		return
+	}
+
+	// Parse request body
+	var req dto.CreateDeployRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Record deploy through service
+	deploy, err := h.releaseService.CreateDeploy(r.Context(), projectID, version, req, nil)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Release")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to record deploy", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Deploy recorded successfully", deploy)
+}
+
+// GetDeploys handles GET /api/v1/projects/{id}/releases/{release}/deploys
+func (h *Handler) GetDeploys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, version, ok := h.parseProjectAndVersion(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get deploys for release
+	deploys, err := h.releaseService.GetDeploys(r.Context(), projectID, version)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Release")
+			return
+		}
+		response.SendInternalError(w, "Failed to retrieve deploys: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Deploys retrieved successfully", deploys)
+}
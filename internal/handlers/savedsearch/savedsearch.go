@@ -0,0 +1,310 @@
+package savedsearch
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	savedSearchRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/savedsearch"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	savedSearchService "github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles saved search-related HTTP requests
+type Handler struct {
+	savedSearchService *savedSearchService.Service
+	orgService         *orgService.Service
+}
+
+// NewHandler creates a new saved search handler
+func NewHandler(db *gorm.DB) *Handler {
+	savedSearchRepository := savedSearchRepo.NewRepository(db)
+	savedSearchSvc := savedSearchService.NewService(savedSearchRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		savedSearchService: savedSearchSvc,
+		orgService:         orgSvc,
+	}
+}
+
+// validateOrganizationOwnership is a DRY helper function to validate if user owns the organization
+func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseOrganizationAndSearchIDs parses the organization and saved search IDs from the URL
+func (h *Handler) parseOrganizationAndSearchIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	searchID, err := uuid.Parse(chi.URLParam(r, "searchId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid saved search ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, searchID, true
+}
+
+// Create handles POST /api/v1/organizations/{id}/saved-searches
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	currentUserID, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create saved search through service
+	search, err := h.savedSearchService.CreateSavedSearch(r.Context(), orgID, req, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to create saved search", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Saved search created successfully", search)
+}
+
+// GetAll handles GET /api/v1/organizations/{id}/saved-searches
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get saved searches for organization
+	searches, err := h.savedSearchService.GetSavedSearchesByOrganization(r.Context(), orgID)
+	if err != nil {
+		response.SendInternalError(w, "Failed to retrieve saved searches: "+err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved searches retrieved successfully", searches)
+}
+
+// GetByID handles GET /api/v1/organizations/{id}/saved-searches/{searchId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, searchID, ok := h.parseOrganizationAndSearchIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get saved search through service
+	search, err := h.savedSearchService.GetSavedSearchByID(r.Context(), searchID, orgID)
+	if err != nil {
+		response.SendNotFound(w, "Saved search")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search retrieved successfully", search)
+}
+
+// Update handles PUT /api/v1/organizations/{id}/saved-searches/{searchId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, searchID, ok := h.parseOrganizationAndSearchIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update saved search through service
+	search, err := h.savedSearchService.UpdateSavedSearch(r.Context(), searchID, req, orgID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to update saved search", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search updated successfully", search)
+}
+
+// Delete handles DELETE /api/v1/organizations/{id}/saved-searches/{searchId}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, searchID, ok := h.parseOrganizationAndSearchIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Delete saved search through service
+	if err := h.savedSearchService.DeleteSavedSearch(r.Context(), searchID, orgID); err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to delete saved search", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search deleted successfully", nil)
+}
+
+// Share handles POST /api/v1/organizations/{id}/saved-searches/{searchId}/share
+func (h *Handler) Share(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, searchID, ok := h.parseOrganizationAndSearchIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Generate share token through service
+	search, err := h.savedSearchService.ShareSavedSearch(r.Context(), searchID, orgID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to share saved search", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search shared successfully", search)
+}
+
+// Unshare handles DELETE /api/v1/organizations/{id}/saved-searches/{searchId}/share
+func (h *Handler) Unshare(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, searchID, ok := h.parseOrganizationAndSearchIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Revoke share token through service
+	search, err := h.savedSearchService.UnshareSavedSearch(r.Context(), searchID, orgID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to unshare saved search", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search unshared successfully", search)
+}
+
+// ResolveShareToken handles GET /api/v1/saved-searches/shared/{token}
+func (h *Handler) ResolveShareToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get share token from URL
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		response.SendValidationError(w, "Share token is required")
+		return
+	}
+
+	// Resolve share token through service
+	search, err := h.savedSearchService.GetSavedSearchByShareToken(r.Context(), token)
+	if err != nil {
+		response.SendNotFound(w, "Saved search")
+		return
+	}
+
+	// Authorization: Only members of the owning organization can open share links
+	_, valid := h.validateOrganizationOwnership(w, r, search.OrganizationID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Saved search retrieved successfully", search)
+}
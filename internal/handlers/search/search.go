@@ -0,0 +1,736 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	archiveRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/archive"
+	exportRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/export"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	archiveService "github.com/nihar-hegde/valtro-backend/internal/services/archive"
+	exportService "github.com/nihar-hegde/valtro-backend/internal/services/export"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	projectService "github.com/nihar-hegde/valtro-backend/internal/services/project"
+	searchService "github.com/nihar-hegde/valtro-backend/internal/services/search"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// attributeParamPrefix marks query parameters that filter on an event attribute, e.g. attr.user_id=42
+const attributeParamPrefix = "attr."
+
+// Handler handles log search HTTP requests
+type Handler struct {
+	searchService  *searchService.Service
+	exportService  *exportService.Service
+	archiveService *archiveService.Service
+	projectService *projectService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new search handler
+func NewHandler(db *gorm.DB, logStore logstore.LogStore, store storage.Store) *Handler {
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	searchSvc := searchService.NewService(logStore, orgRepository, searchService.ConfigFromEnv())
+
+	exportRepository := exportRepo.NewRepository(db)
+	exportSvc := exportService.NewService(logStore, searchSvc, exportRepository, store, exportService.ConfigFromEnv())
+
+	archiveRepository := archiveRepo.NewRepository(db)
+	archiveSvc := archiveService.NewService(archiveRepository, logStore, store, archiveService.ConfigFromEnv())
+
+	projectRepository := projectRepo.NewRepository(db)
+	projectSvc := projectService.NewService(projectRepository)
+
+	return &Handler{
+		searchService:  searchSvc,
+		exportService:  exportSvc,
+		archiveService: archiveSvc,
+		projectService: projectSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateProjectOwnership is a DRY helper function to validate if user owns the project's organization
+func (h *Handler) validateProjectOwnership(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Get project to find its organization
+	project, err := h.projectService.GetProjectByID(r.Context(), projectID)
+	if err != nil {
+		response.SendNotFound(w, "Project")
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), project.OrganizationID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access projects for organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// validateOrganizationOwnership is a DRY helper function to validate if user owns the organization
+func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseSearchParams reads the search filters from the query string
+// level may be repeated or comma-separated; host, service, stream and release match exactly,
+// as does any attribute given as attr.<name>; pattern keeps the events of one log pattern
+func (h *Handler) parseSearchParams(w http.ResponseWriter, r *http.Request) (dto.LogSearchParams, bool) {
+	query := r.URL.Query()
+	params := dto.LogSearchParams{
+		Query:      query.Get("q"),
+		Cursor:     query.Get("cursor"),
+		Descending: true,
+	}
+
+	for _, name := range []string{"from", "to"} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid '"+name+"' parameter, expected RFC3339 timestamp: "+err.Error())
+			return params, false
+		}
+		if name == "from" {
+			params.From = &parsed
+		} else {
+			params.To = &parsed
+		}
+	}
+
+	for _, value := range query["level"] {
+		params.Levels = append(params.Levels, strings.Split(value, ",")...)
+	}
+
+	params.Fields = make(map[string]string)
+	for _, name := range []string{logstore.FieldHost, logstore.FieldService, logstore.FieldStream, logstore.FieldRelease} {
+		if value := query.Get(name); value != "" {
+			params.Fields[name] = value
+		}
+	}
+	for name, values := range query {
+		if attribute, ok := strings.CutPrefix(name, attributeParamPrefix); ok && attribute != "" && len(values) > 0 {
+			params.Fields[attribute] = values[0]
+		}
+	}
+
+	if value := query.Get("pattern"); value != "" {
+		patternID, err := uuid.Parse(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'pattern' parameter, expected a pattern ID")
+			return params, false
+		}
+		params.PatternID = &patternID
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		params.Descending = false
+	default:
+		response.SendValidationError(w, "Invalid 'order' parameter, expected 'asc' or 'desc'")
+		return params, false
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return params, false
+		}
+		params.Limit = limit
+	}
+
+	return params, true
+}
+
+// SearchLogs handles GET /api/v1/projects/{id}/logs
+func (h *Handler) SearchLogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	params, ok := h.parseSearchParams(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Search logs through service
+	result, err := h.searchService.SearchLogs(r.Context(), projectID, params)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to search logs", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Logs retrieved successfully", result)
+}
+
+// SearchOrganizationLogs handles GET /api/v1/organizations/{id}/logs
+// Query parameters: the same filters as a project search, plus project, which may be repeated or
+// comma-separated to search only some of the organization's projects
+func (h *Handler) SearchOrganizationLogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	params, ok := h.parseSearchParams(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+	var projectIDs []uuid.UUID
+	for _, value := range r.URL.Query()["project"] {
+		for _, raw := range strings.Split(value, ",") {
+			projectID, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				response.SendValidationError(w, "Invalid 'project' parameter: "+err.Error())
+				return
+			}
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+
+	// Get the organization's projects
+	projects, err := h.projectService.GetProjectsByOrganization(r.Context(), orgID)
+	if err != nil {
+		response.SendError(w, http.StatusInternalServerError, "Failed to search logs", err.Error())
+		return
+	}
+
+	// Search logs through service
+	result, err := h.searchService.SearchOrganizationLogs(r.Context(), orgID, projects, projectIDs, params)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to search logs", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Logs retrieved successfully", result)
+}
+
+// GetEventContext handles GET /api/v1/projects/{id}/logs/{eventId}/context
+// Query parameters: before and after (events on each side, default 50, max 500) and group_by,
+// the comma-separated fields neighbours must share with the event (default host,service,stream;
+// pass an empty group_by to match every source)
+func (h *Handler) GetEventContext(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project and event IDs from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+	eventID, err := uuid.Parse(chi.URLParam(r, "eventId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid event ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	params := dto.LogContextParams{Before: constants.DefaultLogContextLines, After: constants.DefaultLogContextLines}
+	for name, value := range map[string]*int{"before": &params.Before, "after": &params.After} {
+		if raw := query.Get(name); raw != "" {
+			if *value, err = strconv.Atoi(raw); err != nil {
+				response.SendValidationError(w, "Invalid '"+name+"' parameter: "+err.Error())
+				return
+			}
+		}
+	}
+	if query.Has("group_by") {
+		params.GroupBy = []string{}
+		for _, name := range strings.Split(query.Get("group_by"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				params.GroupBy = append(params.GroupBy, name)
+			}
+		}
+	}
+
+	// Get event context through service
+	result, err := h.searchService.GetEventContext(r.Context(), projectID, eventID, params)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Log event")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to get log context", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Log context retrieved successfully", result)
+}
+
+// parseProjectAndExportIDs reads the project and export job IDs from the URL
+func (h *Handler) parseProjectAndExportIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	exportID, err := uuid.Parse(chi.URLParam(r, "exportId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid export ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, exportID, true
+}
+
+// ExportLogs handles GET /api/v1/projects/{id}/logs/export
+// Query parameters: the search filters, format ("csv", "ndjson" or "parquet"), columns
+// (comma-separated) and limit, the row cap. Events are oldest first unless order=desc.
+// The file is streamed as it is read, so exports are bounded by the row cap, not by memory
+func (h *Handler) ExportLogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	params, ok := h.parseSearchParams(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+	query := r.URL.Query()
+	if query.Get("order") == "" {
+		params.Descending = false
+	}
+	req := dto.ExportRequest{Format: query.Get("format"), LogSearchParams: params}
+	if value := query.Get("columns"); value != "" {
+		req.Columns = strings.Split(value, ",")
+	}
+
+	// Validate the export through service
+	export, err := h.exportService.PrepareExport(r.Context(), projectID, req, constants.MaxExportRows)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to export logs", err.Error())
+		return
+	}
+
+	// Stream the export as a download; once it has started, errors can only be logged
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := h.exportService.WriteExport(r.Context(), export, w); err != nil {
+		log.Printf("Failed to stream log export of project %s: %v", projectID, err)
+	}
+}
+
+// CreateExportJob handles POST /api/v1/projects/{id}/logs/exports
+// Runs an export of up to 10 million events in the background; poll the job for its download URL
+func (h *Handler) CreateExportJob(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Queue export job through service
+	job, err := h.exportService.CreateExportJob(r.Context(), projectID, currentUserID, req)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to create export job", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusAccepted, "Export job created successfully", job)
+}
+
+// GetExportJobs handles GET /api/v1/projects/{id}/logs/exports
+// Query parameters: limit (default 50, max 500)
+func (h *Handler) GetExportJobs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get export jobs through service
+	jobs, err := h.exportService.GetExportJobs(r.Context(), projectID, limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to get export jobs", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Export jobs retrieved successfully", jobs)
+}
+
+// GetExportJob handles GET /api/v1/projects/{id}/logs/exports/{exportId}
+func (h *Handler) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, exportID, ok := h.parseProjectAndExportIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get export job through service
+	job, err := h.exportService.GetExportJob(r.Context(), exportID, projectID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Export job")
+			return
+		}
+		response.SendError(w, http.StatusInternalServerError, "Failed to get export job", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Export job retrieved successfully", job)
+}
+
+// DownloadExport handles GET /api/v1/projects/{id}/logs/exports/{exportId}/download
+// Responds 409 while the export is still running and 410 once its file has expired
+func (h *Handler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, exportID, ok := h.parseProjectAndExportIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Open export file through service
+	download, err := h.exportService.OpenExportFile(r.Context(), exportID, projectID)
+	if err != nil {
+		switch {
+		case appErrors.IsNotFoundError(err):
+			response.SendNotFound(w, "Export job")
+		case appErrors.IsConflictError(err):
+			response.SendError(w, http.StatusConflict, "Failed to download export", err.Error())
+		case appErrors.IsGoneError(err):
+			response.SendError(w, http.StatusGone, "Failed to download export", err.Error())
+		default:
+			response.SendError(w, http.StatusInternalServerError, "Failed to download export", err.Error())
+		}
+		return
+	}
+	defer download.Body.Close()
+
+	// Send the file as a download
+	w.Header().Set("Content-Type", download.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+download.Filename+`"`)
+	w.Header().Set("Content-Length", strconv.FormatInt(download.Size, 10))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, download.Body); err != nil {
+		log.Printf("Failed to send export %s: %v", exportID, err)
+	}
+}
+
+// parseProjectAndRehydrationIDs reads the project and rehydration IDs from the URL
+func (h *Handler) parseProjectAndRehydrationIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	rehydrationID, err := uuid.Parse(chi.URLParam(r, "rehydrationId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid rehydration ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, rehydrationID, true
+}
+
+// CreateRehydration handles POST /api/v1/projects/{id}/logs/rehydrations
+// Archived events in the range are loaded in the background; poll the rehydration until it is ready
+func (h *Handler) CreateRehydration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	currentUserID, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateRehydrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Queue rehydration through service
+	rehydration, err := h.archiveService.CreateRehydration(r.Context(), projectID, currentUserID, req)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to create rehydration", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusAccepted, "Rehydration created successfully", rehydration)
+}
+
+// GetRehydrations handles GET /api/v1/projects/{id}/logs/rehydrations
+// Query parameters: limit (default 50, max 500)
+func (h *Handler) GetRehydrations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get project ID from URL
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid project ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get rehydrations through service
+	rehydrations, err := h.archiveService.GetRehydrations(r.Context(), projectID, limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to get rehydrations", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Rehydrations retrieved successfully", rehydrations)
+}
+
+// GetRehydration handles GET /api/v1/projects/{id}/logs/rehydrations/{rehydrationId}
+func (h *Handler) GetRehydration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, rehydrationID, ok := h.parseProjectAndRehydrationIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get rehydration through service
+	rehydration, err := h.archiveService.GetRehydration(r.Context(), rehydrationID, projectID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Rehydration")
+			return
+		}
+		response.SendError(w, http.StatusInternalServerError, "Failed to get rehydration", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Rehydration retrieved successfully", rehydration)
+}
+
+// SearchRehydration handles GET /api/v1/projects/{id}/logs/rehydrations/{rehydrationId}/search
+// Query parameters: the same filters as a project search; without from and to the whole
+// rehydrated range is searched. Responds 409 while the events are loading and 410 once they expired
+func (h *Handler) SearchRehydration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, rehydrationID, ok := h.parseProjectAndRehydrationIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the project's organization using DRY helper
+	_, valid := h.validateProjectOwnership(w, r, projectID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse query parameters
+	params, ok := h.parseSearchParams(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Search rehydrated logs through service
+	result, err := h.archiveService.SearchRehydration(r.Context(), rehydrationID, projectID, params)
+	if err != nil {
+		switch {
+		case appErrors.IsNotFoundError(err):
+			response.SendNotFound(w, "Rehydration")
+		case appErrors.IsConflictError(err):
+			response.SendError(w, http.StatusConflict, "Failed to search rehydrated logs", err.Error())
+		case appErrors.IsGoneError(err):
+			response.SendError(w, http.StatusGone, "Failed to search rehydrated logs", err.Error())
+		default:
+			response.SendError(w, http.StatusBadRequest, "Failed to search rehydrated logs", err.Error())
+		}
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Rehydrated logs retrieved successfully", result)
+}
@@ -0,0 +1,331 @@
+package silence
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	silenceRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/silence"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	silenceService "github.com/nihar-hegde/valtro-backend/internal/services/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles silence-related HTTP requests
+type Handler struct {
+	silenceService *silenceService.Service
+	orgService     *orgService.Service
+}
+
+// NewHandler creates a new silence handler
+func NewHandler(db *gorm.DB) *Handler {
+	silenceRepository := silenceRepo.NewRepository(db)
+	silenceSvc := silenceService.NewService(silenceRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		silenceService: silenceSvc,
+		orgService:     orgSvc,
+	}
+}
+
+// validateOrganizationOwnership is a DRY helper function to validate if user owns the organization
+func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseOrganizationAndSilenceIDs parses the organization and silence IDs from the URL
+func (h *Handler) parseOrganizationAndSilenceIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	silenceID, err := uuid.Parse(chi.URLParam(r, "silenceId"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid silence ID: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, silenceID, true
+}
+
+// Create handles POST /api/v1/organizations/{id}/silences
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	currentUserID, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.CreateSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Create silence through service
+	silence, err := h.silenceService.CreateSilence(r.Context(), orgID, req, currentUserID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to create silence", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusCreated, "Silence created successfully", silence)
+}
+
+// GetAll handles GET /api/v1/organizations/{id}/silences
+// Query parameters: state ("scheduled", "active" or "expired")
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get silences for organization
+	silences, err := h.silenceService.GetSilencesByOrganization(r.Context(), orgID, r.URL.Query().Get("state"))
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve silences", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silences retrieved successfully", silences)
+}
+
+// GetAuditTrail handles GET /api/v1/organizations/{id}/silences/audit
+// Query parameters: limit
+func (h *Handler) GetAuditTrail(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get audit trail through service
+	entries, err := h.silenceService.GetAuditTrail(r.Context(), orgID, nil, limit)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve silence audit trail", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silence audit trail retrieved successfully", entries)
+}
+
+// GetByID handles GET /api/v1/organizations/{id}/silences/{silenceId}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, silenceID, ok := h.parseOrganizationAndSilenceIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Get silence through service
+	silence, err := h.silenceService.GetSilenceByID(r.Context(), silenceID, orgID)
+	if err != nil {
+		response.SendNotFound(w, "Silence")
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silence retrieved successfully", silence)
+}
+
+// Update handles PUT /api/v1/organizations/{id}/silences/{silenceId}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, silenceID, ok := h.parseOrganizationAndSilenceIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	currentUserID, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse request body
+	var req dto.UpdateSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendValidationError(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Update silence through service
+	silence, err := h.silenceService.UpdateSilence(r.Context(), silenceID, req, orgID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Silence")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to update silence", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to update silence", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silence updated successfully", silence)
+}
+
+// Expire handles DELETE /api/v1/organizations/{id}/silences/{silenceId}
+// The silence is kept for the audit trail; it stops muting notifications straight away
+func (h *Handler) Expire(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, silenceID, ok := h.parseOrganizationAndSilenceIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	currentUserID, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Expire silence through service
+	silence, err := h.silenceService.ExpireSilence(r.Context(), silenceID, orgID, currentUserID)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Silence")
+			return
+		}
+		if appErrors.IsConflictError(err) {
+			response.SendError(w, http.StatusConflict, "Failed to expire silence", err.Error())
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to expire silence", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silence expired successfully", silence)
+}
+
+// GetSilenceAuditTrail handles GET /api/v1/organizations/{id}/silences/{silenceId}/audit
+// Query parameters: limit
+func (h *Handler) GetSilenceAuditTrail(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orgID, silenceID, ok := h.parseOrganizationAndSilenceIDs(w, r)
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			response.SendValidationError(w, "Invalid 'limit' parameter: "+err.Error())
+			return
+		}
+	}
+
+	// Get audit trail through service
+	entries, err := h.silenceService.GetAuditTrail(r.Context(), orgID, &silenceID, limit)
+	if err != nil {
+		if appErrors.IsNotFoundError(err) {
+			response.SendNotFound(w, "Silence")
+			return
+		}
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve silence audit trail", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Silence audit trail retrieved successfully", entries)
+}
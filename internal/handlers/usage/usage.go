@@ -0,0 +1,120 @@
+package usage
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
+	orgService "github.com/nihar-hegde/valtro-backend/internal/services/organization"
+	usageService "github.com/nihar-hegde/valtro-backend/internal/services/usage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// Handler handles usage-related HTTP requests
+type Handler struct {
+	usageService *usageService.Service
+	orgService   *orgService.Service
+}
+
+// NewHandler creates a new usage handler
+func NewHandler(db *gorm.DB) *Handler {
+	usageRepository := usageRepo.NewRepository(db)
+	usageSvc := usageService.NewService(usageRepository)
+
+	orgRepository := orgRepo.NewRepository(db)
+	orgSvc := orgService.NewService(orgRepository)
+
+	return &Handler{
+		usageService: usageSvc,
+		orgService:   orgSvc,
+	}
+}
+
+// validateOrganizationOwnership is a DRY helper function to validate if user owns the organization
+func (h *Handler) validateOrganizationOwnership(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) (uuid.UUID, bool) {
+	// Get current user ID from JWT middleware
+	currentUserIDStr := r.Header.Get("X-User-ID")
+	if currentUserIDStr == "" {
+		response.SendUnauthorized(w, "User ID required")
+		return uuid.Nil, false
+	}
+
+	currentUserID, err := uuid.Parse(currentUserIDStr)
+	if err != nil {
+		response.SendValidationError(w, "Invalid current user ID: "+err.Error())
+		return uuid.Nil, false
+	}
+
+	// Verify user owns the organization
+	organization, err := h.orgService.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		response.SendNotFound(w, "Organization")
+		return uuid.Nil, false
+	}
+
+	if organization.OwnerID != currentUserID {
+		response.SendForbidden(w, "You can only access organizations you own")
+		return uuid.Nil, false
+	}
+
+	return currentUserID, true
+}
+
+// parseTimeParam parses an optional RFC3339 query parameter
+func (h *Handler) parseTimeParam(w http.ResponseWriter, r *http.Request, name string) (*time.Time, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		response.SendValidationError(w, "Invalid '"+name+"' parameter, expected RFC3339 timestamp: "+err.Error())
+		return nil, false
+	}
+
+	return &parsed, true
+}
+
+// GetOrganizationUsage handles GET /api/v1/organizations/{id}/usage
+// Query parameters: granularity (day|month), from and to (RFC3339)
+func (h *Handler) GetOrganizationUsage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get organization ID from URL
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.SendValidationError(w, "Invalid organization ID: "+err.Error())
+		return
+	}
+
+	// Authorization: Verify user owns the organization using DRY helper
+	_, valid := h.validateOrganizationOwnership(w, r, orgID)
+	if !valid {
+		return // Response already sent by helper
+	}
+
+	// Parse reporting window
+	from, ok := h.parseTimeParam(w, r, "from")
+	if !ok {
+		return // Response already sent by helper
+	}
+	to, ok := h.parseTimeParam(w, r, "to")
+	if !ok {
+		return // Response already sent by helper
+	}
+
+	// Build usage report through service
+	usage, err := h.usageService.GetOrganizationUsage(r.Context(), orgID, r.URL.Query().Get("granularity"), from, to)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "Failed to retrieve usage", err.Error())
+		return
+	}
+
+	// Send success response
+	response.SendSuccess(w, http.StatusOK, "Usage retrieved successfully", usage)
+}
@@ -41,7 +41,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create user through service
-	user, err := h.userService.CreateUser(req)
+	user, err := h.userService.CreateUser(r.Context(), req)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to create user", err.Error())
 		return
@@ -75,7 +75,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get users through service
-	users, err := h.userService.GetAllUsers(limit, offset)
+	users, err := h.userService.GetAllUsers(r.Context(), limit, offset)
 	if err != nil {
 		response.SendInternalError(w, "Failed to retrieve users: "+err.Error())
 		return
@@ -98,7 +98,7 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user through service
-	user, err := h.userService.GetUserByID(id)
+	user, err := h.userService.GetUserByID(r.Context(), id)
 	if err != nil {
 		response.SendNotFound(w, "User")
 		return
@@ -147,7 +147,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update user through service
-	user, err := h.userService.UpdateUser(targetUserID, req)
+	user, err := h.userService.UpdateUser(r.Context(), targetUserID, req)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, "Failed to update user", err.Error())
 		return
@@ -189,7 +189,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Delete user through service
-	if err := h.userService.DeleteUser(targetUserID); err != nil {
+	if err := h.userService.DeleteUser(r.Context(), targetUserID); err != nil {
 		response.SendNotFound(w, "User")
 		return
 	}
@@ -216,7 +216,7 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user profile
-	user, err := h.userService.GetUserByID(currentUserID)
+	user, err := h.userService.GetUserByID(r.Context(), currentUserID)
 	if err != nil {
 		response.SendNotFound(w, "User")
 		return
@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -76,11 +77,11 @@ func (h *Handler) HandleClerkWebhook(w http.ResponseWriter, r *http.Request) {
 	// Route to appropriate handler based on event type
 	switch event.Type {
 	case "user.created":
-		h.handleUserCreated(w, event)
+		h.handleUserCreated(r.Context(), w, event)
 	case "user.updated":
-		h.handleUserUpdated(w, event)
+		h.handleUserUpdated(r.Context(), w, event)
 	case "user.deleted":
-		h.handleUserDeleted(w, event)
+		h.handleUserDeleted(r.Context(), w, event)
 	default:
 		// For unsupported event types, return success but don't process
 		h.sendWebhookSuccessResponse(w, "Event type not handled", nil)
@@ -88,7 +89,7 @@ func (h *Handler) HandleClerkWebhook(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleUserCreated processes user.created events
-func (h *Handler) handleUserCreated(w http.ResponseWriter, event dto.WebhookEvent) {
+func (h *Handler) handleUserCreated(ctx context.Context, w http.ResponseWriter, event dto.WebhookEvent) {
 	fmt.Println("event: ", event)
 	// Parse user data from the event
 	userData, err := h.parseClerkUserData(event.Data)
@@ -102,7 +103,7 @@ func (h *Handler) handleUserCreated(w http.ResponseWriter, event dto.WebhookEven
 	fmt.Println("createUserReq: ", createUserReq)
 
 	// Create user in database
-	user, err := h.userService.CreateUser(createUserReq)
+	user, err := h.userService.CreateUser(ctx, createUserReq)
 
 	if err != nil {
 		// Check if it's a duplicate user error
@@ -118,7 +119,7 @@ func (h *Handler) handleUserCreated(w http.ResponseWriter, event dto.WebhookEven
 }
 
 // handleUserUpdated processes user.updated events
-func (h *Handler) handleUserUpdated(w http.ResponseWriter, event dto.WebhookEvent) {
+func (h *Handler) handleUserUpdated(ctx context.Context, w http.ResponseWriter, event dto.WebhookEvent) {
 	// Parse user data from the event
 	userData, err := h.parseClerkUserData(event.Data)
 	if err != nil {
@@ -127,7 +128,7 @@ func (h *Handler) handleUserUpdated(w http.ResponseWriter, event dto.WebhookEven
 	}
 
 	// Get existing user by Clerk ID
-	existingUser, err := h.userService.GetUserByClerkID(userData.ID)
+	existingUser, err := h.userService.GetUserByClerkID(ctx, userData.ID)
 	if err != nil {
 		h.sendWebhookErrorResponse(w, http.StatusNotFound, "User not found", err.Error())
 		return
@@ -137,7 +138,7 @@ func (h *Handler) handleUserUpdated(w http.ResponseWriter, event dto.WebhookEven
 	updateUserReq := h.clerkUserToUpdateRequest(userData)
 
 	// Update user in database
-	updatedUser, err := h.userService.UpdateUser(existingUser.ID, updateUserReq)
+	updatedUser, err := h.userService.UpdateUser(ctx, existingUser.ID, updateUserReq)
 	if err != nil {
 		h.sendWebhookErrorResponse(w, http.StatusInternalServerError, "Failed to update user", err.Error())
 		return
@@ -147,7 +148,7 @@ func (h *Handler) handleUserUpdated(w http.ResponseWriter, event dto.WebhookEven
 }
 
 // handleUserDeleted processes user.deleted events
-func (h *Handler) handleUserDeleted(w http.ResponseWriter, event dto.WebhookEvent) {
+func (h *Handler) handleUserDeleted(ctx context.Context, w http.ResponseWriter, event dto.WebhookEvent) {
 	// Parse user data from the event
 	userData, err := h.parseClerkUserData(event.Data)
 	if err != nil {
@@ -156,7 +157,7 @@ func (h *Handler) handleUserDeleted(w http.ResponseWriter, event dto.WebhookEven
 	}
 
 	// Get existing user by Clerk ID
-	existingUser, err := h.userService.GetUserByClerkID(userData.ID)
+	existingUser, err := h.userService.GetUserByClerkID(ctx, userData.ID)
 	if err != nil {
 		// User doesn't exist, consider it successful
 		h.sendWebhookSuccessResponse(w, "User already deleted or doesn't exist", nil)
@@ -164,7 +165,7 @@ func (h *Handler) handleUserDeleted(w http.ResponseWriter, event dto.WebhookEven
 	}
 
 	// Delete user from database (soft delete)
-	if err := h.userService.DeleteUser(existingUser.ID); err != nil {
+	if err := h.userService.DeleteUser(ctx, existingUser.ID); err != nil {
 		h.sendWebhookErrorResponse(w, http.StatusInternalServerError, "Failed to delete user", err.Error())
 		return
 	}
@@ -0,0 +1,67 @@
+package logstore
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// bloomFalsePositiveRate is the share of absent keys a segment's bloom filter reports as present
+const bloomFalsePositiveRate = 0.01
+
+// bloomFilter answers "might this segment contain the key?" without reading the segment
+// False positives only cost a wasted read; there are no false negatives
+type bloomFilter struct {
+	Bits   []uint64 `json:"bits"`
+	Hashes int      `json:"hashes"`
+}
+
+// newBloomFilter sizes a filter for the expected number of keys
+func newBloomFilter(keys int) *bloomFilter {
+	if keys < 1 {
+		keys = 1
+	}
+	bits := int(math.Ceil(-float64(keys) * math.Log(bloomFalsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := int(math.Round(float64(bits) / float64(keys) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	return &bloomFilter{
+		Bits:   make([]uint64, (bits+63)/64),
+		Hashes: hashes,
+	}
+}
+
+// positions derives the filter's bit positions for a key by double hashing
+func (f *bloomFilter) positions(key string) []uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h1 := h.Sum64()
+	h2 := h1>>33 | h1<<31 | 1 // Odd, so every position is reachable
+
+	size := uint64(len(f.Bits) * 64)
+	positions := make([]uint64, f.Hashes)
+	for i := range positions {
+		positions[i] = (h1 + uint64(i)*h2) % size
+	}
+	return positions
+}
+
+// add records a key
+func (f *bloomFilter) add(key string) {
+	for _, position := range f.positions(key) {
+		f.Bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+// mayContain reports whether the key may have been added
+func (f *bloomFilter) mayContain(key string) bool {
+	if f == nil || len(f.Bits) == 0 {
+		return true
+	}
+	for _, position := range f.positions(key) {
+		if f.Bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
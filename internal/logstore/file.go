@@ -0,0 +1,441 @@
+package logstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSegmentsPerDay is how many segments a project's day can have before they are merged into one
+// Small ingest batches each write a segment; merging keeps queries from opening thousands of files
+const maxSegmentsPerDay = 16
+
+// FileStore keeps log events in compressed, time-sorted segment files on local disk
+// Segments live under {baseDir}/{projectID}/{YYYY-MM-DD}/ and each has a metadata sidecar with
+// its time range, level counts and a bloom filter, so queries only open segments that may match
+type FileStore struct {
+	baseDir string
+
+	// writeMu serializes appends, merges and deletes so segments are never rewritten twice at once
+	writeMu sync.Mutex
+
+	// mu guards segments, the in-memory index of each loaded project's segment metadata
+	mu       sync.RWMutex
+	segments map[uuid.UUID][]*segmentMeta
+}
+
+// NewFileStore creates a file store, creating the base directory if needed
+func NewFileStore(baseDir string) (*FileStore, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log store directory: %w", err)
+	}
+	return &FileStore{
+		baseDir:  baseDir,
+		segments: make(map[uuid.UUID][]*segmentMeta),
+	}, nil
+}
+
+// dayDir returns the directory holding a project's segments for the day of t
+func (s *FileStore) dayDir(projectID uuid.UUID, t time.Time) string {
+	return filepath.Join(s.baseDir, projectID.String(), t.UTC().Format(time.DateOnly))
+}
+
+// projectSegments returns a project's segment metadata, loading it from disk the first time
+func (s *FileStore) projectSegments(projectID uuid.UUID) ([]*segmentMeta, error) {
+	s.mu.RLock()
+	segments, ok := s.segments[projectID]
+	s.mu.RUnlock()
+	if ok {
+		return segments, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.baseDir, projectID.String(), "*", "*"+segmentSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	segments = make([]*segmentMeta, 0, len(paths))
+	for _, path := range paths {
+		meta, err := readMeta(path)
+		if os.IsNotExist(err) {
+			continue // Interrupted before its sidecar was written; never visible to queries
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, meta)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.segments[projectID]; ok {
+		return existing, nil
+	}
+	s.segments[projectID] = segments
+	return segments, nil
+}
+
+// replaceSegments swaps segments of a project in the index: removed ones go, added ones join
+func (s *FileStore) replaceSegments(projectID uuid.UUID, removed []*segmentMeta, added []*segmentMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gone := make(map[*segmentMeta]bool, len(removed))
+	for _, meta := range removed {
+		gone[meta] = true
+	}
+
+	current := s.segments[projectID]
+	updated := make([]*segmentMeta, 0, len(current)+len(added))
+	for _, meta := range current {
+		if !gone[meta] {
+			updated = append(updated, meta)
+		}
+	}
+	s.segments[projectID] = append(updated, added...)
+}
+
+// Append writes the events as one segment per project and day, merging a day's segments
+// once there are too many of them
+func (s *FileStore) Append(ctx context.Context, events []*Event) error {
+	type group struct {
+		projectID uuid.UUID
+		day       string
+	}
+	groups := make(map[group][]*Event)
+	for _, event := range events {
+		key := group{projectID: event.ProjectID, day: event.Timestamp.UTC().Format(time.DateOnly)}
+		groups[key] = append(groups[key], event)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	for key, batch := range groups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := s.projectSegments(key.projectID); err != nil {
+			return err
+		}
+
+		meta, err := writeSegment(s.dayDir(key.projectID, batch[0].Timestamp), key.projectID, batch)
+		if err != nil {
+			return err
+		}
+		s.replaceSegments(key.projectID, nil, []*segmentMeta{meta})
+
+		if err := s.mergeDay(key.projectID, batch[0].Timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeDay rewrites a project's segments for a day as a single segment once there are too many
+// The caller must hold writeMu
+func (s *FileStore) mergeDay(projectID uuid.UUID, day time.Time) error {
+	segments, err := s.projectSegments(projectID)
+	if err != nil {
+		return err
+	}
+
+	dir := s.dayDir(projectID, day)
+	var daySegments []*segmentMeta
+	for _, meta := range segments {
+		if filepath.Dir(meta.path) == dir {
+			daySegments = append(daySegments, meta)
+		}
+	}
+	if len(daySegments) <= maxSegmentsPerDay {
+		return nil
+	}
+
+	var events []*Event
+	for _, meta := range daySegments {
+		segmentEvents, err := readSegment(meta)
+		if err != nil {
+			return err
+		}
+		events = append(events, segmentEvents...)
+	}
+
+	merged, err := writeSegment(dir, projectID, events)
+	if err != nil {
+		return err
+	}
+	s.replaceSegments(projectID, daySegments, []*segmentMeta{merged})
+
+	for _, meta := range daySegments {
+		if err := removeSegment(meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candidates returns the segments of the query's projects that may hold matching events
+func (s *FileStore) candidates(matcher *matcher) ([]*segmentMeta, error) {
+	var candidates []*segmentMeta
+	for _, projectID := range matcher.query.ProjectIDs {
+		segments, err := s.projectSegments(projectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, meta := range segments {
+			if meta.mayMatch(matcher) {
+				candidates = append(candidates, meta)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// Query reads candidate segments in time order and merges their matching events
+// Only events that no later segment can precede are handed out, so memory stays bounded
+// by the segments that overlap in time rather than by the size of the result
+func (s *FileStore) Query(ctx context.Context, query Query, fn func(*Event) error) error {
+	matcher := newMatcher(query)
+	candidates, err := s.candidates(matcher)
+	if err != nil {
+		return err
+	}
+
+	if query.Descending {
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].MaxTime.After(candidates[j].MaxTime) })
+	} else {
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].MinTime.Before(candidates[j].MinTime) })
+	}
+
+	var pending []*Event
+	sent := 0
+	emit := func(ready func(*Event) bool) error {
+		n := 0
+		for n < len(pending) && ready(pending[n]) {
+			if query.Limit > 0 && sent >= query.Limit {
+				return ErrStop
+			}
+			if err := fn(pending[n]); err != nil {
+				return err
+			}
+			sent++
+			n++
+		}
+		pending = pending[n:]
+		return nil
+	}
+
+	err = func() error {
+		for i, meta := range candidates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			events, err := readSegment(meta)
+			if os.IsNotExist(err) {
+				continue // Merged or deleted since the candidates were picked
+			}
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				if matcher.match(event) {
+					pending = append(pending, event)
+				}
+			}
+			sortEvents(pending)
+			if query.Descending {
+				reverse(pending)
+			}
+
+			// Events strictly before the next segment's start (after its end, newest first) are final
+			ready := func(*Event) bool { return true }
+			if i+1 < len(candidates) {
+				next := candidates[i+1]
+				if query.Descending {
+					ready = func(e *Event) bool { return e.Timestamp.After(next.MaxTime) }
+				} else {
+					ready = func(e *Event) bool { return e.Timestamp.Before(next.MinTime) }
+				}
+			}
+			if err := emit(ready); err != nil {
+				return err
+			}
+		}
+		return emit(func(*Event) bool { return true })
+	}()
+	if err == ErrStop {
+		return nil
+	}
+	return err
+}
+
+// Estimate counts the events of the segments a query would read
+// Segments are ruled out by their time range, levels and bloom filter, as in Query
+func (s *FileStore) Estimate(ctx context.Context, query Query) (Estimate, error) {
+	candidates, err := s.candidates(newMatcher(query))
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	var rows int64
+	for _, meta := range candidates {
+		rows += int64(meta.Count)
+	}
+	return Estimate{Rows: rows, Cost: float64(rows)}, nil
+}
+
+// reverse reverses events in place
+func reverse(events []*Event) {
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+}
+
+// Get finds an event by ID, opening only segments whose bloom filter may hold it
+func (s *FileStore) Get(ctx context.Context, projectID uuid.UUID, id uuid.UUID) (*Event, error) {
+	segments, err := s.projectSegments(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, meta := range segments {
+		if !meta.Bloom.mayContain(idKey(id)) {
+			continue
+		}
+		events, err := readSegment(meta)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			if event.ID == id {
+				return event, nil
+			}
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Aggregate counts matching events per level and time bucket
+func (s *FileStore) Aggregate(ctx context.Context, query Query, interval time.Duration) ([]Bucket, error) {
+	query.Limit, query.After, query.Descending = 0, nil, false
+
+	type key struct {
+		start int64
+		level string
+	}
+	counts := make(map[key]int64)
+	err := s.Query(ctx, query, func(event *Event) error {
+		counts[key{start: bucketStart(event.Timestamp, interval), level: event.Level}]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]Bucket, 0, len(counts))
+	for k, count := range counts {
+		buckets = append(buckets, Bucket{Start: time.Unix(0, k.start).UTC(), Level: k.level, Count: count})
+	}
+	sortBuckets(buckets)
+	return buckets, nil
+}
+
+// bucketStart returns the start of the interval containing t, counting intervals from the Unix epoch
+func bucketStart(t time.Time, interval time.Duration) int64 {
+	nanos := t.UnixNano()
+	start := nanos - nanos%int64(interval)
+	if nanos < 0 && start != nanos {
+		start -= int64(interval)
+	}
+	return start
+}
+
+// sortBuckets orders buckets by start, then level
+func sortBuckets(buckets []Bucket) {
+	sort.Slice(buckets, func(i, j int) bool {
+		if !buckets[i].Start.Equal(buckets[j].Start) {
+			return buckets[i].Start.Before(buckets[j].Start)
+		}
+		return strings.Compare(buckets[i].Level, buckets[j].Level) < 0
+	})
+}
+
+// DeleteRange removes segments entirely inside the range and rewrites those that straddle it
+func (s *FileStore) DeleteRange(ctx context.Context, projectID uuid.UUID, from, to time.Time, levels []string) (DeleteResult, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	var deleteLevel map[string]bool
+	if len(levels) > 0 {
+		deleteLevel = make(map[string]bool, len(levels))
+		for _, level := range levels {
+			deleteLevel[strings.ToLower(level)] = true
+		}
+	}
+
+	var result DeleteResult
+	segments, err := s.projectSegments(projectID)
+	if err != nil {
+		return result, err
+	}
+
+	for _, meta := range segments {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if !meta.overlaps(from, to) || !meta.hasLevels(deleteLevel) {
+			continue
+		}
+
+		var added []*segmentMeta
+		if meta.within(from, to) && meta.onlyLevels(deleteLevel) {
+			result.Events += int64(meta.Count)
+			result.Bytes += meta.Bytes
+		} else {
+			events, err := readSegment(meta)
+			if err != nil {
+				return result, err
+			}
+			kept := make([]*Event, 0, len(events))
+			for _, event := range events {
+				if (!from.IsZero() && event.Timestamp.Before(from)) || (!to.IsZero() && !event.Timestamp.Before(to)) ||
+					(deleteLevel != nil && !deleteLevel[event.Level]) {
+					kept = append(kept, event)
+				}
+			}
+			if len(kept) == len(events) {
+				continue
+			}
+
+			result.Events += int64(len(events) - len(kept))
+			result.Bytes += meta.Bytes
+			if len(kept) > 0 {
+				rewritten, err := writeSegment(filepath.Dir(meta.path), projectID, kept)
+				if err != nil {
+					return result, err
+				}
+				result.Bytes -= rewritten.Bytes
+				added = append(added, rewritten)
+			}
+		}
+
+		s.replaceSegments(projectID, []*segmentMeta{meta}, added)
+		if err := removeSegment(meta); err != nil {
+			return result, err
+		}
+		os.Remove(filepath.Dir(meta.path)) // Only succeeds once the day directory is empty
+	}
+
+	return result, nil
+}
@@ -0,0 +1,289 @@
+// Package logstore stores ingested log events and answers searches over them, either in
+// Postgres or in an embedded segment store on local disk.
+package logstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned by Get when no event exists with the ID
+var ErrNotFound = errors.New("log event not found")
+
+// ErrStop can be returned by a Query callback to end the query early without an error
+var ErrStop = errors.New("stop query")
+
+// Event is a single stored log event
+type Event struct {
+	ID         uuid.UUID         `json:"id"`
+	ProjectID  uuid.UUID         `json:"project_id"`
+	Timestamp  time.Time         `json:"timestamp"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Level      string            `json:"level"`
+	Message    string            `json:"message"`
+	Host       string            `json:"host,omitempty"`
+	Service    string            `json:"service,omitempty"`
+	Stream     string            `json:"stream,omitempty"`
+	Release    string            `json:"release,omitempty"`
+	PatternID  *uuid.UUID        `json:"pattern_id,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// SearchText is matched by full-text searches besides the message: the values of the
+	// attributes the project has chosen to index, set at ingest
+	SearchText string `json:"-"`
+}
+
+// Size estimates the bytes the event takes up, used for usage accounting and purge reports
+func (e *Event) Size() int64 {
+	size := 16 + 16 + 8 + 8 + len(e.Level) + len(e.Message) + len(e.Host) + len(e.Service) +
+		len(e.Stream) + len(e.Release) + len(e.SearchText)
+	if e.PatternID != nil {
+		size += 16
+	}
+	for key, value := range e.Attributes {
+		size += len(key) + len(value)
+	}
+	return int64(size)
+}
+
+// Field returns the value of a field that queries and context views can match on:
+// "host", "service", "stream", "release", "level", or the name of an attribute
+func (e *Event) Field(name string) string {
+	switch name {
+	case FieldHost:
+		return e.Host
+	case FieldService:
+		return e.Service
+	case FieldStream:
+		return e.Stream
+	case FieldRelease:
+		return e.Release
+	case FieldLevel:
+		return e.Level
+	default:
+		return e.Attributes[name]
+	}
+}
+
+// Fields stored as columns rather than attributes
+const (
+	FieldHost    = "host"
+	FieldService = "service"
+	FieldStream  = "stream"
+	FieldRelease = "release"
+	FieldLevel   = "level"
+)
+
+// Cursor marks a position in a query's order so the next page can continue after it
+type Cursor struct {
+	Timestamp time.Time
+	ID        uuid.UUID
+}
+
+// String encodes the cursor for use in URLs
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d_%s", c.Timestamp.UnixNano(), c.ID)
+}
+
+// ParseCursor decodes a cursor produced by Cursor.String
+func ParseCursor(value string) (*Cursor, error) {
+	nanos, id, ok := strings.Cut(value, "_")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor %q", value)
+	}
+
+	var unixNano int64
+	if _, err := fmt.Sscan(nanos, &unixNano); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", value)
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", value)
+	}
+
+	return &Cursor{Timestamp: time.Unix(0, unixNano).UTC(), ID: parsed}, nil
+}
+
+// before reports whether the event sorts before the cursor in ascending order
+func (c Cursor) before(e *Event) bool {
+	if !e.Timestamp.Equal(c.Timestamp) {
+		return e.Timestamp.Before(c.Timestamp)
+	}
+	return strings.Compare(e.ID.String(), c.ID.String()) < 0
+}
+
+// Query selects events
+// Events come back ordered by timestamp, then ID, ascending unless Descending is set
+type Query struct {
+	// ProjectIDs lists the projects to search; at least one is required
+	ProjectIDs []uuid.UUID
+
+	// From and To bound the timestamps searched, From inclusive and To exclusive
+	// A zero value leaves that end open
+	From time.Time
+	To   time.Time
+
+	// Levels keeps only events with one of these levels; empty means every level
+	Levels []string
+
+	// Fields keeps only events whose fields all have exactly these values (see Event.Field)
+	Fields map[string]string
+
+	// Text is a full-text search term, interpreted by ParseText
+	Text string
+
+	// PatternID keeps only events grouped under this log pattern; nil means any pattern
+	PatternID *uuid.UUID
+
+	// After continues from a cursor; only events strictly after it in the query's order are returned
+	After *Cursor
+
+	// Descending returns the newest events first
+	Descending bool
+
+	// Limit caps the number of events returned; zero means no limit
+	Limit int
+}
+
+// TextMode says how a full-text search term is matched
+type TextMode int
+
+const (
+	// TextNone matches every event
+	TextNone TextMode = iota
+
+	// TextWords matches events containing every word of the term, in any order
+	TextWords
+
+	// TextPhrase matches events containing the words of the term next to each other, in order
+	TextPhrase
+
+	// TextSubstring matches events containing the term anywhere, ignoring case
+	TextSubstring
+)
+
+// TextMatch is a parsed full-text search term
+type TextMatch struct {
+	Mode  TextMode
+	Value string
+}
+
+// ParseText picks how a search term is matched
+// A term in double quotes is a phrase; a term made only of letters, digits and spaces is a
+// word search; anything else, such as a path, an address or a partial identifier, is a
+// substring search
+func ParseText(text string) TextMatch {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return TextMatch{Mode: TextNone}
+	}
+
+	if len(text) >= 2 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+		phrase := strings.TrimSpace(text[1 : len(text)-1])
+		if phrase == "" {
+			return TextMatch{Mode: TextNone}
+		}
+		return TextMatch{Mode: TextPhrase, Value: phrase}
+	}
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
+			return TextMatch{Mode: TextSubstring, Value: text}
+		}
+	}
+	return TextMatch{Mode: TextWords, Value: text}
+}
+
+// Bucket counts the events of one level in one time bucket
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Level string    `json:"level"`
+	Count int64     `json:"count"`
+}
+
+// DeleteResult reports what DeleteRange removed
+type DeleteResult struct {
+	Events int64
+	Bytes  int64
+}
+
+// LogStore stores log events and searches them
+// Implementations must be safe for concurrent use
+type LogStore interface {
+	// Append stores a batch of events
+	Append(ctx context.Context, events []*Event) error
+
+	// Query calls fn for every event the query selects, in the query's order, without
+	// holding the whole result in memory. Returning ErrStop from fn ends the query early
+	Query(ctx context.Context, query Query, fn func(*Event) error) error
+
+	// Get retrieves a single event of a project
+	Get(ctx context.Context, projectID uuid.UUID, id uuid.UUID) (*Event, error)
+
+	// Aggregate counts the events the query selects per level and time bucket
+	// Limit, After and Descending are ignored
+	Aggregate(ctx context.Context, query Query, interval time.Duration) ([]Bucket, error)
+
+	// DeleteRange permanently removes a project's events with timestamps in [from, to) and one
+	// of the given levels. A zero bound leaves that end open and no levels means every level,
+	// so DeleteRange(ctx, id, time.Time{}, time.Time{}, nil) removes every event of the project
+	DeleteRange(ctx context.Context, projectID uuid.UUID, from, to time.Time, levels []string) (DeleteResult, error)
+
+	// Estimate predicts how expensive the query is without running it, as if it had no limit
+	Estimate(ctx context.Context, query Query) (Estimate, error)
+}
+
+// Estimate is the predicted cost of a query
+// Postgres reports its planner's estimate, in planner cost units; the file store counts the
+// events in the segments it would have to read, so its cost and rows are upper bounds
+type Estimate struct {
+	Rows int64
+	Cost float64
+}
+
+// Default log store settings used when the corresponding environment variables are not set
+const (
+	defaultBackend  = "postgres"
+	defaultFilePath = "./data/logs"
+)
+
+// NewFromEnv builds the log store selected by LOG_STORE ("postgres" or "file")
+// The file store keeps its segments under LOG_STORE_PATH
+func NewFromEnv(db *gorm.DB) (LogStore, error) {
+	backend := os.Getenv("LOG_STORE")
+	if backend == "" {
+		backend = defaultBackend
+	}
+
+	switch backend {
+	case "postgres":
+		return NewPostgresStore(db), nil
+	case "file":
+		path := os.Getenv("LOG_STORE_PATH")
+		if path == "" {
+			path = defaultFilePath
+		}
+		return NewFileStore(path)
+	default:
+		return nil, fmt.Errorf("unknown LOG_STORE %q, expected \"postgres\" or \"file\"", backend)
+	}
+}
+
+// Collect runs a query and returns the selected events as a slice
+// Meant for bounded queries such as a page of search results
+func Collect(ctx context.Context, store LogStore, query Query) ([]*Event, error) {
+	var events []*Event
+	err := store.Query(ctx, query, func(event *Event) error {
+		events = append(events, event)
+		return nil
+	})
+	return events, err
+}
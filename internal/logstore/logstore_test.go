@@ -0,0 +1,278 @@
+package logstore
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want TextMatch
+	}{
+		{name: "empty", text: "  ", want: TextMatch{Mode: TextNone}},
+		{name: "single word", text: "timeout", want: TextMatch{Mode: TextWords, Value: "timeout"}},
+		{name: "several words", text: "connection refused", want: TextMatch{Mode: TextWords, Value: "connection refused"}},
+		{name: "quoted phrase", text: `"connection refused"`, want: TextMatch{Mode: TextPhrase, Value: "connection refused"}},
+		{name: "empty quotes", text: `""`, want: TextMatch{Mode: TextNone}},
+		{name: "path", text: "/api/v1/users", want: TextMatch{Mode: TextSubstring, Value: "/api/v1/users"}},
+		{name: "partial identifier", text: "req-8f3a", want: TextMatch{Mode: TextSubstring, Value: "req-8f3a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseText(tt.text); got != tt.want {
+				t.Errorf("ParseText(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	otherProjectID := uuid.New()
+	start := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	messages := []string{
+		"GET /api/v1/users took 12ms",
+		"connection refused by db-1",
+		"user 42 logged in",
+		"refused connection to cache",
+		"GET /api/v1/orders took 40ms",
+	}
+
+	// Events straddle midnight so they land in two day directories
+	var events []*Event
+	for i, message := range messages {
+		level := "info"
+		if i%2 == 1 {
+			level = "error"
+		}
+		events = append(events, &Event{
+			ID:         uuid.New(),
+			ProjectID:  projectID,
+			Timestamp:  start.Add(time.Duration(i) * 30 * time.Minute),
+			ReceivedAt: start.Add(time.Duration(i) * 30 * time.Minute),
+			Level:      level,
+			Message:    message,
+			Host:       "web-1",
+		})
+	}
+	events = append(events, &Event{ID: uuid.New(), ProjectID: otherProjectID, Timestamp: start, ReceivedAt: start, Level: "info", Message: "other project"})
+	events[2].SearchText = "alice@example.com checkout"
+	refusedPattern, otherPattern := uuid.New(), uuid.New()
+	events[1].PatternID = &refusedPattern
+	events[3].PatternID = &otherPattern
+
+	// Several appends exercise merging of a day's segments
+	for _, event := range events {
+		if err := store.Append(ctx, []*Event{event}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queries := []struct {
+		name  string
+		query Query
+		want  []int // indexes into events, in result order
+	}{
+		{name: "all ascending", query: Query{}, want: []int{0, 1, 2, 3, 4}},
+		{name: "all descending", query: Query{Descending: true}, want: []int{4, 3, 2, 1, 0}},
+		{name: "limit", query: Query{Limit: 2}, want: []int{0, 1}},
+		{name: "time range", query: Query{From: start.Add(30 * time.Minute), To: start.Add(90 * time.Minute)}, want: []int{1, 2}},
+		{name: "level", query: Query{Levels: []string{"error"}}, want: []int{1, 3}},
+		{name: "words in any order", query: Query{Text: "refused connection"}, want: []int{1, 3}},
+		{name: "phrase", query: Query{Text: `"connection refused"`}, want: []int{1}},
+		{name: "substring", query: Query{Text: "/api/v1/"}, want: []int{0, 4}},
+		{name: "words in search text", query: Query{Text: "checkout"}, want: []int{2}},
+		{name: "words across message and search text", query: Query{Text: "logged alice"}, want: []int{2}},
+		{name: "phrase does not span message and search text", query: Query{Text: `"in alice"`}, want: nil},
+		{name: "substring in search text", query: Query{Text: "alice@ex"}, want: []int{2}},
+		{name: "field", query: Query{Fields: map[string]string{FieldHost: "web-2"}}, want: nil},
+		{name: "pattern", query: Query{PatternID: &refusedPattern}, want: []int{1}},
+		{name: "pattern is not an attribute", query: Query{Fields: map[string]string{"pattern_id": refusedPattern.String()}}, want: nil},
+		{name: "cursor", query: Query{After: &Cursor{Timestamp: events[2].Timestamp, ID: events[2].ID}}, want: []int{3, 4}},
+		{name: "cursor descending", query: Query{Descending: true, After: &Cursor{Timestamp: events[2].Timestamp, ID: events[2].ID}}, want: []int{1, 0}},
+	}
+
+	for _, tt := range queries {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.ProjectIDs = []uuid.UUID{projectID}
+			got, err := Collect(ctx, store, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events, want %d", len(got), len(tt.want))
+			}
+			for i, index := range tt.want {
+				if got[i].ID != events[index].ID {
+					t.Errorf("event %d is %q, want %q", i, got[i].Message, events[index].Message)
+				}
+			}
+		})
+	}
+
+	t.Run("get", func(t *testing.T) {
+		got, err := store.Get(ctx, projectID, events[3].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Message != events[3].Message || !got.Timestamp.Equal(events[3].Timestamp) {
+			t.Errorf("got %+v, want %+v", got, events[3])
+		}
+		if _, err := store.Get(ctx, projectID, uuid.New()); err != ErrNotFound {
+			t.Errorf("missing event: got error %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("aggregate", func(t *testing.T) {
+		buckets, err := store.Aggregate(ctx, Query{ProjectIDs: []uuid.UUID{projectID}, Levels: []string{"info"}}, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Bucket{
+			{Start: start, Level: "info", Count: 1},
+			{Start: start.Add(time.Hour), Level: "info", Count: 1},
+			{Start: start.Add(2 * time.Hour), Level: "info", Count: 1},
+		}
+		if len(buckets) != len(want) {
+			t.Fatalf("got %+v, want %+v", buckets, want)
+		}
+		for i := range want {
+			if !buckets[i].Start.Equal(want[i].Start) || buckets[i].Level != want[i].Level || buckets[i].Count != want[i].Count {
+				t.Errorf("bucket %d is %+v, want %+v", i, buckets[i], want[i])
+			}
+		}
+	})
+
+	t.Run("delete range", func(t *testing.T) {
+		result, err := store.DeleteRange(ctx, projectID, time.Time{}, start.Add(time.Hour), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Events != 2 || result.Bytes <= 0 {
+			t.Errorf("got %+v, want 2 events and some bytes", result)
+		}
+
+		// A fresh store reads the rewritten segments back from disk
+		reopened, err := NewFileStore(store.baseDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		remaining, err := Collect(ctx, reopened, Query{ProjectIDs: []uuid.UUID{projectID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(remaining) != 3 || remaining[0].ID != events[2].ID {
+			t.Errorf("got %d remaining events, want events 2 to 4", len(remaining))
+		}
+
+		other, err := Collect(ctx, reopened, Query{ProjectIDs: []uuid.UUID{otherProjectID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(other) != 1 {
+			t.Errorf("other project has %d events, want 1", len(other))
+		}
+	})
+
+	t.Run("delete range of some levels", func(t *testing.T) {
+		result, err := store.DeleteRange(ctx, projectID, time.Time{}, time.Time{}, []string{"error"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Events != 1 {
+			t.Errorf("deleted %d events, want the one remaining error event", result.Events)
+		}
+
+		remaining, err := Collect(ctx, store, Query{ProjectIDs: []uuid.UUID{projectID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(remaining) != 2 || remaining[0].ID != events[2].ID || remaining[1].ID != events[4].ID {
+			t.Errorf("got %d remaining events, want events 2 and 4", len(remaining))
+		}
+	})
+}
+
+func TestFileStoreMergesSmallSegments(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	start := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	appended := maxSegmentsPerDay + 4
+	for i := 0; i < appended; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		event := &Event{ID: uuid.New(), ProjectID: projectID, Timestamp: at, ReceivedAt: at, Level: "info", Message: "tick"}
+		if err := store.Append(ctx, []*Event{event}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	segments, err := store.projectSegments(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) > maxSegmentsPerDay {
+		t.Errorf("got %d segments, want at most %d", len(segments), maxSegmentsPerDay)
+	}
+
+	events, err := Collect(ctx, store, Query{ProjectIDs: []uuid.UUID{projectID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != appended {
+		t.Errorf("got %d events after merging, want %d", len(events), appended)
+	}
+}
+
+func TestReadSegmentWithoutSearchText(t *testing.T) {
+	// Segments written before attributes could be indexed have no search_texts column
+	projectID := uuid.New()
+	id := uuid.New()
+	timestamp := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).UnixNano()
+	columns := map[string]interface{}{
+		"ids": []uuid.UUID{id}, "timestamps": []int64{timestamp}, "received_at": []int64{0},
+		"levels": []string{"info"}, "messages": []string{"hello"}, "hosts": []string{""},
+		"services": []string{""}, "streams": []string{""}, "releases": []string{""},
+		"pattern_ids": []*uuid.UUID{nil}, "attributes": []map[string]string{nil},
+	}
+
+	path := filepath.Join(t.TempDir(), "old"+segmentSuffix)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := gzip.NewWriter(file)
+	if err := json.NewEncoder(writer).Encode(columns); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	file.Close()
+
+	events, err := readSegment(&segmentMeta{ProjectID: projectID, path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != id || events[0].Message != "hello" || events[0].SearchText != "" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+}
@@ -0,0 +1,126 @@
+package logstore
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits text into lowercase words the way word and phrase searches see it
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matcher tests events against a query in memory
+type matcher struct {
+	query  Query
+	levels map[string]bool
+	text   TextMatch
+	words  []string // Words of a word or phrase search
+}
+
+// newMatcher prepares a query for matching
+func newMatcher(query Query) *matcher {
+	m := &matcher{query: query, text: ParseText(query.Text)}
+	if len(query.Levels) > 0 {
+		m.levels = make(map[string]bool, len(query.Levels))
+		for _, level := range query.Levels {
+			m.levels[strings.ToLower(level)] = true
+		}
+	}
+	if m.text.Mode == TextWords || m.text.Mode == TextPhrase {
+		m.words = tokenize(m.text.Value)
+	}
+	return m
+}
+
+// inRange reports whether a timestamp falls within the query's time range
+func (m *matcher) inRange(e *Event) bool {
+	if !m.query.From.IsZero() && e.Timestamp.Before(m.query.From) {
+		return false
+	}
+	if !m.query.To.IsZero() && !e.Timestamp.Before(m.query.To) {
+		return false
+	}
+	return true
+}
+
+// afterCursor reports whether the event comes after the query's cursor in the query's order
+func (m *matcher) afterCursor(e *Event) bool {
+	cursor := m.query.After
+	if cursor == nil {
+		return true
+	}
+	if m.query.Descending {
+		return cursor.before(e)
+	}
+	if e.Timestamp.Equal(cursor.Timestamp) && e.ID == cursor.ID {
+		return false
+	}
+	return !cursor.before(e)
+}
+
+// match reports whether the event satisfies every condition of the query
+func (m *matcher) match(e *Event) bool {
+	if !m.inRange(e) || !m.afterCursor(e) {
+		return false
+	}
+	if m.levels != nil && !m.levels[e.Level] {
+		return false
+	}
+	for name, value := range m.query.Fields {
+		if e.Field(name) != value {
+			return false
+		}
+	}
+	if m.query.PatternID != nil && (e.PatternID == nil || *e.PatternID != *m.query.PatternID) {
+		return false
+	}
+	return m.matchText(e)
+}
+
+// matchText applies the query's full-text term to the event's message and search text
+// A phrase must lie within one of them; the words of a word search may be spread over both
+func (m *matcher) matchText(e *Event) bool {
+	switch m.text.Mode {
+	case TextWords:
+		present := make(map[string]bool)
+		for _, word := range tokenize(e.Message + " " + e.SearchText) {
+			present[word] = true
+		}
+		for _, word := range m.words {
+			if !present[word] {
+				return false
+			}
+		}
+		return true
+	case TextPhrase:
+		return containsSequence(tokenize(e.Message), m.words) || containsSequence(tokenize(e.SearchText), m.words)
+	case TextSubstring:
+		value := strings.ToLower(m.text.Value)
+		return strings.Contains(strings.ToLower(e.Message), value) || strings.Contains(strings.ToLower(e.SearchText), value)
+	default:
+		return true
+	}
+}
+
+// containsSequence reports whether words appear in tokens next to each other, in order
+func containsSequence(tokens []string, words []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+	for i := 0; i+len(words) <= len(tokens); i++ {
+		found := true
+		for j, word := range words {
+			if tokens[i+j] != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
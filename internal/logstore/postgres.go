@@ -0,0 +1,396 @@
+package logstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"gorm.io/gorm"
+)
+
+// postgresChunkSize is how many rows Query reads per round trip
+// Each chunk continues from the last row of the previous one, so no query holds a long-lived cursor
+const postgresChunkSize = 1000
+
+// postgresInsertBatchSize is how many rows Append sends per INSERT statement
+const postgresInsertBatchSize = 500
+
+// logEventRow is a row of the log_events table
+type logEventRow struct {
+	ID         uuid.UUID
+	ProjectID  uuid.UUID
+	Timestamp  time.Time
+	ReceivedAt time.Time
+	Level      string
+	Message    string
+	Host       string
+	Service    string
+	Stream     string
+	Release    string
+	PatternID  *uuid.UUID
+	Attributes map[string]string `gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+	SearchText string
+	SizeBytes  int64
+}
+
+// TableName specifies the table name for GORM
+func (logEventRow) TableName() string {
+	return "log_events"
+}
+
+// logEventColumns are the columns read back into events; search_vector is left out
+const logEventColumns = "id, project_id, timestamp, received_at, level, message, host, service, stream, release, pattern_id, attributes, search_text, size_bytes"
+
+func newLogEventRow(e *Event) *logEventRow {
+	attributes := e.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return &logEventRow{
+		ID:         e.ID,
+		ProjectID:  e.ProjectID,
+		Timestamp:  e.Timestamp,
+		ReceivedAt: e.ReceivedAt,
+		Level:      e.Level,
+		Message:    e.Message,
+		Host:       e.Host,
+		Service:    e.Service,
+		Stream:     e.Stream,
+		Release:    e.Release,
+		PatternID:  e.PatternID,
+		Attributes: attributes,
+		SearchText: e.SearchText,
+		SizeBytes:  e.Size(),
+	}
+}
+
+func (r *logEventRow) event() *Event {
+	return &Event{
+		ID:         r.ID,
+		ProjectID:  r.ProjectID,
+		Timestamp:  r.Timestamp.UTC(),
+		ReceivedAt: r.ReceivedAt.UTC(),
+		Level:      r.Level,
+		Message:    r.Message,
+		Host:       r.Host,
+		Service:    r.Service,
+		Stream:     r.Stream,
+		Release:    r.Release,
+		PatternID:  r.PatternID,
+		Attributes: r.Attributes,
+		SearchText: r.SearchText,
+	}
+}
+
+// PostgresStore keeps log events in the log_events table, partitioned by day
+// Partitions are created on demand the first time an event for a day is appended
+type PostgresStore struct {
+	db *gorm.DB
+
+	// partitions caches the days whose partition is known to exist
+	mu         sync.Mutex
+	partitions map[string]bool
+}
+
+// NewPostgresStore creates a log store backed by the log_events table
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db, partitions: make(map[string]bool)}
+}
+
+// ensurePartition creates the partition holding the day of t if it does not exist yet
+func (s *PostgresStore) ensurePartition(ctx context.Context, t time.Time) error {
+	day := t.UTC().Truncate(24 * time.Hour)
+	name := "log_events_p" + day.Format("20060102")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.partitions[name] {
+		return nil
+	}
+
+	statement := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF log_events FOR VALUES FROM ('%s') TO ('%s')",
+		name, day.Format(time.RFC3339), day.Add(24*time.Hour).Format(time.RFC3339),
+	)
+	if err := s.db.WithContext(ctx).Exec(statement).Error; err != nil {
+		// Another instance may have created it between our check and our statement
+		var exists bool
+		if checkErr := s.db.WithContext(ctx).Raw("SELECT to_regclass(?) IS NOT NULL", name).Scan(&exists).Error; checkErr != nil || !exists {
+			return fmt.Errorf("failed to create log partition %s: %w", name, err)
+		}
+	}
+	s.partitions[name] = true
+	return nil
+}
+
+// Append inserts the events, creating any missing daily partitions first
+func (s *PostgresStore) Append(ctx context.Context, events []*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]*logEventRow, 0, len(events))
+	for _, event := range events {
+		if err := s.ensurePartition(ctx, event.Timestamp); err != nil {
+			return err
+		}
+		rows = append(rows, newLogEventRow(event))
+	}
+
+	if err := s.db.WithContext(ctx).CreateInBatches(rows, postgresInsertBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to store log events: %w", err)
+	}
+	return nil
+}
+
+// filter applies the query's conditions, other than its cursor, to a log_events query
+func filter(db *gorm.DB, query Query) *gorm.DB {
+	db = db.Where("project_id IN ?", query.ProjectIDs)
+	if !query.From.IsZero() {
+		db = db.Where("timestamp >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("timestamp < ?", query.To)
+	}
+	if len(query.Levels) > 0 {
+		levels := make([]string, len(query.Levels))
+		for i, level := range query.Levels {
+			levels[i] = strings.ToLower(level)
+		}
+		db = db.Where("level IN ?", levels)
+	}
+	for name, value := range query.Fields {
+		switch name {
+		case FieldHost, FieldService, FieldStream, FieldRelease, FieldLevel:
+			db = db.Where(name+" = ?", value)
+		default:
+			db = db.Where("attributes ->> ? = ?", name, value)
+		}
+	}
+	if query.PatternID != nil {
+		db = db.Where("pattern_id = ?", *query.PatternID)
+	}
+
+	// Word and phrase searches use the GIN index on search_vector; substring searches use the
+	// trigram indexes on message and search_text
+	text := ParseText(query.Text)
+	switch text.Mode {
+	case TextWords:
+		db = db.Where("search_vector @@ plainto_tsquery('simple', ?)", text.Value)
+	case TextPhrase:
+		db = db.Where("search_vector @@ phraseto_tsquery('simple', ?)", text.Value)
+	case TextSubstring:
+		pattern := "%" + escapeLike(text.Value) + "%"
+		db = db.Where("(message ILIKE ? OR search_text ILIKE ?)", pattern, pattern)
+	}
+	return db
+}
+
+// escapeLike escapes the LIKE wildcards in a value so it matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// Query reads matching events in chunks, each continuing from the last row of the previous one
+func (s *PostgresStore) Query(ctx context.Context, query Query, fn func(*Event) error) error {
+	order := "timestamp ASC, id ASC"
+	comparison := ">"
+	if query.Descending {
+		order = "timestamp DESC, id DESC"
+		comparison = "<"
+	}
+
+	after := query.After
+	sent := 0
+	for {
+		size := postgresChunkSize
+		if query.Limit > 0 && query.Limit-sent < size {
+			size = query.Limit - sent
+		}
+
+		db := filter(s.db.WithContext(ctx).Model(&logEventRow{}).Select(logEventColumns), query)
+		if after != nil {
+			db = db.Where("(timestamp, id) "+comparison+" (?, ?)", after.Timestamp, after.ID)
+		}
+
+		var rows []*logEventRow
+		if err := db.Order(order).Limit(size).Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to query log events: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := fn(row.event()); err != nil {
+				if err == ErrStop {
+					return nil
+				}
+				return err
+			}
+		}
+		sent += len(rows)
+
+		if len(rows) < size || (query.Limit > 0 && sent >= query.Limit) {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		after = &Cursor{Timestamp: last.Timestamp, ID: last.ID}
+	}
+}
+
+// Estimate asks the planner for the cost of selecting every matching row, via EXPLAIN
+// The query is built exactly as Query builds its first chunk, minus the order and limit
+func (s *PostgresStore) Estimate(ctx context.Context, query Query) (Estimate, error) {
+	var rows []*logEventRow
+	statement := filter(s.db.Session(&gorm.Session{DryRun: true}).Model(&logEventRow{}).Select("id"), query).
+		Find(&rows).Statement
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	var plan []byte
+	if err := sqlDB.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+statement.SQL.String(), statement.Vars...).Scan(&plan); err != nil {
+		return Estimate{}, fmt.Errorf("failed to estimate log query: %w", err)
+	}
+	return parseExplain(plan)
+}
+
+// parseExplain reads the root node's estimates from EXPLAIN (FORMAT JSON) output
+func parseExplain(output []byte) (Estimate, error) {
+	var plans []struct {
+		Plan struct {
+			TotalCost float64 `json:"Total Cost"`
+			PlanRows  float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(output, &plans); err != nil || len(plans) == 0 {
+		return Estimate{}, fmt.Errorf("failed to read query plan: %s", output)
+	}
+	return Estimate{Rows: int64(plans[0].Plan.PlanRows), Cost: plans[0].Plan.TotalCost}, nil
+}
+
+// Get retrieves a single event of a project
+func (s *PostgresStore) Get(ctx context.Context, projectID uuid.UUID, id uuid.UUID) (*Event, error) {
+	var row logEventRow
+	if err := s.db.WithContext(ctx).Select(logEventColumns).
+		Where("project_id = ? AND id = ?", projectID, id).
+		First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve log event: %w", err)
+	}
+	return row.event(), nil
+}
+
+// Aggregate counts matching events per level in buckets counted from the Unix epoch
+func (s *PostgresStore) Aggregate(ctx context.Context, query Query, interval time.Duration) ([]Bucket, error) {
+	seconds := interval.Seconds()
+	if seconds < 1 {
+		return nil, fmt.Errorf("aggregation interval must be at least one second")
+	}
+
+	var buckets []Bucket
+	db := filter(s.db.WithContext(ctx).Model(&logEventRow{}), query).
+		Select("to_timestamp(floor(extract(epoch FROM timestamp) / ?) * ?) AS start, level, COUNT(*) AS count", seconds, seconds).
+		Group("1, level").
+		Order("1 ASC, level ASC")
+	if err := db.Scan(&buckets).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate log events: %w", err)
+	}
+	for i := range buckets {
+		buckets[i].Start = buckets[i].Start.UTC()
+	}
+	return buckets, nil
+}
+
+// DeleteRange deletes a project's events in [from, to), reporting the bytes they took up
+func (s *PostgresStore) DeleteRange(ctx context.Context, projectID uuid.UUID, from, to time.Time, levels []string) (DeleteResult, error) {
+	conditions := []string{"project_id = ?"}
+	args := []interface{}{projectID}
+	if len(levels) > 0 {
+		lower := make([]string, len(levels))
+		for i, level := range levels {
+			lower[i] = strings.ToLower(level)
+		}
+		conditions = append(conditions, "level IN ?")
+		args = append(args, lower)
+	}
+	if !from.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, from)
+	}
+	if !to.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, to)
+	}
+
+	var result DeleteResult
+	statement := `WITH deleted AS (DELETE FROM log_events WHERE ` + strings.Join(conditions, " AND ") + ` RETURNING size_bytes)
+		SELECT COUNT(*) AS events, COALESCE(SUM(size_bytes), 0) AS bytes FROM deleted`
+	if err := s.db.WithContext(ctx).Raw(statement, args...).Scan(&result).Error; err != nil {
+		return result, fmt.Errorf("failed to delete log events: %w", err)
+	}
+
+	if err := s.dropEmptyPartitions(ctx, to); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// partitionDropDelay keeps a day's partition for longer than ingestion accepts events for that
+// day, so a partition is never dropped while events may still be written to it
+const partitionDropDelay = constants.MaxLogEventAgeSeconds*time.Second + 24*time.Hour
+
+// dropEmptyPartitions drops the daily partitions that end by before (any, if zero) and hold no
+// events any more. Partitions are shared by every project, so a day's partition goes once the
+// last project has deleted its events for the day; dropping it returns its space to the
+// operating system straight away, which deleting rows does not
+func (s *PostgresStore) dropEmptyPartitions(ctx context.Context, before time.Time) error {
+	cutoff := time.Now().UTC().Add(-partitionDropDelay)
+	if !before.IsZero() && before.Before(cutoff) {
+		cutoff = before
+	}
+
+	var names []string
+	if err := s.db.WithContext(ctx).Raw(`SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'log_events'::regclass`).Scan(&names).Error; err != nil {
+		return fmt.Errorf("failed to list log partitions: %w", err)
+	}
+
+	for _, name := range names {
+		day, err := time.Parse("20060102", strings.TrimPrefix(name, "log_events_p"))
+		if err != nil || day.Add(24*time.Hour).After(cutoff) {
+			continue
+		}
+
+		// Lock the partition so no event can be written between the check and the drop
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("LOCK TABLE " + name + " IN ACCESS EXCLUSIVE MODE").Error; err != nil {
+				return err
+			}
+			var empty bool
+			if err := tx.Raw("SELECT NOT EXISTS (SELECT 1 FROM " + name + ")").Scan(&empty).Error; err != nil {
+				return err
+			}
+			if !empty {
+				return nil
+			}
+			return tx.Exec("DROP TABLE " + name).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop log partition %s: %w", name, err)
+		}
+
+		s.mu.Lock()
+		delete(s.partitions, name)
+		s.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package logstore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresFilter(t *testing.T) {
+	// A dry run builds the statement without a database to run it on
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	patternID := uuid.New()
+
+	tests := []struct {
+		name     string
+		query    Query
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "project only",
+			query:    Query{},
+			wantSQL:  `SELECT * FROM "log_events" WHERE project_id IN ($1)`,
+			wantVars: []any{projectID},
+		},
+		{
+			name:     "pattern",
+			query:    Query{PatternID: &patternID},
+			wantSQL:  `SELECT * FROM "log_events" WHERE project_id IN ($1) AND pattern_id = $2`,
+			wantVars: []any{projectID, patternID},
+		},
+		{
+			name:     "pattern is a column, not an attribute",
+			query:    Query{Fields: map[string]string{"region": "eu"}, PatternID: &patternID},
+			wantSQL:  `SELECT * FROM "log_events" WHERE project_id IN ($1) AND attributes ->> $2 = $3 AND pattern_id = $4`,
+			wantVars: []any{projectID, "region", "eu", patternID},
+		},
+		{
+			name:     "levels and text",
+			query:    Query{Levels: []string{"ERROR"}, Text: `"connection refused"`},
+			wantSQL:  `SELECT * FROM "log_events" WHERE project_id IN ($1) AND level IN ($2) AND search_vector @@ phraseto_tsquery('simple', $3)`,
+			wantVars: []any{projectID, "error", "connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.ProjectIDs = []uuid.UUID{projectID}
+			statement := filter(db.Model(&logEventRow{}), tt.query).Find(&[]logEventRow{}).Statement
+			if got := statement.SQL.String(); got != tt.wantSQL {
+				t.Errorf("SQL = %s, want %s", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(statement.Vars, tt.wantVars) {
+				t.Errorf("vars = %v, want %v", statement.Vars, tt.wantVars)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package logstore
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// File name suffixes of a segment and of its metadata sidecar
+const (
+	segmentSuffix = ".seg"
+	metaSuffix    = ".meta"
+)
+
+// segmentMeta describes a segment so queries can skip it without opening it
+type segmentMeta struct {
+	ProjectID uuid.UUID        `json:"project_id"`
+	MinTime   time.Time        `json:"min_time"`
+	MaxTime   time.Time        `json:"max_time"`
+	Count     int              `json:"count"`
+	Bytes     int64            `json:"bytes"`     // Compressed size of the segment file
+	RawBytes  int64            `json:"raw_bytes"` // Sum of the events' sizes
+	Levels    map[string]int64 `json:"levels"`    // Events per level
+	Bloom     *bloomFilter     `json:"bloom"`     // Words, field values and event IDs
+
+	// path is the segment file the metadata describes
+	path string
+}
+
+// overlaps reports whether the segment may hold events in [from, to); zero bounds are open
+func (m *segmentMeta) overlaps(from, to time.Time) bool {
+	if !from.IsZero() && m.MaxTime.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !m.MinTime.Before(to) {
+		return false
+	}
+	return true
+}
+
+// within reports whether every event of the segment lies in [from, to); zero bounds are open
+func (m *segmentMeta) within(from, to time.Time) bool {
+	return (from.IsZero() || !m.MinTime.Before(from)) && (to.IsZero() || m.MaxTime.Before(to))
+}
+
+// hasLevels reports whether the segment holds events of any of the levels; nil means every level
+func (m *segmentMeta) hasLevels(levels map[string]bool) bool {
+	if levels == nil {
+		return true
+	}
+	for level := range levels {
+		if m.Levels[level] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyLevels reports whether every event of the segment has one of the levels; nil means every level
+func (m *segmentMeta) onlyLevels(levels map[string]bool) bool {
+	if levels == nil {
+		return true
+	}
+	for level, count := range m.Levels {
+		if count > 0 && !levels[level] {
+			return false
+		}
+	}
+	return true
+}
+
+// mayMatch uses the level counts and the bloom filter to rule out segments that cannot match
+func (m *segmentMeta) mayMatch(matcher *matcher) bool {
+	if !m.overlaps(matcher.query.From, matcher.query.To) {
+		return false
+	}
+	if matcher.levels != nil {
+		found := false
+		for level := range matcher.levels {
+			if m.Levels[level] > 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for name, value := range matcher.query.Fields {
+		if name == FieldLevel {
+			if m.Levels[value] == 0 {
+				return false
+			}
+			continue
+		}
+		if !m.Bloom.mayContain(fieldKey(name, value)) {
+			return false
+		}
+	}
+	for _, word := range matcher.words {
+		if !m.Bloom.mayContain(wordKey(word)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bloom filter keys for the different kinds of values
+func wordKey(word string) string                { return "w:" + word }
+func fieldKey(name string, value string) string { return "f:" + name + "=" + value }
+func idKey(id uuid.UUID) string                 { return "i:" + id.String() }
+
+// segmentColumns is the on-disk layout of a segment: one array per field, so similar values
+// sit together and compress well. Timestamps are stored as deltas from the previous event
+type segmentColumns struct {
+	IDs        []uuid.UUID         `json:"ids"`
+	Timestamps []int64             `json:"timestamps"`
+	ReceivedAt []int64             `json:"received_at"` // Offset from the event's timestamp
+	Levels     []string            `json:"levels"`
+	Messages   []string            `json:"messages"`
+	Hosts      []string            `json:"hosts"`
+	Services   []string            `json:"services"`
+	Streams    []string            `json:"streams"`
+	Releases   []string            `json:"releases"`
+	PatternIDs []*uuid.UUID        `json:"pattern_ids"`
+	Attributes []map[string]string `json:"attributes"`
+
+	// SearchTexts is absent from segments written before attributes could be indexed
+	SearchTexts []string `json:"search_texts,omitempty"`
+}
+
+// sortEvents orders events by timestamp, then ID
+func sortEvents(events []*Event) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Timestamp.Equal(events[j].Timestamp) {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		}
+		return strings.Compare(events[i].ID.String(), events[j].ID.String()) < 0
+	})
+}
+
+// writeSegment writes events of one project as a new segment in dir, returning its metadata
+// The segment and its sidecar are written to temporary files and renamed into place,
+// so readers never see a partial segment
+func writeSegment(dir string, projectID uuid.UUID, events []*Event) (*segmentMeta, error) {
+	sortEvents(events)
+
+	meta := &segmentMeta{
+		ProjectID: projectID,
+		MinTime:   events[0].Timestamp,
+		MaxTime:   events[len(events)-1].Timestamp,
+		Count:     len(events),
+		Levels:    make(map[string]int64),
+	}
+
+	columns := segmentColumns{}
+	keys := make(map[string]bool)
+	var previous int64
+	for _, event := range events {
+		timestamp := event.Timestamp.UnixNano()
+		columns.IDs = append(columns.IDs, event.ID)
+		columns.Timestamps = append(columns.Timestamps, timestamp-previous)
+		columns.ReceivedAt = append(columns.ReceivedAt, event.ReceivedAt.UnixNano()-timestamp)
+		columns.Levels = append(columns.Levels, event.Level)
+		columns.Messages = append(columns.Messages, event.Message)
+		columns.Hosts = append(columns.Hosts, event.Host)
+		columns.Services = append(columns.Services, event.Service)
+		columns.Streams = append(columns.Streams, event.Stream)
+		columns.Releases = append(columns.Releases, event.Release)
+		columns.PatternIDs = append(columns.PatternIDs, event.PatternID)
+		columns.Attributes = append(columns.Attributes, event.Attributes)
+		columns.SearchTexts = append(columns.SearchTexts, event.SearchText)
+		previous = timestamp
+
+		meta.Levels[event.Level]++
+		meta.RawBytes += event.Size()
+
+		keys[idKey(event.ID)] = true
+		for _, word := range tokenize(event.Message + " " + event.SearchText) {
+			keys[wordKey(word)] = true
+		}
+		for _, name := range []string{FieldHost, FieldService, FieldStream, FieldRelease} {
+			if value := event.Field(name); value != "" {
+				keys[fieldKey(name, value)] = true
+			}
+		}
+		for name, value := range event.Attributes {
+			keys[fieldKey(name, value)] = true
+		}
+	}
+
+	meta.Bloom = newBloomFilter(len(keys))
+	for key := range keys {
+		meta.Bloom.add(key)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create segment directory: %w", err)
+	}
+	name := fmt.Sprintf("%020d-%s", meta.MinTime.UnixNano(), uuid.New())
+	meta.path = filepath.Join(dir, name+segmentSuffix)
+
+	size, err := writeFileAtomic(meta.path, func(file *os.File) error {
+		writer := gzip.NewWriter(file)
+		if err := json.NewEncoder(writer).Encode(columns); err != nil {
+			return err
+		}
+		return writer.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+	meta.Bytes = size
+
+	if _, err := writeFileAtomic(metaPath(meta.path), func(file *os.File) error {
+		return json.NewEncoder(file).Encode(meta)
+	}); err != nil {
+		os.Remove(meta.path)
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// readSegment decodes every event of a segment, in timestamp order
+func readSegment(meta *segmentMeta) ([]*Event, error) {
+	file, err := os.Open(meta.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segment %s: %w", meta.path, err)
+	}
+	defer reader.Close()
+
+	var columns segmentColumns
+	if err := json.NewDecoder(reader).Decode(&columns); err != nil {
+		return nil, fmt.Errorf("failed to read segment %s: %w", meta.path, err)
+	}
+
+	events := make([]*Event, len(columns.IDs))
+	var timestamp int64
+	for i := range columns.IDs {
+		timestamp += columns.Timestamps[i]
+		events[i] = &Event{
+			ID:         columns.IDs[i],
+			ProjectID:  meta.ProjectID,
+			Timestamp:  time.Unix(0, timestamp).UTC(),
+			ReceivedAt: time.Unix(0, timestamp+columns.ReceivedAt[i]).UTC(),
+			Level:      columns.Levels[i],
+			Message:    columns.Messages[i],
+			Host:       columns.Hosts[i],
+			Service:    columns.Services[i],
+			Stream:     columns.Streams[i],
+			Release:    columns.Releases[i],
+			PatternID:  columns.PatternIDs[i],
+			Attributes: columns.Attributes[i],
+		}
+		if len(columns.SearchTexts) == len(columns.IDs) {
+			events[i].SearchText = columns.SearchTexts[i]
+		}
+	}
+	return events, nil
+}
+
+// readMeta loads the metadata sidecar of a segment
+func readMeta(segmentPath string) (*segmentMeta, error) {
+	data, err := os.ReadFile(metaPath(segmentPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var meta segmentMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to read segment metadata %s: %w", metaPath(segmentPath), err)
+	}
+	meta.path = segmentPath
+	return &meta, nil
+}
+
+// removeSegment deletes a segment and its sidecar
+func removeSegment(meta *segmentMeta) error {
+	if err := os.Remove(metaPath(meta.path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(meta.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// metaPath returns the sidecar path of a segment
+func metaPath(segmentPath string) string {
+	return strings.TrimSuffix(segmentPath, segmentSuffix) + metaSuffix
+}
+
+// writeFileAtomic writes a file through a temporary file renamed into place, returning its size
+func writeFileAtomic(path string, write func(*os.File) error) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".segment-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create segment file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("failed to write segment file: %w", err)
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("failed to write segment file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write segment file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to store segment file: %w", err)
+	}
+	return info.Size(), nil
+}
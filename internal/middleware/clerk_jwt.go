@@ -95,7 +95,7 @@ func ClerkJWTMiddleware(db *gorm.DB) func(http.Handler) http.Handler {
 			}
 
 			// Convert Clerk user ID to internal UUID (with caching)
-			internalUserID, err := getCachedInternalUserID(db, clerkUserID)
+			internalUserID, err := getCachedInternalUserID(r.Context(), db, clerkUserID)
 			if err != nil {
 				response.SendUnauthorized(w, "User not found: "+err.Error())
 				return
@@ -195,7 +195,7 @@ func jwkToRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
 }
 
 // getCachedInternalUserID converts Clerk user ID to internal UUID with caching
-func getCachedInternalUserID(db *gorm.DB, clerkUserID string) (string, error) {
+func getCachedInternalUserID(ctx context.Context, db *gorm.DB, clerkUserID string) (string, error) {
 	const userCacheTTL = 10 * time.Minute
 
 	// Check cache first with read lock
@@ -212,7 +212,7 @@ func getCachedInternalUserID(db *gorm.DB, clerkUserID string) (string, error) {
 
 	// Cache miss or expired - fetch from database
 	userRepo := user.NewRepository(db)
-	userModel, err := userRepo.GetByClerkUserID(clerkUserID)
+	userModel, err := userRepo.GetByClerkUserID(ctx, clerkUserID)
 	if err != nil {
 		return "", fmt.Errorf("user with Clerk ID %s not found", clerkUserID)
 	}
@@ -229,6 +229,6 @@ func getCachedInternalUserID(db *gorm.DB, clerkUserID string) (string, error) {
 }
 
 // getInternalUserID is kept for backward compatibility but uses the cached version
-func getInternalUserID(db *gorm.DB, clerkUserID string) (string, error) {
-	return getCachedInternalUserID(db, clerkUserID)
+func getInternalUserID(ctx context.Context, db *gorm.DB, clerkUserID string) (string, error) {
+	return getCachedInternalUserID(ctx, db, clerkUserID)
 }
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/response"
+	"gorm.io/gorm"
+)
+
+// projectIDKey is the context key under which ProjectAPIKeyMiddleware stores the project ID
+// Its unexported type keeps other packages from reading or overwriting the value by accident
+type projectIDKey struct{}
+
+// ProjectAPIKeyMiddleware authenticates SDKs and scripts by their project API key
+// The key is sent as "Authorization: Bearer <key>"; the project's ID is added to the context
+func ProjectAPIKeyMiddleware(db *gorm.DB) func(http.Handler) http.Handler {
+	projectRepo := project.NewRepository(db)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get the Authorization header
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				response.SendUnauthorized(w, "Authorization header required")
+				return
+			}
+
+			// Extract the API key
+			apiKey := strings.TrimPrefix(authHeader, "Bearer ")
+			if apiKey == authHeader || !strings.HasPrefix(apiKey, constants.APIKeyPrefix) {
+				response.SendUnauthorized(w, "Invalid authorization format. Expected 'Bearer <project API key>'")
+				return
+			}
+
+			// Look up the project the key belongs to
+			// Only an unknown key is the caller's fault; anything else is reported as a server
+			// error so SDKs retry instead of discarding their events
+			projectModel, err := projectRepo.GetByAPIKey(r.Context(), apiKey)
+			if err != nil {
+				if appErrors.IsNotFoundError(err) {
+					response.SendUnauthorized(w, "Invalid API key")
+					return
+				}
+				response.SendInternalError(w, "Failed to verify API key")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), projectIDKey{}, projectModel.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetProjectIDFromContext extracts the project ID set by ProjectAPIKeyMiddleware from request context
+func GetProjectIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	projectID, ok := ctx.Value(projectIDKey{}).(uuid.UUID)
+	return projectID, ok
+}
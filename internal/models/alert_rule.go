@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertRule represents a threshold alert evaluated periodically against a project's events
+// The rule fires when the aggregated value breaches the threshold and resolves once it no
+// longer breaches the resolve threshold, which can sit below the threshold for hysteresis
+type AlertRule struct {
+	// ID is the primary key for the alert rule record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the rule watches
+	// Required field with CASCADE delete behavior (if project is deleted, rules are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_alert_rules_project_id"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Name stores the rule's display name, unique per project
+	Name string `gorm:"type:varchar(255);not null"`
+
+	// Query stores the log query selecting the events to aggregate
+	// Empty means every event of the project, unless SavedSearchID is set
+	Query string `gorm:"type:text;not null;default:''"`
+
+	// SavedSearchID references the organization saved search whose query the rule uses
+	// Nullable for rules with a query of their own; the saved search's query is read at every
+	// evaluation, so edits to it apply straight away
+	SavedSearchID *uuid.UUID `gorm:"type:uuid"`
+
+	// Aggregation stores how matching events are aggregated ("count" or "rate" per minute)
+	Aggregation string `gorm:"type:varchar(20);not null"`
+
+	// Comparison stores how the value is compared with the thresholds (gt, gte, lt or lte)
+	Comparison string `gorm:"type:varchar(5);not null"`
+
+	// Threshold stores the value that fires the rule
+	Threshold float64 `gorm:"type:double precision;not null"`
+
+	// ResolveThreshold stores the value that resolves a firing rule
+	// Nil means the rule resolves as soon as the threshold is no longer breached
+	ResolveThreshold *float64 `gorm:"type:double precision"`
+
+	// WindowSeconds stores the length of the window events are aggregated over
+	WindowSeconds int `gorm:"type:integer;not null"`
+
+	// EvaluationIntervalSeconds stores how often the scheduler evaluates the rule
+	EvaluationIntervalSeconds int `gorm:"type:integer;not null"`
+
+	// ChannelIDs stores the notification channels notified when the rule changes state
+	// Channels of the project's organization only; deleted channels are skipped
+	ChannelIDs []uuid.UUID `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// Labels stores free-form key/value pairs describing the rule (e.g. team=payments)
+	// Silences select the rules they mute by these labels
+	Labels map[string]string `gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+
+	// Enabled controls whether the scheduler evaluates the rule
+	Enabled bool `gorm:"not null;default:true"`
+
+	// State stores whether the rule is currently "ok" or "firing"
+	State string `gorm:"type:varchar(10);not null;default:'ok'"`
+
+	// StateChangedAt records when the rule last changed state
+	StateChangedAt *time.Time `gorm:"type:timestamptz"`
+
+	// LastEvaluatedAt and LastValue record the most recent successful evaluation
+	LastEvaluatedAt *time.Time `gorm:"type:timestamptz"`
+	LastValue       *float64   `gorm:"type:double precision"`
+
+	// NextEvaluationAt stores when the scheduler should next evaluate the rule
+	NextEvaluationAt time.Time `gorm:"type:timestamptz;not null;index:idx_alert_rules_next_evaluation_at"`
+
+	// CreatedByID is a foreign key reference to the user who created the rule
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the alert rule record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the alert rule record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// AlertEvaluation records one evaluation of an alert rule
+// Entries are append-only and are pruned by age
+type AlertEvaluation struct {
+	// ID is the primary key for the evaluation, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// AlertRuleID is a foreign key reference to the evaluated rule
+	// Required field with CASCADE delete behavior (if the rule is deleted, its history is deleted)
+	AlertRuleID uuid.UUID `gorm:"type:uuid;not null;index:idx_alert_evaluations_rule_evaluated_at"`
+
+	// AlertRule is the relationship to the AlertRule model
+	AlertRule AlertRule `gorm:"foreignKey:AlertRuleID;constraint:OnDelete:CASCADE"`
+
+	// EvaluatedAt records when the evaluation ran; the window ends here
+	EvaluatedAt time.Time `gorm:"type:timestamptz;not null;index:idx_alert_evaluations_rule_evaluated_at"`
+
+	// WindowStart records the start of the aggregation window
+	WindowStart time.Time `gorm:"type:timestamptz;not null"`
+
+	// Value stores the aggregated value; nil when the evaluation failed
+	Value *float64 `gorm:"type:double precision"`
+
+	// PreviousState and State record the rule's state before and after the evaluation
+	PreviousState string `gorm:"type:varchar(10);not null"`
+	State         string `gorm:"type:varchar(10);not null"`
+
+	// Error stores why the evaluation failed, if it did
+	Error string `gorm:"type:text"`
+}
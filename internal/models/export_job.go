@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFilter stores the search an export job runs
+type ExportFilter struct {
+	Query      string            `json:"q,omitempty"`
+	From       *time.Time        `json:"from,omitempty"`
+	To         *time.Time        `json:"to,omitempty"`
+	Levels     []string          `json:"levels,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	PatternID  *uuid.UUID        `json:"pattern_id,omitempty"`
+	Descending bool              `json:"descending,omitempty"`
+
+	// Cursor, when set, starts the export after this event instead of at From or To
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ExportJob represents an export of log search results that runs in the background
+// The finished file is kept in object storage until ExpiresAt, then deleted
+type ExportJob struct {
+	// ID is the primary key for the export job, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project whose logs are exported
+	// Required field with CASCADE delete behavior (if project is deleted, its export jobs are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_export_jobs_project_created_at"`
+
+	// Project is the relationship to the Project model
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// CreatedByID is a foreign key reference to the user who requested the export
+	// Set to NULL if the user is purged
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Format stores the file format ("csv", "ndjson" or "parquet")
+	Format string `gorm:"type:varchar(10);not null"`
+
+	// Columns stores the exported columns, in order
+	Columns []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// Filter stores the search whose results are exported
+	Filter ExportFilter `gorm:"type:jsonb;serializer:json;not null"`
+
+	// MaxRows caps the number of exported events
+	MaxRows int `gorm:"type:integer;not null"`
+
+	// Status stores whether the job is "pending", "running", "succeeded", "failed" or "expired"
+	Status string `gorm:"type:varchar(20);not null;index:idx_export_jobs_status"`
+
+	// Attempts counts how often a worker has started the job
+	Attempts int `gorm:"type:integer;not null;default:0"`
+
+	// LeaseExpiresAt is when a running job may be taken over by another worker,
+	// in case the worker running it died
+	LeaseExpiresAt *time.Time `gorm:"type:timestamptz"`
+
+	// RowCount and SizeBytes describe the finished file
+	RowCount  int64 `gorm:"type:bigint;not null;default:0"`
+	SizeBytes int64 `gorm:"type:bigint;not null;default:0"`
+
+	// StorageKey is where the finished file is kept in object storage
+	StorageKey string `gorm:"type:text;not null;default:''"`
+
+	// Error describes why a failed job failed
+	Error string `gorm:"type:text;not null;default:''"`
+
+	// CompletedAt records when the job succeeded or failed
+	CompletedAt *time.Time `gorm:"type:timestamptz"`
+
+	// ExpiresAt is when the finished file is deleted and can no longer be downloaded
+	ExpiresAt *time.Time `gorm:"type:timestamptz"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the export was requested
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now();index:idx_export_jobs_project_created_at"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the export job was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
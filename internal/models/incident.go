@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Incident groups what is known about an outage: the alert evaluations that triggered it,
+// related issues, a pinned log query and a timeline of what happened and who did what
+// Incidents are opened automatically when an alert rule fires, or by members by hand
+type Incident struct {
+	// ID is the primary key for the incident record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the incident affects
+	// Required field with CASCADE delete behavior (if project is deleted, incidents are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_incidents_project_started_at"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// AlertRuleID references the alert rule that opened the incident
+	// Nullable for incidents opened by hand; cleared if the rule is deleted
+	// A rule has at most one unresolved incident, which later firings are added to
+	AlertRuleID *uuid.UUID `gorm:"type:uuid"`
+
+	// Title stores a short description of the incident
+	Title string `gorm:"type:varchar(255);not null"`
+
+	// Status stores where the incident is in its lifecycle (open, acknowledged or resolved)
+	Status string `gorm:"type:varchar(20);not null;default:'open'"`
+
+	// LogQuery, LogQueryFrom and LogQueryTo pin the logs to look at while investigating
+	// LogQueryTo is nullable while the alert is still firing
+	LogQuery     string     `gorm:"type:text;not null;default:''"`
+	LogQueryFrom time.Time  `gorm:"type:timestamptz;not null"`
+	LogQueryTo   *time.Time `gorm:"type:timestamptz"`
+
+	// SavedSearchID references the organization saved search the pinned log query was copied from
+	// Nullable for queries entered by hand; incidents opened by an alert rule take the rule's reference
+	SavedSearchID *uuid.UUID `gorm:"type:uuid"`
+
+	// StartedAt records when the incident started, e.g. the first firing evaluation
+	StartedAt time.Time `gorm:"type:timestamptz;not null;index:idx_incidents_project_started_at"`
+
+	// AcknowledgedAt and AcknowledgedByID record who took ownership of the incident and when
+	AcknowledgedAt   *time.Time `gorm:"type:timestamptz"`
+	AcknowledgedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// ResolvedAt and ResolvedByID record who resolved the incident and when
+	ResolvedAt   *time.Time `gorm:"type:timestamptz"`
+	ResolvedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// CreatedByID is a foreign key reference to the user who opened the incident
+	// Nil for incidents opened by an alert rule
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the incident record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the incident record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IncidentAlertEvaluation is a copy of an alert evaluation that changed the state of the
+// incident's rule. It is copied rather than referenced so the post-mortem survives the
+// pruning of evaluation history
+type IncidentAlertEvaluation struct {
+	// ID is the primary key for the record, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// IncidentID is a foreign key reference to the incident
+	// Required field with CASCADE delete behavior (if the incident is deleted, this is deleted)
+	IncidentID uuid.UUID `gorm:"type:uuid;not null;index:idx_incident_alert_evaluations_incident_id"`
+
+	// AlertEvaluationID is the ID of the original evaluation, which may since have been pruned
+	AlertEvaluationID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// EvaluatedAt and WindowStart bound the evaluated window
+	EvaluatedAt time.Time `gorm:"type:timestamptz;not null"`
+	WindowStart time.Time `gorm:"type:timestamptz;not null"`
+
+	// Value stores the aggregated value and State the rule's state after the evaluation
+	Value float64 `gorm:"type:double precision;not null"`
+	State string  `gorm:"type:varchar(10);not null"`
+
+	// Aggregation, Comparison and Threshold record how the value was judged at the time
+	Aggregation string  `gorm:"type:varchar(20);not null"`
+	Comparison  string  `gorm:"type:varchar(5);not null"`
+	Threshold   float64 `gorm:"type:double precision;not null"`
+}
+
+// IncidentIssue links an issue to an incident
+type IncidentIssue struct {
+	// IncidentID and IssueID form the composite primary key
+	// Both have CASCADE delete behavior
+	IncidentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	IssueID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// LinkedByID is a foreign key reference to the user who linked the issue
+	// Nil for issues linked automatically when the incident opened
+	LinkedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// CreatedAt records when the issue was linked
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IncidentTimelineEntry records something that happened during an incident
+// Entries are append-only and are never updated or deleted
+type IncidentTimelineEntry struct {
+	// ID is the primary key for the entry, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// IncidentID is a foreign key reference to the incident
+	IncidentID uuid.UUID `gorm:"type:uuid;not null;index:idx_incident_timeline_entries_incident_id"`
+
+	// Kind stores what happened (opened, alert_firing, alert_resolved, acknowledged,
+	// resolved, updated, note, issue_linked or issue_unlinked)
+	Kind string `gorm:"type:varchar(20);not null"`
+
+	// ActorID is a foreign key reference to the member who acted
+	// Nil for entries recorded by the alert scheduler
+	ActorID *uuid.UUID `gorm:"type:uuid"`
+
+	// Message stores the note, or a human-readable description of the event
+	Message string `gorm:"type:text;not null"`
+
+	// CreatedAt records when it happened
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
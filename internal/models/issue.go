@@ -0,0 +1,147 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Issue groups error events from a project that share a fingerprint
+// The fingerprint comes from the normalized exception type and in-app stack frames,
+// or from a fingerprint supplied by the SDK
+type Issue struct {
+	// ID is the primary key for the issue record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the errors came from
+	// Required field with CASCADE delete behavior (if project is deleted, issues are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_issues_project_fingerprint"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Fingerprint is the hex SHA-256 of the grouping components
+	// Unique per project so each fingerprint maps to exactly one issue
+	Fingerprint string `gorm:"type:varchar(64);not null;uniqueIndex:idx_issues_project_fingerprint"`
+
+	// Title is a short summary, usually "ExceptionType: message"
+	Title string `gorm:"type:text;not null"`
+
+	// Culprit names the innermost in-app frame (e.g. "handleRequest in server/api.go")
+	Culprit string `gorm:"type:text"`
+
+	// Level stores the log level of the first event (usually "error" or "fatal")
+	Level string `gorm:"type:varchar(10);not null"`
+
+	// EventCount stores how many events have been grouped into the issue
+	EventCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Hosts stores the distinct hosts that reported the issue, capped in size
+	Hosts []string `gorm:"type:jsonb;serializer:json;not null"`
+
+	// SampleEvents keeps the most recent events for inspection, capped in size
+	SampleEvents []IssueSample `gorm:"type:jsonb;serializer:json;not null"`
+
+	// FirstSeenAt and LastSeenAt bound the timestamps of grouped events
+	FirstSeenAt time.Time `gorm:"type:timestamptz;not null"`
+	LastSeenAt  time.Time `gorm:"type:timestamptz;not null"`
+
+	// FirstRelease stores the release reported by the event that created the issue, if any
+	// Issues are counted as new in this release
+	FirstRelease string `gorm:"type:varchar(255)"`
+
+	// LastRelease stores the release reported by the most recent event, if any
+	LastRelease string `gorm:"type:varchar(255)"`
+
+	// Status stores the triage state (unresolved, resolved, resolved_in_next_release or ignored)
+	Status string `gorm:"type:varchar(30);not null;default:'unresolved'"`
+
+	// AssigneeID is a foreign key reference to the organization member handling the issue
+	// Nullable for unassigned issues
+	AssigneeID *uuid.UUID `gorm:"type:uuid"`
+
+	// ResolvedAt records when the issue was last resolved
+	// Events timestamped after it reopen the issue as a regression
+	ResolvedAt *time.Time `gorm:"type:timestamptz"`
+
+	// ResolvedRelease stores the release that was current when the issue was resolved in the next release
+	// Events from any other release reopen the issue
+	ResolvedRelease string `gorm:"type:varchar(255)"`
+
+	// IgnoreUntilCount and IgnoreUntil end an ignore once the event count or time is reached
+	// Both nil means the issue stays ignored until changed by hand
+	IgnoreUntilCount *int64     `gorm:"type:bigint"`
+	IgnoreUntil      *time.Time `gorm:"type:timestamptz"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the issue record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the issue record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IssueActivity records a single change to an issue's triage state
+// Entries are append-only and are never updated or deleted
+type IssueActivity struct {
+	// ID is the primary key for the activity entry, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// IssueID is a foreign key reference to the issue that changed
+	IssueID uuid.UUID `gorm:"type:uuid;not null;index:idx_issue_activities_issue_id"`
+
+	// Action stores what happened (status_changed, assigned, unassigned, regressed or unignored)
+	Action string `gorm:"type:varchar(20);not null"`
+
+	// ActorID is a foreign key reference to the user who made the change
+	// Nil for automatic transitions such as regressions
+	ActorID *uuid.UUID `gorm:"type:uuid"`
+
+	// FromStatus and ToStatus record the status before and after the change
+	FromStatus string `gorm:"type:varchar(30)"`
+	ToStatus   string `gorm:"type:varchar(30)"`
+
+	// Details stores a human-readable description of the change
+	Details string `gorm:"type:text;not null"`
+
+	// CreatedAt records when the change was made
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IssueSample is a stored copy of one event grouped into an issue
+type IssueSample struct {
+	EventID   string     `json:"event_id,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+	Host      string     `json:"host,omitempty"`
+	Release   string     `json:"release,omitempty"`
+	Message   string     `json:"message"`
+	Exception *Exception `json:"exception,omitempty"`
+}
+
+// Exception describes an exception attached to an error event
+type Exception struct {
+	Type   string       `json:"type"`
+	Value  string       `json:"value,omitempty"`
+	Frames []StackFrame `json:"frames,omitempty"`
+}
+
+// StackFrame is a single stack frame, ordered outermost first as sent by SDKs
+type StackFrame struct {
+	Function string `json:"function,omitempty"`
+	Module   string `json:"module,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	InApp    bool   `json:"in_app"`
+
+	// Source context around Line, filled in when the frame is symbolicated with a source map
+	PreContext   []string `json:"pre_context,omitempty"`
+	ContextLine  string   `json:"context_line,omitempty"`
+	PostContext  []string `json:"post_context,omitempty"`
+	Symbolicated bool     `json:"symbolicated,omitempty"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegalHold represents a preservation order on a project's logs that overrides retention
+// Holds are never deleted; releasing a hold sets ReleasedAt so the audit trail stays complete
+type LegalHold struct {
+	// ID is the primary key for the legal hold record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project whose logs are held
+	// Required field with CASCADE delete behavior (if project is deleted, hold is deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_legal_holds_project_id"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Reason stores why the hold was placed (e.g. a case or ticket reference)
+	Reason string `gorm:"type:text;not null"`
+
+	// RangeStart and RangeEnd limit the hold to logs within a time range
+	// Both are nullable; a hold without a range covers all of the project's logs
+	RangeStart *time.Time `gorm:"type:timestamptz"`
+	RangeEnd   *time.Time `gorm:"type:timestamptz"`
+
+	// ExpiresAt is when the hold lapses on its own
+	// Nullable for holds that stay in place until explicitly released
+	ExpiresAt *time.Time `gorm:"type:timestamptz"`
+
+	// CreatedByID is a foreign key reference to the user who placed the hold
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// ReleasedAt records when the hold was released
+	// Nullable while the hold is in place
+	ReleasedAt *time.Time `gorm:"type:timestamptz"`
+
+	// ReleasedByID is a foreign key reference to the user who released the hold
+	ReleasedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the legal hold record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the legal hold record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IsActive reports whether the hold is in force at the given time
+func (h *LegalHold) IsActive(at time.Time) bool {
+	if h.ReleasedAt != nil {
+		return false
+	}
+	return h.ExpiresAt == nil || h.ExpiresAt.After(at)
+}
+
+// LegalHoldAuditEntry records a single change to a legal hold
+// Entries are append-only and are never updated or deleted
+type LegalHoldAuditEntry struct {
+	// ID is the primary key for the audit entry, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// LegalHoldID is a foreign key reference to the hold that changed
+	// Cleared rather than cascaded when the hold's project is purged, so the trail is kept
+	LegalHoldID uuid.UUID `gorm:"type:uuid;index:idx_legal_hold_audit_entries_legal_hold_id"`
+
+	// ProjectID is denormalized from the hold so a project's full trail can be read in one query
+	// Cleared when the project is purged
+	ProjectID uuid.UUID `gorm:"type:uuid;index:idx_legal_hold_audit_entries_project_id"`
+
+	// Action stores what happened to the hold (created, updated or released)
+	Action string `gorm:"type:varchar(20);not null"`
+
+	// ActorID is a foreign key reference to the user who made the change
+	// Cleared when the user is purged
+	ActorID uuid.UUID `gorm:"type:uuid"`
+
+	// Details stores a human-readable description of the change
+	Details string `gorm:"type:text;not null"`
+
+	// CreatedAt records when the change was made
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
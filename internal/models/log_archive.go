@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogArchive represents a file of expired log events archived to object storage before retention deleted them
+// Each file holds one UTC day of a project's events as gzip-compressed NDJSON
+type LogArchive struct {
+	// ID is the primary key for the archive, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project whose events are archived
+	// Required field with CASCADE delete behavior (if project is deleted, its archive records are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_log_archives_project_range"`
+
+	// Project is the relationship to the Project model
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Day is the UTC day the archived events belong to
+	Day time.Time `gorm:"type:date;not null"`
+
+	// FirstTimestamp and LastTimestamp are the timestamps of the oldest and newest archived events
+	FirstTimestamp time.Time `gorm:"type:timestamptz;not null;index:idx_log_archives_project_range"`
+	LastTimestamp  time.Time `gorm:"type:timestamptz;not null;index:idx_log_archives_project_range"`
+
+	// Levels stores the log levels the archive was taken for
+	Levels []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// EventCount and SizeBytes describe the archived file
+	EventCount int64 `gorm:"type:bigint;not null"`
+	SizeBytes  int64 `gorm:"type:bigint;not null"`
+
+	// StorageKey is where the file is kept in object storage
+	StorageKey string `gorm:"type:text;not null"`
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the events were archived
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// LogRehydration represents a request to load archived log events back into a temporary store so they can be searched
+// The loaded events are kept until ExpiresAt, then removed
+type LogRehydration struct {
+	// ID is the primary key for the rehydration, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project whose archives are loaded
+	// Required field with CASCADE delete behavior (if project is deleted, its rehydrations are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_log_rehydrations_project_created_at"`
+
+	// Project is the relationship to the Project model
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// CreatedByID is a foreign key reference to the user who requested the rehydration
+	// Set to NULL if the user is purged
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// From and To bound the timestamps loaded, From inclusive and To exclusive
+	From time.Time `gorm:"column:from_time;type:timestamptz;not null"`
+	To   time.Time `gorm:"column:to_time;type:timestamptz;not null"`
+
+	// Status stores whether the rehydration is "pending", "running", "ready", "failed" or "expired"
+	Status string `gorm:"type:varchar(20);not null;index:idx_log_rehydrations_status"`
+
+	// Attempts counts how often a worker has started loading the events
+	Attempts int `gorm:"type:integer;not null;default:0"`
+
+	// LeaseExpiresAt is when a running rehydration may be taken over by another worker,
+	// in case the worker running it died
+	LeaseExpiresAt *time.Time `gorm:"type:timestamptz"`
+
+	// EventCount counts the events loaded
+	EventCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Error describes why a failed rehydration failed
+	Error string `gorm:"type:text;not null;default:''"`
+
+	// CompletedAt records when the events finished loading or the rehydration failed
+	CompletedAt *time.Time `gorm:"type:timestamptz"`
+
+	// ExpiresAt is when the loaded events are removed and can no longer be searched
+	ExpiresAt *time.Time `gorm:"type:timestamptz"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the rehydration was requested
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now();index:idx_log_rehydrations_project_created_at"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the rehydration was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
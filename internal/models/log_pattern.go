@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogPattern represents a message template mined from a project's log events
+// Variable parts of the message (IDs, numbers, ...) are replaced by the "<*>" wildcard
+type LogPattern struct {
+	// ID is the primary key for the pattern, generated by the pattern miner
+	// Ingested events reference it as their pattern ID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the pattern was mined from
+	// Required field with CASCADE delete behavior (if project is deleted, patterns are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_log_patterns_project_id"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Template stores the message template, e.g. "user <*> logged in from <*>"
+	// It can become more general over time as new messages join the pattern
+	Template string `gorm:"type:text;not null"`
+
+	// EventCount stores how many events have matched the pattern
+	EventCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// FirstSeenAt and LastSeenAt bound the timestamps of matching events
+	FirstSeenAt time.Time `gorm:"type:timestamptz;not null"`
+	LastSeenAt  time.Time `gorm:"type:timestamptz;not null"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the pattern record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the pattern record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
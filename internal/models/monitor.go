@@ -0,0 +1,135 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Monitor watches a scheduled job or heartbeat through the check-ins it sends
+// A run that does not check in before its deadline is recorded as missed, and a run that
+// reports an error or exceeds its maximum runtime marks the monitor as failed
+type Monitor struct {
+	// ID is the primary key for the monitor record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the monitor belongs to
+	// Required field with CASCADE delete behavior (if project is deleted, monitors are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_monitors_project_slug"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Name stores the monitor's display name
+	Name string `gorm:"type:varchar(255);not null"`
+
+	// Slug identifies the monitor in check-in URLs, unique per project
+	Slug string `gorm:"type:varchar(64);not null;uniqueIndex:idx_monitors_project_slug"`
+
+	// ScheduleType stores how runs are expected ("cron" or "interval")
+	ScheduleType string `gorm:"type:varchar(10);not null"`
+
+	// CronExpression stores the five-field cron schedule of "cron" monitors
+	CronExpression string `gorm:"type:varchar(255);not null;default:''"`
+
+	// IntervalSeconds stores the expected time between check-ins of "interval" monitors
+	IntervalSeconds int `gorm:"type:integer;not null;default:0"`
+
+	// Timezone stores the IANA time zone the cron expression is evaluated in
+	Timezone string `gorm:"type:varchar(64);not null;default:'UTC'"`
+
+	// MarginSeconds stores the grace period after the expected time before a run is missed
+	MarginSeconds int `gorm:"type:integer;not null"`
+
+	// MaxRuntimeSeconds stores how long a started run may take before it times out
+	// Zero means runs never time out
+	MaxRuntimeSeconds int `gorm:"type:integer;not null;default:0"`
+
+	// ChannelIDs stores the notification channels notified when the monitor changes status
+	// Channels of the project's organization only; deleted channels are skipped
+	ChannelIDs []uuid.UUID `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// Labels stores free-form key/value pairs describing the monitor (e.g. team=payments)
+	// Silences select the monitors they mute by these labels, as they do for alert rules
+	Labels map[string]string `gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+
+	// Enabled controls whether missed and failed runs are detected and notified
+	Enabled bool `gorm:"not null;default:true"`
+
+	// Status stores whether the monitor is "pending" (no check-in yet), "ok", "missed" or "failed"
+	Status string `gorm:"type:varchar(10);not null;default:'pending'"`
+
+	// StatusChangedAt records when the monitor last changed status
+	StatusChangedAt *time.Time `gorm:"type:timestamptz"`
+
+	// LastCheckInAt records when the monitor last received a check-in
+	LastCheckInAt *time.Time `gorm:"type:timestamptz"`
+
+	// NextCheckInAt stores when the next run is expected to start
+	// DeadlineAt adds the margin; the scheduler records the run as missed once it passes
+	// Both are nil while the monitor is disabled
+	NextCheckInAt *time.Time `gorm:"type:timestamptz"`
+	DeadlineAt    *time.Time `gorm:"type:timestamptz;index:idx_monitors_deadline_at"`
+
+	// CreatedByID is a foreign key reference to the user who created the monitor
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the monitor record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the monitor record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// MonitorCheckIn records one run of a monitored job
+// Runs are opened by an "in_progress" check-in and closed by an "ok" or "error" check-in,
+// or recorded in one go; the scheduler adds "missed" and "timeout" runs
+type MonitorCheckIn struct {
+	// ID is the primary key for the check-in, automatically generated as a UUID
+	// Returned to the job so it can close the run it started
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// MonitorID is a foreign key reference to the monitor the run belongs to
+	// Required field with CASCADE delete behavior (if the monitor is deleted, its history is deleted)
+	MonitorID uuid.UUID `gorm:"type:uuid;not null;index:idx_monitor_check_ins_monitor_created_at"`
+
+	// Monitor is the relationship to the Monitor model
+	Monitor Monitor `gorm:"foreignKey:MonitorID;constraint:OnDelete:CASCADE"`
+
+	// Status stores the outcome of the run (in_progress, ok, error, missed or timeout)
+	Status string `gorm:"type:varchar(20);not null"`
+
+	// ExpectedAt records the scheduled time the run was for
+	// Nil for runs outside the schedule, such as a manual run
+	ExpectedAt *time.Time `gorm:"type:timestamptz"`
+
+	// StartedAt and FinishedAt record when the run started and finished, when known
+	StartedAt  *time.Time `gorm:"type:timestamptz"`
+	FinishedAt *time.Time `gorm:"type:timestamptz"`
+
+	// DurationMs stores how long the run took in milliseconds
+	DurationMs *int64 `gorm:"type:bigint"`
+
+	// TimeoutAt stores when a run still in progress times out
+	// Nil when the monitor has no maximum runtime or the run has finished
+	TimeoutAt *time.Time `gorm:"type:timestamptz;index:idx_monitor_check_ins_timeout_at"`
+
+	// Message stores an optional note sent by the job, such as an error message
+	Message string `gorm:"type:text;not null;default:''"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the run was recorded
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now();index:idx_monitor_check_ins_monitor_created_at"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the run was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannel represents a destination for alert notifications, shared by an
+// organization's projects. Alert rules pick the channels they notify.
+type NotificationChannel struct {
+	// ID is the primary key for the notification channel record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// OrganizationID is a foreign key reference to the organization that owns the channel
+	// Required field with CASCADE delete behavior (if organization is deleted, channel is deleted)
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_notification_channels_organization_name"`
+
+	// Organization is the relationship to the Organization model
+	// This allows GORM to handle the foreign key relationship
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+
+	// Name stores the channel's display name, unique per organization
+	Name string `gorm:"type:varchar(255);not null;uniqueIndex:idx_notification_channels_organization_name"`
+
+	// Type stores how notifications are delivered ("webhook", "slack" or "email")
+	Type string `gorm:"type:varchar(20);not null"`
+
+	// URL stores the endpoint notifications are posted to, for webhook and slack channels
+	URL string `gorm:"type:text;not null;default:''"`
+
+	// Secret stores the key webhook payloads are signed with (HMAC-SHA256)
+	// Generated when the channel is created and only returned then
+	Secret string `gorm:"type:varchar(64);not null;default:''"`
+
+	// Recipients stores the email addresses of email channels
+	Recipients []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// SubjectTemplate and BodyTemplate customize the message using Go text/template syntax
+	// Empty means the default template; the subject is only used by email channels
+	SubjectTemplate string `gorm:"type:text;not null;default:''"`
+	BodyTemplate    string `gorm:"type:text;not null;default:''"`
+
+	// Enabled controls whether notifications are sent to the channel
+	Enabled bool `gorm:"not null;default:true"`
+
+	// CreatedByID is a foreign key reference to the user who created the channel
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the notification channel record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the notification channel record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// NotificationDelivery records one notification sent, or being sent, to a channel
+// The rendered payload is stored so retries send exactly the same message
+type NotificationDelivery struct {
+	// ID is the primary key for the delivery, automatically generated as a UUID
+	// Webhook receivers get it in the X-Valtro-Delivery header to deduplicate retries
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ChannelID is a foreign key reference to the channel the notification is sent to
+	// Required field with CASCADE delete behavior (if the channel is deleted, its log is deleted)
+	ChannelID uuid.UUID `gorm:"type:uuid;not null;index:idx_notification_deliveries_channel_created_at"`
+
+	// Channel is the relationship to the NotificationChannel model
+	Channel NotificationChannel `gorm:"foreignKey:ChannelID;constraint:OnDelete:CASCADE"`
+
+	// AlertRuleID references the alert rule that triggered the notification
+	// Nil for test notifications, and set to NULL if the rule is deleted
+	AlertRuleID *uuid.UUID `gorm:"type:uuid"`
+
+	// MonitorID references the monitor that triggered the notification
+	// Nil for alert and test notifications, and set to NULL if the monitor is deleted
+	MonitorID *uuid.UUID `gorm:"type:uuid"`
+
+	// Event stores what the notification is about ("alert.firing", "alert.resolved",
+	// "monitor.missed", "monitor.failed", "monitor.recovered" or "test")
+	Event string `gorm:"type:varchar(30);not null"`
+
+	// Subject and Body store the rendered message
+	// Body is the exact request body for webhook and slack channels
+	Subject string `gorm:"type:text;not null;default:''"`
+	Body    string `gorm:"type:text;not null"`
+
+	// Status stores whether the delivery is "pending", "succeeded" or "failed"
+	Status string `gorm:"type:varchar(20);not null"`
+
+	// Attempts counts the delivery attempts made so far
+	Attempts int `gorm:"type:integer;not null;default:0"`
+
+	// NextAttemptAt stores when a pending delivery is next attempted
+	NextAttemptAt *time.Time `gorm:"type:timestamptz;index:idx_notification_deliveries_next_attempt_at"`
+
+	// LastError and ResponseStatus describe the outcome of the latest attempt
+	LastError      string `gorm:"type:text"`
+	ResponseStatus *int   `gorm:"type:integer"`
+
+	// DeliveredAt records when the notification was delivered
+	DeliveredAt *time.Time `gorm:"type:timestamptz"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the notification was queued
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now();index:idx_notification_deliveries_channel_created_at"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the delivery was last attempted
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
@@ -29,6 +29,15 @@ type Organization struct {
 	// One organization can have many projects
 	Projects []Project `gorm:"foreignKey:OrganizationID"`
 
+	// RetentionPolicy is the default log retention applied to every project in the organization
+	// Projects may override it with their own RetentionPolicy
+	// Persisted as JSONB
+	RetentionPolicy RetentionPolicy `gorm:"type:jsonb;serializer:json;not null"`
+
+	// Plan stores the organization's plan ("free", "team" or "enterprise")
+	// The plan caps how far back a single log search may reach; it is changed by billing, not by users
+	Plan string `gorm:"type:varchar(20);not null;default:'free'"`
+
 	// Standard timestamp fields
 
 	// CreatedAt is automatically managed by GORM
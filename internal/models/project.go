@@ -30,6 +30,15 @@ type Project struct {
 	// Has a unique index for fast lookups during SDK requests
 	APIKey string `gorm:"type:varchar(255);not null;uniqueIndex:idx_projects_api_key"`
 
+	// RetentionPolicy overrides the organization's default log retention for this project
+	// Pointer type makes it nullable; NULL means the organization default applies unchanged
+	// Persisted as JSONB
+	RetentionPolicy *RetentionPolicy `gorm:"type:jsonb;serializer:json"`
+
+	// IndexedAttributes lists the event attributes whose values are full-text indexed
+	// Applies to events ingested after the list changes; persisted as JSONB
+	IndexedAttributes []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
 	// Standard timestamp fields
 
 	// CreatedAt is automatically managed by GORM
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Release represents a version of a project's code, as reported by SDKs and deploy tooling
+// Events carrying the release's version are counted against it
+type Release struct {
+	// ID is the primary key for the release record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the release belongs to
+	// Required field with CASCADE delete behavior (if project is deleted, releases are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_releases_project_version"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Version stores the release identifier SDKs attach to events (e.g. "web@1.4.2")
+	// Unique per project
+	Version string `gorm:"type:varchar(255);not null;uniqueIndex:idx_releases_project_version"`
+
+	// CommitSHA stores the commit the release was built from, if known
+	CommitSHA string `gorm:"type:varchar(64)"`
+
+	// EventCount and ErrorCount store how many events, and how many error or fatal events,
+	// reported this release
+	EventCount int64 `gorm:"type:bigint;not null;default:0"`
+	ErrorCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// FirstEventAt and LastEventAt bound the timestamps of events reporting this release
+	// Nil until the first event arrives
+	FirstEventAt *time.Time `gorm:"type:timestamptz"`
+	LastEventAt  *time.Time `gorm:"type:timestamptz"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the release was created; releases are ordered by it
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the release record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// Deploy records a release being deployed to an environment
+type Deploy struct {
+	// ID is the primary key for the deploy record, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ReleaseID is a foreign key reference to the deployed release
+	// Required field with CASCADE delete behavior (if release is deleted, deploys are deleted)
+	ReleaseID uuid.UUID `gorm:"type:uuid;not null;index:idx_deploys_release_id"`
+
+	// Release is the relationship to the Release model
+	// This allows GORM to handle the foreign key relationship
+	Release Release `gorm:"foreignKey:ReleaseID;constraint:OnDelete:CASCADE"`
+
+	// Environment stores where the release was deployed (e.g. "production", "staging")
+	Environment string `gorm:"type:varchar(64);not null"`
+
+	// DeployedAt stores when the deploy finished
+	DeployedAt time.Time `gorm:"type:timestamptz;not null"`
+
+	// CreatedByID is a foreign key reference to the user who recorded the deploy
+	// NULL when the deploy was recorded with the project API key, e.g. by the CLI
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// CreatedAt records when the deploy was recorded
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReleaseArtifact represents a file uploaded for a specific release of a project
+// Currently only JavaScript source maps, used to symbolicate minified stack traces
+type ReleaseArtifact struct {
+	// ID is the primary key for the artifact record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the artifact belongs to
+	// Required field with CASCADE delete behavior (if project is deleted, artifacts are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_release_artifacts_project_release_name"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Release stores the release identifier SDKs report with their events (e.g. "web@1.4.2")
+	Release string `gorm:"type:varchar(255);not null;uniqueIndex:idx_release_artifacts_project_release_name"`
+
+	// Name stores the URL path of the artifact with "~" standing for any scheme and host,
+	// e.g. "~/static/js/app.js.map" for frames from https://example.com/static/js/app.js
+	Name string `gorm:"type:varchar(500);not null;uniqueIndex:idx_release_artifacts_project_release_name"`
+
+	// StorageKey stores the key of the file contents in artifact storage
+	StorageKey string `gorm:"type:varchar(255);not null"`
+
+	// Size stores the file size in bytes
+	Size int64 `gorm:"type:bigint;not null"`
+
+	// SHA256 stores the hex SHA-256 checksum of the file contents
+	SHA256 string `gorm:"column:sha256;type:varchar(64);not null"`
+
+	// CreatedByID is a foreign key reference to the user who uploaded the artifact
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the artifact was uploaded
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
@@ -0,0 +1,54 @@
+package models
+
+// RetentionPolicy describes how many days log events are kept before they expire
+// It is stored as JSONB on organizations (the default) and on projects (an optional override)
+type RetentionPolicy struct {
+	// DefaultDays applies to every log level without an entry in LevelDays
+	// Zero on a project override means "inherit the organization default"
+	DefaultDays int `json:"default_days"`
+
+	// LevelDays holds per-level durations keyed by log level (e.g. "debug": 3, "error": 90)
+	LevelDays map[string]int `json:"level_days,omitempty"`
+}
+
+// IsEmpty reports whether the policy sets no durations at all
+func (p RetentionPolicy) IsEmpty() bool {
+	return p.DefaultDays == 0 && len(p.LevelDays) == 0
+}
+
+// DaysForLevel returns the retention in days for the given log level
+func (p RetentionPolicy) DaysForLevel(level string) int {
+	if days, ok := p.LevelDays[level]; ok {
+		return days
+	}
+	return p.DefaultDays
+}
+
+// WithOverride returns the effective policy after applying a project override on top of p
+// Project values take precedence field by field, so a level's retention is the first of: the
+// project's entry for the level, the project default, the organization's entry for the level,
+// the organization default. A project default therefore replaces every organization level entry.
+func (p RetentionPolicy) WithOverride(override *RetentionPolicy) RetentionPolicy {
+	effective := RetentionPolicy{
+		DefaultDays: p.DefaultDays,
+		LevelDays:   make(map[string]int, len(p.LevelDays)),
+	}
+
+	if override == nil || override.DefaultDays == 0 {
+		for level, days := range p.LevelDays {
+			effective.LevelDays[level] = days
+		}
+	}
+
+	if override == nil {
+		return effective
+	}
+	if override.DefaultDays > 0 {
+		effective.DefaultDays = override.DefaultDays
+	}
+	for level, days := range override.LevelDays {
+		effective.LevelDays[level] = days
+	}
+
+	return effective
+}
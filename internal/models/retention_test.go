@@ -0,0 +1,50 @@
+package models
+
+import "testing"
+
+func TestRetentionPolicyWithOverride(t *testing.T) {
+	organization := RetentionPolicy{DefaultDays: 30, LevelDays: map[string]int{"debug": 3, "error": 90}}
+
+	tests := []struct {
+		name     string
+		override *RetentionPolicy
+		want     map[string]int // days per level
+	}{
+		{
+			name:     "no override inherits the organization",
+			override: nil,
+			want:     map[string]int{"debug": 3, "error": 90, "info": 30},
+		},
+		{
+			name:     "empty override inherits the organization",
+			override: &RetentionPolicy{},
+			want:     map[string]int{"debug": 3, "error": 90, "info": 30},
+		},
+		{
+			name:     "project default beats organization level entries",
+			override: &RetentionPolicy{DefaultDays: 7},
+			want:     map[string]int{"debug": 7, "error": 7, "info": 7},
+		},
+		{
+			name:     "project level entry beats the project default",
+			override: &RetentionPolicy{DefaultDays: 7, LevelDays: map[string]int{"error": 365}},
+			want:     map[string]int{"debug": 7, "error": 365, "info": 7},
+		},
+		{
+			name:     "project level entries alone keep the other organization values",
+			override: &RetentionPolicy{LevelDays: map[string]int{"debug": 1}},
+			want:     map[string]int{"debug": 1, "error": 90, "info": 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effective := organization.WithOverride(tt.override)
+			for level, want := range tt.want {
+				if got := effective.DaysForLevel(level); got != want {
+					t.Errorf("DaysForLevel(%q) = %d, want %d", level, got, want)
+				}
+			}
+		})
+	}
+}
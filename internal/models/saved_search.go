@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedSearch represents a named log query saved at the organization level
+type SavedSearch struct {
+	// ID is the primary key for the saved search record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// OrganizationID is a foreign key reference to the organization that owns this saved search
+	// Required field with CASCADE delete behavior (if organization is deleted, saved search is deleted)
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index:idx_saved_searches_organization_id"`
+
+	// Organization is the relationship to the Organization model
+	// This allows GORM to handle the foreign key relationship
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+
+	// Name stores the saved search's display name
+	// Required field with maximum length of 255 characters
+	Name string `gorm:"type:varchar(255);not null"`
+
+	// Query stores the raw search query text exactly as the user entered it
+	Query string `gorm:"type:text;not null"`
+
+	// TimeRange stores the relative time range of the search (e.g. "15m", "24h", "7d")
+	TimeRange string `gorm:"type:varchar(50);not null"`
+
+	// Columns stores the ordered list of columns displayed in the result table
+	// Persisted as a JSONB array
+	Columns []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// CreatedByID is a foreign key reference to the user who created this saved search
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// CreatedBy is the relationship to the User model
+	CreatedBy User `gorm:"foreignKey:CreatedByID"`
+
+	// ShareToken is the short token used to resolve this saved search from a share link
+	// Pointer type (*string) makes it nullable until the search is shared
+	// Has a unique index for fast lookups when a share link is opened
+	ShareToken *string `gorm:"type:varchar(32);uniqueIndex:idx_saved_searches_share_token"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the saved search record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the saved search record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// DeletedAt enables soft deletion in GORM
+	// When a record is "deleted", this field is set instead of removing the record
+	// Has an index for efficient filtering of deleted records
+	// GORM automatically excludes soft-deleted records from queries
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
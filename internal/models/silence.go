@@ -0,0 +1,191 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Silence mutes alert notifications during planned work
+// A silence covers every alert rule of its organization, or of a single project, whose labels
+// contain all of its matchers. Rules keep being evaluated; only their notifications are held back.
+// Silences are never deleted; expiring one early sets ExpiredAt so the audit trail stays complete
+type Silence struct {
+	// ID is the primary key for the silence record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// OrganizationID is a foreign key reference to the organization the silence belongs to
+	// Required field with CASCADE delete behavior (if organization is deleted, silences are deleted)
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index:idx_silences_organization_id"`
+
+	// Organization is the relationship to the Organization model
+	// This allows GORM to handle the foreign key relationship
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+
+	// ProjectID limits the silence to the alert rules and monitors of one project
+	// Nullable; a silence without a project covers every project of the organization
+	ProjectID *uuid.UUID `gorm:"type:uuid"`
+
+	// Matchers stores the labels a rule or monitor must carry, all with equal values, to be silenced
+	// Empty means every rule and monitor in scope
+	Matchers map[string]string `gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+
+	// Comment stores why the silence was created (e.g. a maintenance ticket reference)
+	Comment string `gorm:"type:text;not null"`
+
+	// StartsAt and EndsAt bound the period the silence applies in
+	// EndsAt is nullable for recurring silences that repeat until expired
+	StartsAt time.Time  `gorm:"type:timestamptz;not null"`
+	EndsAt   *time.Time `gorm:"type:timestamptz"`
+
+	// RecurrenceDays turns the silence into a weekly maintenance window on these weekdays
+	// (0 = Sunday). Empty means the silence applies for its whole period
+	RecurrenceDays []int `gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+
+	// RecurrenceStartTime stores when each window opens as "HH:MM" in RecurrenceTimezone
+	RecurrenceStartTime string `gorm:"type:varchar(5);not null;default:''"`
+
+	// RecurrenceDurationSeconds stores how long each window stays open
+	RecurrenceDurationSeconds int `gorm:"type:integer;not null;default:0"`
+
+	// RecurrenceTimezone stores the IANA time zone windows are scheduled in (e.g. Europe/Berlin)
+	RecurrenceTimezone string `gorm:"type:varchar(64);not null;default:''"`
+
+	// CreatedByID is a foreign key reference to the user who created the silence
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// ExpiredAt records when the silence was expired early
+	// Nullable while the silence runs its course
+	ExpiredAt *time.Time `gorm:"type:timestamptz"`
+
+	// ExpiredByID is a foreign key reference to the user who expired the silence
+	ExpiredByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Standard timestamp fields
+
+	// CreatedAt is automatically managed by GORM
+	// Records when the silence record was created
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// UpdatedAt is automatically managed by GORM
+	// Records when the silence record was last updated
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// IsExpired reports whether the silence no longer applies at or after the given time
+func (s *Silence) IsExpired(at time.Time) bool {
+	if s.ExpiredAt != nil {
+		return true
+	}
+	return s.EndsAt != nil && !at.Before(*s.EndsAt)
+}
+
+// IsRecurring reports whether the silence only applies during weekly windows
+func (s *Silence) IsRecurring() bool {
+	return len(s.RecurrenceDays) > 0
+}
+
+// InEffect reports whether the silence mutes notifications at the given time
+func (s *Silence) InEffect(at time.Time) bool {
+	if at.Before(s.StartsAt) || s.IsExpired(at) {
+		return false
+	}
+	if !s.IsRecurring() {
+		return true
+	}
+
+	location, err := time.LoadLocation(s.RecurrenceTimezone)
+	if err != nil {
+		location = time.UTC
+	}
+	start, err := time.Parse("15:04", s.RecurrenceStartTime)
+	if err != nil {
+		return false
+	}
+	duration := time.Duration(s.RecurrenceDurationSeconds) * time.Second
+
+	// A window can last up to a week, so one opened on any of the last seven days may still be open
+	local := at.In(location)
+	for back := 0; back <= 7; back++ {
+		day := local.AddDate(0, 0, -back)
+		if !s.recursOn(day.Weekday()) {
+			continue
+		}
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		if !at.Before(opens) && at.Before(opens.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// recursOn reports whether a window opens on the given weekday
+func (s *Silence) recursOn(weekday time.Weekday) bool {
+	for _, day := range s.RecurrenceDays {
+		if time.Weekday(day) == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// SilenceAuditEntry records a single change to a silence, or a notification it suppressed
+// Entries are append-only and are never updated or deleted
+type SilenceAuditEntry struct {
+	// ID is the primary key for the audit entry, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// SilenceID is a foreign key reference to the silence the entry is about
+	SilenceID uuid.UUID `gorm:"type:uuid;not null;index:idx_silence_audit_entries_silence_id"`
+
+	// OrganizationID is denormalized from the silence so an organization's full trail can be read in one query
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index:idx_silence_audit_entries_organization_id"`
+
+	// Action stores what happened (created, updated, expired or suppressed)
+	Action string `gorm:"type:varchar(20);not null"`
+
+	// ActorID is a foreign key reference to the user who made the change
+	// Nil for suppressed notifications, which the alert scheduler records
+	ActorID *uuid.UUID `gorm:"type:uuid"`
+
+	// AlertRuleID references the rule whose notification was suppressed
+	// Nullable; set only for suppressed entries and cleared if the rule is deleted
+	AlertRuleID *uuid.UUID `gorm:"type:uuid"`
+
+	// MonitorID references the monitor whose notification was suppressed
+	// Nullable; set only for suppressed entries and cleared if the monitor is deleted
+	MonitorID *uuid.UUID `gorm:"type:uuid"`
+
+	// Details stores a human-readable description of the change
+	Details string `gorm:"type:text;not null"`
+
+	// CreatedAt records when the change was made
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// HeldNotification is a firing alert or failing monitor notification that a silence held back
+// It is sent once no silence mutes it any more, or dropped if the alert or monitor recovers first,
+// so a rule that starts firing during a maintenance window and keeps firing is not missed
+type HeldNotification struct {
+	// ID is the primary key for the held notification, automatically generated as a UUID
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the notification is about
+	ProjectID uuid.UUID `gorm:"type:uuid;not null"`
+
+	// AlertRuleID references the rule whose firing notification is held
+	// Unique so each rule has at most one held notification; removed if the rule is deleted
+	AlertRuleID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_held_notifications_alert_rule_id"`
+
+	// MonitorID references the monitor whose missed or failed notification is held
+	// Unique so each monitor has at most one held notification; removed if the monitor is deleted
+	MonitorID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_held_notifications_monitor_id"`
+
+	// Payload stores the notification exactly as it would have been sent
+	Payload json.RawMessage `gorm:"type:jsonb;serializer:json;not null"`
+
+	// HeldAt records when the notification was first held back
+	HeldAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestSilenceInEffect(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	local := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, berlin)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	oneOff := Silence{StartsAt: utc(1, 1, 0, 0), EndsAt: ptr(utc(1, 2, 0, 0))}
+	expired := oneOff
+	expired.ExpiredAt = ptr(utc(1, 1, 12, 0))
+
+	// Saturday nights from 22:00 to 02:00 Berlin time; 2024-01-06 is a Saturday
+	weekly := Silence{
+		StartsAt:                  utc(1, 1, 0, 0),
+		RecurrenceDays:            []int{int(time.Saturday)},
+		RecurrenceStartTime:       "22:00",
+		RecurrenceDurationSeconds: 4 * 60 * 60,
+		RecurrenceTimezone:        "Europe/Berlin",
+	}
+	notStarted := weekly
+	notStarted.StartsAt = utc(1, 10, 0, 0)
+	ended := weekly
+	ended.EndsAt = ptr(utc(1, 7, 0, 0))
+	unknownZone := weekly
+	unknownZone.RecurrenceTimezone = "Mars/Olympus_Mons"
+	invalidStart := weekly
+	invalidStart.RecurrenceStartTime = "late"
+
+	// A window open all week long, reopening every Monday at midnight
+	allWeek := Silence{
+		StartsAt:                  utc(1, 1, 0, 0),
+		RecurrenceDays:            []int{int(time.Monday)},
+		RecurrenceStartTime:       "00:00",
+		RecurrenceDurationSeconds: 7 * 24 * 60 * 60,
+		RecurrenceTimezone:        "UTC",
+	}
+
+	// Sundays from 01:00 for three hours; clocks go from 02:00 to 03:00 in Berlin on 2024-03-31
+	overDST := Silence{
+		StartsAt:                  utc(1, 1, 0, 0),
+		RecurrenceDays:            []int{int(time.Sunday)},
+		RecurrenceStartTime:       "01:00",
+		RecurrenceDurationSeconds: 3 * 60 * 60,
+		RecurrenceTimezone:        "Europe/Berlin",
+	}
+
+	tests := []struct {
+		name    string
+		silence Silence
+		at      time.Time
+		want    bool
+	}{
+		{name: "before a one-off silence", silence: oneOff, at: time.Date(2023, 12, 31, 23, 59, 0, 0, time.UTC), want: false},
+		{name: "at the start of a one-off silence", silence: oneOff, at: utc(1, 1, 0, 0), want: true},
+		{name: "during a one-off silence", silence: oneOff, at: utc(1, 1, 18, 0), want: true},
+		{name: "at the end of a one-off silence", silence: oneOff, at: utc(1, 2, 0, 0), want: false},
+		{name: "after a silence was expired early", silence: expired, at: utc(1, 1, 18, 0), want: false},
+		{name: "window opening", silence: weekly, at: local(1, 6, 22, 0), want: true},
+		{name: "window open past midnight", silence: weekly, at: local(1, 7, 1, 30), want: true},
+		{name: "window closing", silence: weekly, at: local(1, 7, 2, 0), want: false},
+		{name: "just before the window", silence: weekly, at: local(1, 6, 21, 59), want: false},
+		{name: "another weekday", silence: weekly, at: local(1, 5, 23, 0), want: false},
+		{name: "window in a later week", silence: weekly, at: local(3, 2, 23, 0), want: true},
+		{name: "window before the silence starts", silence: notStarted, at: local(1, 6, 23, 0), want: false},
+		{name: "window after the silence ends", silence: ended, at: local(1, 13, 23, 0), want: false},
+		{name: "unknown time zone falls back to UTC", silence: unknownZone, at: utc(1, 6, 22, 30), want: true},
+		{name: "unknown time zone before the UTC window", silence: unknownZone, at: utc(1, 6, 21, 30), want: false},
+		{name: "invalid start time never applies", silence: invalidStart, at: local(1, 6, 23, 0), want: false},
+		{name: "week-long window at its opening", silence: allWeek, at: utc(1, 8, 0, 0), want: true},
+		{name: "week-long window just before reopening", silence: allWeek, at: utc(1, 14, 23, 59), want: true},
+		{name: "window lasts its duration across the clock going forward", silence: overDST, at: local(3, 31, 4, 30), want: true},
+		{name: "window closes its duration after opening", silence: overDST, at: local(3, 31, 5, 0), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.silence.InEffect(tt.at); got != tt.want {
+				t.Errorf("InEffect(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
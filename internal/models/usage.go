@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectUsageHourly is an hourly rollup of ingest and storage counters for a project and log level
+// Rows are upserted by the ingestion pipeline; the composite primary key keeps one row per bucket
+type ProjectUsageHourly struct {
+	// ProjectID is a foreign key reference to the project the usage belongs to
+	// Part of the composite primary key
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Hour is the start of the hourly bucket, truncated to the hour in UTC
+	// Part of the composite primary key
+	Hour time.Time `gorm:"type:timestamptz;primaryKey"`
+
+	// Level is the log level the counters apply to
+	// Part of the composite primary key
+	Level string `gorm:"type:varchar(10);primaryKey"`
+
+	// EventCount is the number of events ingested in the bucket
+	EventCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// RawBytes is the size of the events as received from SDKs
+	RawBytes int64 `gorm:"type:bigint;not null;default:0"`
+
+	// StoredBytes is the size of the events after encoding and compression in storage
+	StoredBytes int64 `gorm:"type:bigint;not null;default:0"`
+
+	// UpdatedAt records when the bucket was last incremented
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// TableName overrides the table name used by ProjectUsageHourly
+func (ProjectUsageHourly) TableName() string {
+	return "project_usage_hourly"
+}
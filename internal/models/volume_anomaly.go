@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VolumeBaseline is the learned hourly event volume of a project and log level for one
+// hour of the week, so weekly seasonality (e.g. quiet weekends) is part of the expectation
+// Mean and Variance are exponentially weighted, favouring recent weeks
+type VolumeBaseline struct {
+	// ProjectID is a foreign key reference to the project the baseline belongs to
+	// Part of the composite primary key
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Level is the log level the baseline applies to
+	// Part of the composite primary key
+	Level string `gorm:"type:varchar(10);primaryKey"`
+
+	// HourOfWeek is the hour the baseline applies to, 0 for Sunday 00:00 UTC to 167
+	// Part of the composite primary key
+	HourOfWeek int `gorm:"type:smallint;primaryKey"`
+
+	// Mean and Variance describe the expected number of events in the hour
+	Mean     float64 `gorm:"type:double precision;not null"`
+	Variance float64 `gorm:"type:double precision;not null"`
+
+	// Samples counts the hours learned so far
+	Samples int `gorm:"type:integer;not null"`
+
+	// LastHour is the most recent hour learned, so no hour is ever learned twice
+	LastHour time.Time `gorm:"type:timestamptz;not null"`
+
+	// UpdatedAt records when the baseline was last learned from
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// VolumeAnomaly represents a period in which a project's event rate deviated from its baseline
+// An anomaly stays open while the deviation lasts and is resolved once the rate is back to normal
+type VolumeAnomaly struct {
+	// ID is the primary key for the anomaly record, automatically generated as a UUID
+	// Uses PostgreSQL's gen_random_uuid() function for generation
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// ProjectID is a foreign key reference to the project the anomaly was detected in
+	// Required field with CASCADE delete behavior (if project is deleted, anomalies are deleted)
+	ProjectID uuid.UUID `gorm:"type:uuid;not null;index:idx_volume_anomalies_project_started_at"`
+
+	// Project is the relationship to the Project model
+	// This allows GORM to handle the foreign key relationship
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Level is the log level whose rate deviated; empty for silence, which covers every level
+	Level string `gorm:"type:varchar(10);not null;default:''"`
+
+	// Kind stores how the rate deviated ("spike", "drop" or "silence")
+	Kind string `gorm:"type:varchar(10);not null"`
+
+	// Observed and Expected store the event counts at the strongest deviation,
+	// over the part of the hour elapsed at the time
+	Observed int64   `gorm:"type:bigint;not null"`
+	Expected float64 `gorm:"type:double precision;not null"`
+
+	// Score stores the strongest deviation in standard deviations from the baseline
+	Score float64 `gorm:"type:double precision;not null"`
+
+	// StartedAt, LastSeenAt and ResolvedAt record when the deviation was first seen,
+	// last seen, and found to be over
+	// ResolvedAt is nil while the anomaly is open
+	StartedAt  time.Time  `gorm:"type:timestamptz;not null;index:idx_volume_anomalies_project_started_at"`
+	LastSeenAt time.Time  `gorm:"type:timestamptz;not null"`
+	ResolvedAt *time.Time `gorm:"type:timestamptz"`
+}
+
+// AnomalySettings stores how sensitive anomaly detection is for a project
+// Projects without settings use the defaults
+type AnomalySettings struct {
+	// ProjectID is the primary key and a foreign key reference to the project
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Project is the relationship to the Project model
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	// Enabled controls whether anomalies are detected for the project
+	Enabled bool `gorm:"not null;default:true"`
+
+	// Sensitivity is how many standard deviations from the baseline count as anomalous
+	// Lower values detect subtler deviations but raise more false alarms
+	Sensitivity float64 `gorm:"type:double precision;not null"`
+
+	// MinimumEvents ignores deviations smaller than this many events,
+	// so low-volume levels do not raise anomalies over a handful of events
+	MinimumEvents int `gorm:"type:integer;not null"`
+
+	// DetectSilence controls whether a project going quiet raises an anomaly
+	DetectSilence bool `gorm:"not null;default:true"`
+
+	// UpdatedAt records when the settings were last changed
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// TableName overrides the table name used by AnomalySettings
+func (AnomalySettings) TableName() string {
+	return "anomaly_settings"
+}
@@ -0,0 +1,152 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles alert rule data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new alert rule repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new alert rule
+func (r *Repository) Create(ctx context.Context, rule *models.AlertRule) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(rule).Error; err != nil {
+		return errors.NewInternalError("Failed to create alert rule", err.Error())
+	}
+	return nil
+}
+
+// Update saves changes to an alert rule's definition
+// State columns are left to the scheduler so an edit never overwrites a concurrent evaluation
+func (r *Repository) Update(ctx context.Context, rule *models.AlertRule) error {
+	if err := r.db.WithContext(ctx).Omit("Project", "State", "StateChangedAt", "LastEvaluatedAt", "LastValue").Save(rule).Error; err != nil {
+		return errors.NewInternalError("Failed to update alert rule", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves an alert rule by ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&rule).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Alert rule", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve alert rule", err.Error())
+	}
+	return &rule, nil
+}
+
+// GetByProjectID retrieves all alert rules of a project ordered by name
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.AlertRule, error) {
+	var rules []*models.AlertRule
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("name").Find(&rules).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve alert rules", err.Error())
+	}
+	return rules, nil
+}
+
+// NameExistsForProject checks if an alert rule name already exists in a project
+func (r *Repository) NameExistsForProject(ctx context.Context, name string, projectID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.AlertRule{}).
+		Where("name = ? AND project_id = ?", name, projectID).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check alert rule name", err.Error())
+	}
+	return count > 0, nil
+}
+
+// Delete deletes an alert rule together with its evaluation history
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.AlertRule{}, "id = ?", id).Error; err != nil {
+		return errors.NewInternalError("Failed to delete alert rule", err.Error())
+	}
+	return nil
+}
+
+// ClaimDue returns enabled rules whose evaluation is due and pushes their next evaluation
+// one interval ahead, so concurrent schedulers never evaluate the same rule twice
+// Rules of soft-deleted projects are skipped
+func (r *Repository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*models.AlertRule, error) {
+	var rules []*models.AlertRule
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("enabled AND next_evaluation_at <= ?", now).
+			Where("project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)").
+			Order("next_evaluation_at").
+			Limit(limit).
+			Find(&rules).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(rules))
+		for i, rule := range rules {
+			ids[i] = rule.ID
+		}
+		return tx.Model(&models.AlertRule{}).Where("id IN ?", ids).
+			Update("next_evaluation_at", gorm.Expr("? + evaluation_interval_seconds * interval '1 second'", now)).Error
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to claim due alert rules", err.Error())
+	}
+	return rules, nil
+}
+
+// RecordEvaluation saves an evaluation and the rule's resulting state in one transaction
+// Only the state columns are written so concurrent edits to the rule's definition are kept
+func (r *Repository) RecordEvaluation(ctx context.Context, rule *models.AlertRule, evaluation *models.AlertEvaluation) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("AlertRule").Create(evaluation).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.AlertRule{}).Where("id = ?", rule.ID).Updates(map[string]interface{}{
+			"state":             rule.State,
+			"state_changed_at":  rule.StateChangedAt,
+			"last_evaluated_at": rule.LastEvaluatedAt,
+			"last_value":        rule.LastValue,
+		}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to record alert evaluation", err.Error())
+	}
+	return nil
+}
+
+// GetEvaluations retrieves a rule's most recent evaluations, newest first
+func (r *Repository) GetEvaluations(ctx context.Context, ruleID uuid.UUID, limit int) ([]*models.AlertEvaluation, error) {
+	var evaluations []*models.AlertEvaluation
+	if err := r.db.WithContext(ctx).
+		Where("alert_rule_id = ?", ruleID).
+		Order("evaluated_at DESC").
+		Limit(limit).
+		Find(&evaluations).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve alert evaluations", err.Error())
+	}
+	return evaluations, nil
+}
+
+// DeleteEvaluationsBefore prunes evaluation history older than the cutoff
+func (r *Repository) DeleteEvaluationsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("evaluated_at < ?", cutoff).Delete(&models.AlertEvaluation{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to prune alert evaluations", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,169 @@
+package anomaly
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles anomaly detection data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new anomaly repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// GetSettings retrieves a project's anomaly detection settings
+func (r *Repository) GetSettings(ctx context.Context, projectID uuid.UUID) (*models.AnomalySettings, error) {
+	var settings models.AnomalySettings
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).First(&settings).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Anomaly settings", projectID.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve anomaly settings", err.Error())
+	}
+	return &settings, nil
+}
+
+// GetAllSettings retrieves the anomaly detection settings of every project that has them
+func (r *Repository) GetAllSettings(ctx context.Context) ([]*models.AnomalySettings, error) {
+	var settings []*models.AnomalySettings
+	if err := r.db.WithContext(ctx).Find(&settings).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve anomaly settings", err.Error())
+	}
+	return settings, nil
+}
+
+// SaveSettings creates or replaces a project's anomaly detection settings
+func (r *Repository) SaveSettings(ctx context.Context, settings *models.AnomalySettings) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "sensitivity", "minimum_events", "detect_silence", "updated_at"}),
+	}).Create(settings).Error; err != nil {
+		return errors.NewInternalError("Failed to save anomaly settings", err.Error())
+	}
+	return nil
+}
+
+// GetBaselinesForHour retrieves every active project's baselines for one hour of the week
+func (r *Repository) GetBaselinesForHour(ctx context.Context, hourOfWeek int) ([]*models.VolumeBaseline, error) {
+	var baselines []*models.VolumeBaseline
+	if err := r.db.WithContext(ctx).
+		Where("hour_of_week = ?", hourOfWeek).
+		Where("project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)").
+		Find(&baselines).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve volume baselines", err.Error())
+	}
+	return baselines, nil
+}
+
+// GetBaselinesByProject retrieves a project's baselines ordered by level and hour of the week
+// An empty level returns every level
+func (r *Repository) GetBaselinesByProject(ctx context.Context, projectID uuid.UUID, level string) ([]*models.VolumeBaseline, error) {
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	if level != "" {
+		query = query.Where("level = ?", level)
+	}
+
+	var baselines []*models.VolumeBaseline
+	if err := query.Order("level, hour_of_week").Find(&baselines).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve volume baselines", err.Error())
+	}
+	return baselines, nil
+}
+
+// SaveBaselines writes learned baselines in one statement
+// A row is only replaced if it was learned from an earlier hour, so concurrent
+// detectors never learn the same hour twice
+func (r *Repository) SaveBaselines(ctx context.Context, baselines []*models.VolumeBaseline) error {
+	if len(baselines) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}, {Name: "level"}, {Name: "hour_of_week"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mean", "variance", "samples", "last_hour", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "volume_baselines.last_hour < excluded.last_hour"},
+		}},
+	}).Create(&baselines).Error; err != nil {
+		return errors.NewInternalError("Failed to save volume baselines", err.Error())
+	}
+	return nil
+}
+
+// GetOpenAnomalies retrieves every open anomaly
+func (r *Repository) GetOpenAnomalies(ctx context.Context) ([]*models.VolumeAnomaly, error) {
+	var anomalies []*models.VolumeAnomaly
+	if err := r.db.WithContext(ctx).Where("resolved_at IS NULL").Find(&anomalies).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve open anomalies", err.Error())
+	}
+	return anomalies, nil
+}
+
+// OpenAnomaly records a new anomaly unless the project and level already have an open one
+// It reports whether the anomaly was recorded
+func (r *Repository) OpenAnomaly(ctx context.Context, anomaly *models.VolumeAnomaly) (bool, error) {
+	result := r.db.WithContext(ctx).Omit("Project").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project_id"}, {Name: "level"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "resolved_at IS NULL"},
+		}},
+		DoNothing: true,
+	}).Create(anomaly)
+	if result.Error != nil {
+		return false, errors.NewInternalError("Failed to record anomaly", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateAnomaly saves the latest observation of an open anomaly
+func (r *Repository) UpdateAnomaly(ctx context.Context, anomaly *models.VolumeAnomaly) error {
+	if err := r.db.WithContext(ctx).Model(&models.VolumeAnomaly{}).
+		Where("id = ? AND resolved_at IS NULL", anomaly.ID).
+		Updates(map[string]interface{}{
+			"observed":     anomaly.Observed,
+			"expected":     anomaly.Expected,
+			"score":        anomaly.Score,
+			"last_seen_at": anomaly.LastSeenAt,
+		}).Error; err != nil {
+		return errors.NewInternalError("Failed to update anomaly", err.Error())
+	}
+	return nil
+}
+
+// ResolveAnomaly marks an open anomaly as resolved
+func (r *Repository) ResolveAnomaly(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&models.VolumeAnomaly{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Update("resolved_at", resolvedAt).Error; err != nil {
+		return errors.NewInternalError("Failed to resolve anomaly", err.Error())
+	}
+	return nil
+}
+
+// GetAnomaliesByProject retrieves a project's most recent anomalies, newest first
+// Status filters to "open" or "resolved" anomalies; empty returns both
+func (r *Repository) GetAnomaliesByProject(ctx context.Context, projectID uuid.UUID, status string, limit int) ([]*models.VolumeAnomaly, error) {
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	switch status {
+	case constants.AnomalyStatusOpen:
+		query = query.Where("resolved_at IS NULL")
+	case constants.AnomalyStatusResolved:
+		query = query.Where("resolved_at IS NOT NULL")
+	}
+
+	var anomalies []*models.VolumeAnomaly
+	if err := query.Order("started_at DESC").Limit(limit).Find(&anomalies).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve anomalies", err.Error())
+	}
+	return anomalies, nil
+}
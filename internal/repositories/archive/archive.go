@@ -0,0 +1,157 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles log archive and rehydration data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new archive repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateArchive records a file of archived log events
+func (r *Repository) CreateArchive(ctx context.Context, archive *models.LogArchive) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(archive).Error; err != nil {
+		return errors.NewInternalError("Failed to create log archive", err.Error())
+	}
+	return nil
+}
+
+// GetArchivesInRange retrieves a project's archives holding events in [from, to), oldest first
+func (r *Repository) GetArchivesInRange(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]*models.LogArchive, error) {
+	var archives []*models.LogArchive
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND first_timestamp < ? AND last_timestamp >= ?", projectID, to, from).
+		Order("first_timestamp").
+		Find(&archives).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve log archives", err.Error())
+	}
+	return archives, nil
+}
+
+// CreateRehydration queues a new rehydration
+func (r *Repository) CreateRehydration(ctx context.Context, rehydration *models.LogRehydration) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(rehydration).Error; err != nil {
+		return errors.NewInternalError("Failed to create rehydration", err.Error())
+	}
+	return nil
+}
+
+// UpdateRehydration saves a rehydration
+func (r *Repository) UpdateRehydration(ctx context.Context, rehydration *models.LogRehydration) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Save(rehydration).Error; err != nil {
+		return errors.NewInternalError("Failed to update rehydration", err.Error())
+	}
+	return nil
+}
+
+// GetRehydrationByID retrieves a rehydration of a project
+func (r *Repository) GetRehydrationByID(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.LogRehydration, error) {
+	var rehydration models.LogRehydration
+	if err := r.db.WithContext(ctx).Where("id = ? AND project_id = ?", id, projectID).First(&rehydration).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Rehydration", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve rehydration", err.Error())
+	}
+	return &rehydration, nil
+}
+
+// GetRehydrationsByProjectID retrieves a project's most recent rehydrations, newest first
+func (r *Repository) GetRehydrationsByProjectID(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.LogRehydration, error) {
+	var rehydrations []*models.LogRehydration
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&rehydrations).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve rehydrations", err.Error())
+	}
+	return rehydrations, nil
+}
+
+// ClaimNextRehydration marks the oldest runnable rehydration as running and leases it to the caller
+// A rehydration is runnable while pending, or while running with an expired lease because the
+// worker running it died. Returns nil when there is nothing to run
+func (r *Repository) ClaimNextRehydration(ctx context.Context, now time.Time, lease time.Duration) (*models.LogRehydration, error) {
+	var claimed *models.LogRehydration
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rehydration models.LogRehydration
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND lease_expires_at <= ?)",
+				constants.RehydrationStatusPending, constants.RehydrationStatusRunning, now).
+			Order("created_at").
+			First(&rehydration).Error
+		if gorm.ErrRecordNotFound == err {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		leaseExpiresAt := now.Add(lease)
+		rehydration.Status = constants.RehydrationStatusRunning
+		rehydration.Attempts++
+		rehydration.LeaseExpiresAt = &leaseExpiresAt
+		rehydration.UpdatedAt = now
+		if err := tx.Omit("Project").Save(&rehydration).Error; err != nil {
+			return err
+		}
+		claimed = &rehydration
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to claim rehydration", err.Error())
+	}
+	return claimed, nil
+}
+
+// GetExpiredRehydrations retrieves up to limit ready rehydrations past their expiry
+func (r *Repository) GetExpiredRehydrations(ctx context.Context, now time.Time, limit int) ([]*models.LogRehydration, error) {
+	var rehydrations []*models.LogRehydration
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", constants.RehydrationStatusReady, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&rehydrations).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve expired rehydrations", err.Error())
+	}
+	return rehydrations, nil
+}
+
+// GetLiveRehydrationIDs retrieves which of the given rehydrations still exist and have not
+// failed or expired, so their loaded events must be kept
+func (r *Repository) GetLiveRehydrationIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	live := make(map[uuid.UUID]bool)
+	if len(ids) == 0 {
+		return live, nil
+	}
+
+	var found []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.LogRehydration{}).
+		Where("id IN ? AND status IN ?", ids, []string{
+			constants.RehydrationStatusPending,
+			constants.RehydrationStatusRunning,
+			constants.RehydrationStatusReady,
+		}).
+		Pluck("id", &found).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve rehydrations", err.Error())
+	}
+	for _, id := range found {
+		live[id] = true
+	}
+	return live, nil
+}
@@ -0,0 +1,82 @@
+package artifact
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Repository handles release artifact data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new release artifact repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new release artifact
+func (r *Repository) Create(ctx context.Context, artifact *models.ReleaseArtifact) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(artifact).Error; err != nil {
+		return errors.NewInternalError("Failed to create release artifact", err.Error())
+	}
+	return nil
+}
+
+// Update saves a replaced release artifact
+func (r *Repository) Update(ctx context.Context, artifact *models.ReleaseArtifact) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Save(artifact).Error; err != nil {
+		return errors.NewInternalError("Failed to update release artifact", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a release artifact by ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.ReleaseArtifact, error) {
+	var artifact models.ReleaseArtifact
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&artifact).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Release artifact", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve release artifact", err.Error())
+	}
+	return &artifact, nil
+}
+
+// GetByName retrieves the artifact with the given name in a release
+func (r *Repository) GetByName(ctx context.Context, projectID uuid.UUID, release string, name string) (*models.ReleaseArtifact, error) {
+	var artifact models.ReleaseArtifact
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND release = ? AND name = ?", projectID, release, name).
+		First(&artifact).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Release artifact", name)
+		}
+		return nil, errors.NewInternalError("Failed to retrieve release artifact", err.Error())
+	}
+	return &artifact, nil
+}
+
+// GetByRelease retrieves every artifact of a release ordered by name
+func (r *Repository) GetByRelease(ctx context.Context, projectID uuid.UUID, release string) ([]*models.ReleaseArtifact, error) {
+	var artifacts []*models.ReleaseArtifact
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND release = ?", projectID, release).
+		Order("name").
+		Find(&artifacts).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve release artifacts", err.Error())
+	}
+	return artifacts, nil
+}
+
+// Delete deletes a release artifact record
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.ReleaseArtifact{}, "id = ?", id).Error; err != nil {
+		return errors.NewInternalError("Failed to delete release artifact", err.Error())
+	}
+	return nil
+}
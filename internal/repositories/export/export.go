@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles export job data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new export repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create queues a new export job
+func (r *Repository) Create(ctx context.Context, job *models.ExportJob) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(job).Error; err != nil {
+		return errors.NewInternalError("Failed to create export job", err.Error())
+	}
+	return nil
+}
+
+// Update saves an export job
+func (r *Repository) Update(ctx context.Context, job *models.ExportJob) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Save(job).Error; err != nil {
+		return errors.NewInternalError("Failed to update export job", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves an export job of a project
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.ExportJob, error) {
+	var job models.ExportJob
+	if err := r.db.WithContext(ctx).Where("id = ? AND project_id = ?", id, projectID).First(&job).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Export job", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve export job", err.Error())
+	}
+	return &job, nil
+}
+
+// GetByProjectID retrieves a project's most recent export jobs, newest first
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.ExportJob, error) {
+	var jobs []*models.ExportJob
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve export jobs", err.Error())
+	}
+	return jobs, nil
+}
+
+// ClaimNext marks the oldest runnable job as running and leases it to the caller
+// A job is runnable while pending, or while running with an expired lease because the worker
+// running it died. Returns nil when there is nothing to run
+func (r *Repository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*models.ExportJob, error) {
+	var claimed *models.ExportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job models.ExportJob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND lease_expires_at <= ?)",
+				constants.ExportStatusPending, constants.ExportStatusRunning, now).
+			Order("created_at").
+			First(&job).Error
+		if gorm.ErrRecordNotFound == err {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		leaseExpiresAt := now.Add(lease)
+		job.Status = constants.ExportStatusRunning
+		job.Attempts++
+		job.LeaseExpiresAt = &leaseExpiresAt
+		job.UpdatedAt = now
+		if err := tx.Omit("Project").Save(&job).Error; err != nil {
+			return err
+		}
+		claimed = &job
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to claim export job", err.Error())
+	}
+	return claimed, nil
+}
+
+// GetExpired retrieves up to limit finished jobs whose file has passed its expiry
+func (r *Repository) GetExpired(ctx context.Context, now time.Time, limit int) ([]*models.ExportJob, error) {
+	var jobs []*models.ExportJob
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", constants.ExportStatusSucceeded, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve expired export jobs", err.Error())
+	}
+	return jobs, nil
+}
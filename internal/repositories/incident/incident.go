@@ -0,0 +1,280 @@
+package incident
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkedIssue is a summary of an issue linked to an incident, with who linked it and when
+type LinkedIssue struct {
+	ID         uuid.UUID
+	Title      string
+	Level      string
+	Status     string
+	EventCount int64
+	LastSeenAt time.Time
+	LinkedByID *uuid.UUID
+	LinkedAt   time.Time
+}
+
+// Repository handles incident data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new incident repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates an incident with its linked issues and first timeline entries in a single transaction
+func (r *Repository) Create(ctx context.Context, incident *models.Incident, issues []*models.IncidentIssue, entries []*models.IncidentTimelineEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Project").Create(incident).Error; err != nil {
+			return err
+		}
+		return createChildren(tx, issues, entries)
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to create incident", err.Error())
+	}
+	return nil
+}
+
+// OpenForAlertRule creates an incident for an alert rule unless the rule already has an unresolved one
+// Returns the rule's unresolved incident and whether it was created by this call; the issues
+// and timeline entries are only saved with a newly created incident
+func (r *Repository) OpenForAlertRule(ctx context.Context, incident *models.Incident, issues []*models.IncidentIssue, entries []*models.IncidentTimelineEntry) (*models.Incident, bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Project").Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "alert_rule_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "resolved_at IS NULL"},
+			}},
+			DoNothing: true,
+		}).Create(incident)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			created = true
+			return createChildren(tx, issues, entries)
+		}
+
+		var existing models.Incident
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("alert_rule_id = ? AND resolved_at IS NULL", incident.AlertRuleID).
+			First(&existing).Error; err != nil {
+			return err
+		}
+		incident = &existing
+		return nil
+	})
+	if err != nil {
+		return nil, false, errors.NewInternalError("Failed to open incident", err.Error())
+	}
+	return incident, created, nil
+}
+
+// GetOpenByAlertRuleID retrieves an alert rule's unresolved incident
+func (r *Repository) GetOpenByAlertRuleID(ctx context.Context, alertRuleID uuid.UUID) (*models.Incident, error) {
+	var incident models.Incident
+	if err := r.db.WithContext(ctx).Where("alert_rule_id = ? AND resolved_at IS NULL", alertRuleID).First(&incident).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Incident", "Unresolved incident of alert rule "+alertRuleID.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve incident", err.Error())
+	}
+	return &incident, nil
+}
+
+// Update saves an incident and appends its timeline entries in a single transaction
+func (r *Repository) Update(ctx context.Context, incident *models.Incident, entries ...*models.IncidentTimelineEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Project").Save(incident).Error; err != nil {
+			return err
+		}
+		return createChildren(tx, nil, entries)
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to update incident", err.Error())
+	}
+	return nil
+}
+
+// RecordEvaluation adds an alert evaluation and its timeline entry to an incident and saves the
+// end of the pinned log query in a single transaction
+// Only the log query end is written so a member's concurrent changes to the incident are kept
+func (r *Repository) RecordEvaluation(ctx context.Context, incident *models.Incident, evaluation *models.IncidentAlertEvaluation, entry *models.IncidentTimelineEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Incident{}).Where("id = ?", incident.ID).Updates(map[string]interface{}{
+			"log_query_to": incident.LogQueryTo,
+			"updated_at":   incident.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(evaluation).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to record incident evaluation", err.Error())
+	}
+	return nil
+}
+
+// LinkIssue links an issue to an incident and appends its timeline entry in a single transaction
+// Returns false without adding the entry if the issue was already linked
+func (r *Repository) LinkIssue(ctx context.Context, link *models.IncidentIssue, entry *models.IncidentTimelineEntry) (bool, error) {
+	linked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(link)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		linked = true
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return false, errors.NewInternalError("Failed to link issue to incident", err.Error())
+	}
+	return linked, nil
+}
+
+// UnlinkIssue removes an issue from an incident and appends its timeline entry in a single transaction
+// Returns false without adding the entry if the issue was not linked
+func (r *Repository) UnlinkIssue(ctx context.Context, incidentID uuid.UUID, issueID uuid.UUID, entry *models.IncidentTimelineEntry) (bool, error) {
+	unlinked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("incident_id = ? AND issue_id = ?", incidentID, issueID).Delete(&models.IncidentIssue{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		unlinked = true
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return false, errors.NewInternalError("Failed to unlink issue from incident", err.Error())
+	}
+	return unlinked, nil
+}
+
+// AddTimelineEntry appends an entry to an incident's timeline
+func (r *Repository) AddTimelineEntry(ctx context.Context, entry *models.IncidentTimelineEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return errors.NewInternalError("Failed to add incident timeline entry", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves an incident by its ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Incident, error) {
+	var incident models.Incident
+	if err := r.db.WithContext(ctx).First(&incident, "id = ?", id).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Incident", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve incident", err.Error())
+	}
+	return &incident, nil
+}
+
+// List retrieves a project's most recent incidents, optionally only those with a given status
+func (r *Repository) List(ctx context.Context, projectID uuid.UUID, status string, limit int) ([]*models.Incident, error) {
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var incidents []*models.Incident
+	if err := query.Order("started_at DESC").Limit(limit).Find(&incidents).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve incidents", err.Error())
+	}
+	return incidents, nil
+}
+
+// GetEvaluations retrieves an incident's alert evaluations, oldest first
+func (r *Repository) GetEvaluations(ctx context.Context, incidentID uuid.UUID) ([]*models.IncidentAlertEvaluation, error) {
+	var evaluations []*models.IncidentAlertEvaluation
+	if err := r.db.WithContext(ctx).Where("incident_id = ?", incidentID).
+		Order("evaluated_at ASC").
+		Find(&evaluations).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve incident evaluations", err.Error())
+	}
+	return evaluations, nil
+}
+
+// GetIssues retrieves the issues linked to an incident, in the order they were linked
+func (r *Repository) GetIssues(ctx context.Context, incidentID uuid.UUID) ([]*LinkedIssue, error) {
+	var issues []*LinkedIssue
+	if err := r.db.WithContext(ctx).Table("issues").
+		Select(`issues.id, issues.title, issues.level, issues.status, issues.event_count, issues.last_seen_at,
+			ii.linked_by_id, ii.created_at AS linked_at`).
+		Joins("JOIN incident_issues ii ON ii.issue_id = issues.id").
+		Where("ii.incident_id = ?", incidentID).
+		Order("ii.created_at ASC, issues.id ASC").
+		Scan(&issues).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve incident issues", err.Error())
+	}
+	return issues, nil
+}
+
+// GetTimeline retrieves an incident's timeline, oldest first
+func (r *Repository) GetTimeline(ctx context.Context, incidentID uuid.UUID) ([]*models.IncidentTimelineEntry, error) {
+	var entries []*models.IncidentTimelineEntry
+	if err := r.db.WithContext(ctx).Where("incident_id = ?", incidentID).
+		Order("created_at ASC, id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve incident timeline", err.Error())
+	}
+	return entries, nil
+}
+
+// GetRecentIssues retrieves a project's unresolved issues seen since the given time, most frequent first
+func (r *Repository) GetRecentIssues(ctx context.Context, projectID uuid.UUID, since time.Time, limit int) ([]*models.Issue, error) {
+	var issues []*models.Issue
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND status = ? AND last_seen_at >= ?", projectID, constants.IssueStatusUnresolved, since).
+		Order("event_count DESC").
+		Limit(limit).
+		Find(&issues).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve recent issues", err.Error())
+	}
+	return issues, nil
+}
+
+// GetIssueInProject retrieves an issue, reporting issues of other projects as missing
+func (r *Repository) GetIssueInProject(ctx context.Context, issueID uuid.UUID, projectID uuid.UUID) (*models.Issue, error) {
+	var issue models.Issue
+	if err := r.db.WithContext(ctx).Where("id = ? AND project_id = ?", issueID, projectID).First(&issue).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Issue", issueID.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve issue", err.Error())
+	}
+	return &issue, nil
+}
+
+// createChildren creates linked issues and timeline entries within a transaction
+func createChildren(tx *gorm.DB, issues []*models.IncidentIssue, entries []*models.IncidentTimelineEntry) error {
+	if len(issues) > 0 {
+		if err := tx.Create(&issues).Error; err != nil {
+			return err
+		}
+	}
+	if len(entries) > 0 {
+		if err := tx.Create(&entries).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
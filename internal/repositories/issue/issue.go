@@ -0,0 +1,109 @@
+package issue
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// triageColumns are the issue columns changed by status and assignment updates
+// Updates are limited to them so they never overwrite counters written by concurrent events
+var triageColumns = []string{"status", "assignee_id", "resolved_at", "resolved_release", "ignore_until_count", "ignore_until", "updated_at"}
+
+// Repository handles issue data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new issue repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// RecordOccurrence groups one event into the issue matching seed's project and fingerprint
+// The issue is created from seed if it does not exist yet, then locked while record applies
+// the event to it, so concurrent events for the same fingerprint never lose updates
+// Activity entries returned by record are saved in the same transaction
+func (r *Repository) RecordOccurrence(ctx context.Context, seed *models.Issue, record func(issue *models.Issue) []*models.IssueActivity) (*models.Issue, error) {
+	var issue models.Issue
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Project").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "fingerprint"}},
+			DoNothing: true,
+		}).Create(seed).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("project_id = ? AND fingerprint = ?", seed.ProjectID, seed.Fingerprint).
+			First(&issue).Error; err != nil {
+			return err
+		}
+
+		activities := record(&issue)
+		if err := tx.Omit("Project").Save(&issue).Error; err != nil {
+			return err
+		}
+		if len(activities) > 0 {
+			return tx.Create(activities).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to record issue occurrence", err.Error())
+	}
+	return &issue, nil
+}
+
+// UpdateTriage saves an issue's status and assignment and appends its activity entry in a single transaction
+func (r *Repository) UpdateTriage(ctx context.Context, issue *models.Issue, activity *models.IssueActivity) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(issue).Select(triageColumns).Updates(issue).Error; err != nil {
+			return err
+		}
+		return tx.Create(activity).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to update issue", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves an issue by its ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Issue, error) {
+	var issue models.Issue
+	if err := r.db.WithContext(ctx).First(&issue, "id = ?", id).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Issue", "Issue with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve issue", err.Error())
+	}
+	return &issue, nil
+}
+
+// List retrieves a project's issues in the given order, optionally limited to one status
+func (r *Repository) List(ctx context.Context, projectID uuid.UUID, status string, orderBy string, limit int) ([]*models.Issue, error) {
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var issues []*models.Issue
+	if err := query.Order(orderBy).Order("id").Limit(limit).Find(&issues).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve issues", err.Error())
+	}
+	return issues, nil
+}
+
+// GetActivityByIssueID retrieves an issue's activity log, oldest first
+func (r *Repository) GetActivityByIssueID(ctx context.Context, issueID uuid.UUID) ([]*models.IssueActivity, error) {
+	var activities []*models.IssueActivity
+	if err := r.db.WithContext(ctx).Where("issue_id = ?", issueID).Order("created_at ASC").Find(&activities).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve issue activity", err.Error())
+	}
+	return activities, nil
+}
@@ -0,0 +1,111 @@
+package legalhold
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Repository handles legal hold data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new legal hold repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new legal hold and its audit entry in a single transaction
+func (r *Repository) Create(ctx context.Context, hold *models.LegalHold, entry *models.LegalHoldAuditEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(hold).Error; err != nil {
+			return err
+		}
+		entry.LegalHoldID = hold.ID
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to create legal hold", err.Error())
+	}
+	return nil
+}
+
+// Update saves a legal hold and appends its audit entry in a single transaction
+func (r *Repository) Update(ctx context.Context, hold *models.LegalHold, entry *models.LegalHoldAuditEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Project").Save(hold).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to update legal hold", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a legal hold by its ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.LegalHold, error) {
+	var hold models.LegalHold
+	if err := r.db.WithContext(ctx).First(&hold, "id = ?", id).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Legal hold", "Legal hold with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve legal hold", err.Error())
+	}
+	return &hold, nil
+}
+
+// GetByProjectID retrieves all legal holds for a specific project, newest first
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.LegalHold, error) {
+	var holds []*models.LegalHold
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		Find(&holds).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve legal holds by project ID", err.Error())
+	}
+	return holds, nil
+}
+
+// GetAuditTrailByProjectID retrieves every legal hold change for a project, oldest first
+func (r *Repository) GetAuditTrailByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.LegalHoldAuditEntry, error) {
+	var entries []*models.LegalHoldAuditEntry
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).
+		Order("created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve legal hold audit trail", err.Error())
+	}
+	return entries, nil
+}
+
+// GetActiveByProjectID retrieves the holds of a project in force at the given time
+func (r *Repository) GetActiveByProjectID(ctx context.Context, projectID uuid.UUID, at time.Time) ([]*models.LegalHold, error) {
+	var holds []*models.LegalHold
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND released_at IS NULL", projectID).
+		Where("expires_at IS NULL OR expires_at > ?", at).
+		Find(&holds).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve active legal holds", err.Error())
+	}
+	return holds, nil
+}
+
+// HasActiveHoldInRange checks if any hold in force at the given time overlaps [from, to] for a project
+// Holds without a range cover every timestamp
+func (r *Repository) HasActiveHoldInRange(ctx context.Context, projectID uuid.UUID, from, to, at time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.LegalHold{}).
+		Where("project_id = ? AND released_at IS NULL", projectID).
+		Where("expires_at IS NULL OR expires_at > ?", at).
+		Where("range_start IS NULL OR range_start <= ?", to).
+		Where("range_end IS NULL OR range_end >= ?", from).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check legal holds", err.Error())
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,273 @@
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles monitor and check-in data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new monitor repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new monitor
+func (r *Repository) Create(ctx context.Context, monitor *models.Monitor) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(monitor).Error; err != nil {
+		return errors.NewInternalError("Failed to create monitor", err.Error())
+	}
+	return nil
+}
+
+// Update saves changes to a monitor's definition and schedule
+// Status columns are left to check-ins and the scheduler so an edit never overwrites them
+func (r *Repository) Update(ctx context.Context, monitor *models.Monitor) error {
+	if err := r.db.WithContext(ctx).Omit("Project", "Status", "StatusChangedAt", "LastCheckInAt").Save(monitor).Error; err != nil {
+		return errors.NewInternalError("Failed to update monitor", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a monitor by ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Monitor, error) {
+	var monitor models.Monitor
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&monitor).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Monitor", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve monitor", err.Error())
+	}
+	return &monitor, nil
+}
+
+// GetBySlug retrieves a project's monitor by its slug
+func (r *Repository) GetBySlug(ctx context.Context, projectID uuid.UUID, slug string) (*models.Monitor, error) {
+	var monitor models.Monitor
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND slug = ?", projectID, slug).First(&monitor).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Monitor", slug)
+		}
+		return nil, errors.NewInternalError("Failed to retrieve monitor", err.Error())
+	}
+	return &monitor, nil
+}
+
+// GetByProjectID retrieves all monitors of a project ordered by name
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Monitor, error) {
+	var monitors []*models.Monitor
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("name").Find(&monitors).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve monitors", err.Error())
+	}
+	return monitors, nil
+}
+
+// SlugExistsForProject checks if a monitor slug already exists in a project
+func (r *Repository) SlugExistsForProject(ctx context.Context, slug string, projectID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Monitor{}).
+		Where("slug = ? AND project_id = ?", slug, projectID).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check monitor slug", err.Error())
+	}
+	return count > 0, nil
+}
+
+// Delete deletes a monitor together with its run history
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.Monitor{}, "id = ?", id).Error; err != nil {
+		return errors.NewInternalError("Failed to delete monitor", err.Error())
+	}
+	return nil
+}
+
+// ClaimMissed returns enabled monitors whose deadline has passed and pushes their deadline
+// out by the lease, so concurrent schedulers never record the same missed run twice
+// Monitors of soft-deleted projects are skipped
+func (r *Repository) ClaimMissed(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Monitor, error) {
+	var monitors []*models.Monitor
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("enabled AND deadline_at <= ?", now).
+			Where("project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)").
+			Order("deadline_at").
+			Limit(limit).
+			Find(&monitors).Error; err != nil {
+			return err
+		}
+		if len(monitors) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(monitors))
+		for i, monitor := range monitors {
+			ids[i] = monitor.ID
+		}
+		return tx.Model(&models.Monitor{}).Where("id IN ?", ids).Update("deadline_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to claim missed monitors", err.Error())
+	}
+	return monitors, nil
+}
+
+// RecordMissed saves a missed run and the monitor's resulting status and schedule in one transaction
+// Nothing is written if a check-in moved the schedule on since the monitor was claimed,
+// which is reported by returning false
+func (r *Repository) RecordMissed(ctx context.Context, monitor *models.Monitor, checkIn *models.MonitorCheckIn, expectedAt time.Time) (bool, error) {
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Monitor{}).
+			Where("id = ? AND next_check_in_at = ?", monitor.ID, expectedAt).
+			Updates(statusColumns(monitor))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		recorded = true
+		return tx.Omit("Monitor").Create(checkIn).Error
+	})
+	if err != nil {
+		return false, errors.NewInternalError("Failed to record missed run", err.Error())
+	}
+	return recorded, nil
+}
+
+// RecordCheckIn saves a new run and the monitor's resulting status and schedule in one transaction
+func (r *Repository) RecordCheckIn(ctx context.Context, monitor *models.Monitor, checkIn *models.MonitorCheckIn) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Monitor").Create(checkIn).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Monitor{}).Where("id = ?", monitor.ID).Updates(statusColumns(monitor)).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to record check-in", err.Error())
+	}
+	return nil
+}
+
+// FinishCheckIn closes a run in progress and saves the monitor's resulting status in one transaction
+// Nothing is written if the run has already finished, which is reported by returning false
+func (r *Repository) FinishCheckIn(ctx context.Context, monitor *models.Monitor, checkIn *models.MonitorCheckIn) (bool, error) {
+	finished := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MonitorCheckIn{}).
+			Where("id = ? AND status = ?", checkIn.ID, constants.CheckInStatusInProgress).
+			Updates(map[string]interface{}{
+				"status":      checkIn.Status,
+				"finished_at": checkIn.FinishedAt,
+				"duration_ms": checkIn.DurationMs,
+				"timeout_at":  nil,
+				"message":     checkIn.Message,
+				"updated_at":  checkIn.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		finished = true
+		return tx.Model(&models.Monitor{}).Where("id = ?", monitor.ID).Updates(map[string]interface{}{
+			"status":            monitor.Status,
+			"status_changed_at": monitor.StatusChangedAt,
+			"last_check_in_at":  monitor.LastCheckInAt,
+		}).Error
+	})
+	if err != nil {
+		return false, errors.NewInternalError("Failed to finish check-in", err.Error())
+	}
+	return finished, nil
+}
+
+// GetCheckIn retrieves a monitor's run by ID
+func (r *Repository) GetCheckIn(ctx context.Context, monitorID uuid.UUID, id uuid.UUID) (*models.MonitorCheckIn, error) {
+	var checkIn models.MonitorCheckIn
+	if err := r.db.WithContext(ctx).Where("id = ? AND monitor_id = ?", id, monitorID).First(&checkIn).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Check-in", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve check-in", err.Error())
+	}
+	return &checkIn, nil
+}
+
+// GetLatestInProgress retrieves a monitor's most recently started run that is still in progress
+// It returns nil when no run is in progress
+func (r *Repository) GetLatestInProgress(ctx context.Context, monitorID uuid.UUID) (*models.MonitorCheckIn, error) {
+	var checkIns []*models.MonitorCheckIn
+	if err := r.db.WithContext(ctx).
+		Where("monitor_id = ? AND status = ?", monitorID, constants.CheckInStatusInProgress).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&checkIns).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve check-in", err.Error())
+	}
+	if len(checkIns) == 0 {
+		return nil, nil
+	}
+	return checkIns[0], nil
+}
+
+// GetTimedOut retrieves runs still in progress past their timeout, with their monitors
+func (r *Repository) GetTimedOut(ctx context.Context, now time.Time, limit int) ([]*models.MonitorCheckIn, error) {
+	var checkIns []*models.MonitorCheckIn
+	if err := r.db.WithContext(ctx).Preload("Monitor").
+		Where("status = ? AND timeout_at <= ?", constants.CheckInStatusInProgress, now).
+		Order("timeout_at").
+		Limit(limit).
+		Find(&checkIns).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve timed out check-ins", err.Error())
+	}
+	return checkIns, nil
+}
+
+// GetCheckIns retrieves a monitor's most recent runs, newest first
+// An empty status returns runs of every status
+func (r *Repository) GetCheckIns(ctx context.Context, monitorID uuid.UUID, status string, limit int) ([]*models.MonitorCheckIn, error) {
+	query := r.db.WithContext(ctx).Where("monitor_id = ?", monitorID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var checkIns []*models.MonitorCheckIn
+	if err := query.Order("created_at DESC").Limit(limit).Find(&checkIns).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve check-ins", err.Error())
+	}
+	return checkIns, nil
+}
+
+// DeleteCheckInsBefore prunes run history older than the cutoff
+func (r *Repository) DeleteCheckInsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&models.MonitorCheckIn{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to prune check-ins", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
+
+// statusColumns returns the status and schedule columns written by check-ins and the scheduler
+func statusColumns(monitor *models.Monitor) map[string]interface{} {
+	return map[string]interface{}{
+		"status":            monitor.Status,
+		"status_changed_at": monitor.StatusChangedAt,
+		"last_check_in_at":  monitor.LastCheckInAt,
+		"next_check_in_at":  monitor.NextCheckInAt,
+		"deadline_at":       monitor.DeadlineAt,
+	}
+}
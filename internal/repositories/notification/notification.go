@@ -0,0 +1,177 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles notification channel and delivery data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new notification channel repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new notification channel
+func (r *Repository) Create(ctx context.Context, channel *models.NotificationChannel) error {
+	if err := r.db.WithContext(ctx).Omit("Organization").Create(channel).Error; err != nil {
+		return errors.NewInternalError("Failed to create notification channel", err.Error())
+	}
+	return nil
+}
+
+// Update saves changes to a notification channel
+func (r *Repository) Update(ctx context.Context, channel *models.NotificationChannel) error {
+	if err := r.db.WithContext(ctx).Omit("Organization").Save(channel).Error; err != nil {
+		return errors.NewInternalError("Failed to update notification channel", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a notification channel by ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.NotificationChannel, error) {
+	var channel models.NotificationChannel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&channel).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Notification channel", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve notification channel", err.Error())
+	}
+	return &channel, nil
+}
+
+// GetByIDs retrieves the notification channels with the given IDs; missing IDs are skipped
+func (r *Repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.NotificationChannel, error) {
+	var channels []*models.NotificationChannel
+	if len(ids) == 0 {
+		return channels, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&channels).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve notification channels", err.Error())
+	}
+	return channels, nil
+}
+
+// GetByOrganizationID retrieves all notification channels of an organization ordered by name
+func (r *Repository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.NotificationChannel, error) {
+	var channels []*models.NotificationChannel
+	if err := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).Order("name").Find(&channels).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve notification channels", err.Error())
+	}
+	return channels, nil
+}
+
+// NameExistsForOrganization checks if a notification channel name already exists in an organization
+func (r *Repository) NameExistsForOrganization(ctx context.Context, name string, organizationID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.NotificationChannel{}).
+		Where("name = ? AND organization_id = ?", name, organizationID).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check notification channel name", err.Error())
+	}
+	return count > 0, nil
+}
+
+// CountForProject counts how many of the given channels belong to the project's organization
+func (r *Repository) CountForProject(ctx context.Context, projectID uuid.UUID, ids []uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.NotificationChannel{}).
+		Where("id IN ?", ids).
+		Where("organization_id = (SELECT organization_id FROM projects WHERE id = ?)", projectID).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to check notification channels", err.Error())
+	}
+	return count, nil
+}
+
+// Delete deletes a notification channel together with its delivery log
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.NotificationChannel{}, "id = ?", id).Error; err != nil {
+		return errors.NewInternalError("Failed to delete notification channel", err.Error())
+	}
+	return nil
+}
+
+// CreateDeliveries queues notifications in one statement
+func (r *Repository) CreateDeliveries(ctx context.Context, deliveries []*models.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Omit("Channel").Create(&deliveries).Error; err != nil {
+		return errors.NewInternalError("Failed to queue notifications", err.Error())
+	}
+	return nil
+}
+
+// UpdateDelivery saves the outcome of a delivery attempt
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *models.NotificationDelivery) error {
+	if err := r.db.WithContext(ctx).Omit("Channel").Save(delivery).Error; err != nil {
+		return errors.NewInternalError("Failed to update notification delivery", err.Error())
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns pending deliveries whose next attempt is due and leases them
+// by pushing their next attempt past the lease, so concurrent dispatchers never send the
+// same delivery twice and a dispatcher that dies mid-send only delays the retry
+func (r *Repository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.NotificationDelivery, error) {
+	var deliveries []*models.NotificationDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", constants.DeliveryStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.NotificationDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to claim due notification deliveries", err.Error())
+	}
+	return deliveries, nil
+}
+
+// GetDeliveries retrieves a channel's most recent deliveries, newest first
+func (r *Repository) GetDeliveries(ctx context.Context, channelID uuid.UUID, limit int) ([]*models.NotificationDelivery, error) {
+	var deliveries []*models.NotificationDelivery
+	if err := r.db.WithContext(ctx).
+		Where("channel_id = ?", channelID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve notification deliveries", err.Error())
+	}
+	return deliveries, nil
+}
+
+// DeleteDeliveriesBefore prunes finished deliveries older than the cutoff
+// Pending deliveries are kept until they succeed or run out of attempts
+func (r *Repository) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ? AND status <> ?", cutoff, constants.DeliveryStatusPending).
+		Delete(&models.NotificationDelivery{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to prune notification deliveries", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
@@ -1,6 +1,9 @@
 package organization
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/nihar-hegde/valtro-backend/internal/errors"
 	"github.com/nihar-hegde/valtro-backend/internal/models"
@@ -18,17 +21,17 @@ func NewRepository(db *gorm.DB) *Repository {
 }
 
 // Create creates a new organization in the database
-func (r *Repository) Create(organization *models.Organization) error {
-	if err := r.db.Create(organization).Error; err != nil {
+func (r *Repository) Create(ctx context.Context, organization *models.Organization) error {
+	if err := r.db.WithContext(ctx).Create(organization).Error; err != nil {
 		return errors.NewInternalError("Failed to create organization", err.Error())
 	}
 	return nil
 }
 
 // GetByID retrieves an organization by its ID
-func (r *Repository) GetByID(id uuid.UUID) (*models.Organization, error) {
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
 	var organization models.Organization
-	if err := r.db.Where("id = ? AND deleted_at IS NULL", id).First(&organization).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&organization).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("Organization", "Organization with ID "+id.String()+" not found")
 		}
@@ -37,29 +40,44 @@ func (r *Repository) GetByID(id uuid.UUID) (*models.Organization, error) {
 	return &organization, nil
 }
 
+// GetByProjectID retrieves the organization a project belongs to
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN projects ON projects.organization_id = organizations.id").
+		Where("projects.id = ? AND organizations.deleted_at IS NULL", projectID).
+		First(&organization).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Organization", "Organization of project "+projectID.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve organization", err.Error())
+	}
+	return &organization, nil
+}
+
 // GetByOwnerID retrieves organizations owned by a specific user
-func (r *Repository) GetByOwnerID(ownerID uuid.UUID) ([]*models.Organization, error) {
+func (r *Repository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Organization, error) {
 	var organizations []*models.Organization
-	if err := r.db.Where("owner_id = ? AND deleted_at IS NULL", ownerID).Find(&organizations).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("owner_id = ? AND deleted_at IS NULL", ownerID).Find(&organizations).Error; err != nil {
 		return nil, errors.NewInternalError("Failed to retrieve organizations by owner ID", err.Error())
 	}
 	return organizations, nil
 }
 
 // GetByOwnerIDPaginated retrieves organizations owned by a specific user with pagination
-func (r *Repository) GetByOwnerIDPaginated(ownerID uuid.UUID, offset, limit int) ([]*models.Organization, int64, error) {
+func (r *Repository) GetByOwnerIDPaginated(ctx context.Context, ownerID uuid.UUID, offset, limit int) ([]*models.Organization, int64, error) {
 	var organizations []*models.Organization
 	var totalCount int64
 	
 	// Get total count
-	if err := r.db.Model(&models.Organization{}).
+	if err := r.db.WithContext(ctx).Model(&models.Organization{}).
 		Where("owner_id = ? AND deleted_at IS NULL", ownerID).
 		Count(&totalCount).Error; err != nil {
 		return nil, 0, errors.NewInternalError("Failed to count organizations", err.Error())
 	}
 	
 	// Get paginated results
-	if err := r.db.Where("owner_id = ? AND deleted_at IS NULL", ownerID).
+	if err := r.db.WithContext(ctx).Where("owner_id = ? AND deleted_at IS NULL", ownerID).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
@@ -71,10 +89,10 @@ func (r *Repository) GetByOwnerIDPaginated(ownerID uuid.UUID, offset, limit int)
 }
 
 // GetByOwnerIDWithProjects retrieves an organization by owner ID with its projects
-func (r *Repository) GetByOwnerIDWithProjects(ownerID uuid.UUID) (*models.Organization, error) {
+func (r *Repository) GetByOwnerIDWithProjects(ctx context.Context, ownerID uuid.UUID) (*models.Organization, error) {
 	var organization models.Organization
 	// Optimize preload to only load non-deleted projects and essential fields
-	if err := r.db.Preload("Projects", "deleted_at IS NULL").
+	if err := r.db.WithContext(ctx).Preload("Projects", "deleted_at IS NULL").
 		Where("owner_id = ? AND deleted_at IS NULL", ownerID).
 		First(&organization).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
@@ -86,10 +104,10 @@ func (r *Repository) GetByOwnerIDWithProjects(ownerID uuid.UUID) (*models.Organi
 }
 
 // GetByIDWithProjects retrieves an organization by ID with its projects
-func (r *Repository) GetByIDWithProjects(id uuid.UUID) (*models.Organization, error) {
+func (r *Repository) GetByIDWithProjects(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
 	var organization models.Organization
 	// Optimize preload to only load non-deleted projects
-	if err := r.db.Preload("Projects", "deleted_at IS NULL").
+	if err := r.db.WithContext(ctx).Preload("Projects", "deleted_at IS NULL").
 		Where("id = ? AND deleted_at IS NULL", id).
 		First(&organization).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
@@ -101,25 +119,90 @@ func (r *Repository) GetByIDWithProjects(id uuid.UUID) (*models.Organization, er
 }
 
 // Update updates an organization in the database
-func (r *Repository) Update(organization *models.Organization) error {
-	if err := r.db.Save(organization).Error; err != nil {
+func (r *Repository) Update(ctx context.Context, organization *models.Organization) error {
+	if err := r.db.WithContext(ctx).Save(organization).Error; err != nil {
 		return errors.NewInternalError("Failed to update organization", err.Error())
 	}
 	return nil
 }
 
-// Delete soft deletes an organization from the database
-func (r *Repository) Delete(id uuid.UUID) error {
-	if err := r.db.Delete(&models.Organization{}, "id = ?", id).Error; err != nil {
+// Delete soft deletes an organization and all of its live projects in one transaction
+// Both share a single deleted_at timestamp so Restore can bring them back together
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Soft delete projects first; their API keys stop resolving as soon as deleted_at is set
+		if err := tx.Model(&models.Project{}).
+			Where("organization_id = ?", id).
+			Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Organization{}).
+			Where("id = ?", id).
+			Update("deleted_at", now).Error
+	})
+	if err != nil {
 		return errors.NewInternalError("Failed to delete organization", err.Error())
 	}
 	return nil
 }
 
+// GetDeletedByID retrieves a soft-deleted organization by its ID
+func (r *Repository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&organization).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Organization", "Deleted organization with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve deleted organization", err.Error())
+	}
+	return &organization, nil
+}
+
+// GetDeletedByOwnerID retrieves organizations owned by a specific user that were soft-deleted at or after since,
+// most recently deleted first
+func (r *Repository) GetDeletedByOwnerID(ctx context.Context, ownerID uuid.UUID, since time.Time) ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	if err := r.db.WithContext(ctx).Unscoped().Where("owner_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", ownerID, since).
+		Order("deleted_at DESC").
+		Find(&organizations).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve deleted organizations", err.Error())
+	}
+	return organizations, nil
+}
+
+// Restore clears the soft delete on an organization and on the projects deleted together with it
+// Projects count as deleted together when their deleted_at matches the organization's exactly
+func (r *Repository) Restore(ctx context.Context, organization *models.Organization) error {
+	deletedAt := organization.DeletedAt.Time
+	now := time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Project{}).
+			Where("organization_id = ? AND deleted_at = ?", organization.ID, deletedAt).
+			Updates(map[string]interface{}{"deleted_at": nil, "updated_at": now}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&models.Organization{}).
+			Where("id = ?", organization.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "name": organization.Name, "updated_at": now}).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to restore organization", err.Error())
+	}
+
+	organization.DeletedAt = gorm.DeletedAt{}
+	organization.UpdatedAt = now
+	return nil
+}
+
 // NameExistsForOwner checks if an organization name already exists for a specific owner
-func (r *Repository) NameExistsForOwner(name string, ownerID uuid.UUID) (bool, error) {
+func (r *Repository) NameExistsForOwner(ctx context.Context, name string, ownerID uuid.UUID) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.Organization{}).
+	if err := r.db.WithContext(ctx).Model(&models.Organization{}).
 		Where("name = ? AND owner_id = ? AND deleted_at IS NULL", name, ownerID).
 		Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check organization name existence", err.Error())
@@ -128,9 +211,9 @@ func (r *Repository) NameExistsForOwner(name string, ownerID uuid.UUID) (bool, e
 }
 
 // HasOrganization checks if a user has any organization
-func (r *Repository) HasOrganization(ownerID uuid.UUID) (bool, error) {
+func (r *Repository) HasOrganization(ctx context.Context, ownerID uuid.UUID) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.Organization{}).
+	if err := r.db.WithContext(ctx).Model(&models.Organization{}).
 		Where("owner_id = ? AND deleted_at IS NULL", ownerID).
 		Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check organization existence", err.Error())
@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles log pattern data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new log pattern repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Record inserts a pattern or, if it already exists, adds its count and widens its seen range
+// The template is always overwritten because the miner only ever generalizes it
+func (r *Repository) Record(ctx context.Context, pattern *models.LogPattern) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"template":      gorm.Expr("excluded.template"),
+			"event_count":   gorm.Expr("log_patterns.event_count + excluded.event_count"),
+			"first_seen_at": gorm.Expr("LEAST(log_patterns.first_seen_at, excluded.first_seen_at)"),
+			"last_seen_at":  gorm.Expr("GREATEST(log_patterns.last_seen_at, excluded.last_seen_at)"),
+			"updated_at":    gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(pattern).Error; err != nil {
+		return errors.NewInternalError("Failed to record log pattern", err.Error())
+	}
+	return nil
+}
+
+// GetByProjectID retrieves every pattern of a project
+func (r *Repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.LogPattern, error) {
+	var patterns []*models.LogPattern
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Find(&patterns).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve log patterns", err.Error())
+	}
+	return patterns, nil
+}
+
+// List retrieves a project's patterns in the given order
+// When since is set only patterns first seen at or after it are returned
+func (r *Repository) List(ctx context.Context, projectID uuid.UUID, orderBy string, since *time.Time, limit int) ([]*models.LogPattern, error) {
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	if since != nil {
+		query = query.Where("first_seen_at >= ?", *since)
+	}
+
+	var patterns []*models.LogPattern
+	if err := query.Order(orderBy).Order("id").Limit(limit).Find(&patterns).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve log patterns", err.Error())
+	}
+	return patterns, nil
+}
@@ -1,10 +1,14 @@
 package project
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/nihar-hegde/valtro-backend/internal/errors"
 	"github.com/nihar-hegde/valtro-backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository handles project data access operations
@@ -18,17 +22,17 @@ func NewRepository(db *gorm.DB) *Repository {
 }
 
 // Create creates a new project in the database
-func (r *Repository) Create(project *models.Project) error {
-	if err := r.db.Create(project).Error; err != nil {
+func (r *Repository) Create(ctx context.Context, project *models.Project) error {
+	if err := r.db.WithContext(ctx).Create(project).Error; err != nil {
 		return errors.NewInternalError("Failed to create project", err.Error())
 	}
 	return nil
 }
 
-// GetByID retrieves a project by its ID
-func (r *Repository) GetByID(id uuid.UUID) (*models.Project, error) {
+// GetByID retrieves a project by its ID, along with its organization for retention defaults
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
 	var project models.Project
-	if err := r.db.First(&project, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Organization").First(&project, "id = ?", id).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("Project", "Project with ID "+id.String()+" not found")
 		}
@@ -38,9 +42,12 @@ func (r *Repository) GetByID(id uuid.UUID) (*models.Project, error) {
 }
 
 // GetByAPIKey retrieves a project by its API key
-func (r *Repository) GetByAPIKey(apiKey string) (*models.Project, error) {
+// Keys of projects whose organization has been deleted never resolve
+func (r *Repository) GetByAPIKey(ctx context.Context, apiKey string) (*models.Project, error) {
 	var project models.Project
-	if err := r.db.First(&project, "api_key = ?", apiKey).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = projects.organization_id AND organizations.deleted_at IS NULL").
+		First(&project, "projects.api_key = ?", apiKey).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("Project", "Project with API key not found")
 		}
@@ -50,43 +57,91 @@ func (r *Repository) GetByAPIKey(apiKey string) (*models.Project, error) {
 }
 
 // GetByOrganizationID retrieves all projects for a specific organization
-func (r *Repository) GetByOrganizationID(organizationID uuid.UUID) ([]*models.Project, error) {
+func (r *Repository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Project, error) {
 	var projects []*models.Project
-	if err := r.db.Where("organization_id = ?", organizationID).Find(&projects).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Organization").Where("organization_id = ?", organizationID).Find(&projects).Error; err != nil {
 		return nil, errors.NewInternalError("Failed to retrieve projects by organization ID", err.Error())
 	}
 	return projects, nil
 }
 
+// GetAllLive retrieves every project whose organization is not deleted, with its organization
+func (r *Repository) GetAllLive(ctx context.Context) ([]*models.Project, error) {
+	var projects []*models.Project
+	if err := r.db.WithContext(ctx).Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = projects.organization_id AND organizations.deleted_at IS NULL").
+		Find(&projects).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve projects", err.Error())
+	}
+	return projects, nil
+}
+
 // Update updates a project in the database
-func (r *Repository) Update(project *models.Project) error {
-	if err := r.db.Save(project).Error; err != nil {
+// Associations are omitted so a preloaded organization is never written back
+func (r *Repository) Update(ctx context.Context, project *models.Project) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(project).Error; err != nil {
 		return errors.NewInternalError("Failed to update project", err.Error())
 	}
 	return nil
 }
 
 // Delete soft deletes a project from the database
-func (r *Repository) Delete(id uuid.UUID) error {
-	if err := r.db.Delete(&models.Project{}, "id = ?", id).Error; err != nil {
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.Project{}, "id = ?", id).Error; err != nil {
 		return errors.NewInternalError("Failed to delete project", err.Error())
 	}
 	return nil
 }
 
+// GetDeletedByID retrieves a soft-deleted project by its ID
+func (r *Repository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	if err := r.db.WithContext(ctx).Unscoped().Preload("Organization").Where("id = ? AND deleted_at IS NOT NULL", id).First(&project).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Project", "Deleted project with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve deleted project", err.Error())
+	}
+	return &project, nil
+}
+
+// GetDeletedByOrganizationID retrieves projects of an organization that were soft-deleted at or after since,
+// most recently deleted first
+func (r *Repository) GetDeletedByOrganizationID(ctx context.Context, organizationID uuid.UUID, since time.Time) ([]*models.Project, error) {
+	var projects []*models.Project
+	if err := r.db.WithContext(ctx).Unscoped().Preload("Organization").
+		Where("organization_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", organizationID, since).
+		Order("deleted_at DESC").
+		Find(&projects).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve deleted projects", err.Error())
+	}
+	return projects, nil
+}
+
+// Restore clears the soft delete on a project, saving its (possibly renamed) name
+func (r *Repository) Restore(ctx context.Context, project *models.Project) error {
+	if err := r.db.WithContext(ctx).Unscoped().Model(&models.Project{}).
+		Where("id = ?", project.ID).
+		Updates(map[string]interface{}{"deleted_at": nil, "name": project.Name, "updated_at": project.UpdatedAt}).Error; err != nil {
+		return errors.NewInternalError("Failed to restore project", err.Error())
+	}
+	project.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
 // APIKeyExists checks if an API key already exists
-func (r *Repository) APIKeyExists(apiKey string) (bool, error) {
+func (r *Repository) APIKeyExists(ctx context.Context, apiKey string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.Project{}).Where("api_key = ?", apiKey).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.Project{}).Where("api_key = ?", apiKey).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check API key existence", err.Error())
 	}
 	return count > 0, nil
 }
 
 // NameExistsForOrganization checks if a project name already exists for a specific organization
-func (r *Repository) NameExistsForOrganization(name string, organizationID uuid.UUID) (bool, error) {
+func (r *Repository) NameExistsForOrganization(ctx context.Context, name string, organizationID uuid.UUID) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.Project{}).Where("name = ? AND organization_id = ?", name, organizationID).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.Project{}).Where("name = ? AND organization_id = ?", name, organizationID).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check project name existence", err.Error())
 	}
 	return count > 0, nil
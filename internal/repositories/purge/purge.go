@@ -0,0 +1,210 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// activeHoldCondition matches an active legal hold on the row aliased as h
+const activeHoldCondition = "h.released_at IS NULL AND (h.expires_at IS NULL OR h.expires_at > ?)"
+
+// Repository handles permanent deletion of soft-deleted records
+// Dependent rows (saved searches, legal holds, usage counters) are removed by ON DELETE CASCADE;
+// audit trails referencing purged records keep their entries with the reference cleared
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new purge repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// projectPurgeable is the condition, on the project aliased as p, for it to be soft-deleted
+// before the cutoff and not under an active legal hold; it takes the cutoff and now as arguments
+const projectPurgeable = `p.deleted_at IS NOT NULL AND p.deleted_at < ?
+	AND NOT EXISTS (SELECT 1 FROM legal_holds h WHERE h.project_id = p.id AND ` + activeHoldCondition + ")"
+
+// organizationPurgeable is the condition, on the organization aliased as o, for it to be soft-deleted
+// before the cutoff with every one of its projects purgeable; it takes the cutoff, cutoff and now as arguments
+const organizationPurgeable = `o.deleted_at IS NOT NULL AND o.deleted_at < ?
+	AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.organization_id = o.id AND NOT (` + projectPurgeable + "))"
+
+// purgeableProjects selects projects soft-deleted before the cutoff that are not under an active legal hold
+func (r *Repository) purgeableProjects(ctx context.Context, cutoff, now time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Table("projects p").Where(projectPurgeable, cutoff, now)
+}
+
+// purgeableOrganizations selects organizations soft-deleted before the cutoff whose projects are all purgeable
+// Live projects and projects still in their grace period or under a legal hold keep their organization
+func (r *Repository) purgeableOrganizations(ctx context.Context, cutoff, now time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Table("organizations o").Where(organizationPurgeable, cutoff, cutoff, now)
+}
+
+// purgeableUsers selects users soft-deleted before the cutoff whose owned organizations are all purgeable
+// A user who still owns a live organization is kept, since deleting them would delete it
+func (r *Repository) purgeableUsers(ctx context.Context, cutoff, now time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.User{}).
+		Where("users.deleted_at IS NOT NULL AND users.deleted_at < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM organizations o WHERE o.owner_id = users.id AND NOT ("+organizationPurgeable+"))",
+			cutoff, cutoff, now)
+}
+
+// CountPurgeableProjects counts projects eligible for permanent deletion
+func (r *Repository) CountPurgeableProjects(ctx context.Context, cutoff, now time.Time) (int64, error) {
+	var count int64
+	if err := r.purgeableProjects(ctx, cutoff, now).Count(&count).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to count purgeable projects", err.Error())
+	}
+	return count, nil
+}
+
+// CountPurgeableOrganizations counts organizations eligible for permanent deletion
+func (r *Repository) CountPurgeableOrganizations(ctx context.Context, cutoff, now time.Time) (int64, error) {
+	var count int64
+	if err := r.purgeableOrganizations(ctx, cutoff, now).Count(&count).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to count purgeable organizations", err.Error())
+	}
+	return count, nil
+}
+
+// CountPurgeableUsers counts users eligible for permanent deletion
+func (r *Repository) CountPurgeableUsers(ctx context.Context, cutoff, now time.Time) (int64, error) {
+	var count int64
+	if err := r.purgeableUsers(ctx, cutoff, now).Count(&count).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to count purgeable users", err.Error())
+	}
+	return count, nil
+}
+
+// CountPurgeableArtifacts counts the stored release artifacts of projects eligible for permanent deletion
+func (r *Repository) CountPurgeableArtifacts(ctx context.Context, cutoff, now time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.ReleaseArtifact{}).
+		Where("project_id IN (?)", r.purgeableProjects(ctx, cutoff, now).Select("p.id")).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to count purgeable artifacts", err.Error())
+	}
+	return count, nil
+}
+
+// GetPurgeableProjectIDs retrieves up to limit projects eligible for permanent deletion, oldest deletions first
+func (r *Repository) GetPurgeableProjectIDs(ctx context.Context, cutoff, now time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.purgeableProjects(ctx, cutoff, now).Order("p.deleted_at ASC").Limit(limit).Pluck("p.id", &ids).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to find purgeable projects", err.Error())
+	}
+	return ids, nil
+}
+
+// GetArtifactStorageKeys retrieves the storage keys of every release artifact of the given projects
+func (r *Repository) GetArtifactStorageKeys(ctx context.Context, projectIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	var artifacts []*models.ReleaseArtifact
+	if err := r.db.WithContext(ctx).Select("project_id", "storage_key").
+		Where("project_id IN ?", projectIDs).Find(&artifacts).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve artifacts of purgeable projects", err.Error())
+	}
+
+	keys := make(map[uuid.UUID][]string)
+	for _, artifact := range artifacts {
+		keys[artifact.ProjectID] = append(keys[artifact.ProjectID], artifact.StorageKey)
+	}
+	return keys, nil
+}
+
+// GetExportStorageKeys retrieves the storage keys of the export files the given projects still keep
+func (r *Repository) GetExportStorageKeys(ctx context.Context, projectIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	var jobs []*models.ExportJob
+	if err := r.db.WithContext(ctx).Select("project_id", "storage_key").
+		Where("project_id IN ? AND storage_key <> ''", projectIDs).Find(&jobs).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve exports of purgeable projects", err.Error())
+	}
+
+	keys := make(map[uuid.UUID][]string)
+	for _, job := range jobs {
+		keys[job.ProjectID] = append(keys[job.ProjectID], job.StorageKey)
+	}
+	return keys, nil
+}
+
+// GetArchiveStorageKeys retrieves the storage keys of the log archives the given projects keep
+func (r *Repository) GetArchiveStorageKeys(ctx context.Context, projectIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	var archives []*models.LogArchive
+	if err := r.db.WithContext(ctx).Select("project_id", "storage_key").
+		Where("project_id IN ?", projectIDs).Find(&archives).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve log archives of purgeable projects", err.Error())
+	}
+
+	keys := make(map[uuid.UUID][]string)
+	for _, archive := range archives {
+		keys[archive.ProjectID] = append(keys[archive.ProjectID], archive.StorageKey)
+	}
+	return keys, nil
+}
+
+// PurgeProjects permanently deletes the given projects if they are still eligible
+// Returns the number of projects removed
+func (r *Repository) PurgeProjects(ctx context.Context, ids []uuid.UUID, cutoff, now time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Eligibility is checked again so a project restored or put on hold since it was selected is kept
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id IN ? AND id IN (?)", ids, r.purgeableProjects(ctx, cutoff, now).Select("p.id")).
+		Delete(&models.Project{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to purge projects", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeOrganizations permanently deletes up to limit eligible organizations that have no projects left
+// Their projects are purged first, one by one, so their stored artifacts are removed too
+// Returns the number of organizations removed
+func (r *Repository) PurgeOrganizations(ctx context.Context, cutoff, now time.Time, limit int) (int64, error) {
+	var ids []uuid.UUID
+	if err := r.purgeableOrganizations(ctx, cutoff, now).
+		Where("NOT EXISTS (SELECT 1 FROM projects p WHERE p.organization_id = o.id)").
+		Order("o.deleted_at ASC").Limit(limit).Pluck("o.id", &ids).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to find purgeable organizations", err.Error())
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id IN ? AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.organization_id = organizations.id)", ids).
+		Delete(&models.Organization{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to purge organizations", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeUsers permanently deletes up to limit eligible users that own no organizations any more
+// Returns the number of users removed
+func (r *Repository) PurgeUsers(ctx context.Context, cutoff, now time.Time, limit int) (int64, error) {
+	var ids []uuid.UUID
+	if err := r.purgeableUsers(ctx, cutoff, now).
+		Where("NOT EXISTS (SELECT 1 FROM organizations o WHERE o.owner_id = users.id)").
+		Order("users.deleted_at ASC").Limit(limit).Pluck("users.id", &ids).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to find purgeable users", err.Error())
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id IN ? AND NOT EXISTS (SELECT 1 FROM organizations o WHERE o.owner_id = users.id)", ids).
+		Delete(&models.User{})
+	if result.Error != nil {
+		return 0, errors.NewInternalError("Failed to purge users", result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
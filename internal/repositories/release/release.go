@@ -0,0 +1,158 @@
+package release
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles release and deploy data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new release repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new release
+func (r *Repository) Create(ctx context.Context, release *models.Release) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Create(release).Error; err != nil {
+		return errors.NewInternalError("Failed to create release", err.Error())
+	}
+	return nil
+}
+
+// RecordEvents adds events to a release's counters, creating the release on its first event
+func (r *Repository) RecordEvents(ctx context.Context, release *models.Release) error {
+	if err := r.db.WithContext(ctx).Omit("Project").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project_id"}, {Name: "version"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"event_count":    gorm.Expr("releases.event_count + excluded.event_count"),
+			"error_count":    gorm.Expr("releases.error_count + excluded.error_count"),
+			"first_event_at": gorm.Expr("LEAST(releases.first_event_at, excluded.first_event_at)"),
+			"last_event_at":  gorm.Expr("GREATEST(releases.last_event_at, excluded.last_event_at)"),
+			"updated_at":     gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(release).Error; err != nil {
+		return errors.NewInternalError("Failed to record release events", err.Error())
+	}
+	return nil
+}
+
+// GetByVersion retrieves a project's release by version
+func (r *Repository) GetByVersion(ctx context.Context, projectID uuid.UUID, version string) (*models.Release, error) {
+	var release models.Release
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND version = ?", projectID, version).First(&release).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Release", version)
+		}
+		return nil, errors.NewInternalError("Failed to retrieve release", err.Error())
+	}
+	return &release, nil
+}
+
+// VersionExists checks if a project already has a release with the version
+func (r *Repository) VersionExists(ctx context.Context, projectID uuid.UUID, version string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Release{}).
+		Where("project_id = ? AND version = ?", projectID, version).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check release version", err.Error())
+	}
+	return count > 0, nil
+}
+
+// List retrieves a project's releases, newest first
+func (r *Repository) List(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.Release, error) {
+	var releases []*models.Release
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").Order("id DESC").
+		Limit(limit).
+		Find(&releases).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve releases", err.Error())
+	}
+	return releases, nil
+}
+
+// GetPrevious retrieves the release created just before the given one, if any
+func (r *Repository) GetPrevious(ctx context.Context, release *models.Release) (*models.Release, error) {
+	var previous models.Release
+	err := r.db.WithContext(ctx).
+		Where("project_id = ?", release.ProjectID).
+		Where("(created_at, id) < (?, ?)", release.CreatedAt, release.ID).
+		Order("created_at DESC").Order("id DESC").
+		First(&previous).Error
+	if gorm.ErrRecordNotFound == err {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve previous release", err.Error())
+	}
+	return &previous, nil
+}
+
+// CountNewIssues counts the issues first seen in each of a project's release versions
+func (r *Repository) CountNewIssues(ctx context.Context, projectID uuid.UUID, versions []string) (map[string]int64, error) {
+	var rows []struct {
+		FirstRelease string
+		Count        int64
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("first_release, COUNT(*) AS count").
+		Where("project_id = ? AND first_release IN ?", projectID, versions).
+		Group("first_release").
+		Scan(&rows).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to count new issues", err.Error())
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.FirstRelease] = row.Count
+	}
+	return counts, nil
+}
+
+// CreateDeploy records a deploy of a release
+func (r *Repository) CreateDeploy(ctx context.Context, deploy *models.Deploy) error {
+	if err := r.db.WithContext(ctx).Omit("Release").Create(deploy).Error; err != nil {
+		return errors.NewInternalError("Failed to create deploy", err.Error())
+	}
+	return nil
+}
+
+// GetDeploysByReleaseID retrieves a release's deploys, most recent first
+func (r *Repository) GetDeploysByReleaseID(ctx context.Context, releaseID uuid.UUID) ([]*models.Deploy, error) {
+	var deploys []*models.Deploy
+	if err := r.db.WithContext(ctx).
+		Where("release_id = ?", releaseID).
+		Order("deployed_at DESC").
+		Find(&deploys).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve deploys", err.Error())
+	}
+	return deploys, nil
+}
+
+// GetLatestDeploys retrieves the most recent deploy of each of the given releases
+func (r *Repository) GetLatestDeploys(ctx context.Context, releaseIDs []uuid.UUID) (map[uuid.UUID]*models.Deploy, error) {
+	var deploys []*models.Deploy
+	if err := r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (release_id) * FROM deploys
+			WHERE release_id IN ?
+			ORDER BY release_id, deployed_at DESC`, releaseIDs).
+		Scan(&deploys).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve latest deploys", err.Error())
+	}
+
+	latest := make(map[uuid.UUID]*models.Deploy, len(deploys))
+	for _, deploy := range deploys {
+		latest[deploy.ReleaseID] = deploy
+	}
+	return latest, nil
+}
@@ -0,0 +1,114 @@
+package savedsearch
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Repository handles saved search data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new saved search repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new saved search in the database
+func (r *Repository) Create(ctx context.Context, search *models.SavedSearch) error {
+	if err := r.db.WithContext(ctx).Create(search).Error; err != nil {
+		return errors.NewInternalError("Failed to create saved search", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a saved search by its ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.WithContext(ctx).First(&search, "id = ?", id).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Saved search", "Saved search with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve saved search", err.Error())
+	}
+	return &search, nil
+}
+
+// GetByShareToken retrieves a saved search by its share token
+func (r *Repository) GetByShareToken(ctx context.Context, token string) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.WithContext(ctx).First(&search, "share_token = ?", token).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Saved search", "Saved search with share token not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve saved search by share token", err.Error())
+	}
+	return &search, nil
+}
+
+// GetByIDForProject retrieves a saved search of the organization that owns a project
+// Saved searches of other organizations are reported as missing
+func (r *Repository) GetByIDForProject(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.WithContext(ctx).
+		Select("saved_searches.*").
+		Joins("JOIN projects ON projects.organization_id = saved_searches.organization_id").
+		Where("saved_searches.id = ? AND projects.id = ?", id, projectID).
+		First(&search).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Saved search", "Saved search with ID "+id.String()+" not found")
+		}
+		return nil, errors.NewInternalError("Failed to retrieve saved search", err.Error())
+	}
+	return &search, nil
+}
+
+// GetByOrganizationID retrieves all saved searches for a specific organization
+func (r *Repository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.SavedSearch, error) {
+	var searches []*models.SavedSearch
+	if err := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).
+		Order("name ASC").
+		Find(&searches).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve saved searches by organization ID", err.Error())
+	}
+	return searches, nil
+}
+
+// Update updates a saved search in the database
+func (r *Repository) Update(ctx context.Context, search *models.SavedSearch) error {
+	if err := r.db.WithContext(ctx).Save(search).Error; err != nil {
+		return errors.NewInternalError("Failed to update saved search", err.Error())
+	}
+	return nil
+}
+
+// Delete soft deletes a saved search from the database
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.SavedSearch{}, "id = ?", id).Error; err != nil {
+		return errors.NewInternalError("Failed to delete saved search", err.Error())
+	}
+	return nil
+}
+
+// NameExistsForOrganization checks if a saved search name already exists for a specific organization
+func (r *Repository) NameExistsForOrganization(ctx context.Context, name string, organizationID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.SavedSearch{}).Where("name = ? AND organization_id = ?", name, organizationID).Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check saved search name existence", err.Error())
+	}
+	return count > 0, nil
+}
+
+// ShareTokenExists checks if a share token already exists
+func (r *Repository) ShareTokenExists(ctx context.Context, token string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.SavedSearch{}).Unscoped().Where("share_token = ?", token).Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check share token existence", err.Error())
+	}
+	return count > 0, nil
+}
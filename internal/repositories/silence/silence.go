@@ -0,0 +1,190 @@
+package silence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository handles silence data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new silence repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new silence and its audit entry in a single transaction
+func (r *Repository) Create(ctx context.Context, silence *models.Silence, entry *models.SilenceAuditEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organization").Create(silence).Error; err != nil {
+			return err
+		}
+		entry.SilenceID = silence.ID
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to create silence", err.Error())
+	}
+	return nil
+}
+
+// Update saves a silence and appends its audit entry in a single transaction
+func (r *Repository) Update(ctx context.Context, silence *models.Silence, entry *models.SilenceAuditEntry) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organization").Save(silence).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return errors.NewInternalError("Failed to update silence", err.Error())
+	}
+	return nil
+}
+
+// GetByID retrieves a silence by its ID
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Silence, error) {
+	var silence models.Silence
+	if err := r.db.WithContext(ctx).First(&silence, "id = ?", id).Error; err != nil {
+		if gorm.ErrRecordNotFound == err {
+			return nil, errors.NewNotFoundError("Silence", id.String())
+		}
+		return nil, errors.NewInternalError("Failed to retrieve silence", err.Error())
+	}
+	return &silence, nil
+}
+
+// GetByOrganizationID retrieves an organization's silences, newest first
+// A non-empty state keeps only the silences that are scheduled, active or expired at the given time
+func (r *Repository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, state string, at time.Time) ([]*models.Silence, error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", organizationID)
+	switch state {
+	case constants.SilenceStateScheduled:
+		query = query.Where("expired_at IS NULL AND starts_at > ?", at)
+	case constants.SilenceStateActive:
+		query = query.Where("expired_at IS NULL AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", at, at)
+	case constants.SilenceStateExpired:
+		query = query.Where("expired_at IS NOT NULL OR ends_at <= ?", at)
+	}
+
+	var silences []*models.Silence
+	if err := query.Order("created_at DESC").Find(&silences).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve silences", err.Error())
+	}
+	return silences, nil
+}
+
+// GetActiveForProject retrieves the silences covering a project that are active at the given time
+// These are the organization-wide silences of the project's organization and the project's own ones
+// Recurring silences are returned whether or not one of their windows is open
+func (r *Repository) GetActiveForProject(ctx context.Context, projectID uuid.UUID, at time.Time) ([]*models.Silence, error) {
+	var silences []*models.Silence
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = (SELECT organization_id FROM projects WHERE id = ?)", projectID).
+		Where("project_id IS NULL OR project_id = ?", projectID).
+		Where("expired_at IS NULL AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", at, at).
+		Order("created_at").
+		Find(&silences).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve active silences", err.Error())
+	}
+	return silences, nil
+}
+
+// ProjectExistsInOrganization checks if a project belongs to an organization and is not deleted
+func (r *Repository) ProjectExistsInOrganization(ctx context.Context, projectID uuid.UUID, organizationID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Project{}).
+		Where("id = ? AND organization_id = ?", projectID, organizationID).
+		Count(&count).Error; err != nil {
+		return false, errors.NewInternalError("Failed to check project", err.Error())
+	}
+	return count > 0, nil
+}
+
+// CreateAuditEntries appends audit entries that are not tied to a change of the silence itself
+func (r *Repository) CreateAuditEntries(ctx context.Context, entries []*models.SilenceAuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&entries).Error; err != nil {
+		return errors.NewInternalError("Failed to record silence audit entries", err.Error())
+	}
+	return nil
+}
+
+// GetAuditTrail retrieves an organization's most recent silence audit entries, newest first
+// A non-nil silence ID limits the trail to that silence
+func (r *Repository) GetAuditTrail(ctx context.Context, organizationID uuid.UUID, silenceID *uuid.UUID, limit int) ([]*models.SilenceAuditEntry, error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", organizationID)
+	if silenceID != nil {
+		query = query.Where("silence_id = ?", *silenceID)
+	}
+
+	var entries []*models.SilenceAuditEntry
+	if err := query.Order("created_at DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve silence audit trail", err.Error())
+	}
+	return entries, nil
+}
+
+// HoldNotification stores a held notification, replacing any one already held for the same rule or monitor
+func (r *Repository) HoldNotification(ctx context.Context, held *models.HeldNotification) error {
+	column := "alert_rule_id"
+	if held.MonitorID != nil {
+		column = "monitor_id"
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: column}},
+		DoUpdates: clause.AssignmentColumns([]string{"payload"}),
+	}).Create(held).Error; err != nil {
+		return errors.NewInternalError("Failed to hold notification", err.Error())
+	}
+	return nil
+}
+
+// DeleteHeldForAlertRule removes the notification held for a rule, reporting whether there was one
+func (r *Repository) DeleteHeldForAlertRule(ctx context.Context, alertRuleID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.HeldNotification{}, "alert_rule_id = ?", alertRuleID)
+	if result.Error != nil {
+		return false, errors.NewInternalError("Failed to delete held notification", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteHeldForMonitor removes the notification held for a monitor, reporting whether there was one
+func (r *Repository) DeleteHeldForMonitor(ctx context.Context, monitorID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.HeldNotification{}, "monitor_id = ?", monitorID)
+	if result.Error != nil {
+		return false, errors.NewInternalError("Failed to delete held notification", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetHeld retrieves up to limit held notifications, longest held first
+func (r *Repository) GetHeld(ctx context.Context, limit int) ([]*models.HeldNotification, error) {
+	var held []*models.HeldNotification
+	if err := r.db.WithContext(ctx).Order("held_at ASC").Limit(limit).Find(&held).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve held notifications", err.Error())
+	}
+	return held, nil
+}
+
+// TakeHeld deletes a held notification, reporting whether this call removed it
+// Only the caller that removes the row sends it, so concurrent instances never send it twice
+func (r *Repository) TakeHeld(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.HeldNotification{}, "id = ?", id)
+	if result.Error != nil {
+		return false, errors.NewInternalError("Failed to release held notification", result.Error.Error())
+	}
+	return result.RowsAffected == 1, nil
+}
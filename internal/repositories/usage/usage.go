@@ -0,0 +1,107 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BucketTotal is an aggregated usage row for one period, project and log level
+type BucketTotal struct {
+	Period      time.Time
+	ProjectID   uuid.UUID
+	ProjectName string
+	Level       string
+	EventCount  int64
+	RawBytes    int64
+	StoredBytes int64
+}
+
+// Repository handles usage data access operations
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new usage repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Increment adds the row's counters to its hourly bucket, creating the bucket if needed
+func (r *Repository) Increment(ctx context.Context, row *models.ProjectUsageHourly) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project_id"}, {Name: "hour"}, {Name: "level"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"event_count":  gorm.Expr("project_usage_hourly.event_count + excluded.event_count"),
+			"raw_bytes":    gorm.Expr("project_usage_hourly.raw_bytes + excluded.raw_bytes"),
+			"stored_bytes": gorm.Expr("project_usage_hourly.stored_bytes + excluded.stored_bytes"),
+			"updated_at":   gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(row).Error; err != nil {
+		return errors.NewInternalError("Failed to record usage", err.Error())
+	}
+	return nil
+}
+
+// GetOrganizationTotals aggregates hourly usage for every project of an organization into periods
+// The period is a PostgreSQL date_trunc unit ("day" or "month") evaluated in UTC
+// Soft-deleted projects are included because their usage still counts towards the organization
+func (r *Repository) GetOrganizationTotals(ctx context.Context, organizationID uuid.UUID, period string, from, to time.Time) ([]*BucketTotal, error) {
+	var totals []*BucketTotal
+	if err := r.db.WithContext(ctx).Table("project_usage_hourly AS u").
+		Select(`date_trunc(?, u.hour AT TIME ZONE 'UTC') AS period,
+			u.project_id, p.name AS project_name, u.level,
+			SUM(u.event_count) AS event_count,
+			SUM(u.raw_bytes) AS raw_bytes,
+			SUM(u.stored_bytes) AS stored_bytes`, period).
+		Joins("JOIN projects p ON p.id = u.project_id").
+		Where("p.organization_id = ? AND u.hour >= ? AND u.hour < ?", organizationID, from, to).
+		Group("1, u.project_id, p.name, u.level").
+		Order("period ASC, p.name ASC, u.project_id ASC, u.level ASC").
+		Scan(&totals).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve organization usage", err.Error())
+	}
+	return totals, nil
+}
+
+// CountEvents sums the events of the given levels in hourly buckets starting within [from, to)
+// An empty level list counts every level
+func (r *Repository) CountEvents(ctx context.Context, projectID uuid.UUID, levels []string, from, to time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ProjectUsageHourly{}).
+		Where("project_id = ? AND hour >= ? AND hour < ?", projectID, from, to)
+	if len(levels) > 0 {
+		query = query.Where("level IN ?", levels)
+	}
+
+	var total int64
+	if err := query.Select("COALESCE(SUM(event_count), 0)").Scan(&total).Error; err != nil {
+		return 0, errors.NewInternalError("Failed to count events", err.Error())
+	}
+	return total, nil
+}
+
+// HourCount is the number of events of one project and log level in an hourly bucket
+type HourCount struct {
+	ProjectID  uuid.UUID
+	Level      string
+	EventCount int64
+}
+
+// GetHourCounts returns the event counts of every active project and level in one hourly bucket
+// Projects and levels without events in the hour have no row
+func (r *Repository) GetHourCounts(ctx context.Context, hour time.Time) ([]*HourCount, error) {
+	var counts []*HourCount
+	if err := r.db.WithContext(ctx).Table("project_usage_hourly AS u").
+		Select("u.project_id, u.level, u.event_count").
+		Joins("JOIN projects p ON p.id = u.project_id AND p.deleted_at IS NULL").
+		Where("u.hour = ?", hour).
+		Scan(&counts).Error; err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve hourly event counts", err.Error())
+	}
+	return counts, nil
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/nihar-hegde/valtro-backend/internal/errors"
 	"github.com/nihar-hegde/valtro-backend/internal/models"
@@ -18,17 +20,17 @@ func NewRepository(db *gorm.DB) *Repository {
 }
 
 // Create creates a new user in the database
-func (r *Repository) Create(user *models.User) error {
-	if err := r.db.Create(user).Error; err != nil {
+func (r *Repository) Create(ctx context.Context, user *models.User) error {
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
 		return errors.NewInternalError("Failed to create user", err.Error())
 	}
 	return nil
 }
 
 // GetByID retrieves a user by their ID
-func (r *Repository) GetByID(id uuid.UUID) (*models.User, error) {
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("User", "User with ID "+id.String()+" not found")
 		}
@@ -38,9 +40,9 @@ func (r *Repository) GetByID(id uuid.UUID) (*models.User, error) {
 }
 
 // GetByEmail retrieves a user by their email
-func (r *Repository) GetByEmail(email string) (*models.User, error) {
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "email = ?", email).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("User", "User with email "+email+" not found")
 		}
@@ -50,9 +52,9 @@ func (r *Repository) GetByEmail(email string) (*models.User, error) {
 }
 
 // GetByClerkUserID retrieves a user by their Clerk user ID
-func (r *Repository) GetByClerkUserID(clerkUserID string) (*models.User, error) {
+func (r *Repository) GetByClerkUserID(ctx context.Context, clerkUserID string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "clerk_user_id = ?", clerkUserID).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "clerk_user_id = ?", clerkUserID).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("User", "User with Clerk ID "+clerkUserID+" not found")
 		}
@@ -62,9 +64,9 @@ func (r *Repository) GetByClerkUserID(clerkUserID string) (*models.User, error)
 }
 
 // GetByUsername retrieves a user by their username
-func (r *Repository) GetByUsername(username string) (*models.User, error) {
+func (r *Repository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "username = ?", username).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "username = ?", username).Error; err != nil {
 		if gorm.ErrRecordNotFound == err {
 			return nil, errors.NewNotFoundError("User", "User with username "+username+" not found")
 		}
@@ -74,9 +76,9 @@ func (r *Repository) GetByUsername(username string) (*models.User, error) {
 }
 
 // GetAll retrieves all active users with pagination
-func (r *Repository) GetAll(limit, offset int) ([]*models.User, error) {
+func (r *Repository) GetAll(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	var users []*models.User
-	if err := r.db.Where("active = ?", true).
+	if err := r.db.WithContext(ctx).Where("active = ?", true).
 		Limit(limit).
 		Offset(offset).
 		Find(&users).Error; err != nil {
@@ -86,43 +88,43 @@ func (r *Repository) GetAll(limit, offset int) ([]*models.User, error) {
 }
 
 // Update updates a user in the database
-func (r *Repository) Update(user *models.User) error {
-	if err := r.db.Save(user).Error; err != nil {
+func (r *Repository) Update(ctx context.Context, user *models.User) error {
+	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
 		return errors.NewInternalError("Failed to update user", err.Error())
 	}
 	return nil
 }
 
 // Delete soft deletes a user from the database
-func (r *Repository) Delete(id uuid.UUID) error {
-	if err := r.db.Delete(&models.User{}, "id = ?", id).Error; err != nil {
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.User{}, "id = ?", id).Error; err != nil {
 		return errors.NewInternalError("Failed to delete user", err.Error())
 	}
 	return nil
 }
 
 // EmailExists checks if an email already exists
-func (r *Repository) EmailExists(email string) (bool, error) {
+func (r *Repository) EmailExists(ctx context.Context, email string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check email existence", err.Error())
 	}
 	return count > 0, nil
 }
 
 // UsernameExists checks if a username already exists
-func (r *Repository) UsernameExists(username string) (bool, error) {
+func (r *Repository) UsernameExists(ctx context.Context, username string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check username existence", err.Error())
 	}
 	return count > 0, nil
 }
 
 // ClerkUserIDExists checks if a Clerk user ID already exists
-func (r *Repository) ClerkUserIDExists(clerkUserID string) (bool, error) {
+func (r *Repository) ClerkUserIDExists(ctx context.Context, clerkUserID string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.User{}).Where("clerk_user_id = ?", clerkUserID).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("clerk_user_id = ?", clerkUserID).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError("Failed to check Clerk user ID existence", err.Error())
 	}
 	return count > 0, nil
@@ -57,6 +57,48 @@ func (s *Server) RegisterRoutes() {
 		
 		// Onboarding routes
 		routes.RegisterOnboardingRoutes(r, s.db, s.onboardingHandler)
+
+		// Saved search routes
+		routes.RegisterSavedSearchRoutes(r, s.db, s.savedSearchHandler)
+
+		// Legal hold routes
+		routes.RegisterLegalHoldRoutes(r, s.db, s.legalHoldHandler)
+
+		// Usage routes
+		routes.RegisterUsageRoutes(r, s.db, s.usageHandler)
+
+		// Log pattern routes
+		routes.RegisterPatternRoutes(r, s.db, s.patternHandler)
+
+		// Issue routes
+		routes.RegisterIssueRoutes(r, s.db, s.issueHandler)
+
+		// Release routes
+		routes.RegisterReleaseRoutes(r, s.db, s.releaseHandler)
+
+		// Release artifact routes
+		routes.RegisterArtifactRoutes(r, s.db, s.artifactHandler)
+
+		// Alert routes
+		routes.RegisterAlertRoutes(r, s.db, s.alertHandler)
+
+		// Notification channel routes
+		routes.RegisterNotificationChannelRoutes(r, s.db, s.notificationHandler)
+
+		// Anomaly detection routes
+		routes.RegisterAnomalyRoutes(r, s.db, s.anomalyHandler)
+
+		// Silence routes
+		routes.RegisterSilenceRoutes(r, s.db, s.silenceHandler)
+
+		// Incident routes
+		routes.RegisterIncidentRoutes(r, s.db, s.incidentHandler)
+
+		// Monitor and check-in routes
+		routes.RegisterMonitorRoutes(r, s.db, s.monitorHandler)
+
+		// Log ingest and search routes
+		routes.RegisterLogRoutes(r, s.db, s.ingestHandler, s.searchHandler)
 	})
 
 	// Webhook routes (outside of API versioning as they're called by external services)
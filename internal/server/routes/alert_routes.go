@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterAlertRoutes registers all alert rule-related routes
+func RegisterAlertRoutes(r chi.Router, db *gorm.DB, alertHandler *alert.Handler) {
+	r.Route("/projects/{id}/alerts", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all alert routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", alertHandler.Create)                             // POST /api/v1/projects/{id}/alerts
+		r.Get("/", alertHandler.GetAll)                              // GET /api/v1/projects/{id}/alerts
+		r.Get("/{alertId}", alertHandler.GetByID)                    // GET /api/v1/projects/{id}/alerts/{alertId}
+		r.Put("/{alertId}", alertHandler.Update)                     // PUT /api/v1/projects/{id}/alerts/{alertId}
+		r.Delete("/{alertId}", alertHandler.Delete)                  // DELETE /api/v1/projects/{id}/alerts/{alertId}
+		r.Get("/{alertId}/evaluations", alertHandler.GetEvaluations) // GET /api/v1/projects/{id}/alerts/{alertId}/evaluations
+	})
+}
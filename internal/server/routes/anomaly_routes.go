@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/anomaly"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterAnomalyRoutes registers all anomaly detection-related routes
+func RegisterAnomalyRoutes(r chi.Router, db *gorm.DB, anomalyHandler *anomaly.Handler) {
+	r.Route("/projects/{id}/anomalies", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all anomaly routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", anomalyHandler.GetAll)                 // GET /api/v1/projects/{id}/anomalies
+		r.Get("/settings", anomalyHandler.GetSettings)    // GET /api/v1/projects/{id}/anomalies/settings
+		r.Put("/settings", anomalyHandler.UpdateSettings) // PUT /api/v1/projects/{id}/anomalies/settings
+		r.Get("/baselines", anomalyHandler.GetBaselines)  // GET /api/v1/projects/{id}/anomalies/baselines
+	})
+}
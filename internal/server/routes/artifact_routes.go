@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/artifact"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterArtifactRoutes registers all release artifact-related routes
+func RegisterArtifactRoutes(r chi.Router, db *gorm.DB, artifactHandler *artifact.Handler) {
+	r.Route("/projects/{id}/releases/{release}/artifacts", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all artifact routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", artifactHandler.Upload)               // POST /api/v1/projects/{id}/releases/{release}/artifacts
+		r.Get("/", artifactHandler.GetAll)                // GET /api/v1/projects/{id}/releases/{release}/artifacts
+		r.Delete("/{artifactId}", artifactHandler.Delete) // DELETE /api/v1/projects/{id}/releases/{release}/artifacts/{artifactId}
+	})
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/incident"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterIncidentRoutes registers all incident-related routes
+func RegisterIncidentRoutes(r chi.Router, db *gorm.DB, incidentHandler *incident.Handler) {
+	r.Route("/projects/{id}/incidents", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all incident routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", incidentHandler.Create)                                     // POST /api/v1/projects/{id}/incidents
+		r.Get("/", incidentHandler.GetAll)                                      // GET /api/v1/projects/{id}/incidents
+		r.Get("/{incidentId}", incidentHandler.GetByID)                         // GET /api/v1/projects/{id}/incidents/{incidentId}
+		r.Put("/{incidentId}", incidentHandler.Update)                          // PUT /api/v1/projects/{id}/incidents/{incidentId}
+		r.Post("/{incidentId}/acknowledge", incidentHandler.Acknowledge)        // POST /api/v1/projects/{id}/incidents/{incidentId}/acknowledge
+		r.Post("/{incidentId}/resolve", incidentHandler.Resolve)                // POST /api/v1/projects/{id}/incidents/{incidentId}/resolve
+		r.Post("/{incidentId}/notes", incidentHandler.AddNote)                  // POST /api/v1/projects/{id}/incidents/{incidentId}/notes
+		r.Post("/{incidentId}/issues", incidentHandler.LinkIssue)               // POST /api/v1/projects/{id}/incidents/{incidentId}/issues
+		r.Delete("/{incidentId}/issues/{issueId}", incidentHandler.UnlinkIssue) // DELETE /api/v1/projects/{id}/incidents/{incidentId}/issues/{issueId}
+		r.Get("/{incidentId}/export", incidentHandler.Export)                   // GET /api/v1/projects/{id}/incidents/{incidentId}/export
+	})
+}
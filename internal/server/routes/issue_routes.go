@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/issue"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterIssueRoutes registers all issue-related routes
+func RegisterIssueRoutes(r chi.Router, db *gorm.DB, issueHandler *issue.Handler) {
+	r.Route("/projects/{id}/issues", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all issue routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", issueHandler.GetAll)                        // GET /api/v1/projects/{id}/issues
+		r.Get("/{issueId}", issueHandler.GetByID)              // GET /api/v1/projects/{id}/issues/{issueId}
+		r.Put("/{issueId}/status", issueHandler.UpdateStatus)  // PUT /api/v1/projects/{id}/issues/{issueId}/status
+		r.Put("/{issueId}/assignee", issueHandler.Assign)      // PUT /api/v1/projects/{id}/issues/{issueId}/assignee
+		r.Get("/{issueId}/activity", issueHandler.GetActivity) // GET /api/v1/projects/{id}/issues/{issueId}/activity
+	})
+}
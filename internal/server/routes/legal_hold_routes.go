@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/legalhold"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterLegalHoldRoutes registers all legal hold-related routes
+func RegisterLegalHoldRoutes(r chi.Router, db *gorm.DB, legalHoldHandler *legalhold.Handler) {
+	r.Route("/projects/{id}/legal-holds", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all legal hold routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", legalHoldHandler.Create)            // POST /api/v1/projects/{id}/legal-holds
+		r.Get("/", legalHoldHandler.GetAll)             // GET /api/v1/projects/{id}/legal-holds
+		r.Get("/audit", legalHoldHandler.GetAuditTrail) // GET /api/v1/projects/{id}/legal-holds/audit
+		r.Put("/{holdId}", legalHoldHandler.Update)     // PUT /api/v1/projects/{id}/legal-holds/{holdId}
+		r.Delete("/{holdId}", legalHoldHandler.Release) // DELETE /api/v1/projects/{id}/legal-holds/{holdId}
+	})
+}
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/ingest"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/search"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterLogRoutes registers the log ingest and search routes
+func RegisterLogRoutes(r chi.Router, db *gorm.DB, ingestHandler *ingest.Handler, searchHandler *search.Handler) {
+	r.Route("/ingest", func(r chi.Router) {
+		// Logs are sent by SDKs, authenticated with the project API key
+		r.Use(middleware.ProjectAPIKeyMiddleware(db))
+
+		r.Post("/logs", ingestHandler.IngestLogs) // POST /api/v1/ingest/logs
+	})
+
+	r.Route("/projects/{id}/logs", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all log search routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", searchHandler.SearchLogs)       // GET /api/v1/projects/{id}/logs
+		r.Get("/export", searchHandler.ExportLogs) // GET /api/v1/projects/{id}/logs/export
+
+		r.Get("/{eventId}/context", searchHandler.GetEventContext) // GET /api/v1/projects/{id}/logs/{eventId}/context
+
+		// Background exports
+		r.Post("/exports", searchHandler.CreateExportJob)                   // POST /api/v1/projects/{id}/logs/exports
+		r.Get("/exports", searchHandler.GetExportJobs)                      // GET /api/v1/projects/{id}/logs/exports
+		r.Get("/exports/{exportId}", searchHandler.GetExportJob)            // GET /api/v1/projects/{id}/logs/exports/{exportId}
+		r.Get("/exports/{exportId}/download", searchHandler.DownloadExport) // GET /api/v1/projects/{id}/logs/exports/{exportId}/download
+
+		// Archived logs loaded back for searching
+		r.Post("/rehydrations", searchHandler.CreateRehydration)                       // POST /api/v1/projects/{id}/logs/rehydrations
+		r.Get("/rehydrations", searchHandler.GetRehydrations)                          // GET /api/v1/projects/{id}/logs/rehydrations
+		r.Get("/rehydrations/{rehydrationId}", searchHandler.GetRehydration)           // GET /api/v1/projects/{id}/logs/rehydrations/{rehydrationId}
+		r.Get("/rehydrations/{rehydrationId}/search", searchHandler.SearchRehydration) // GET /api/v1/projects/{id}/logs/rehydrations/{rehydrationId}/search
+	})
+
+	r.Route("/organizations/{id}/logs", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all log search routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", searchHandler.SearchOrganizationLogs) // GET /api/v1/organizations/{id}/logs
+	})
+}
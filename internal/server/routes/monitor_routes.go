@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/monitor"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterMonitorRoutes registers all monitor and check-in routes
+func RegisterMonitorRoutes(r chi.Router, db *gorm.DB, monitorHandler *monitor.Handler) {
+	r.Route("/projects/{id}/monitors", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all monitor routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", monitorHandler.Create)                          // POST /api/v1/projects/{id}/monitors
+		r.Get("/", monitorHandler.GetAll)                           // GET /api/v1/projects/{id}/monitors
+		r.Get("/{monitorId}", monitorHandler.GetByID)               // GET /api/v1/projects/{id}/monitors/{monitorId}
+		r.Put("/{monitorId}", monitorHandler.Update)                // PUT /api/v1/projects/{id}/monitors/{monitorId}
+		r.Delete("/{monitorId}", monitorHandler.Delete)             // DELETE /api/v1/projects/{id}/monitors/{monitorId}
+		r.Get("/{monitorId}/check-ins", monitorHandler.GetCheckIns) // GET /api/v1/projects/{id}/monitors/{monitorId}/check-ins
+	})
+
+	r.Route("/monitors/{slug}/check-ins", func(r chi.Router) {
+		// Check-ins are sent by jobs, authenticated with the project API key
+		r.Use(middleware.ProjectAPIKeyMiddleware(db))
+
+		r.Post("/", monitorHandler.CheckIn) // POST /api/v1/monitors/{slug}/check-ins
+	})
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/notification"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterNotificationChannelRoutes registers all notification channel-related routes
+func RegisterNotificationChannelRoutes(r chi.Router, db *gorm.DB, notificationHandler *notification.Handler) {
+	r.Route("/organizations/{id}/notification-channels", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all notification channel routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", notificationHandler.Create)                             // POST /api/v1/organizations/{id}/notification-channels
+		r.Get("/", notificationHandler.GetAll)                              // GET /api/v1/organizations/{id}/notification-channels
+		r.Get("/{channelId}", notificationHandler.GetByID)                  // GET /api/v1/organizations/{id}/notification-channels/{channelId}
+		r.Put("/{channelId}", notificationHandler.Update)                   // PUT /api/v1/organizations/{id}/notification-channels/{channelId}
+		r.Delete("/{channelId}", notificationHandler.Delete)                // DELETE /api/v1/organizations/{id}/notification-channels/{channelId}
+		r.Post("/{channelId}/test", notificationHandler.Test)               // POST /api/v1/organizations/{id}/notification-channels/{channelId}/test
+		r.Get("/{channelId}/deliveries", notificationHandler.GetDeliveries) // GET /api/v1/organizations/{id}/notification-channels/{channelId}/deliveries
+	})
+}
@@ -17,8 +17,10 @@ func RegisterOrganizationRoutes(r chi.Router, db *gorm.DB, orgHandler *organizat
 		r.Get("/", orgHandler.GetAll)                       // GET /api/v1/organizations
 		r.Get("/check", orgHandler.CheckUserOrganization)   // GET /api/v1/organizations/check
 		r.Get("/with-projects", orgHandler.GetWithProjects) // GET /api/v1/organizations/with-projects
+		r.Get("/deleted", orgHandler.GetDeleted)            // GET /api/v1/organizations/deleted
 		r.Get("/{id}", orgHandler.GetByID)                  // GET /api/v1/organizations/{id}
 		r.Put("/{id}", orgHandler.Update)                   // PUT /api/v1/organizations/{id}
 		r.Delete("/{id}", orgHandler.Delete)                // DELETE /api/v1/organizations/{id}
+		r.Post("/{id}/restore", orgHandler.Restore)         // POST /api/v1/organizations/{id}/restore
 	})
 }
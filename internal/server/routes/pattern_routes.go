@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/pattern"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterPatternRoutes registers all log pattern-related routes
+func RegisterPatternRoutes(r chi.Router, db *gorm.DB, patternHandler *pattern.Handler) {
+	r.Route("/projects/{id}/patterns", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all pattern routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", patternHandler.GetAll) // GET /api/v1/projects/{id}/patterns
+	})
+}
@@ -17,8 +17,10 @@ func RegisterProjectRoutes(r chi.Router, db *gorm.DB, projectHandler *project.Ha
 		r.Get("/{id}", projectHandler.GetByID)                                       // GET /api/v1/projects/{id}
 		r.Get("/by-api-key/{apiKey}", projectHandler.GetByAPIKey)                    // GET /api/v1/projects/by-api-key/{apiKey}
 		r.Get("/organization/{organizationId}", projectHandler.GetByOrganization)    // GET /api/v1/projects/organization/{organizationId}
+		r.Get("/organization/{organizationId}/deleted", projectHandler.GetDeletedByOrganization) // GET /api/v1/projects/organization/{organizationId}/deleted
 		r.Put("/{id}", projectHandler.Update)                                        // PUT /api/v1/projects/{id}
 		r.Delete("/{id}", projectHandler.Delete)                                     // DELETE /api/v1/projects/{id}
 		r.Post("/{id}/regenerate-api-key", projectHandler.RegenerateAPIKey)          // POST /api/v1/projects/{id}/regenerate-api-key
+		r.Post("/{id}/restore", projectHandler.Restore)                              // POST /api/v1/projects/{id}/restore
 	})
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/release"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterReleaseRoutes registers all release and deploy-related routes
+func RegisterReleaseRoutes(r chi.Router, db *gorm.DB, releaseHandler *release.Handler) {
+	r.Route("/releases", func(r chi.Router) {
+		// Releases and deploys recorded by the CLI or CI, authenticated with the project API key
+		r.Use(middleware.ProjectAPIKeyMiddleware(db))
+
+		r.Post("/", releaseHandler.CreateWithAPIKey)                        // POST /api/v1/releases
+		r.Post("/{release}/deploys", releaseHandler.CreateDeployWithAPIKey) // POST /api/v1/releases/{release}/deploys
+	})
+
+	r.Route("/projects/{id}/releases", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all release routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", releaseHandler.Create)                        // POST /api/v1/projects/{id}/releases
+		r.Get("/", releaseHandler.GetAll)                         // GET /api/v1/projects/{id}/releases
+		r.Get("/{release}", releaseHandler.GetByVersion)          // GET /api/v1/projects/{id}/releases/{release}
+		r.Post("/{release}/deploys", releaseHandler.CreateDeploy) // POST /api/v1/projects/{id}/releases/{release}/deploys
+		r.Get("/{release}/deploys", releaseHandler.GetDeploys)    // GET /api/v1/projects/{id}/releases/{release}/deploys
+	})
+}
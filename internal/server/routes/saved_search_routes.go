@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterSavedSearchRoutes registers all saved search-related routes
+func RegisterSavedSearchRoutes(r chi.Router, db *gorm.DB, savedSearchHandler *savedsearch.Handler) {
+	r.Route("/organizations/{id}/saved-searches", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all saved search routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", savedSearchHandler.Create)                    // POST /api/v1/organizations/{id}/saved-searches
+		r.Get("/", savedSearchHandler.GetAll)                     // GET /api/v1/organizations/{id}/saved-searches
+		r.Get("/{searchId}", savedSearchHandler.GetByID)          // GET /api/v1/organizations/{id}/saved-searches/{searchId}
+		r.Put("/{searchId}", savedSearchHandler.Update)           // PUT /api/v1/organizations/{id}/saved-searches/{searchId}
+		r.Delete("/{searchId}", savedSearchHandler.Delete)        // DELETE /api/v1/organizations/{id}/saved-searches/{searchId}
+		r.Post("/{searchId}/share", savedSearchHandler.Share)     // POST /api/v1/organizations/{id}/saved-searches/{searchId}/share
+		r.Delete("/{searchId}/share", savedSearchHandler.Unshare) // DELETE /api/v1/organizations/{id}/saved-searches/{searchId}/share
+	})
+
+	r.Route("/saved-searches", func(r chi.Router) {
+		// Apply Clerk JWT authentication to share link resolution
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/shared/{token}", savedSearchHandler.ResolveShareToken) // GET /api/v1/saved-searches/shared/{token}
+	})
+}
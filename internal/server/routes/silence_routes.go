@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterSilenceRoutes registers all silence-related routes
+func RegisterSilenceRoutes(r chi.Router, db *gorm.DB, silenceHandler *silence.Handler) {
+	r.Route("/organizations/{id}/silences", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all silence routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Post("/", silenceHandler.Create)                               // POST /api/v1/organizations/{id}/silences
+		r.Get("/", silenceHandler.GetAll)                                // GET /api/v1/organizations/{id}/silences
+		r.Get("/audit", silenceHandler.GetAuditTrail)                    // GET /api/v1/organizations/{id}/silences/audit
+		r.Get("/{silenceId}", silenceHandler.GetByID)                    // GET /api/v1/organizations/{id}/silences/{silenceId}
+		r.Put("/{silenceId}", silenceHandler.Update)                     // PUT /api/v1/organizations/{id}/silences/{silenceId}
+		r.Delete("/{silenceId}", silenceHandler.Expire)                  // DELETE /api/v1/organizations/{id}/silences/{silenceId}
+		r.Get("/{silenceId}/audit", silenceHandler.GetSilenceAuditTrail) // GET /api/v1/organizations/{id}/silences/{silenceId}/audit
+	})
+}
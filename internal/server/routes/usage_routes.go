@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/usage"
+	"github.com/nihar-hegde/valtro-backend/internal/middleware"
+	"gorm.io/gorm"
+)
+
+// RegisterUsageRoutes registers all usage-related routes
+func RegisterUsageRoutes(r chi.Router, db *gorm.DB, usageHandler *usage.Handler) {
+	r.Route("/organizations/{id}/usage", func(r chi.Router) {
+		// Apply Clerk JWT authentication to all usage routes
+		r.Use(middleware.ClerkJWTMiddleware(db))
+
+		r.Get("/", usageHandler.GetOrganizationUsage) // GET /api/v1/organizations/{id}/usage
+	})
+}
@@ -5,12 +5,31 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/anomaly"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/artifact"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/health"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/incident"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/ingest"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/issue"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/legalhold"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/monitor"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/notification"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/onboarding"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/organization"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/pattern"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/project"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/release"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/search"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/handlers/usage"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/user"
 	"github.com/nihar-hegde/valtro-backend/internal/handlers/webhook"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	artifactRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/artifact"
+	artifactService "github.com/nihar-hegde/valtro-backend/internal/services/artifact"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
@@ -18,27 +37,61 @@ import (
 
 // Server holds the dependencies for our HTTP server.
 type Server struct {
-	db                 *gorm.DB
-	router             *chi.Mux
-	healthHandler      *health.Handler
-	userHandler        *user.Handler
-	orgHandler         *organization.Handler
-	projectHandler     *project.Handler
-	webhookHandler     *webhook.Handler
-	onboardingHandler  *onboarding.Handler
+	db                  *gorm.DB
+	router              *chi.Mux
+	healthHandler       *health.Handler
+	userHandler         *user.Handler
+	orgHandler          *organization.Handler
+	projectHandler      *project.Handler
+	webhookHandler      *webhook.Handler
+	onboardingHandler   *onboarding.Handler
+	savedSearchHandler  *savedsearch.Handler
+	legalHoldHandler    *legalhold.Handler
+	usageHandler        *usage.Handler
+	patternHandler      *pattern.Handler
+	issueHandler        *issue.Handler
+	releaseHandler      *release.Handler
+	artifactHandler     *artifact.Handler
+	alertHandler        *alert.Handler
+	notificationHandler *notification.Handler
+	anomalyHandler      *anomaly.Handler
+	silenceHandler      *silence.Handler
+	incidentHandler     *incident.Handler
+	monitorHandler      *monitor.Handler
+	ingestHandler       *ingest.Handler
+	searchHandler       *search.Handler
 }
 
 // NewServer creates a new Server instance.
-func NewServer(db *gorm.DB) *Server {
+// The object store holds release artifacts; services that use it are built once and shared between handlers
+// The log store holds ingested log events
+func NewServer(db *gorm.DB, store storage.Store, logStore logstore.LogStore) *Server {
+	artifactSvc := artifactService.NewService(artifactRepo.NewRepository(db), store)
+
 	server := &Server{
-		db:                db,
-		router:            chi.NewRouter(),
-		healthHandler:     health.NewHandler(db),
-		userHandler:       user.NewHandler(db),
-		orgHandler:        organization.NewHandler(db),
-		projectHandler:    project.NewHandler(db),
-		webhookHandler:    webhook.NewHandler(db),
-		onboardingHandler: onboarding.NewHandler(db),
+		db:                  db,
+		router:              chi.NewRouter(),
+		healthHandler:       health.NewHandler(db),
+		userHandler:         user.NewHandler(db),
+		orgHandler:          organization.NewHandler(db),
+		projectHandler:      project.NewHandler(db),
+		webhookHandler:      webhook.NewHandler(db),
+		onboardingHandler:   onboarding.NewHandler(db),
+		savedSearchHandler:  savedsearch.NewHandler(db),
+		legalHoldHandler:    legalhold.NewHandler(db),
+		usageHandler:        usage.NewHandler(db),
+		patternHandler:      pattern.NewHandler(db),
+		issueHandler:        issue.NewHandler(db, artifactSvc),
+		releaseHandler:      release.NewHandler(db),
+		artifactHandler:     artifact.NewHandler(db, artifactSvc),
+		alertHandler:        alert.NewHandler(db, logStore),
+		notificationHandler: notification.NewHandler(db),
+		anomalyHandler:      anomaly.NewHandler(db),
+		silenceHandler:      silence.NewHandler(db),
+		incidentHandler:     incident.NewHandler(db),
+		monitorHandler:      monitor.NewHandler(db),
+		ingestHandler:       ingest.NewHandler(db, logStore, artifactSvc),
+		searchHandler:       search.NewHandler(db, logStore, store),
 	}
 
 	// Register all the application routes.
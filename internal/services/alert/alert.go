@@ -0,0 +1,513 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	alertRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/alert"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	savedSearchService "github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
+)
+
+// aggregations lists the supported aggregations
+var aggregations = map[string]bool{
+	constants.AlertAggregationCount: true,
+	constants.AlertAggregationRate:  true,
+}
+
+// comparisons lists the supported comparisons
+var comparisons = map[string]bool{
+	constants.AlertComparisonGT:  true,
+	constants.AlertComparisonGTE: true,
+	constants.AlertComparisonLT:  true,
+	constants.AlertComparisonLTE: true,
+}
+
+// Service handles alert rule management and evaluation
+type Service struct {
+	alertRepo        *alertRepo.Repository
+	notificationRepo *notificationRepo.Repository
+	savedSearches    *savedSearchService.Service
+	source           Source
+}
+
+// NewService creates a new alert rule service
+func NewService(alertRepo *alertRepo.Repository, notificationRepo *notificationRepo.Repository, savedSearches *savedSearchService.Service, source Source) *Service {
+	return &Service{
+		alertRepo:        alertRepo,
+		notificationRepo: notificationRepo,
+		savedSearches:    savedSearches,
+		source:           source,
+	}
+}
+
+// CreateAlertRule creates an alert rule, due for evaluation straight away
+func (s *Service) CreateAlertRule(ctx context.Context, projectID uuid.UUID, req dto.CreateAlertRuleRequest, createdByID uuid.UUID) (*dto.AlertRuleResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.validateName(name); err != nil {
+		return nil, err
+	}
+
+	window, err := parseSeconds("Window", req.Window, constants.MinAlertIntervalSeconds, constants.MaxAlertWindowSeconds)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := parseSeconds("Evaluation interval", req.EvaluationInterval, constants.MinAlertIntervalSeconds, constants.MaxAlertIntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+	channelIDs, err := s.validateChannels(ctx, projectID, req.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := validator.ValidateLabels("Alert rule labels", req.Labels, constants.MaxAlertRuleLabels)
+	if err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(req.Query)
+	if req.SavedSearchID != nil && query != "" {
+		return nil, errors.NewValidationError("Set either a query or a saved search, not both")
+	}
+
+	now := time.Now()
+	rule := &models.AlertRule{
+		ID:                        uuid.New(),
+		ProjectID:                 projectID,
+		Name:                      name,
+		Query:                     query,
+		SavedSearchID:             req.SavedSearchID,
+		Aggregation:               req.Aggregation,
+		Comparison:                req.Comparison,
+		Threshold:                 req.Threshold,
+		ResolveThreshold:          req.ResolveThreshold,
+		WindowSeconds:             window,
+		EvaluationIntervalSeconds: interval,
+		ChannelIDs:                channelIDs,
+		Labels:                    labels,
+		Enabled:                   req.Enabled == nil || *req.Enabled,
+		State:                     constants.AlertStateOK,
+		NextEvaluationAt:          now,
+		CreatedByID:               createdByID,
+		CreatedAt:                 now,
+		UpdatedAt:                 now,
+	}
+
+	// Validate business rules
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	nameExists, err := s.alertRepo.NameExistsForProject(ctx, name, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if nameExists {
+		return nil, errors.NewConflictError("Alert rule with this name already exists in project", "Name: "+name)
+	}
+
+	if err := s.alertRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return s.toAlertRuleResponse(rule), nil
+}
+
+// GetAlertRuleByID retrieves an alert rule of a project
+func (s *Service) GetAlertRuleByID(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.AlertRuleResponse, error) {
+	rule, err := s.getProjectAlertRule(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toAlertRuleResponse(rule), nil
+}
+
+// GetAlertRulesByProject lists a project's alert rules
+func (s *Service) GetAlertRulesByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.AlertRuleResponse, error) {
+	rules, err := s.alertRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, s.toAlertRuleResponse(rule))
+	}
+
+	return responses, nil
+}
+
+// UpdateAlertRule updates an alert rule's definition
+// Changing what or how the rule measures makes it due for evaluation straight away
+func (s *Service) UpdateAlertRule(ctx context.Context, id uuid.UUID, req dto.UpdateAlertRuleRequest, projectID uuid.UUID) (*dto.AlertRuleResponse, error) {
+	rule, err := s.getProjectAlertRule(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := s.validateName(name); err != nil {
+			return nil, err
+		}
+
+		// Check if new name already exists for this project (excluding current rule)
+		nameExists, err := s.alertRepo.NameExistsForProject(ctx, name, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if nameExists && rule.Name != name {
+			return nil, errors.NewConflictError("Alert rule with this name already exists in project", "Name: "+name)
+		}
+
+		rule.Name = name
+	}
+	if req.Query != nil && req.SavedSearchID != nil {
+		return nil, errors.NewValidationError("Set either a query or a saved search, not both")
+	}
+	if req.Query != nil {
+		rule.Query = strings.TrimSpace(*req.Query)
+		rule.SavedSearchID = nil
+	}
+	if req.SavedSearchID != nil {
+		rule.Query = ""
+		rule.SavedSearchID = req.SavedSearchID
+	}
+	if req.Aggregation != nil {
+		rule.Aggregation = *req.Aggregation
+	}
+	if req.Comparison != nil {
+		rule.Comparison = *req.Comparison
+	}
+	if req.Threshold != nil {
+		rule.Threshold = *req.Threshold
+	}
+	if req.ClearResolveThreshold {
+		rule.ResolveThreshold = nil
+	} else if req.ResolveThreshold != nil {
+		rule.ResolveThreshold = req.ResolveThreshold
+	}
+	if req.Window != nil {
+		rule.WindowSeconds, err = parseSeconds("Window", *req.Window, constants.MinAlertIntervalSeconds, constants.MaxAlertWindowSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.EvaluationInterval != nil {
+		rule.EvaluationIntervalSeconds, err = parseSeconds("Evaluation interval", *req.EvaluationInterval, constants.MinAlertIntervalSeconds, constants.MaxAlertIntervalSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.ChannelIDs != nil {
+		rule.ChannelIDs, err = s.validateChannels(ctx, projectID, *req.ChannelIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Labels != nil {
+		rule.Labels, err = validator.ValidateLabels("Alert rule labels", req.Labels, constants.MaxAlertRuleLabels)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	// Validate business rules
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.NextEvaluationAt = now
+	rule.UpdatedAt = now
+
+	// Save changes
+	if err := s.alertRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return s.toAlertRuleResponse(rule), nil
+}
+
+// DeleteAlertRule deletes an alert rule and its evaluation history
+func (s *Service) DeleteAlertRule(ctx context.Context, id uuid.UUID, projectID uuid.UUID) error {
+	if _, err := s.getProjectAlertRule(ctx, id, projectID); err != nil {
+		return err
+	}
+	return s.alertRepo.Delete(ctx, id)
+}
+
+// GetAlertEvaluations retrieves an alert rule's evaluation history, newest first
+func (s *Service) GetAlertEvaluations(ctx context.Context, id uuid.UUID, projectID uuid.UUID, limit int) ([]*dto.AlertEvaluationResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultEvaluationLimit
+	}
+	if limit < 1 || limit > constants.MaxEvaluationLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxEvaluationLimit))
+	}
+
+	if _, err := s.getProjectAlertRule(ctx, id, projectID); err != nil {
+		return nil, err
+	}
+
+	evaluations, err := s.alertRepo.GetEvaluations(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.AlertEvaluationResponse, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		responses = append(responses, s.toAlertEvaluationResponse(evaluation))
+	}
+
+	return responses, nil
+}
+
+// Evaluate aggregates the rule's events over the source's window at now and applies the result
+// to the rule's state. The returned evaluation is not saved.
+//
+// A rule in the "ok" state fires when the value breaches the threshold. A firing rule only
+// resolves once the value stops breaching the resolve threshold, so a value hovering around
+// the threshold does not flap. Failed evaluations leave the state unchanged.
+func (s *Service) Evaluate(ctx context.Context, rule *models.AlertRule, now time.Time) *models.AlertEvaluation {
+	window := time.Duration(rule.WindowSeconds) * time.Second
+	from, to := s.source.Window(now, window)
+	evaluation := &models.AlertEvaluation{
+		ID:            uuid.New(),
+		AlertRuleID:   rule.ID,
+		EvaluatedAt:   now,
+		WindowStart:   from,
+		PreviousState: rule.State,
+		State:         rule.State,
+	}
+
+	query, err := s.ruleQuery(ctx, rule)
+	if err != nil {
+		evaluation.Error = err.Error()
+		return evaluation
+	}
+	count, err := s.source.Count(ctx, rule.ProjectID, query, from, to)
+	if err != nil {
+		evaluation.Error = err.Error()
+		return evaluation
+	}
+
+	value := float64(count)
+	if rule.Aggregation == constants.AlertAggregationRate {
+		value /= window.Minutes()
+	}
+	evaluation.Value = &value
+
+	switch rule.State {
+	case constants.AlertStateFiring:
+		if !breaches(value, rule.Comparison, resolveThreshold(rule)) {
+			evaluation.State = constants.AlertStateOK
+		}
+	default:
+		if breaches(value, rule.Comparison, rule.Threshold) {
+			evaluation.State = constants.AlertStateFiring
+		}
+	}
+
+	rule.LastEvaluatedAt = &now
+	rule.LastValue = &value
+	if evaluation.State != rule.State {
+		rule.State = evaluation.State
+		rule.StateChangedAt = &now
+	}
+
+	return evaluation
+}
+
+// getProjectAlertRule retrieves an alert rule, reporting rules of other projects as missing
+func (s *Service) getProjectAlertRule(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.AlertRule, error) {
+	rule, err := s.alertRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.ProjectID != projectID {
+		return nil, errors.NewNotFoundError("Alert rule", id.String())
+	}
+	return rule, nil
+}
+
+// validateName validates an alert rule name
+func (s *Service) validateName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("Alert rule name is required")
+	}
+	if len(name) > constants.MaxAlertRuleNameLength {
+		return errors.NewValidationError(fmt.Sprintf("Alert rule name must be at most %d characters", constants.MaxAlertRuleNameLength))
+	}
+	return nil
+}
+
+// validateRule validates the aggregation, comparison, thresholds and query of a rule
+// A rule using a saved search is checked against the saved search's current query
+func (s *Service) validateRule(ctx context.Context, rule *models.AlertRule) error {
+	if !aggregations[rule.Aggregation] {
+		return errors.NewValidationError("Aggregation must be one of 'count' or 'rate'")
+	}
+	if !comparisons[rule.Comparison] {
+		return errors.NewValidationError("Comparison must be one of 'gt', 'gte', 'lt' or 'lte'")
+	}
+	if rule.Threshold < 0 {
+		return errors.NewValidationError("Threshold cannot be negative")
+	}
+
+	// The resolve threshold must sit on the safe side of the threshold
+	if rule.ResolveThreshold != nil {
+		above := rule.Comparison == constants.AlertComparisonGT || rule.Comparison == constants.AlertComparisonGTE
+		if above && *rule.ResolveThreshold > rule.Threshold {
+			return errors.NewValidationError("Resolve threshold cannot be above the threshold for 'gt' and 'gte' rules")
+		}
+		if !above && *rule.ResolveThreshold < rule.Threshold {
+			return errors.NewValidationError("Resolve threshold cannot be below the threshold for 'lt' and 'lte' rules")
+		}
+	}
+
+	query, err := s.ruleQuery(ctx, rule)
+	if err != nil {
+		return err
+	}
+	return s.source.Validate(query, time.Duration(rule.WindowSeconds)*time.Second)
+}
+
+// ruleQuery returns the query a rule is evaluated with
+// Rules using a saved search read its query each time, so edits to the saved search apply straight away
+func (s *Service) ruleQuery(ctx context.Context, rule *models.AlertRule) (string, error) {
+	if rule.SavedSearchID == nil {
+		return rule.Query, nil
+	}
+	return s.savedSearches.QueryForProject(ctx, *rule.SavedSearchID, rule.ProjectID)
+}
+
+// validateChannels checks that the channels exist in the project's organization,
+// returning them without duplicates
+func (s *Service) validateChannels(ctx context.Context, projectID uuid.UUID, channelIDs []uuid.UUID) ([]uuid.UUID, error) {
+	unique := make([]uuid.UUID, 0, len(channelIDs))
+	seen := make(map[uuid.UUID]bool, len(channelIDs))
+	for _, id := range channelIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return unique, nil
+	}
+	if len(unique) > constants.MaxAlertRuleChannels {
+		return nil, errors.NewValidationError(fmt.Sprintf("An alert rule can notify at most %d channels", constants.MaxAlertRuleChannels))
+	}
+
+	count, err := s.notificationRepo.CountForProject(ctx, projectID, unique)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(unique)) {
+		return nil, errors.NewValidationError("Notification channels must exist in the project's organization")
+	}
+
+	return unique, nil
+}
+
+// parseSeconds parses a duration such as "5m" into whole seconds within [lower, upper]
+func parseSeconds(field string, value string, lower, upper int) (int, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.NewValidationError(field+" must be a duration such as 5m or 1h", err.Error())
+	}
+	if duration%time.Second != 0 {
+		return 0, errors.NewValidationError(field + " must be a whole number of seconds")
+	}
+
+	seconds := int(duration / time.Second)
+	if seconds < lower || seconds > upper {
+		return 0, errors.NewValidationError(fmt.Sprintf("%s must be between %s and %s", field,
+			formatSeconds(lower), formatSeconds(upper)))
+	}
+	return seconds, nil
+}
+
+// breaches reports whether the value is on the alerting side of the threshold
+func breaches(value float64, comparison string, threshold float64) bool {
+	switch comparison {
+	case constants.AlertComparisonGT:
+		return value > threshold
+	case constants.AlertComparisonGTE:
+		return value >= threshold
+	case constants.AlertComparisonLT:
+		return value < threshold
+	case constants.AlertComparisonLTE:
+		return value <= threshold
+	}
+	return false
+}
+
+// resolveThreshold returns the threshold a firing rule must clear to resolve
+func resolveThreshold(rule *models.AlertRule) float64 {
+	if rule.ResolveThreshold != nil {
+		return *rule.ResolveThreshold
+	}
+	return rule.Threshold
+}
+
+// formatSeconds renders a number of seconds as a duration string such as "5m0s"
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// toAlertRuleResponse converts an alert rule model to response DTO
+func (s *Service) toAlertRuleResponse(rule *models.AlertRule) *dto.AlertRuleResponse {
+	return &dto.AlertRuleResponse{
+		ID:                 rule.ID,
+		ProjectID:          rule.ProjectID,
+		Name:               rule.Name,
+		Query:              rule.Query,
+		SavedSearchID:      rule.SavedSearchID,
+		Aggregation:        rule.Aggregation,
+		Comparison:         rule.Comparison,
+		Threshold:          rule.Threshold,
+		ResolveThreshold:   rule.ResolveThreshold,
+		Window:             formatSeconds(rule.WindowSeconds),
+		EvaluationInterval: formatSeconds(rule.EvaluationIntervalSeconds),
+		ChannelIDs:         rule.ChannelIDs,
+		Labels:             rule.Labels,
+		Enabled:            rule.Enabled,
+		State:              rule.State,
+		StateChangedAt:     rule.StateChangedAt,
+		LastEvaluatedAt:    rule.LastEvaluatedAt,
+		LastValue:          rule.LastValue,
+		NextEvaluationAt:   rule.NextEvaluationAt,
+		CreatedByID:        rule.CreatedByID,
+		CreatedAt:          rule.CreatedAt,
+		UpdatedAt:          rule.UpdatedAt,
+	}
+}
+
+// toAlertEvaluationResponse converts an alert evaluation model to response DTO
+func (s *Service) toAlertEvaluationResponse(evaluation *models.AlertEvaluation) *dto.AlertEvaluationResponse {
+	return &dto.AlertEvaluationResponse{
+		ID:            evaluation.ID,
+		AlertRuleID:   evaluation.AlertRuleID,
+		EvaluatedAt:   evaluation.EvaluatedAt,
+		WindowStart:   evaluation.WindowStart,
+		Value:         evaluation.Value,
+		PreviousState: evaluation.PreviousState,
+		State:         evaluation.State,
+		Error:         evaluation.Error,
+	}
+}
@@ -0,0 +1,220 @@
+package alert
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	alertRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/alert"
+)
+
+// Default scheduler settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval        = 15 * time.Second
+	defaultBatchSize           = 100
+	defaultEvaluationRetention = 30 * 24 * time.Hour
+)
+
+// SchedulerConfig controls how often due alert rules are evaluated
+type SchedulerConfig struct {
+	// TickInterval is how often the scheduler looks for due rules
+	TickInterval time.Duration
+
+	// BatchSize caps how many rules are claimed per tick
+	BatchSize int
+
+	// EvaluationRetention is how long evaluation history is kept
+	EvaluationRetention time.Duration
+}
+
+// SchedulerConfigFromEnv reads ALERT_TICK_INTERVAL, ALERT_BATCH_SIZE and ALERT_EVALUATION_RETENTION
+func SchedulerConfigFromEnv() SchedulerConfig {
+	config := SchedulerConfig{
+		TickInterval:        envDuration("ALERT_TICK_INTERVAL", defaultTickInterval),
+		BatchSize:           defaultBatchSize,
+		EvaluationRetention: envDuration("ALERT_EVALUATION_RETENTION", defaultEvaluationRetention),
+	}
+
+	if value := os.Getenv("ALERT_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Printf("Invalid ALERT_BATCH_SIZE %q, using default of %d", value, defaultBatchSize)
+		} else {
+			config.BatchSize = size
+		}
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "15s" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// Notification describes an alert rule changing state
+type Notification struct {
+	RuleID        uuid.UUID
+	EvaluationID  uuid.UUID
+	ProjectID     uuid.UUID
+	RuleName      string
+	Query         string     // Query the rule was evaluated with
+	SavedSearchID *uuid.UUID // Saved search the query was read from, if any
+	State         string     // "firing" or "ok" (resolved)
+	Value         float64
+	Aggregation   string
+	Comparison    string
+	Threshold     float64
+	EvaluatedAt   time.Time
+	WindowStart   time.Time
+	ChannelIDs    []uuid.UUID       // Notification channels picked by the rule
+	Labels        map[string]string // Labels silences match on
+}
+
+// Notifier delivers alert notifications
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// LogNotifier writes notifications to the application log
+type LogNotifier struct{}
+
+// Notify logs the notification
+func (LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	log.Printf("Alert %q (%s) for project %s is %s: %s %g, threshold %s %g",
+		notification.RuleName, notification.RuleID, notification.ProjectID, notification.State,
+		notification.Aggregation, notification.Value, notification.Comparison, notification.Threshold)
+	return nil
+}
+
+// Scheduler periodically evaluates due alert rules and notifies on state changes
+// Rules are claimed with row locks, so several API instances can run schedulers side by side
+type Scheduler struct {
+	alertService *Service
+	alertRepo    *alertRepo.Repository
+	notifier     Notifier
+	config       SchedulerConfig
+}
+
+// NewScheduler creates a new alert scheduler
+func NewScheduler(alertService *Service, alertRepo *alertRepo.Repository, notifier Notifier, config SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		alertService: alertService,
+		alertRepo:    alertRepo,
+		notifier:     notifier,
+		config:       config,
+	}
+}
+
+// Start evaluates due rules on every tick until the context is cancelled
+// Evaluation history older than the retention period is pruned about once an hour
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("Alert scheduler started (tick %s, batch size %d, history retention %s)",
+		s.config.TickInterval, s.config.BatchSize, s.config.EvaluationRetention)
+
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		if err := s.RunDue(ctx); err != nil {
+			log.Printf("Alert scheduler failed: %v", err)
+		}
+
+		if time.Since(lastPrune) >= time.Hour {
+			lastPrune = time.Now()
+			if pruned, err := s.alertRepo.DeleteEvaluationsBefore(ctx, lastPrune.Add(-s.config.EvaluationRetention)); err != nil {
+				log.Printf("Failed to prune alert evaluations: %v", err)
+			} else if pruned > 0 {
+				log.Printf("Pruned %d alert evaluations", pruned)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue evaluates every rule that is due, batch by batch
+func (s *Scheduler) RunDue(ctx context.Context) error {
+	for {
+		now := time.Now()
+		rules, err := s.alertRepo.ClaimDue(ctx, now, s.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			s.evaluate(ctx, rule, now)
+		}
+
+		if len(rules) < s.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// evaluate runs and records one evaluation, notifying if the rule changed state
+func (s *Scheduler) evaluate(ctx context.Context, rule *models.AlertRule, now time.Time) {
+	evaluation := s.alertService.Evaluate(ctx, rule, now)
+	if evaluation.Error != "" {
+		log.Printf("Alert rule %s evaluation failed: %s", rule.ID, evaluation.Error)
+	}
+
+	if err := s.alertRepo.RecordEvaluation(ctx, rule, evaluation); err != nil {
+		log.Printf("Failed to record evaluation of alert rule %s: %v", rule.ID, err)
+		return
+	}
+
+	if evaluation.State == evaluation.PreviousState {
+		return
+	}
+
+	query, err := s.alertService.ruleQuery(ctx, rule)
+	if err != nil {
+		log.Printf("Failed to read the query of alert rule %s: %v", rule.ID, err)
+		query = rule.Query
+	}
+	notification := Notification{
+		RuleID:        rule.ID,
+		EvaluationID:  evaluation.ID,
+		ProjectID:     rule.ProjectID,
+		RuleName:      rule.Name,
+		Query:         query,
+		SavedSearchID: rule.SavedSearchID,
+		State:         evaluation.State,
+		Value:         *evaluation.Value,
+		Aggregation:   rule.Aggregation,
+		Comparison:    rule.Comparison,
+		Threshold:     rule.Threshold,
+		EvaluatedAt:   now,
+		WindowStart:   evaluation.WindowStart,
+		ChannelIDs:    rule.ChannelIDs,
+		Labels:        rule.Labels,
+	}
+	if evaluation.State == constants.AlertStateOK {
+		notification.Threshold = resolveThreshold(rule)
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		log.Printf("Failed to send notification for alert rule %s: %v", rule.ID, err)
+	}
+}
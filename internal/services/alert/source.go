@@ -0,0 +1,199 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
+)
+
+// Source counts the events matching an alert query
+type Source interface {
+	// Validate reports whether the source can evaluate the query over windows of the given length
+	Validate(query string, window time.Duration) error
+
+	// Window returns the range [from, to) evaluated at now for a window of the given length
+	// Sources backed by rollups align it to completed buckets so partial buckets are never counted
+	Window(now time.Time, window time.Duration) (from, to time.Time)
+
+	// Count returns the number of a project's events matching the query in [from, to)
+	Count(ctx context.Context, projectID uuid.UUID, query string, from, to time.Time) (int64, error)
+}
+
+// logLevels lists the levels an alert query can select
+var logLevels = map[string]bool{
+	constants.LogLevelTrace: true,
+	constants.LogLevelDebug: true,
+	constants.LogLevelInfo:  true,
+	constants.LogLevelWarn:  true,
+	constants.LogLevelError: true,
+	constants.LogLevelFatal: true,
+}
+
+// LogStoreSource evaluates alerts against the log store, so events count as soon as they are
+// stored and queries can search text as well as filter on level (see parseQuery)
+// Windows are whole minutes ending at the start of the current minute, so every evaluation
+// covers the same number of completed minutes
+type LogStoreSource struct {
+	logStore logstore.LogStore
+}
+
+// NewLogStoreSource creates a log store-backed alert source, the default for alert rules
+func NewLogStoreSource(logStore logstore.LogStore) *LogStoreSource {
+	return &LogStoreSource{logStore: logStore}
+}
+
+// Validate checks that the query parses and the window is whole minutes
+func (s *LogStoreSource) Validate(query string, window time.Duration) error {
+	if _, _, err := parseQuery(query); err != nil {
+		return err
+	}
+	if window%time.Minute != 0 {
+		return errors.NewValidationError("Alert windows must be whole minutes (e.g. 5m or 1h)")
+	}
+	return nil
+}
+
+// Window returns the last window-length span of completed minutes before now
+func (s *LogStoreSource) Window(now time.Time, window time.Duration) (time.Time, time.Time) {
+	to := now.UTC().Truncate(time.Minute)
+	return to.Add(-window), to
+}
+
+// Count counts the project's events in [from, to) that match the query
+// The log store aggregates the range as a single bucket, so no events are read back
+func (s *LogStoreSource) Count(ctx context.Context, projectID uuid.UUID, query string, from, to time.Time) (int64, error) {
+	levels, text, err := parseQuery(query)
+	if err != nil {
+		return 0, err
+	}
+
+	buckets, err := s.logStore.Aggregate(ctx, logstore.Query{
+		ProjectIDs: []uuid.UUID{projectID},
+		From:       from,
+		To:         to,
+		Levels:     levels,
+		Text:       text,
+	}, to.Sub(from))
+	if err != nil {
+		return 0, errors.NewInternalError("Failed to count log events", err.Error())
+	}
+
+	var count int64
+	for _, bucket := range buckets {
+		count += bucket.Count
+	}
+	return count, nil
+}
+
+// UsageSource evaluates alerts against the hourly usage rollups
+// It is cheaper than LogStoreSource for event volume by level but only understands level
+// filters such as "level:error OR level:fatal" (an empty query selects every level), and
+// because the rollups are hourly, windows must be whole hours
+// Windows end at the start of the current hour, so every evaluation covers the same number
+// of completed buckets; the hour in progress is only counted once it has ended
+type UsageSource struct {
+	usageRepo *usageRepo.Repository
+}
+
+// NewUsageSource creates a usage-backed alert source
+func NewUsageSource(usageRepo *usageRepo.Repository) *UsageSource {
+	return &UsageSource{usageRepo: usageRepo}
+}
+
+// Validate checks that the query only filters on level and the window is whole hours
+func (s *UsageSource) Validate(query string, window time.Duration) error {
+	if _, err := parseLevels(query); err != nil {
+		return err
+	}
+	if window%time.Hour != 0 {
+		return errors.NewValidationError("Alert windows must be whole hours (e.g. 1h or 6h) because events are counted from hourly rollups")
+	}
+	return nil
+}
+
+// Window returns the last window-length span of completed hourly buckets before now
+func (s *UsageSource) Window(now time.Time, window time.Duration) (time.Time, time.Time) {
+	to := now.UTC().Truncate(time.Hour)
+	return to.Add(-window), to
+}
+
+// Count sums the events of the queried levels in hourly buckets starting within [from, to)
+func (s *UsageSource) Count(ctx context.Context, projectID uuid.UUID, query string, from, to time.Time) (int64, error) {
+	levels, err := parseLevels(query)
+	if err != nil {
+		return 0, err
+	}
+	return s.usageRepo.CountEvents(ctx, projectID, levels, from, to)
+}
+
+// parseLevels parses a query of "level:<name>" terms joined by OR
+func parseLevels(query string) ([]string, error) {
+	levels, text, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if text != "" {
+		return nil, errors.NewValidationError("Unsupported alert query", "Only level filters joined by OR are supported, e.g. \"level:error OR level:fatal\"")
+	}
+	return levels, nil
+}
+
+// parseQuery splits an alert query into its level filters and full-text search term
+// A query starts with optional "level:<name>" terms joined by OR, and the rest is searched for
+// as in log search (see logstore.ParseText), so "level:error OR level:fatal \"connection refused\""
+// counts error and fatal events containing the phrase. An empty query matches every event
+func parseQuery(query string) ([]string, string, error) {
+	var levels []string
+	rest := strings.TrimSpace(query)
+	afterOR := false
+	for rest != "" {
+		term, remainder := nextTerm(rest)
+		name, value, ok := strings.Cut(term, ":")
+		if !ok || !strings.EqualFold(name, "level") {
+			if afterOR {
+				return nil, "", errors.NewValidationError("Unsupported alert query", "OR can only join level filters, e.g. \"level:error OR level:fatal\"")
+			}
+			break
+		}
+		value = strings.ToLower(value)
+		if !logLevels[value] {
+			return nil, "", errors.NewValidationError("Unsupported alert query", fmt.Sprintf("Unknown log level %q", value))
+		}
+		levels = append(levels, value)
+		rest = remainder
+
+		operator, remainder := nextTerm(rest)
+		afterOR = strings.EqualFold(operator, "OR")
+		if !afterOR {
+			break
+		}
+		if remainder == "" {
+			return nil, "", errors.NewValidationError("Unsupported alert query", "Query cannot end with OR")
+		}
+		rest = remainder
+	}
+
+	for _, term := range strings.Fields(rest) {
+		if name, _, ok := strings.Cut(term, ":"); ok && strings.EqualFold(name, "level") {
+			return nil, "", errors.NewValidationError("Unsupported alert query", "Level filters must come before the search text")
+		}
+	}
+	return levels, rest, nil
+}
+
+// nextTerm splits the first whitespace-separated term off a query, trimming what follows
+func nextTerm(query string) (string, string) {
+	end := strings.IndexFunc(query, unicode.IsSpace)
+	if end < 0 {
+		return query, ""
+	}
+	return query[:end], strings.TrimSpace(query[end:])
+}
@@ -0,0 +1,113 @@
+package alert
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantLevels []string
+		wantText   string
+		wantErr    bool
+	}{
+		{name: "empty", query: "  "},
+		{name: "one level", query: "level:error", wantLevels: []string{"error"}},
+		{name: "levels joined by OR", query: "Level:ERROR or level:fatal", wantLevels: []string{"error", "fatal"}},
+		{name: "text only", query: "connection refused", wantText: "connection refused"},
+		{name: "levels then text", query: "level:error OR level:fatal  timeout", wantLevels: []string{"error", "fatal"}, wantText: "timeout"},
+		{name: "level then phrase", query: `level:warn "disk  full"`, wantLevels: []string{"warn"}, wantText: `"disk  full"`},
+		{name: "unknown level", query: "level:loud", wantErr: true},
+		{name: "ends with OR", query: "level:error OR", wantErr: true},
+		{name: "OR joins text", query: "level:error OR timeout", wantErr: true},
+		{name: "level after text", query: "timeout level:error", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels, text, err := parseQuery(tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseQuery(%q) = (%v, %q), want an error", tt.query, levels, text)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQuery(%q) failed: %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(levels, tt.wantLevels) || text != tt.wantText {
+				t.Errorf("parseQuery(%q) = (%v, %q), want (%v, %q)", tt.query, levels, text, tt.wantLevels, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestLogStoreSource(t *testing.T) {
+	ctx := context.Background()
+	store, err := logstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []*logstore.Event{
+		{Level: "error", Message: "connection refused by db-1", Timestamp: start.Add(10 * time.Second)},
+		{Level: "error", Message: "payment declined", Timestamp: start.Add(2 * time.Minute)},
+		{Level: "info", Message: "connection refused, retrying", Timestamp: start.Add(3 * time.Minute)},
+		{Level: "fatal", Message: "connection refused by db-2", Timestamp: start.Add(4*time.Minute + 59*time.Second)},
+		{Level: "error", Message: "connection refused by db-3", Timestamp: start.Add(5 * time.Minute)},
+	}
+	for _, event := range events {
+		event.ID = uuid.New()
+		event.ProjectID = projectID
+		event.ReceivedAt = event.Timestamp
+	}
+	if err := store.Append(ctx, events); err != nil {
+		t.Fatal(err)
+	}
+
+	source := NewLogStoreSource(store)
+	window := 5 * time.Minute
+	from, to := source.Window(start.Add(5*time.Minute+30*time.Second), window)
+	if !from.Equal(start) || !to.Equal(start.Add(window)) {
+		t.Fatalf("Window() = (%s, %s), want the five minutes from %s", from, to, start)
+	}
+
+	tests := []struct {
+		query string
+		want  int64
+	}{
+		{query: "", want: 4},
+		{query: "level:error", want: 2},
+		{query: "connection refused", want: 3},
+		{query: "level:error OR level:fatal connection", want: 2},
+		{query: `level:info "refused retrying"`, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := source.Count(ctx, projectID, tt.query, from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Count(%q) = %d, want %d", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if err := source.Validate("level:error", 90*time.Second); err == nil {
+		t.Error("Validate() accepted a window that is not whole minutes")
+	}
+	if err := source.Validate("connection refused", 5*time.Minute); err != nil {
+		t.Errorf("Validate() rejected a text query: %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package anomaly
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	anomalyRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/anomaly"
+)
+
+// Service handles anomaly detection settings and the anomalies detected for projects
+type Service struct {
+	anomalyRepo *anomalyRepo.Repository
+}
+
+// NewService creates a new anomaly service
+func NewService(anomalyRepo *anomalyRepo.Repository) *Service {
+	return &Service{
+		anomalyRepo: anomalyRepo,
+	}
+}
+
+// GetSettings retrieves a project's anomaly detection settings, or the defaults
+func (s *Service) GetSettings(ctx context.Context, projectID uuid.UUID) (*dto.AnomalySettingsResponse, error) {
+	settings, customized, err := s.loadSettings(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSettingsResponse(settings, customized), nil
+}
+
+// UpdateSettings changes a project's anomaly detection settings
+func (s *Service) UpdateSettings(ctx context.Context, projectID uuid.UUID, req dto.UpdateAnomalySettingsRequest) (*dto.AnomalySettingsResponse, error) {
+	settings, _, err := s.loadSettings(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.Sensitivity != nil {
+		settings.Sensitivity = *req.Sensitivity
+	}
+	if req.MinimumEvents != nil {
+		settings.MinimumEvents = *req.MinimumEvents
+	}
+	if req.DetectSilence != nil {
+		settings.DetectSilence = *req.DetectSilence
+	}
+
+	// Validate business rules
+	if settings.Sensitivity < constants.MinAnomalySensitivity || settings.Sensitivity > constants.MaxAnomalySensitivity {
+		return nil, errors.NewValidationError(fmt.Sprintf("Sensitivity must be between %g and %g standard deviations",
+			constants.MinAnomalySensitivity, constants.MaxAnomalySensitivity))
+	}
+	if settings.MinimumEvents < 0 {
+		return nil, errors.NewValidationError("Minimum events cannot be negative")
+	}
+
+	settings.UpdatedAt = time.Now()
+	if err := s.anomalyRepo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return s.toSettingsResponse(settings, true), nil
+}
+
+// GetAnomalies lists a project's anomalies, newest first
+func (s *Service) GetAnomalies(ctx context.Context, projectID uuid.UUID, status string, limit int) ([]*dto.VolumeAnomalyResponse, error) {
+	if status != "" && status != constants.AnomalyStatusOpen && status != constants.AnomalyStatusResolved {
+		return nil, errors.NewValidationError("Status must be 'open' or 'resolved'")
+	}
+	if limit == 0 {
+		limit = constants.DefaultAnomalyLimit
+	}
+	if limit < 1 || limit > constants.MaxAnomalyLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxAnomalyLimit))
+	}
+
+	anomalies, err := s.anomalyRepo.GetAnomaliesByProject(ctx, projectID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.VolumeAnomalyResponse, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		responses = append(responses, s.toAnomalyResponse(anomaly))
+	}
+
+	return responses, nil
+}
+
+// GetBaselines lists a project's learned baselines, optionally for one level
+func (s *Service) GetBaselines(ctx context.Context, projectID uuid.UUID, level string) ([]*dto.VolumeBaselineResponse, error) {
+	baselines, err := s.anomalyRepo.GetBaselinesByProject(ctx, projectID, strings.ToLower(strings.TrimSpace(level)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.VolumeBaselineResponse, 0, len(baselines))
+	for _, baseline := range baselines {
+		responses = append(responses, &dto.VolumeBaselineResponse{
+			Level:      baseline.Level,
+			HourOfWeek: baseline.HourOfWeek,
+			Mean:       baseline.Mean,
+			StdDev:     math.Sqrt(baseline.Variance),
+			Samples:    baseline.Samples,
+			Ready:      baseline.Samples >= constants.AnomalyMinBaselineSamples,
+			LastHour:   baseline.LastHour,
+		})
+	}
+
+	return responses, nil
+}
+
+// loadSettings retrieves a project's settings, falling back to the defaults
+// It reports whether the project has customized its settings
+func (s *Service) loadSettings(ctx context.Context, projectID uuid.UUID) (*models.AnomalySettings, bool, error) {
+	settings, err := s.anomalyRepo.GetSettings(ctx, projectID)
+	if errors.IsNotFoundError(err) {
+		return defaultSettings(projectID), false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return settings, true, nil
+}
+
+// defaultSettings returns the settings used by projects that have not customized them
+func defaultSettings(projectID uuid.UUID) *models.AnomalySettings {
+	return &models.AnomalySettings{
+		ProjectID:     projectID,
+		Enabled:       true,
+		Sensitivity:   constants.DefaultAnomalySensitivity,
+		MinimumEvents: constants.DefaultAnomalyMinimumEvents,
+		DetectSilence: true,
+	}
+}
+
+// toSettingsResponse converts anomaly settings to response DTO
+func (s *Service) toSettingsResponse(settings *models.AnomalySettings, customized bool) *dto.AnomalySettingsResponse {
+	response := &dto.AnomalySettingsResponse{
+		ProjectID:     settings.ProjectID,
+		Enabled:       settings.Enabled,
+		Sensitivity:   settings.Sensitivity,
+		MinimumEvents: settings.MinimumEvents,
+		DetectSilence: settings.DetectSilence,
+	}
+	if customized {
+		response.UpdatedAt = &settings.UpdatedAt
+	}
+	return response
+}
+
+// toAnomalyResponse converts an anomaly model to response DTO
+func (s *Service) toAnomalyResponse(anomaly *models.VolumeAnomaly) *dto.VolumeAnomalyResponse {
+	status := constants.AnomalyStatusOpen
+	if anomaly.ResolvedAt != nil {
+		status = constants.AnomalyStatusResolved
+	}
+
+	return &dto.VolumeAnomalyResponse{
+		ID:         anomaly.ID,
+		ProjectID:  anomaly.ProjectID,
+		Level:      anomaly.Level,
+		Kind:       anomaly.Kind,
+		Status:     status,
+		Observed:   anomaly.Observed,
+		Expected:   anomaly.Expected,
+		Score:      anomaly.Score,
+		StartedAt:  anomaly.StartedAt,
+		LastSeenAt: anomaly.LastSeenAt,
+		ResolvedAt: anomaly.ResolvedAt,
+	}
+}
@@ -0,0 +1,382 @@
+package anomaly
+
+import (
+	"context"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	anomalyRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/anomaly"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
+)
+
+// Default detector settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval   = 5 * time.Minute
+	defaultIngestionDelay = 10 * time.Minute
+)
+
+// DetectorConfig controls how often event rates are checked against their baselines
+type DetectorConfig struct {
+	// TickInterval is how often live rates are compared with the baselines
+	TickInterval time.Duration
+
+	// IngestionDelay is how long after an hour ends its counters are considered final
+	// and the hour is learned into the baselines
+	IngestionDelay time.Duration
+}
+
+// DetectorConfigFromEnv reads ANOMALY_TICK_INTERVAL and ANOMALY_INGESTION_DELAY
+func DetectorConfigFromEnv() DetectorConfig {
+	return DetectorConfig{
+		TickInterval:   envDuration("ANOMALY_TICK_INTERVAL", defaultTickInterval),
+		IngestionDelay: envDuration("ANOMALY_INGESTION_DELAY", defaultIngestionDelay),
+	}
+}
+
+// envDuration reads a duration such as "5m" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// seriesKey identifies a project's event series; an empty level is the project's total
+type seriesKey struct {
+	projectID uuid.UUID
+	level     string
+}
+
+// Detector learns hour-of-week baselines from the hourly usage counters and compares the
+// current hour's rate with them, opening and resolving anomalies as rates deviate and recover
+// Baselines and anomalies are written idempotently, so several API instances can run detectors
+type Detector struct {
+	anomalyRepo *anomalyRepo.Repository
+	usageRepo   *usageRepo.Repository
+	config      DetectorConfig
+
+	// lastLearned is the most recent hour this detector learned, to skip redundant work
+	lastLearned time.Time
+}
+
+// NewDetector creates a new anomaly detector
+func NewDetector(anomalyRepo *anomalyRepo.Repository, usageRepo *usageRepo.Repository, config DetectorConfig) *Detector {
+	return &Detector{
+		anomalyRepo: anomalyRepo,
+		usageRepo:   usageRepo,
+		config:      config,
+	}
+}
+
+// Start learns and detects on every tick until the context is cancelled
+func (d *Detector) Start(ctx context.Context) {
+	log.Printf("Anomaly detector started (tick %s, ingestion delay %s)", d.config.TickInterval, d.config.IngestionDelay)
+
+	ticker := time.NewTicker(d.config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Run(ctx, time.Now()); err != nil {
+			log.Printf("Anomaly detector failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run learns the latest completed hour, if not yet learned, and checks the current hour
+func (d *Detector) Run(ctx context.Context, now time.Time) error {
+	now = now.UTC()
+
+	learnHour := now.Add(-d.config.IngestionDelay).Truncate(time.Hour).Add(-time.Hour)
+	if learnHour.After(d.lastLearned) {
+		if err := d.learn(ctx, learnHour); err != nil {
+			return err
+		}
+		d.lastLearned = learnHour
+	}
+
+	return d.detect(ctx, now)
+}
+
+// learn folds one completed hour into the baselines of its hour of the week
+// Series with a baseline but no events in the hour learn a zero, so quiet hours are expected
+func (d *Detector) learn(ctx context.Context, hour time.Time) error {
+	counts, err := d.usageRepo.GetHourCounts(ctx, hour)
+	if err != nil {
+		return err
+	}
+	existing, err := d.anomalyRepo.GetBaselinesForHour(ctx, hourOfWeek(hour))
+	if err != nil {
+		return err
+	}
+
+	observed := make(map[seriesKey]float64, len(counts))
+	for _, count := range counts {
+		observed[seriesKey{count.ProjectID, count.Level}] += float64(count.EventCount)
+	}
+
+	now := time.Now()
+	baselines := make([]*models.VolumeBaseline, 0, len(existing)+len(observed))
+	for _, baseline := range existing {
+		key := seriesKey{baseline.ProjectID, baseline.Level}
+		value := observed[key]
+		delete(observed, key)
+
+		if !baseline.LastHour.Before(hour) {
+			continue // Already learned by another detector
+		}
+		updateBaseline(baseline, value)
+		baseline.LastHour = hour
+		baseline.UpdatedAt = now
+		baselines = append(baselines, baseline)
+	}
+
+	// Series seen for the first time at this hour of the week
+	for key, value := range observed {
+		baseline := &models.VolumeBaseline{
+			ProjectID:  key.projectID,
+			Level:      key.level,
+			HourOfWeek: hourOfWeek(hour),
+			LastHour:   hour,
+			UpdatedAt:  now,
+		}
+		updateBaseline(baseline, value)
+		baselines = append(baselines, baseline)
+	}
+
+	return d.anomalyRepo.SaveBaselines(ctx, baselines)
+}
+
+// detect compares the current hour's counts with the baselines, scaled to the part of the
+// hour that has elapsed, and opens, updates or resolves anomalies accordingly
+func (d *Detector) detect(ctx context.Context, now time.Time) error {
+	hour := now.Truncate(time.Hour)
+	elapsed := now.Sub(hour).Hours()
+
+	// Early in the hour there are too few events to tell a deviation from noise
+	if elapsed < constants.AnomalyMinHourFraction {
+		return nil
+	}
+
+	counts, err := d.usageRepo.GetHourCounts(ctx, hour)
+	if err != nil {
+		return err
+	}
+	baselines, err := d.anomalyRepo.GetBaselinesForHour(ctx, hourOfWeek(hour))
+	if err != nil {
+		return err
+	}
+	allSettings, err := d.anomalyRepo.GetAllSettings(ctx)
+	if err != nil {
+		return err
+	}
+	openAnomalies, err := d.anomalyRepo.GetOpenAnomalies(ctx)
+	if err != nil {
+		return err
+	}
+
+	settingsByProject := make(map[uuid.UUID]*models.AnomalySettings, len(allSettings))
+	for _, settings := range allSettings {
+		settingsByProject[settings.ProjectID] = settings
+	}
+	open := make(map[seriesKey]*models.VolumeAnomaly, len(openAnomalies))
+	for _, anomaly := range openAnomalies {
+		open[seriesKey{anomaly.ProjectID, anomaly.Level}] = anomaly
+	}
+	observed := make(map[seriesKey]int64, len(counts))
+	for _, count := range counts {
+		observed[seriesKey{count.ProjectID, count.Level}] += count.EventCount
+		observed[seriesKey{count.ProjectID, ""}] += count.EventCount
+	}
+
+	// Group the ready baselines by project
+	byProject := make(map[uuid.UUID][]*models.VolumeBaseline)
+	for _, baseline := range baselines {
+		if baseline.Samples >= constants.AnomalyMinBaselineSamples {
+			byProject[baseline.ProjectID] = append(byProject[baseline.ProjectID], baseline)
+		}
+	}
+
+	// A verdict is reached for every series with a ready baseline; a nil verdict means normal
+	verdicts := make(map[seriesKey]*models.VolumeAnomaly)
+	for projectID, projectBaselines := range byProject {
+		settings := settingsByProject[projectID]
+		if settings == nil {
+			settings = defaultSettings(projectID)
+		}
+		if !settings.Enabled {
+			continue
+		}
+
+		var totalMean, totalVariance float64
+		for _, baseline := range projectBaselines {
+			key := seriesKey{projectID, baseline.Level}
+			verdicts[key] = judge(key, observed[key], baseline.Mean, baseline.Variance, elapsed, settings, open[key], now)
+			totalMean += baseline.Mean
+			totalVariance += baseline.Variance
+		}
+
+		if settings.DetectSilence {
+			key := seriesKey{projectID, ""}
+			verdicts[key] = judgeSilence(key, observed[key], totalMean, totalVariance, elapsed, open[key], now)
+
+			// Silence explains every level's drop, so those are not reported separately
+			if verdicts[key] != nil {
+				for _, baseline := range projectBaselines {
+					verdicts[seriesKey{projectID, baseline.Level}] = nil
+				}
+			}
+		}
+	}
+
+	// Open anomalies without a verdict stay open, unless detection was turned off for them
+	for key := range open {
+		if _, ok := verdicts[key]; ok {
+			continue
+		}
+		settings := settingsByProject[key.projectID]
+		if settings != nil && (!settings.Enabled || (key.level == "" && !settings.DetectSilence)) {
+			verdicts[key] = nil
+		}
+	}
+
+	for key, verdict := range verdicts {
+		if err := d.apply(ctx, key, open[key], verdict, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply moves a series from its open anomaly, if any, to the verdict, if any
+func (d *Detector) apply(ctx context.Context, key seriesKey, current *models.VolumeAnomaly, verdict *models.VolumeAnomaly, now time.Time) error {
+	switch {
+	case current == nil && verdict == nil:
+		return nil
+	case current != nil && verdict != nil && current.Kind == verdict.Kind:
+		// Still deviating the same way; keep the strongest deviation on record
+		current.LastSeenAt = now
+		if math.Abs(verdict.Score) >= math.Abs(current.Score) {
+			current.Observed = verdict.Observed
+			current.Expected = verdict.Expected
+			current.Score = verdict.Score
+		}
+		return d.anomalyRepo.UpdateAnomaly(ctx, current)
+	}
+
+	if current != nil {
+		if err := d.anomalyRepo.ResolveAnomaly(ctx, current.ID, now); err != nil {
+			return err
+		}
+		log.Printf("Resolved %s anomaly %s for project %s", current.Kind, current.ID, key.projectID)
+	}
+
+	if verdict != nil {
+		recorded, err := d.anomalyRepo.OpenAnomaly(ctx, verdict)
+		if err != nil {
+			return err
+		}
+		if recorded {
+			log.Printf("Detected %s anomaly %s for project %s (level %q): %d events, expected %.1f, score %.1f",
+				verdict.Kind, verdict.ID, key.projectID, key.level, verdict.Observed, verdict.Expected, verdict.Score)
+		}
+	}
+	return nil
+}
+
+// judge decides whether a level's rate is a spike, a drop or normal
+// An open anomaly is kept until the deviation falls below AnomalyResolveRatio of the
+// sensitivity, so a rate hovering around the threshold does not flap
+func judge(key seriesKey, observed int64, mean, variance, elapsed float64, settings *models.AnomalySettings, current *models.VolumeAnomaly, now time.Time) *models.VolumeAnomaly {
+	expected, score := deviation(observed, mean, variance, elapsed)
+	difference := math.Abs(float64(observed) - expected)
+
+	kind := constants.AnomalyKindSpike
+	if score < 0 {
+		kind = constants.AnomalyKindDrop
+	}
+
+	threshold := settings.Sensitivity
+	if current != nil && current.Kind == kind {
+		threshold *= constants.AnomalyResolveRatio
+	}
+	if math.Abs(score) < threshold || difference < float64(settings.MinimumEvents) {
+		return nil
+	}
+
+	return newAnomaly(key, kind, observed, expected, score, now)
+}
+
+// judgeSilence decides whether a project that usually sends events has gone quiet
+// Silence needs most of the hour to have elapsed and enough expected events to be meaningful
+func judgeSilence(key seriesKey, observed int64, mean, variance, elapsed float64, current *models.VolumeAnomaly, now time.Time) *models.VolumeAnomaly {
+	if observed > 0 {
+		return nil
+	}
+
+	expected, score := deviation(observed, mean, variance, elapsed)
+	if current == nil && (elapsed < constants.AnomalySilenceHourFraction || expected < constants.DefaultAnomalySilenceEvents) {
+		return nil
+	}
+	return newAnomaly(key, constants.AnomalyKindSilence, observed, expected, score, now)
+}
+
+// deviation scales the hourly baseline to the elapsed part of the hour and returns the
+// expected count and how many standard deviations the observed count is from it
+// The standard deviation is at least that of a Poisson process with the expected rate,
+// so perfectly regular baselines do not turn every small wobble into an anomaly
+func deviation(observed int64, mean, variance, elapsed float64) (float64, float64) {
+	expected := mean * elapsed
+	stdDev := math.Max(math.Sqrt(variance*elapsed), math.Max(math.Sqrt(expected), 1))
+	return expected, (float64(observed) - expected) / stdDev
+}
+
+// newAnomaly returns an open anomaly for a series
+func newAnomaly(key seriesKey, kind string, observed int64, expected, score float64, now time.Time) *models.VolumeAnomaly {
+	return &models.VolumeAnomaly{
+		ID:         uuid.New(),
+		ProjectID:  key.projectID,
+		Level:      key.level,
+		Kind:       kind,
+		Observed:   observed,
+		Expected:   expected,
+		Score:      score,
+		StartedAt:  now,
+		LastSeenAt: now,
+	}
+}
+
+// updateBaseline folds one hourly count into an exponentially weighted mean and variance
+// The first samples are weighted equally so a new baseline is not dominated by its first hour
+func updateBaseline(baseline *models.VolumeBaseline, value float64) {
+	alpha := math.Max(constants.AnomalyBaselineSmoothing, 1/float64(baseline.Samples+1))
+	diff := value - baseline.Mean
+	increment := alpha * diff
+	baseline.Mean += increment
+	baseline.Variance = (1 - alpha) * (baseline.Variance + diff*increment)
+	baseline.Samples++
+}
+
+// hourOfWeek returns the hour of the week in UTC, 0 for Sunday 00:00 to 167
+func hourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
@@ -0,0 +1,173 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+)
+
+// approx reports whether two floats are equal up to rounding
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestDeviation(t *testing.T) {
+	tests := []struct {
+		name         string
+		observed     int64
+		mean         float64
+		variance     float64
+		elapsed      float64
+		wantExpected float64
+		wantScore    float64
+	}{
+		{name: "on the baseline", observed: 100, mean: 100, elapsed: 1, wantExpected: 100, wantScore: 0},
+		{name: "poisson floor on a regular baseline", observed: 130, mean: 100, elapsed: 1, wantExpected: 100, wantScore: 3},
+		{name: "baseline variance above the poisson floor", observed: 160, mean: 100, variance: 400, elapsed: 1, wantExpected: 100, wantScore: 3},
+		{name: "scaled to the elapsed part of the hour", observed: 50, mean: 400, elapsed: 0.25, wantExpected: 100, wantScore: -5},
+		{name: "standard deviation of at least one", observed: 5, mean: 0, elapsed: 1, wantExpected: 0, wantScore: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, score := deviation(tt.observed, tt.mean, tt.variance, tt.elapsed)
+			if !approx(expected, tt.wantExpected) || !approx(score, tt.wantScore) {
+				t.Errorf("deviation() = (%v, %v), want (%v, %v)", expected, score, tt.wantExpected, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestJudge(t *testing.T) {
+	key := seriesKey{uuid.New(), "error"}
+	settings := &models.AnomalySettings{ProjectID: key.projectID, Enabled: true, Sensitivity: 3, MinimumEvents: 10}
+	now := time.Now()
+	open := func(kind string) *models.VolumeAnomaly {
+		return &models.VolumeAnomaly{ID: uuid.New(), Kind: kind}
+	}
+
+	// Every case has no variance and a full hour elapsed, so a mean of 100 events gives a
+	// standard deviation of 10 events
+	tests := []struct {
+		name     string
+		observed int64
+		mean     float64
+		current  *models.VolumeAnomaly
+		want     string // kind of the verdict, empty for none
+	}{
+		{name: "spike at the sensitivity", observed: 130, mean: 100, want: constants.AnomalyKindSpike},
+		{name: "just below the sensitivity", observed: 129, mean: 100},
+		{name: "drop", observed: 60, mean: 100, want: constants.AnomalyKindDrop},
+		{name: "deviation smaller than the minimum events", observed: 5, mean: 0},
+		{name: "open spike is kept above half the sensitivity", observed: 116, mean: 100, current: open(constants.AnomalyKindSpike), want: constants.AnomalyKindSpike},
+		{name: "open spike resolves below half the sensitivity", observed: 114, mean: 100, current: open(constants.AnomalyKindSpike)},
+		{name: "open drop does not lower the threshold for spikes", observed: 116, mean: 100, current: open(constants.AnomalyKindDrop)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := judge(key, tt.observed, tt.mean, 0, 1, settings, tt.current, now)
+			got := ""
+			if verdict != nil {
+				got = verdict.Kind
+			}
+			if got != tt.want {
+				t.Fatalf("judge() kind = %q, want %q", got, tt.want)
+			}
+			if verdict != nil && (verdict.ProjectID != key.projectID || verdict.Level != key.level || verdict.Observed != tt.observed) {
+				t.Errorf("judge() = %+v, want project %s, level %q and %d observed", verdict, key.projectID, key.level, tt.observed)
+			}
+		})
+	}
+}
+
+func TestJudgeSilence(t *testing.T) {
+	key := seriesKey{uuid.New(), ""}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		observed int64
+		mean     float64
+		elapsed  float64
+		current  *models.VolumeAnomaly
+		want     bool
+	}{
+		{name: "quiet for most of the hour", observed: 0, mean: 100, elapsed: 0.6, want: true},
+		{name: "some events arrived", observed: 1, mean: 100, elapsed: 0.6},
+		{name: "too early in the hour", observed: 0, mean: 100, elapsed: 0.4},
+		{name: "too few events expected", observed: 0, mean: 30, elapsed: 0.6},
+		{name: "open silence is kept early in the hour", observed: 0, mean: 100, elapsed: 0.4, current: &models.VolumeAnomaly{Kind: constants.AnomalyKindSilence}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := judgeSilence(key, tt.observed, tt.mean, 0, tt.elapsed, tt.current, now)
+			if (verdict != nil) != tt.want {
+				t.Fatalf("judgeSilence() = %+v, want a verdict: %v", verdict, tt.want)
+			}
+			if verdict != nil && verdict.Kind != constants.AnomalyKindSilence {
+				t.Errorf("judgeSilence() kind = %q, want %q", verdict.Kind, constants.AnomalyKindSilence)
+			}
+		})
+	}
+}
+
+func TestUpdateBaseline(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseline     models.VolumeBaseline
+		values       []float64
+		wantMean     float64
+		wantVariance float64
+	}{
+		{name: "first sample", values: []float64{10}, wantMean: 10, wantVariance: 0},
+		{name: "early samples are weighted equally", values: []float64{10, 20, 30}, wantMean: 20, wantVariance: 200.0 / 3},
+		{
+			name:         "later samples are smoothed",
+			baseline:     models.VolumeBaseline{Mean: 100, Samples: 10},
+			values:       []float64{150},
+			wantMean:     110,
+			wantVariance: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := tt.baseline
+			for _, value := range tt.values {
+				updateBaseline(&baseline, value)
+			}
+			if !approx(baseline.Mean, tt.wantMean) || !approx(baseline.Variance, tt.wantVariance) {
+				t.Errorf("baseline = (mean %v, variance %v), want (%v, %v)", baseline.Mean, baseline.Variance, tt.wantMean, tt.wantVariance)
+			}
+			if want := tt.baseline.Samples + len(tt.values); baseline.Samples != want {
+				t.Errorf("samples = %d, want %d", baseline.Samples, want)
+			}
+		})
+	}
+}
+
+func TestHourOfWeek(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{name: "start of the week", at: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), want: 0},
+		{name: "end of the week", at: time.Date(2024, 1, 6, 23, 30, 0, 0, time.UTC), want: 167},
+		{name: "converted to UTC", at: time.Date(2024, 1, 8, 5, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)), want: 27},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hourOfWeek(tt.at); got != tt.want {
+				t.Errorf("hourOfWeek(%s) = %d, want %d", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	archiveRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/archive"
+	searchService "github.com/nihar-hegde/valtro-backend/internal/services/search"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+)
+
+// archivedEvent is a line of an archive file
+// SearchText is left out of an event's JSON, so it is written alongside to keep rehydrated
+// events searchable by their indexed attributes
+type archivedEvent struct {
+	*logstore.Event
+	SearchText string `json:"search_text,omitempty"`
+}
+
+// Service archives expired log events to object storage and loads them back on demand
+// Archives are gzip-compressed NDJSON files, one per project and UTC day, stored under
+// "archives/{projectID}/{YYYY-MM-DD}/". Rehydrated events go into a file store of their own, so
+// retention never deletes them and they disappear together when the rehydration expires
+type Service struct {
+	archiveRepo *archiveRepo.Repository
+	logStore    logstore.LogStore
+	store       storage.Store
+	config      Config
+}
+
+// NewService creates a new archive service
+func NewService(archiveRepo *archiveRepo.Repository, logStore logstore.LogStore, store storage.Store, config Config) *Service {
+	return &Service{
+		archiveRepo: archiveRepo,
+		logStore:    logStore,
+		store:       store,
+		config:      config,
+	}
+}
+
+// ArchiveRange archives a project's events of the given levels in [from, to) before they are deleted
+// Returns the number of events archived. Does nothing when archiving is disabled
+func (s *Service) ArchiveRange(ctx context.Context, projectID uuid.UUID, from, to time.Time, levels []string) (int64, error) {
+	if !s.config.Enabled {
+		return 0, nil
+	}
+
+	query := logstore.Query{
+		ProjectIDs: []uuid.UUID{projectID},
+		From:       from,
+		To:         to,
+		Levels:     levels,
+	}
+
+	var archived int64
+	var file *archiveFile
+	err := s.logStore.Query(ctx, query, func(event *logstore.Event) error {
+		day := event.Timestamp.UTC().Truncate(24 * time.Hour)
+		if file != nil && !file.day.Equal(day) {
+			if err := s.saveArchive(ctx, file, projectID, levels); err != nil {
+				return err
+			}
+			archived += file.events
+			file = nil
+		}
+		if file == nil {
+			var err error
+			if file, err = newArchiveFile(day); err != nil {
+				return err
+			}
+		}
+		return file.write(event)
+	})
+	if err == nil && file != nil {
+		err = s.saveArchive(ctx, file, projectID, levels)
+		if err == nil {
+			archived += file.events
+			file = nil
+		}
+	}
+	if file != nil {
+		file.discard()
+	}
+	if err != nil {
+		return archived, errors.NewInternalError("Failed to archive log events", err.Error())
+	}
+	return archived, nil
+}
+
+// archiveFile is an archive being written to a temporary file
+type archiveFile struct {
+	day     time.Time
+	file    *os.File
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+	first   time.Time
+	last    time.Time
+	events  int64
+}
+
+func newArchiveFile(day time.Time) (*archiveFile, error) {
+	file, err := os.CreateTemp("", "valtro-archive-*")
+	if err != nil {
+		return nil, err
+	}
+	writer := gzip.NewWriter(file)
+	return &archiveFile{day: day, file: file, gzip: writer, encoder: json.NewEncoder(writer)}, nil
+}
+
+// write adds an event; events arrive oldest first
+func (f *archiveFile) write(event *logstore.Event) error {
+	if f.events == 0 {
+		f.first = event.Timestamp
+	}
+	f.last = event.Timestamp
+	f.events++
+	return f.encoder.Encode(archivedEvent{Event: event, SearchText: event.SearchText})
+}
+
+// discard removes the temporary file
+func (f *archiveFile) discard() {
+	f.file.Close()
+	os.Remove(f.file.Name())
+}
+
+// saveArchive uploads a finished archive file and records it
+func (s *Service) saveArchive(ctx context.Context, f *archiveFile, projectID uuid.UUID, levels []string) error {
+	defer f.discard()
+
+	if err := f.gzip.Close(); err != nil {
+		return err
+	}
+	size, err := f.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	archive := &models.LogArchive{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Day:            f.day,
+		FirstTimestamp: f.first,
+		LastTimestamp:  f.last,
+		Levels:         levels,
+		EventCount:     f.events,
+		SizeBytes:      size,
+	}
+	archive.StorageKey = fmt.Sprintf("archives/%s/%s/%s.ndjson.gz", projectID, f.day.Format(time.DateOnly), archive.ID)
+
+	if err := s.store.Put(ctx, archive.StorageKey, f.file, size); err != nil {
+		return err
+	}
+	if err := s.archiveRepo.CreateArchive(ctx, archive); err != nil {
+		// Without its record the file could never be found again
+		s.store.Delete(ctx, archive.StorageKey)
+		return err
+	}
+	return nil
+}
+
+// CreateRehydration queues loading a project's archived events in a time range back for searching
+func (s *Service) CreateRehydration(ctx context.Context, projectID uuid.UUID, createdByID uuid.UUID, req dto.CreateRehydrationRequest) (*dto.RehydrationResponse, error) {
+	if req.From.IsZero() || req.To.IsZero() {
+		return nil, errors.NewValidationError("'from' and 'to' are required")
+	}
+	from, to := req.From.UTC(), req.To.UTC()
+	if !from.Before(to) {
+		return nil, errors.NewValidationError("'from' must be before 'to'")
+	}
+	if to.Sub(from) > constants.MaxRehydrationRangeSeconds*time.Second {
+		return nil, errors.NewValidationError(fmt.Sprintf("The range can be at most %d days", constants.MaxRehydrationRangeSeconds/(24*60*60)))
+	}
+
+	archives, err := s.archiveRepo.GetArchivesInRange(ctx, projectID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) == 0 {
+		return nil, errors.NewValidationError("No archived logs in this range")
+	}
+
+	rehydration := &models.LogRehydration{
+		ProjectID:   projectID,
+		CreatedByID: &createdByID,
+		From:        from,
+		To:          to,
+		Status:      constants.RehydrationStatusPending,
+	}
+	if err := s.archiveRepo.CreateRehydration(ctx, rehydration); err != nil {
+		return nil, err
+	}
+
+	return s.toRehydrationResponse(rehydration, time.Now()), nil
+}
+
+// GetRehydrations retrieves a project's most recent rehydrations, newest first
+func (s *Service) GetRehydrations(ctx context.Context, projectID uuid.UUID, limit int) ([]*dto.RehydrationResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultRehydrationLimit
+	}
+	if limit < 1 || limit > constants.MaxRehydrationLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxRehydrationLimit))
+	}
+
+	rehydrations, err := s.archiveRepo.GetRehydrationsByProjectID(ctx, projectID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*dto.RehydrationResponse, len(rehydrations))
+	for i, rehydration := range rehydrations {
+		responses[i] = s.toRehydrationResponse(rehydration, now)
+	}
+	return responses, nil
+}
+
+// GetRehydration retrieves a rehydration of a project
+func (s *Service) GetRehydration(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.RehydrationResponse, error) {
+	rehydration, err := s.archiveRepo.GetRehydrationByID(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toRehydrationResponse(rehydration, time.Now()), nil
+}
+
+// SearchRehydration searches the events a rehydration loaded, like a regular log search
+// Without from and to the whole rehydrated range is searched. Fails with a conflict error while
+// the events are still loading, and a gone error once they expired
+func (s *Service) SearchRehydration(ctx context.Context, id uuid.UUID, projectID uuid.UUID, params dto.LogSearchParams) (*dto.LogSearchResponse, error) {
+	rehydration, err := s.archiveRepo.GetRehydrationByID(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rehydration.Status == constants.RehydrationStatusExpired || (rehydration.ExpiresAt != nil && !time.Now().Before(*rehydration.ExpiresAt)) {
+		return nil, errors.NewGoneError("Rehydration has expired", "Rehydrated logs are removed once they expire; rehydrate the range again")
+	}
+	if rehydration.Status != constants.RehydrationStatusReady {
+		return nil, errors.NewConflictError("Rehydration is not ready", "Rehydration is "+rehydration.Status)
+	}
+
+	if params.From == nil {
+		params.From = &rehydration.From
+	}
+	if params.To == nil {
+		params.To = &rehydration.To
+	}
+
+	store, err := logstore.NewFileStore(s.rehydrationDir(rehydration.ID))
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to open rehydrated logs", err.Error())
+	}
+	// The rehydrated range is already capped, so plan limits do not apply
+	return searchService.NewService(store, nil, searchService.ConfigFromEnv()).SearchLogs(ctx, projectID, params)
+}
+
+// rehydrationDir returns the directory a rehydration's events are loaded into
+func (s *Service) rehydrationDir(id uuid.UUID) string {
+	return filepath.Join(s.config.RehydrationDir, id.String())
+}
+
+// rehydrateBatchSize is how many events are appended to a rehydration's store at a time
+const rehydrateBatchSize = 1000
+
+// runRehydration loads the archived events of a claimed rehydration into its own file store
+// Archives overlapping a retried deletion can hold the same event twice, so events are
+// deduplicated by ID. Returns the number of events loaded
+func (s *Service) runRehydration(ctx context.Context, rehydration *models.LogRehydration) (int64, error) {
+	archives, err := s.archiveRepo.GetArchivesInRange(ctx, rehydration.ProjectID, rehydration.From, rehydration.To)
+	if err != nil {
+		return 0, err
+	}
+
+	// Start from an empty store, in case an earlier attempt left events behind
+	dir := s.rehydrationDir(rehydration.ID)
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, err
+	}
+	store, err := logstore.NewFileStore(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	batch := make([]*logstore.Event, 0, rehydrateBatchSize)
+	for _, archive := range archives {
+		err := s.readArchive(ctx, archive, func(event *logstore.Event) error {
+			if event.Timestamp.Before(rehydration.From) || !event.Timestamp.Before(rehydration.To) || seen[event.ID] {
+				return nil
+			}
+			if len(seen) >= constants.MaxRehydratedEvents {
+				return fmt.Errorf("the range holds more than %d events; rehydrate a shorter range", constants.MaxRehydratedEvents)
+			}
+			seen[event.ID] = true
+
+			batch = append(batch, event)
+			if len(batch) < rehydrateBatchSize {
+				return nil
+			}
+			err := store.Append(ctx, batch)
+			batch = batch[:0]
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(batch) > 0 {
+		if err := store.Append(ctx, batch); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(seen)), nil
+}
+
+// readArchive streams the events of an archive file to fn
+func (s *Service) readArchive(ctx context.Context, archive *models.LogArchive, fn func(*logstore.Event) error) error {
+	body, err := s.store.Get(ctx, archive.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	for {
+		line := archivedEvent{Event: &logstore.Event{}}
+		if err := decoder.Decode(&line); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read archive %s: %w", archive.StorageKey, err)
+		}
+		line.Event.SearchText = line.SearchText
+		if err := fn(line.Event); err != nil {
+			return err
+		}
+	}
+}
+
+// toRehydrationResponse converts a rehydration to its API representation
+func (s *Service) toRehydrationResponse(rehydration *models.LogRehydration, now time.Time) *dto.RehydrationResponse {
+	response := &dto.RehydrationResponse{
+		ID:          rehydration.ID,
+		ProjectID:   rehydration.ProjectID,
+		CreatedByID: rehydration.CreatedByID,
+		From:        rehydration.From,
+		To:          rehydration.To,
+		Status:      rehydration.Status,
+		EventCount:  rehydration.EventCount,
+		Error:       rehydration.Error,
+		CompletedAt: rehydration.CompletedAt,
+		ExpiresAt:   rehydration.ExpiresAt,
+		CreatedAt:   rehydration.CreatedAt,
+	}
+	if rehydration.Status == constants.RehydrationStatusReady && rehydration.ExpiresAt != nil && now.Before(*rehydration.ExpiresAt) {
+		response.SearchURL = fmt.Sprintf("/api/v1/projects/%s/logs/rehydrations/%s/search", rehydration.ProjectID, rehydration.ID)
+	}
+	return response
+}
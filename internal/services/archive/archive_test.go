@@ -0,0 +1,88 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{store: store}
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	patternID := uuid.New()
+	events := []*logstore.Event{
+		{
+			ID:         uuid.New(),
+			ProjectID:  uuid.New(),
+			Timestamp:  day.Add(time.Hour),
+			ReceivedAt: day.Add(time.Hour + time.Second),
+			Level:      "error",
+			Message:    "payment failed",
+			Host:       "web-1",
+			PatternID:  &patternID,
+			Attributes: map[string]string{"order_id": "A-17"},
+			SearchText: "A-17",
+		},
+		{
+			ID:         uuid.New(),
+			ProjectID:  uuid.New(),
+			Timestamp:  day.Add(2 * time.Hour),
+			ReceivedAt: day.Add(2 * time.Hour),
+			Level:      "info",
+			Message:    "retrying",
+		},
+	}
+
+	file, err := newArchiveFile(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.discard()
+	for _, event := range events {
+		if err := file.write(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.gzip.Close(); err != nil {
+		t.Fatal(err)
+	}
+	size, err := file.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.file.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, "archive.ndjson.gz", file.file, size); err != nil {
+		t.Fatal(err)
+	}
+
+	if !file.first.Equal(events[0].Timestamp) || !file.last.Equal(events[1].Timestamp) || file.events != 2 {
+		t.Errorf("archive covers %s to %s with %d events", file.first, file.last, file.events)
+	}
+
+	var read []*logstore.Event
+	err = s.readArchive(ctx, &models.LogArchive{StorageKey: "archive.ndjson.gz"}, func(event *logstore.Event) error {
+		read = append(read, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, events) {
+		t.Errorf("read back %+v, want %+v", read, events)
+	}
+}
@@ -0,0 +1,80 @@
+package archive
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval   = 10 * time.Second
+	defaultLease          = time.Hour
+	defaultRehydrationTTL = 72 * time.Hour
+	defaultRehydrationDir = "./data/rehydrated"
+)
+
+// Config controls whether expired log events are archived, and how rehydrations are run and kept
+type Config struct {
+	// Enabled archives expired events to object storage before retention deletes them
+	// When false, retention deletes them without a copy
+	Enabled bool
+
+	// TickInterval is how often the worker looks for queued and expired rehydrations
+	TickInterval time.Duration
+
+	// Lease is how long a running rehydration is hidden from other workers; one still running
+	// when its lease expires is assumed dead and started again
+	Lease time.Duration
+
+	// RehydrationTTL is how long rehydrated events can be searched before they are removed
+	RehydrationTTL time.Duration
+
+	// RehydrationDir is the local directory rehydrated events are loaded into, one file store
+	// per rehydration. API instances searching rehydrations must share it with the worker
+	RehydrationDir string
+}
+
+// ConfigFromEnv reads LOG_ARCHIVE_ENABLED, REHYDRATION_TICK_INTERVAL, REHYDRATION_LEASE,
+// REHYDRATION_TTL and REHYDRATION_PATH
+func ConfigFromEnv() Config {
+	config := Config{
+		Enabled:        true,
+		TickInterval:   envDuration("REHYDRATION_TICK_INTERVAL", defaultTickInterval),
+		Lease:          envDuration("REHYDRATION_LEASE", defaultLease),
+		RehydrationTTL: envDuration("REHYDRATION_TTL", defaultRehydrationTTL),
+		RehydrationDir: os.Getenv("REHYDRATION_PATH"),
+	}
+
+	if value := os.Getenv("LOG_ARCHIVE_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Invalid LOG_ARCHIVE_ENABLED %q, using default of true", value)
+		} else {
+			config.Enabled = enabled
+		}
+	}
+
+	if config.RehydrationDir == "" {
+		config.RehydrationDir = defaultRehydrationDir
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "72h" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,164 @@
+package archive
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	archiveRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/archive"
+)
+
+// expireBatchSize caps how many expired rehydrations are removed per tick
+const expireBatchSize = 100
+
+// Worker loads queued rehydrations and removes rehydrated events past their expiry
+// Rehydrations are claimed with row locks, so several API instances can run workers side by side
+type Worker struct {
+	archiveService *Service
+	archiveRepo    *archiveRepo.Repository
+}
+
+// NewWorker creates a new rehydration worker
+func NewWorker(archiveService *Service, archiveRepo *archiveRepo.Repository) *Worker {
+	return &Worker{
+		archiveService: archiveService,
+		archiveRepo:    archiveRepo,
+	}
+}
+
+// Start loads queued rehydrations and expires old ones on every tick until the context is cancelled
+func (w *Worker) Start(ctx context.Context) {
+	config := w.archiveService.config
+	log.Printf("Rehydration worker started (tick %s, rehydrations kept %s, lease %s)", config.TickInterval, config.RehydrationTTL, config.Lease)
+
+	ticker := time.NewTicker(config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunPending(ctx); err != nil {
+			log.Printf("Rehydration worker failed: %v", err)
+		}
+		if err := w.ExpireRehydrations(ctx, time.Now()); err != nil {
+			log.Printf("Failed to expire rehydrations: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunPending loads queued rehydrations one at a time until none is left
+func (w *Worker) RunPending(ctx context.Context) error {
+	for {
+		rehydration, err := w.archiveRepo.ClaimNextRehydration(ctx, time.Now(), w.archiveService.config.Lease)
+		if err != nil {
+			return err
+		}
+		if rehydration == nil {
+			return nil
+		}
+		w.run(ctx, rehydration)
+	}
+}
+
+// run loads a claimed rehydration and records the outcome
+// A failed rehydration is queued again until it has used MaxRehydrationAttempts attempts
+func (w *Worker) run(ctx context.Context, rehydration *models.LogRehydration) {
+	events, err := w.archiveService.runRehydration(ctx, rehydration)
+
+	now := time.Now()
+	rehydration.LeaseExpiresAt = nil
+	switch {
+	case err == nil:
+		expiresAt := now.Add(w.archiveService.config.RehydrationTTL)
+		rehydration.Status = constants.RehydrationStatusReady
+		rehydration.EventCount = events
+		rehydration.Error = ""
+		rehydration.CompletedAt = &now
+		rehydration.ExpiresAt = &expiresAt
+	case rehydration.Attempts >= constants.MaxRehydrationAttempts:
+		log.Printf("Rehydration %s failed after %d attempts: %v", rehydration.ID, rehydration.Attempts, err)
+		rehydration.Status = constants.RehydrationStatusFailed
+		rehydration.Error = err.Error()
+		rehydration.CompletedAt = &now
+		w.removeEvents(rehydration.ID)
+	default:
+		log.Printf("Rehydration %s failed, will retry: %v", rehydration.ID, err)
+		rehydration.Status = constants.RehydrationStatusPending
+		rehydration.Error = err.Error()
+	}
+
+	if err := w.archiveRepo.UpdateRehydration(ctx, rehydration); err != nil {
+		log.Printf("Failed to update rehydration %s: %v", rehydration.ID, err)
+	}
+}
+
+// ExpireRehydrations removes the events of rehydrations past their expiry and marks them expired
+// Directories left by rehydrations that no longer exist, such as those of purged projects, are
+// removed too
+func (w *Worker) ExpireRehydrations(ctx context.Context, now time.Time) error {
+	rehydrations, err := w.archiveRepo.GetExpiredRehydrations(ctx, now, expireBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, rehydration := range rehydrations {
+		if !w.removeEvents(rehydration.ID) {
+			continue
+		}
+		rehydration.Status = constants.RehydrationStatusExpired
+		if err := w.archiveRepo.UpdateRehydration(ctx, rehydration); err != nil {
+			return err
+		}
+	}
+	if len(rehydrations) > 0 {
+		log.Printf("Expired %d rehydrations", len(rehydrations))
+	}
+
+	return w.removeOrphans(ctx)
+}
+
+// removeOrphans removes rehydration directories whose rehydration is gone, failed or expired
+func (w *Worker) removeOrphans(ctx context.Context) error {
+	entries, err := os.ReadDir(w.archiveService.config.RehydrationDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		if id, err := uuid.Parse(entry.Name()); err == nil && entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+
+	live, err := w.archiveRepo.GetLiveRehydrationIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !live[id] {
+			w.removeEvents(id)
+		}
+	}
+	return nil
+}
+
+// removeEvents deletes a rehydration's loaded events, reporting whether it succeeded
+func (w *Worker) removeEvents(id uuid.UUID) bool {
+	if err := os.RemoveAll(w.archiveService.rehydrationDir(id)); err != nil {
+		log.Printf("Failed to remove events of rehydration %s: %v", id, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,332 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	artifactRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/artifact"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/sourcemap"
+)
+
+// urlPrefixPattern matches the scheme and host of frame file URLs
+var urlPrefixPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://[^/]*`)
+
+// Service handles release artifact uploads and stack trace symbolication
+type Service struct {
+	artifactRepo *artifactRepo.Repository
+	store        storage.Store
+
+	// Parsed source maps keyed by storage key
+	// Every upload gets a new key, so replaced artifacts are never served stale
+	mu    sync.Mutex
+	cache map[string]*sourcemap.Map
+}
+
+// NewService creates a new release artifact service
+func NewService(artifactRepo *artifactRepo.Repository, store storage.Store) *Service {
+	return &Service{
+		artifactRepo: artifactRepo,
+		store:        store,
+		cache:        make(map[string]*sourcemap.Map),
+	}
+}
+
+// UploadArtifact stores a source map for a release, replacing any artifact with the same name
+func (s *Service) UploadArtifact(ctx context.Context, projectID uuid.UUID, release string, name string, content []byte, userID uuid.UUID) (*dto.ReleaseArtifactResponse, error) {
+	release = strings.TrimSpace(release)
+	name = ArtifactName(strings.TrimSpace(name))
+
+	// Validate business rules
+	if err := s.validateUpload(release, name); err != nil {
+		return nil, err
+	}
+	if _, err := sourcemap.Parse(content); err != nil {
+		return nil, errors.NewValidationError("Artifact is not a valid source map", err.Error())
+	}
+
+	existing, err := s.artifactRepo.GetByName(ctx, projectID, release, name)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	// Store the contents under a fresh key before touching the record,
+	// so a failed upload never leaves the record pointing at missing data
+	sum := sha256.Sum256(content)
+	storageKey := fmt.Sprintf("artifacts/%s/%s", projectID, uuid.New())
+	if err := s.store.Put(ctx, storageKey, bytes.NewReader(content), int64(len(content))); err != nil {
+		return nil, errors.NewInternalError("Failed to store release artifact", err.Error())
+	}
+
+	artifact := &models.ReleaseArtifact{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		Release:     release,
+		Name:        name,
+		StorageKey:  storageKey,
+		Size:        int64(len(content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		CreatedByID: userID,
+		CreatedAt:   time.Now(),
+	}
+
+	if existing != nil {
+		artifact.ID = existing.ID
+		err = s.artifactRepo.Update(ctx, artifact)
+	} else {
+		err = s.artifactRepo.Create(ctx, artifact)
+	}
+	if err != nil {
+		s.deleteObject(ctx, storageKey)
+		return nil, err
+	}
+
+	// The replaced contents are no longer referenced
+	if existing != nil {
+		s.deleteObject(ctx, existing.StorageKey)
+	}
+
+	return s.toReleaseArtifactResponse(artifact), nil
+}
+
+// GetArtifactsByRelease lists the artifacts uploaded for a release
+func (s *Service) GetArtifactsByRelease(ctx context.Context, projectID uuid.UUID, release string) ([]*dto.ReleaseArtifactResponse, error) {
+	artifacts, err := s.artifactRepo.GetByRelease(ctx, projectID, strings.TrimSpace(release))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.ReleaseArtifactResponse, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		responses = append(responses, s.toReleaseArtifactResponse(artifact))
+	}
+
+	return responses, nil
+}
+
+// DeleteArtifact deletes a release artifact and its stored contents
+func (s *Service) DeleteArtifact(ctx context.Context, id uuid.UUID, projectID uuid.UUID, release string) error {
+	artifact, err := s.artifactRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Artifacts of other projects or releases are reported as missing
+	if artifact.ProjectID != projectID || artifact.Release != strings.TrimSpace(release) {
+		return errors.NewNotFoundError("Release artifact", id.String())
+	}
+
+	if err := s.artifactRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.deleteObject(ctx, artifact.StorageKey)
+
+	return nil
+}
+
+// SymbolicateException maps minified JavaScript frames back to their original source
+// using the source maps uploaded for the release, adding the surrounding source lines
+// The ingestion pipeline calls this before grouping an event, and issue details call it
+// again for samples stored before their source maps were uploaded
+// Frames without a matching source map are left unchanged
+func (s *Service) SymbolicateException(ctx context.Context, projectID uuid.UUID, release string, exception *dto.Exception) {
+	if exception == nil || release == "" {
+		return
+	}
+
+	// Each source map is looked up at most once per exception
+	maps := make(map[string]*sourcemap.Map)
+
+	for i := range exception.Frames {
+		frame := &exception.Frames[i]
+		if frame.Symbolicated || frame.Line <= 0 {
+			continue
+		}
+
+		file := ArtifactName(frame.File)
+		if !strings.HasSuffix(file, ".js") {
+			continue
+		}
+
+		name := file + ".map"
+		m, seen := maps[name]
+		if !seen {
+			var err error
+			m, err = s.loadSourceMap(ctx, projectID, release, name)
+			if err != nil {
+				log.Printf("Failed to load source map %s for release %s of project %s: %v", name, release, projectID, err)
+			}
+			maps[name] = m
+		}
+		if m != nil {
+			s.symbolicateFrame(m, frame)
+		}
+	}
+}
+
+// symbolicateFrame rewrites one frame to its original position
+// SDKs report 1-based lines and columns while source maps are 0-based
+func (s *Service) symbolicateFrame(m *sourcemap.Map, frame *dto.StackFrame) {
+	column := frame.Column - 1
+	if column < 0 {
+		column = 0
+	}
+
+	position, ok := m.Lookup(frame.Line-1, column)
+	if !ok {
+		return
+	}
+
+	frame.File = position.Source
+	frame.Line = position.Line + 1
+	frame.Column = position.Column + 1
+	if position.Name != "" {
+		frame.Function = position.Name
+	}
+	if strings.Contains(position.Source, "/node_modules/") {
+		frame.InApp = false
+	}
+	frame.Symbolicated = true
+
+	// Add source context when the map embeds the original source
+	lines, ok := m.SourceLines(position.Source)
+	if !ok || position.Line >= len(lines) {
+		return
+	}
+	start := max(position.Line-constants.SourceContextLines, 0)
+	end := min(position.Line+1+constants.SourceContextLines, len(lines))
+	frame.PreContext = trimLines(lines[start:position.Line])
+	frame.ContextLine = strings.TrimRight(lines[position.Line], "\r")
+	frame.PostContext = trimLines(lines[position.Line+1 : end])
+}
+
+// loadSourceMap returns the parsed source map with the given name, or nil if none was uploaded
+func (s *Service) loadSourceMap(ctx context.Context, projectID uuid.UUID, release string, name string) (*sourcemap.Map, error) {
+	artifact, err := s.artifactRepo.GetByName(ctx, projectID, release, name)
+	if errors.IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	m, ok := s.cache[artifact.StorageKey]
+	s.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+
+	body, err := s.store.Get(ctx, artifact.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	m, err = sourcemap.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= constants.MaxCachedSourceMaps {
+		// Evict an arbitrary entry; maps are cheap to reload
+		for key := range s.cache {
+			delete(s.cache, key)
+			break
+		}
+	}
+	s.cache[artifact.StorageKey] = m
+
+	return m, nil
+}
+
+// deleteObject removes stored contents that are no longer referenced
+// Failures only leak storage, so they are logged rather than returned
+func (s *Service) deleteObject(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("Failed to delete stored artifact %s: %v", key, err)
+	}
+}
+
+// validateUpload validates the release and normalized name of an upload
+func (s *Service) validateUpload(release string, name string) error {
+	if release == "" {
+		return errors.NewValidationError("Release is required")
+	}
+	if len(release) > constants.MaxReleaseLength {
+		return errors.NewValidationError(fmt.Sprintf("Release must be at most %d characters", constants.MaxReleaseLength))
+	}
+	if name == "~/" {
+		return errors.NewValidationError("Artifact name is required")
+	}
+	if len(name) > constants.MaxArtifactNameLength {
+		return errors.NewValidationError(fmt.Sprintf("Artifact name must be at most %d characters", constants.MaxArtifactNameLength))
+	}
+	if !strings.HasSuffix(name, constants.SourceMapExtension) {
+		return errors.NewValidationError("Only JavaScript source maps ('" + constants.SourceMapExtension + "' files) can be uploaded")
+	}
+	return nil
+}
+
+// ArtifactName normalizes a file URL or path to an artifact name
+// The scheme and host become "~" and the query string and fragment are dropped,
+// so "https://cdn.example.com/static/app.js?v=3" becomes "~/static/app.js"
+func ArtifactName(file string) string {
+	if i := strings.IndexAny(file, "?#"); i >= 0 {
+		file = file[:i]
+	}
+	file = urlPrefixPattern.ReplaceAllString(file, "~")
+
+	switch {
+	case strings.HasPrefix(file, "~/"):
+		return file
+	case strings.HasPrefix(file, "/"):
+		return "~" + file
+	default:
+		return "~/" + file
+	}
+}
+
+// trimLines strips carriage returns left over from CRLF sources
+func trimLines(lines []string) []string {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, "\r")
+	}
+	return trimmed
+}
+
+// toReleaseArtifactResponse converts a release artifact model to response DTO
+func (s *Service) toReleaseArtifactResponse(artifact *models.ReleaseArtifact) *dto.ReleaseArtifactResponse {
+	return &dto.ReleaseArtifactResponse{
+		ID:          artifact.ID,
+		ProjectID:   artifact.ProjectID,
+		Release:     artifact.Release,
+		Name:        artifact.Name,
+		Size:        artifact.Size,
+		SHA256:      artifact.SHA256,
+		CreatedByID: artifact.CreatedByID,
+		CreatedAt:   artifact.CreatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// Default settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval = 10 * time.Second
+	defaultFileTTL      = 24 * time.Hour
+	defaultLease        = time.Hour
+)
+
+// Config controls how background exports are run and how long their files are kept
+type Config struct {
+	// TickInterval is how often the worker looks for queued exports and expired files
+	TickInterval time.Duration
+
+	// FileTTL is how long a finished export can be downloaded before its file is deleted
+	FileTTL time.Duration
+
+	// Lease is how long a running export is hidden from other workers; a job still running
+	// when its lease expires is assumed dead and started again
+	Lease time.Duration
+}
+
+// ConfigFromEnv reads EXPORT_TICK_INTERVAL, EXPORT_FILE_TTL and EXPORT_LEASE
+func ConfigFromEnv() Config {
+	return Config{
+		TickInterval: envDuration("EXPORT_TICK_INTERVAL", defaultTickInterval),
+		FileTTL:      envDuration("EXPORT_FILE_TTL", defaultFileTTL),
+		Lease:        envDuration("EXPORT_LEASE", defaultLease),
+	}
+}
+
+// envDuration reads a duration such as "24h" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,328 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	exportRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/export"
+	searchService "github.com/nihar-hegde/valtro-backend/internal/services/search"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+)
+
+// formats maps each export format to its file extension and content type
+var formats = map[string]struct {
+	extension   string
+	contentType string
+}{
+	constants.ExportFormatCSV:     {extension: "csv", contentType: "text/csv; charset=utf-8"},
+	constants.ExportFormatNDJSON:  {extension: "ndjson", contentType: "application/x-ndjson"},
+	constants.ExportFormatParquet: {extension: "parquet", contentType: "application/vnd.apache.parquet"},
+}
+
+// Service handles log exports
+// Small exports are streamed straight to the client; large ones run as background jobs whose
+// files are kept in object storage until they expire. Exports are checked against the search
+// guardrails (plan range cap and cost estimate) before they start
+type Service struct {
+	logStore      logstore.LogStore
+	searchService *searchService.Service
+	exportRepo    *exportRepo.Repository
+	store         storage.Store
+	config        Config
+}
+
+// NewService creates a new export service
+func NewService(logStore logstore.LogStore, searchService *searchService.Service, exportRepo *exportRepo.Repository, store storage.Store, config Config) *Service {
+	return &Service{
+		logStore:      logStore,
+		searchService: searchService,
+		exportRepo:    exportRepo,
+		store:         store,
+		config:        config,
+	}
+}
+
+// Export is a validated log export, ready to be written
+type Export struct {
+	Filename    string
+	ContentType string
+
+	format  string
+	columns []string
+	query   logstore.Query
+}
+
+// PrepareExport validates an export of a project's logs
+// The format defaults to CSV and the row cap to DefaultExportRows; maxRows bounds the cap
+func (s *Service) PrepareExport(ctx context.Context, projectID uuid.UUID, req dto.ExportRequest, maxRows int) (*Export, error) {
+	if req.Format == "" {
+		req.Format = constants.ExportFormatCSV
+	}
+	format, ok := formats[req.Format]
+	if !ok {
+		return nil, errors.NewValidationError("Format must be one of 'csv', 'ndjson' or 'parquet'")
+	}
+
+	columns := req.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		if lookupColumn(name) == nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("Unknown column %q", name))
+		}
+		if seen[name] {
+			return nil, errors.NewValidationError(fmt.Sprintf("Column %q is listed more than once", name))
+		}
+		seen[name] = true
+	}
+
+	query, err := searchService.BuildQuery([]uuid.UUID{projectID}, req.LogSearchParams, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = req.Limit
+	if query.Limit == 0 {
+		query.Limit = constants.DefaultExportRows
+	}
+	if query.Limit < 1 || query.Limit > maxRows {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", maxRows))
+	}
+	if _, err := s.searchService.CheckProjectQuery(ctx, projectID, query); err != nil {
+		return nil, err
+	}
+
+	return &Export{
+		Filename:    fmt.Sprintf("logs-%s-%s.%s", projectID, query.From.Format("20060102T150405Z"), format.extension),
+		ContentType: format.contentType,
+		format:      req.Format,
+		columns:     columns,
+		query:       query,
+	}, nil
+}
+
+// WriteExport streams the export's events to out without holding them in memory
+// Returns the number of events written
+func (s *Service) WriteExport(ctx context.Context, export *Export, out io.Writer) (int64, error) {
+	writer, err := newRowWriter(export.format, out, export.columns)
+	if err != nil {
+		return 0, errors.NewInternalError("Failed to write export", err.Error())
+	}
+
+	var rows int64
+	err = s.logStore.Query(ctx, export.query, func(event *logstore.Event) error {
+		rows++
+		return writer.write(event)
+	})
+	if err == nil {
+		err = writer.close()
+	}
+	if err != nil {
+		return rows, errors.NewInternalError("Failed to write export", err.Error())
+	}
+	return rows, nil
+}
+
+// CreateExportJob queues a background export of a project's logs
+// The time range is resolved now, so "the last 24 hours" means the 24 hours before the request
+func (s *Service) CreateExportJob(ctx context.Context, projectID uuid.UUID, createdByID uuid.UUID, req dto.ExportRequest) (*dto.ExportJobResponse, error) {
+	export, err := s.PrepareExport(ctx, projectID, req, constants.MaxExportJobRows)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.ExportJob{
+		ProjectID:   projectID,
+		CreatedByID: &createdByID,
+		Format:      export.format,
+		Columns:     export.columns,
+		Filter: models.ExportFilter{
+			Query:      req.Query,
+			From:       &export.query.From,
+			To:         &export.query.To,
+			Levels:     export.query.Levels,
+			Fields:     export.query.Fields,
+			PatternID:  export.query.PatternID,
+			Descending: export.query.Descending,
+			Cursor:     req.Cursor,
+		},
+		MaxRows: export.query.Limit,
+		Status:  constants.ExportStatusPending,
+	}
+	if err := s.exportRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return s.toExportJobResponse(job, time.Now()), nil
+}
+
+// GetExportJobs retrieves a project's most recent export jobs, newest first
+func (s *Service) GetExportJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]*dto.ExportJobResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultExportJobLimit
+	}
+	if limit < 1 || limit > constants.MaxExportJobLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxExportJobLimit))
+	}
+
+	jobs, err := s.exportRepo.GetByProjectID(ctx, projectID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*dto.ExportJobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = s.toExportJobResponse(job, now)
+	}
+	return responses, nil
+}
+
+// GetExportJob retrieves an export job of a project
+func (s *Service) GetExportJob(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.ExportJobResponse, error) {
+	job, err := s.exportRepo.GetByID(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toExportJobResponse(job, time.Now()), nil
+}
+
+// Download is a finished export file opened for reading; the caller must close Body
+type Download struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
+
+// OpenExportFile opens the file of a finished export job
+// Fails with a conflict error while the job has not succeeded, and a gone error once the file expired
+func (s *Service) OpenExportFile(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*Download, error) {
+	job, err := s.exportRepo.GetByID(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == constants.ExportStatusExpired || (job.ExpiresAt != nil && !time.Now().Before(*job.ExpiresAt)) {
+		return nil, errors.NewGoneError("Export has expired", "Export files are deleted once they expire; run the export again")
+	}
+	if job.Status != constants.ExportStatusSucceeded {
+		return nil, errors.NewConflictError("Export is not ready", "Export is "+job.Status)
+	}
+
+	body, err := s.store.Get(ctx, job.StorageKey)
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to open export file", err.Error())
+	}
+
+	format := formats[job.Format]
+	return &Download{
+		Filename:    fmt.Sprintf("logs-%s-%s.%s", projectID, job.Filter.From.UTC().Format("20060102T150405Z"), format.extension),
+		ContentType: format.contentType,
+		Size:        job.SizeBytes,
+		Body:        body,
+	}, nil
+}
+
+// runJob writes a claimed job's file to a temporary file, then uploads it to object storage
+// Returns the number of events and bytes written
+func (s *Service) runJob(ctx context.Context, job *models.ExportJob) (int64, int64, error) {
+	export := &Export{
+		format:  job.Format,
+		columns: job.Columns,
+		query: logstore.Query{
+			ProjectIDs: []uuid.UUID{job.ProjectID},
+			Levels:     job.Filter.Levels,
+			Fields:     job.Filter.Fields,
+			PatternID:  job.Filter.PatternID,
+			Text:       job.Filter.Query,
+			Descending: job.Filter.Descending,
+			Limit:      job.MaxRows,
+		},
+	}
+	if job.Filter.From != nil {
+		export.query.From = *job.Filter.From
+	}
+	if job.Filter.To != nil {
+		export.query.To = *job.Filter.To
+	}
+	if job.Filter.Cursor != "" {
+		cursor, err := logstore.ParseCursor(job.Filter.Cursor)
+		if err != nil {
+			return 0, 0, err
+		}
+		export.query.After = cursor
+	}
+
+	file, err := os.CreateTemp("", "valtro-export-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	rows, err := s.WriteExport(ctx, export, file)
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+
+	if err := s.store.Put(ctx, job.StorageKey, file, size); err != nil {
+		return 0, 0, err
+	}
+	return rows, size, nil
+}
+
+// storageKey is where an export job's file is kept
+func storageKey(job *models.ExportJob) string {
+	return fmt.Sprintf("exports/%s/%s.%s", job.ProjectID, job.ID, formats[job.Format].extension)
+}
+
+// toExportJobResponse converts an export job to its API representation
+func (s *Service) toExportJobResponse(job *models.ExportJob, now time.Time) *dto.ExportJobResponse {
+	response := &dto.ExportJobResponse{
+		ID:          job.ID,
+		ProjectID:   job.ProjectID,
+		CreatedByID: job.CreatedByID,
+		Format:      job.Format,
+		Columns:     job.Columns,
+		Filter: dto.LogSearchParams{
+			Query:      job.Filter.Query,
+			From:       job.Filter.From,
+			To:         job.Filter.To,
+			Levels:     job.Filter.Levels,
+			Fields:     job.Filter.Fields,
+			PatternID:  job.Filter.PatternID,
+			Cursor:     job.Filter.Cursor,
+			Descending: job.Filter.Descending,
+		},
+		MaxRows:     job.MaxRows,
+		Status:      job.Status,
+		RowCount:    job.RowCount,
+		SizeBytes:   job.SizeBytes,
+		Error:       job.Error,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+		CreatedAt:   job.CreatedAt,
+	}
+	if job.Status == constants.ExportStatusSucceeded && job.ExpiresAt != nil && now.Before(*job.ExpiresAt) {
+		response.DownloadURL = fmt.Sprintf("/api/v1/projects/%s/logs/exports/%s/download", job.ProjectID, job.ID)
+	}
+	return response
+}
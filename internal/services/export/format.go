@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/parquet"
+)
+
+// attributeColumnPrefix names a column holding one attribute, e.g. "attr.user_id"
+const attributeColumnPrefix = "attr."
+
+// defaultColumns are exported when no columns are chosen
+var defaultColumns = []string{"timestamp", "level", "host", "service", "message"}
+
+// column reads one exported value from an event
+// Values are strings, except timestamps (time.Time) and the attributes map
+type column func(e *logstore.Event) interface{}
+
+// columns are the columns that can be exported, besides individual attributes
+var columns = map[string]column{
+	"id":          func(e *logstore.Event) interface{} { return e.ID.String() },
+	"project_id":  func(e *logstore.Event) interface{} { return e.ProjectID.String() },
+	"timestamp":   func(e *logstore.Event) interface{} { return e.Timestamp },
+	"received_at": func(e *logstore.Event) interface{} { return e.ReceivedAt },
+	"level":       func(e *logstore.Event) interface{} { return e.Level },
+	"message":     func(e *logstore.Event) interface{} { return e.Message },
+	"host":        func(e *logstore.Event) interface{} { return e.Host },
+	"service":     func(e *logstore.Event) interface{} { return e.Service },
+	"stream":      func(e *logstore.Event) interface{} { return e.Stream },
+	"release":     func(e *logstore.Event) interface{} { return e.Release },
+	"pattern_id": func(e *logstore.Event) interface{} {
+		if e.PatternID == nil {
+			return ""
+		}
+		return e.PatternID.String()
+	},
+	"attributes": func(e *logstore.Event) interface{} {
+		if e.Attributes == nil {
+			return map[string]string{}
+		}
+		return e.Attributes
+	},
+}
+
+// lookupColumn returns how to read a column, or nil if there is no such column
+func lookupColumn(name string) column {
+	if attribute, ok := strings.CutPrefix(name, attributeColumnPrefix); ok && attribute != "" {
+		return func(e *logstore.Event) interface{} { return e.Attributes[attribute] }
+	}
+	return columns[name]
+}
+
+// rowWriter writes exported events in one file format
+type rowWriter interface {
+	write(e *logstore.Event) error
+	close() error
+}
+
+// newRowWriter creates a writer for the format; the format and columns must be valid
+func newRowWriter(format string, out io.Writer, names []string) (rowWriter, error) {
+	selected := make([]column, len(names))
+	for i, name := range names {
+		selected[i] = lookupColumn(name)
+	}
+
+	switch format {
+	case constants.ExportFormatCSV:
+		writer := csv.NewWriter(out)
+		if err := writer.Write(names); err != nil {
+			return nil, err
+		}
+		return &csvWriter{writer: writer, columns: selected, record: make([]string, len(names))}, nil
+	case constants.ExportFormatNDJSON:
+		keys := make([][]byte, len(names))
+		for i, name := range names {
+			keys[i], _ = json.Marshal(name)
+		}
+		return &ndjsonWriter{out: out, keys: keys, columns: selected}, nil
+	default:
+		schema := make([]parquet.Column, len(names))
+		for i, name := range names {
+			schema[i] = parquet.Column{Name: name, Type: parquet.String}
+			if name == "timestamp" || name == "received_at" {
+				schema[i].Type = parquet.Timestamp
+			}
+		}
+		return &parquetWriter{writer: parquet.NewWriter(out, schema, 0), columns: selected, row: make([]interface{}, len(names))}, nil
+	}
+}
+
+// text formats a value for formats without nested values
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case map[string]string:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	default:
+		return v.(string)
+	}
+}
+
+// csvWriter writes a header line and one line per event; attributes are a JSON object
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []column
+	record  []string
+}
+
+func (w *csvWriter) write(e *logstore.Event) error {
+	for i, column := range w.columns {
+		w.record[i] = text(column(e))
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ndjsonWriter writes one JSON object per line, with keys in column order
+type ndjsonWriter struct {
+	out     io.Writer
+	keys    [][]byte
+	columns []column
+	line    bytes.Buffer
+}
+
+func (w *ndjsonWriter) write(e *logstore.Event) error {
+	w.line.Reset()
+	w.line.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			w.line.WriteByte(',')
+		}
+		value, err := json.Marshal(column(e))
+		if err != nil {
+			return err
+		}
+		w.line.Write(w.keys[i])
+		w.line.WriteByte(':')
+		w.line.Write(value)
+	}
+	w.line.WriteString("}\n")
+	_, err := w.out.Write(w.line.Bytes())
+	return err
+}
+
+func (w *ndjsonWriter) close() error {
+	return nil
+}
+
+// parquetWriter writes a Parquet file; timestamps are timestamp columns, the rest strings
+type parquetWriter struct {
+	writer  *parquet.Writer
+	columns []column
+	row     []interface{}
+}
+
+func (w *parquetWriter) write(e *logstore.Event) error {
+	for i, column := range w.columns {
+		value := column(e)
+		if _, ok := value.(time.Time); !ok {
+			value = text(value)
+		}
+		w.row[i] = value
+	}
+	return w.writer.Write(w.row)
+}
+
+func (w *parquetWriter) close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+)
+
+func TestRowWriters(t *testing.T) {
+	event := &logstore.Event{
+		ID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Timestamp:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Level:      "error",
+		Message:    `payment "failed", retrying`,
+		Attributes: map[string]string{"user_id": "42"},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		columns []string
+		want    string
+	}{
+		{
+			name:    "csv quotes values and encodes attributes as JSON",
+			format:  constants.ExportFormatCSV,
+			columns: []string{"timestamp", "message", "attributes"},
+			want: "timestamp,message,attributes\n" +
+				`2026-03-01T12:00:00Z,"payment ""failed"", retrying","{""user_id"":""42""}"` + "\n",
+		},
+		{
+			name:    "csv reads single attributes",
+			format:  constants.ExportFormatCSV,
+			columns: []string{"level", "attr.user_id", "attr.missing"},
+			want:    "level,attr.user_id,attr.missing\nerror,42,\n",
+		},
+		{
+			name:    "ndjson keeps column order and nests attributes",
+			format:  constants.ExportFormatNDJSON,
+			columns: []string{"level", "id", "attributes", "pattern_id"},
+			want:    `{"level":"error","id":"00000000-0000-0000-0000-000000000001","attributes":{"user_id":"42"},"pattern_id":""}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer, err := newRowWriter(tt.format, &out, tt.columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.write(event); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
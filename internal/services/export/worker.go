@@ -0,0 +1,126 @@
+package export
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	exportRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/export"
+)
+
+// expireBatchSize caps how many expired files are deleted per tick
+const expireBatchSize = 100
+
+// Worker runs queued export jobs and deletes files past their expiry
+// Jobs are claimed with row locks, so several API instances can run workers side by side
+type Worker struct {
+	exportService *Service
+	exportRepo    *exportRepo.Repository
+}
+
+// NewWorker creates a new export worker
+func NewWorker(exportService *Service, exportRepo *exportRepo.Repository) *Worker {
+	return &Worker{
+		exportService: exportService,
+		exportRepo:    exportRepo,
+	}
+}
+
+// Start runs queued jobs and expires files on every tick until the context is cancelled
+func (w *Worker) Start(ctx context.Context) {
+	config := w.exportService.config
+	log.Printf("Export worker started (tick %s, files kept %s, lease %s)", config.TickInterval, config.FileTTL, config.Lease)
+
+	ticker := time.NewTicker(config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunPending(ctx); err != nil {
+			log.Printf("Export worker failed: %v", err)
+		}
+		if err := w.ExpireFiles(ctx, time.Now()); err != nil {
+			log.Printf("Failed to expire export files: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunPending runs queued jobs one at a time until none is left
+func (w *Worker) RunPending(ctx context.Context) error {
+	for {
+		job, err := w.exportRepo.ClaimNext(ctx, time.Now(), w.exportService.config.Lease)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+		w.run(ctx, job)
+	}
+}
+
+// run runs a claimed job and records the outcome
+// A failed job is queued again until it has used MaxExportJobAttempts attempts
+func (w *Worker) run(ctx context.Context, job *models.ExportJob) {
+	job.StorageKey = storageKey(job)
+	rows, size, err := w.exportService.runJob(ctx, job)
+
+	now := time.Now()
+	job.LeaseExpiresAt = nil
+	switch {
+	case err == nil:
+		expiresAt := now.Add(w.exportService.config.FileTTL)
+		job.Status = constants.ExportStatusSucceeded
+		job.RowCount = rows
+		job.SizeBytes = size
+		job.Error = ""
+		job.CompletedAt = &now
+		job.ExpiresAt = &expiresAt
+	case job.Attempts >= constants.MaxExportJobAttempts:
+		log.Printf("Export job %s failed after %d attempts: %v", job.ID, job.Attempts, err)
+		job.Status = constants.ExportStatusFailed
+		job.StorageKey = ""
+		job.Error = err.Error()
+		job.CompletedAt = &now
+	default:
+		log.Printf("Export job %s failed, will retry: %v", job.ID, err)
+		job.Status = constants.ExportStatusPending
+		job.StorageKey = ""
+		job.Error = err.Error()
+	}
+
+	if err := w.exportRepo.Update(ctx, job); err != nil {
+		log.Printf("Failed to update export job %s: %v", job.ID, err)
+	}
+}
+
+// ExpireFiles deletes the files of finished jobs past their expiry and marks the jobs expired
+func (w *Worker) ExpireFiles(ctx context.Context, now time.Time) error {
+	jobs, err := w.exportRepo.GetExpired(ctx, now, expireBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := w.exportService.store.Delete(ctx, job.StorageKey); err != nil {
+			log.Printf("Failed to delete file of export job %s: %v", job.ID, err)
+			continue
+		}
+		job.Status = constants.ExportStatusExpired
+		job.StorageKey = ""
+		if err := w.exportRepo.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+	if len(jobs) > 0 {
+		log.Printf("Expired %d export files", len(jobs))
+	}
+	return nil
+}
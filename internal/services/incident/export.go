@@ -0,0 +1,121 @@
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+)
+
+// Export is an incident rendered for download
+type Export struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// ExportIncident renders an incident with its evaluations, linked issues and timeline
+// for a post-mortem, as Markdown (the default) or JSON
+func (s *Service) ExportIncident(ctx context.Context, id uuid.UUID, projectID uuid.UUID, format string) (*Export, error) {
+	if format == "" {
+		format = constants.IncidentExportMarkdown
+	}
+	if format != constants.IncidentExportMarkdown && format != constants.IncidentExportJSON {
+		return nil, errors.NewValidationError("Format must be one of 'markdown' or 'json'")
+	}
+
+	incident, err := s.GetIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := "incident-" + incident.ID.String()
+	if format == constants.IncidentExportJSON {
+		content, err := json.MarshalIndent(incident, "", "  ")
+		if err != nil {
+			return nil, errors.NewInternalError("Failed to export incident", err.Error())
+		}
+		return &Export{Filename: filename + ".json", ContentType: "application/json", Content: content}, nil
+	}
+
+	return &Export{Filename: filename + ".md", ContentType: "text/markdown; charset=utf-8", Content: []byte(renderMarkdown(incident))}, nil
+}
+
+// renderMarkdown renders an incident as a Markdown post-mortem draft
+func renderMarkdown(incident *dto.IncidentResponse) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Incident: %s\n\n", escapeMarkdown(incident.Title))
+	fmt.Fprintf(&b, "- **Status:** %s\n", incident.Status)
+	fmt.Fprintf(&b, "- **Project:** %s\n", incident.ProjectID)
+	if incident.AlertRuleID != nil {
+		fmt.Fprintf(&b, "- **Alert rule:** %s\n", *incident.AlertRuleID)
+	}
+	fmt.Fprintf(&b, "- **Started:** %s\n", formatTime(&incident.StartedAt))
+	if incident.AcknowledgedAt != nil {
+		fmt.Fprintf(&b, "- **Acknowledged:** %s by %s (after %s)\n", formatTime(incident.AcknowledgedAt),
+			formatActor(incident.AcknowledgedByID), incident.AcknowledgedAt.Sub(incident.StartedAt).Round(time.Second))
+	}
+	if incident.ResolvedAt != nil {
+		fmt.Fprintf(&b, "- **Resolved:** %s by %s (after %s)\n", formatTime(incident.ResolvedAt),
+			formatActor(incident.ResolvedByID), incident.ResolvedAt.Sub(incident.StartedAt).Round(time.Second))
+	}
+	query := incident.LogQuery.Query
+	if query == "" {
+		query = "all events"
+	}
+	fmt.Fprintf(&b, "- **Pinned log query:** `%s` from %s to %s\n",
+		strings.ReplaceAll(query, "`", "'"), formatTime(&incident.LogQuery.From), formatTime(incident.LogQuery.To))
+
+	if len(incident.Evaluations) > 0 {
+		b.WriteString("\n## Alert evaluations\n\n")
+		b.WriteString("| Evaluated at (UTC) | Window start (UTC) | Value | Threshold | State |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, evaluation := range incident.Evaluations {
+			fmt.Fprintf(&b, "| %s | %s | %s %g | %s %g | %s |\n",
+				formatTime(&evaluation.EvaluatedAt), formatTime(&evaluation.WindowStart),
+				evaluation.Aggregation, evaluation.Value, evaluation.Comparison, evaluation.Threshold, evaluation.State)
+		}
+	}
+
+	if len(incident.Issues) > 0 {
+		b.WriteString("\n## Linked issues\n\n")
+		b.WriteString("| Issue | Level | Status | Events | Last seen (UTC) |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, issue := range incident.Issues {
+			fmt.Fprintf(&b, "| %s (%s) | %s | %s | %d | %s |\n",
+				escapeMarkdown(issue.Title), issue.ID, issue.Level, issue.Status, issue.EventCount, formatTime(&issue.LastSeenAt))
+		}
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	b.WriteString("| Time (UTC) | Event | By | Details |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, entry := range incident.Timeline {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			formatTime(&entry.CreatedAt), entry.Kind, formatActor(entry.ActorID), escapeMarkdown(entry.Message))
+	}
+
+	return b.String()
+}
+
+// formatActor names who recorded a timeline entry
+func formatActor(actorID *uuid.UUID) string {
+	if actorID == nil {
+		return "system"
+	}
+	return actorID.String()
+}
+
+// escapeMarkdown keeps free text from breaking out of a Markdown table cell
+func escapeMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
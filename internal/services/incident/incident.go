@@ -0,0 +1,582 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	incidentRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/incident"
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	savedSearchService "github.com/nihar-hegde/valtro-backend/internal/services/savedsearch"
+)
+
+// statuses lists the incident statuses that can be filtered on
+var statuses = map[string]bool{
+	constants.IncidentStatusOpen:         true,
+	constants.IncidentStatusAcknowledged: true,
+	constants.IncidentStatusResolved:     true,
+}
+
+// Service handles incident business logic
+type Service struct {
+	incidentRepo  *incidentRepo.Repository
+	savedSearches *savedSearchService.Service
+}
+
+// NewService creates a new incident service
+func NewService(incidentRepo *incidentRepo.Repository, savedSearches *savedSearchService.Service) *Service {
+	return &Service{
+		incidentRepo:  incidentRepo,
+		savedSearches: savedSearches,
+	}
+}
+
+// RecordAlert adds an alert rule's state change to its incident
+// A firing rule without an unresolved incident opens one, linking the project's unresolved
+// issues seen during the alert window. A resolved rule closes the pinned log query's time range
+// but leaves resolving the incident to a member
+func (s *Service) RecordAlert(ctx context.Context, notification alert.Notification) error {
+	if notification.State == constants.AlertStateFiring {
+		return s.recordFiring(ctx, notification)
+	}
+	return s.recordResolved(ctx, notification)
+}
+
+// recordFiring opens an incident for a firing rule, or adds the firing to its unresolved incident
+func (s *Service) recordFiring(ctx context.Context, notification alert.Notification) error {
+	issues, err := s.incidentRepo.GetRecentIssues(ctx, notification.ProjectID, notification.WindowStart, constants.MaxIncidentLinkedIssues)
+	if err != nil {
+		return err
+	}
+
+	title := notification.RuleName + " is firing"
+	if runes := []rune(title); len(runes) > constants.MaxIncidentTitleLength {
+		title = string(runes[:constants.MaxIncidentTitleLength])
+	}
+
+	now := time.Now()
+	incident := &models.Incident{
+		ID:            uuid.New(),
+		ProjectID:     notification.ProjectID,
+		AlertRuleID:   &notification.RuleID,
+		Title:         title,
+		Status:        constants.IncidentStatusOpen,
+		LogQuery:      notification.Query,
+		SavedSearchID: notification.SavedSearchID,
+		LogQueryFrom:  notification.WindowStart,
+		StartedAt:     notification.EvaluatedAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	links := make([]*models.IncidentIssue, len(issues))
+	for i, issue := range issues {
+		links[i] = &models.IncidentIssue{IncidentID: incident.ID, IssueID: issue.ID, CreatedAt: now}
+	}
+	opened := s.newTimelineEntry(incident, constants.IncidentEventOpened, nil,
+		fmt.Sprintf("Incident opened by alert rule %q; linked %d unresolved issues seen during the alert window", notification.RuleName, len(issues)))
+
+	incident, created, err := s.incidentRepo.OpenForAlertRule(ctx, incident, links, []*models.IncidentTimelineEntry{opened})
+	if err != nil {
+		return err
+	}
+
+	// A rule firing again after resolving reopens the pinned log query's time range
+	if !created {
+		incident.LogQueryTo = nil
+		incident.UpdatedAt = time.Now()
+	}
+
+	return s.recordEvaluation(ctx, incident, notification, constants.IncidentEventAlertFiring,
+		fmt.Sprintf("Alert rule %q fired: %s", notification.RuleName, describeValue(notification)))
+}
+
+// recordResolved adds a resolved rule to its unresolved incident, if it has one
+func (s *Service) recordResolved(ctx context.Context, notification alert.Notification) error {
+	incident, err := s.incidentRepo.GetOpenByAlertRuleID(ctx, notification.RuleID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	incident.LogQueryTo = &notification.EvaluatedAt
+	incident.UpdatedAt = time.Now()
+
+	return s.recordEvaluation(ctx, incident, notification, constants.IncidentEventAlertResolved,
+		fmt.Sprintf("Alert rule %q resolved: %s", notification.RuleName, describeValue(notification)))
+}
+
+// recordEvaluation saves a copy of the notification's evaluation on the incident
+func (s *Service) recordEvaluation(ctx context.Context, incident *models.Incident, notification alert.Notification, kind string, message string) error {
+	evaluation := &models.IncidentAlertEvaluation{
+		ID:                uuid.New(),
+		IncidentID:        incident.ID,
+		AlertEvaluationID: notification.EvaluationID,
+		EvaluatedAt:       notification.EvaluatedAt,
+		WindowStart:       notification.WindowStart,
+		Value:             notification.Value,
+		State:             notification.State,
+		Aggregation:       notification.Aggregation,
+		Comparison:        notification.Comparison,
+		Threshold:         notification.Threshold,
+	}
+	entry := s.newTimelineEntry(incident, kind, nil, message)
+	return s.incidentRepo.RecordEvaluation(ctx, incident, evaluation, entry)
+}
+
+// CreateIncident opens an incident by hand, optionally linking issues of the project
+func (s *Service) CreateIncident(ctx context.Context, projectID uuid.UUID, req dto.CreateIncidentRequest, actorID uuid.UUID) (*dto.IncidentResponse, error) {
+	logQuery := strings.TrimSpace(req.LogQuery)
+	if req.SavedSearchID != nil {
+		if logQuery != "" {
+			return nil, errors.NewValidationError("Set either a log query or a saved search, not both")
+		}
+		var err error
+		if logQuery, err = s.savedSearches.QueryForProject(ctx, *req.SavedSearchID, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	incident := &models.Incident{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		Title:         strings.TrimSpace(req.Title),
+		Status:        constants.IncidentStatusOpen,
+		LogQuery:      logQuery,
+		SavedSearchID: req.SavedSearchID,
+		LogQueryTo:    req.LogQueryTo,
+		StartedAt:     now,
+		CreatedByID:   &actorID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.StartedAt != nil {
+		if req.StartedAt.After(now.Add(time.Minute)) {
+			return nil, errors.NewValidationError("Incident start cannot be in the future")
+		}
+		incident.StartedAt = *req.StartedAt
+	}
+	incident.LogQueryFrom = incident.StartedAt
+	if req.LogQueryFrom != nil {
+		incident.LogQueryFrom = *req.LogQueryFrom
+	}
+
+	// Validate business rules
+	if err := s.validateIncident(incident); err != nil {
+		return nil, err
+	}
+	if len(req.IssueIDs) > constants.MaxIncidentLinkedIssues {
+		return nil, errors.NewValidationError(fmt.Sprintf("At most %d issues can be linked when opening an incident", constants.MaxIncidentLinkedIssues))
+	}
+
+	links := make([]*models.IncidentIssue, 0, len(req.IssueIDs))
+	seen := make(map[uuid.UUID]bool, len(req.IssueIDs))
+	for _, issueID := range req.IssueIDs {
+		if seen[issueID] {
+			continue
+		}
+		seen[issueID] = true
+		if _, err := s.incidentRepo.GetIssueInProject(ctx, issueID, projectID); err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, errors.NewValidationError("Issues must exist in the project", "Issue ID: "+issueID.String())
+			}
+			return nil, err
+		}
+		links = append(links, &models.IncidentIssue{IncidentID: incident.ID, IssueID: issueID, LinkedByID: &actorID, CreatedAt: now})
+	}
+
+	opened := s.newTimelineEntry(incident, constants.IncidentEventOpened, &actorID,
+		fmt.Sprintf("Incident opened: %q; linked %d issues", incident.Title, len(links)))
+
+	if err := s.incidentRepo.Create(ctx, incident, links, []*models.IncidentTimelineEntry{opened}); err != nil {
+		return nil, err
+	}
+
+	return s.toIncidentResponse(incident), nil
+}
+
+// GetIncidentsByProject lists a project's most recent incidents
+func (s *Service) GetIncidentsByProject(ctx context.Context, projectID uuid.UUID, status string, limit int) ([]*dto.IncidentResponse, error) {
+	if status != "" && !statuses[status] {
+		return nil, errors.NewValidationError("Status must be one of 'open', 'acknowledged' or 'resolved'")
+	}
+	if limit == 0 {
+		limit = constants.DefaultIncidentLimit
+	}
+	if limit < 1 || limit > constants.MaxIncidentLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxIncidentLimit))
+	}
+
+	incidents, err := s.incidentRepo.List(ctx, projectID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		responses = append(responses, s.toIncidentResponse(incident))
+	}
+
+	return responses, nil
+}
+
+// GetIncident retrieves an incident with its evaluations, linked issues and timeline
+func (s *Service) GetIncident(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.IncidentResponse, error) {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations, err := s.incidentRepo.GetEvaluations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.incidentRepo.GetIssues(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := s.incidentRepo.GetTimeline(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toIncidentResponse(incident)
+	response.Evaluations = make([]dto.IncidentEvaluationResponse, len(evaluations))
+	for i, evaluation := range evaluations {
+		response.Evaluations[i] = dto.IncidentEvaluationResponse{
+			AlertEvaluationID: evaluation.AlertEvaluationID,
+			EvaluatedAt:       evaluation.EvaluatedAt,
+			WindowStart:       evaluation.WindowStart,
+			Value:             evaluation.Value,
+			State:             evaluation.State,
+			Aggregation:       evaluation.Aggregation,
+			Comparison:        evaluation.Comparison,
+			Threshold:         evaluation.Threshold,
+		}
+	}
+	response.Issues = make([]dto.IncidentIssueResponse, len(issues))
+	for i, issue := range issues {
+		response.Issues[i] = dto.IncidentIssueResponse{
+			ID:         issue.ID,
+			Title:      issue.Title,
+			Level:      issue.Level,
+			Status:     issue.Status,
+			EventCount: issue.EventCount,
+			LastSeenAt: issue.LastSeenAt,
+			LinkedByID: issue.LinkedByID,
+			LinkedAt:   issue.LinkedAt,
+		}
+	}
+	response.Timeline = make([]dto.IncidentTimelineEntryResponse, len(timeline))
+	for i, entry := range timeline {
+		response.Timeline[i] = *s.toTimelineEntryResponse(entry)
+	}
+
+	return response, nil
+}
+
+// UpdateIncident changes an incident's title or pinned log query
+func (s *Service) UpdateIncident(ctx context.Context, id uuid.UUID, req dto.UpdateIncidentRequest, projectID uuid.UUID, actorID uuid.UUID) (*dto.IncidentResponse, error) {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	before := *incident
+
+	// Update fields if provided
+	if req.Title != nil {
+		incident.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.LogQuery != nil && req.SavedSearchID != nil {
+		return nil, errors.NewValidationError("Set either a log query or a saved search, not both")
+	}
+	if req.LogQuery != nil {
+		incident.LogQuery = strings.TrimSpace(*req.LogQuery)
+		incident.SavedSearchID = nil
+	}
+	if req.SavedSearchID != nil {
+		incident.LogQuery, err = s.savedSearches.QueryForProject(ctx, *req.SavedSearchID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		incident.SavedSearchID = req.SavedSearchID
+	}
+	if req.LogQueryFrom != nil {
+		incident.LogQueryFrom = *req.LogQueryFrom
+	}
+	if req.ClearLogQueryTo {
+		incident.LogQueryTo = nil
+	} else if req.LogQueryTo != nil {
+		incident.LogQueryTo = req.LogQueryTo
+	}
+
+	// Validate business rules
+	if err := s.validateIncident(incident); err != nil {
+		return nil, err
+	}
+
+	// Describe each change for the timeline
+	var changes []string
+	if before.Title != incident.Title {
+		changes = append(changes, fmt.Sprintf("title %q -> %q", before.Title, incident.Title))
+	}
+	if before.LogQuery != incident.LogQuery || !before.LogQueryFrom.Equal(incident.LogQueryFrom) ||
+		formatTime(before.LogQueryTo) != formatTime(incident.LogQueryTo) ||
+		describeSavedSearch(before.SavedSearchID) != describeSavedSearch(incident.SavedSearchID) {
+		changes = append(changes, fmt.Sprintf("log query %s -> %s", describeLogQuery(&before), describeLogQuery(incident)))
+	}
+
+	if len(changes) == 0 {
+		return s.toIncidentResponse(incident), nil
+	}
+
+	incident.UpdatedAt = time.Now()
+	entry := s.newTimelineEntry(incident, constants.IncidentEventUpdated, &actorID, "Incident updated: "+strings.Join(changes, "; "))
+
+	// Save incident and timeline entry together
+	if err := s.incidentRepo.Update(ctx, incident, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toIncidentResponse(incident), nil
+}
+
+// AcknowledgeIncident records that a member has taken ownership of an open incident
+func (s *Service) AcknowledgeIncident(ctx context.Context, id uuid.UUID, projectID uuid.UUID, actorID uuid.UUID) (*dto.IncidentResponse, error) {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != constants.IncidentStatusOpen {
+		return nil, errors.NewConflictError("Incident is already "+incident.Status, "Incident ID: "+id.String())
+	}
+
+	now := time.Now()
+	incident.Status = constants.IncidentStatusAcknowledged
+	incident.AcknowledgedAt = &now
+	incident.AcknowledgedByID = &actorID
+	incident.UpdatedAt = now
+
+	entry := s.newTimelineEntry(incident, constants.IncidentEventAcknowledged, &actorID, "Incident acknowledged")
+
+	// Save incident and timeline entry together
+	if err := s.incidentRepo.Update(ctx, incident, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toIncidentResponse(incident), nil
+}
+
+// ResolveIncident resolves an incident; the rule's next firing opens a new one
+func (s *Service) ResolveIncident(ctx context.Context, id uuid.UUID, projectID uuid.UUID, actorID uuid.UUID) (*dto.IncidentResponse, error) {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == constants.IncidentStatusResolved {
+		return nil, errors.NewConflictError("Incident is already resolved", "Incident ID: "+id.String())
+	}
+
+	now := time.Now()
+	incident.Status = constants.IncidentStatusResolved
+	incident.ResolvedAt = &now
+	incident.ResolvedByID = &actorID
+	if incident.LogQueryTo == nil {
+		incident.LogQueryTo = &now
+	}
+	incident.UpdatedAt = now
+
+	entry := s.newTimelineEntry(incident, constants.IncidentEventResolved, &actorID,
+		"Incident resolved after "+now.Sub(incident.StartedAt).Round(time.Second).String())
+
+	// Save incident and timeline entry together
+	if err := s.incidentRepo.Update(ctx, incident, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toIncidentResponse(incident), nil
+}
+
+// AddNote adds a member's note to an incident's timeline
+// Notes can be added after resolution, e.g. while writing the post-mortem
+func (s *Service) AddNote(ctx context.Context, id uuid.UUID, req dto.AddIncidentNoteRequest, projectID uuid.UUID, actorID uuid.UUID) (*dto.IncidentTimelineEntryResponse, error) {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, errors.NewValidationError("Note message is required")
+	}
+	if len(message) > constants.MaxIncidentNoteLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("Note message must be at most %d characters", constants.MaxIncidentNoteLength))
+	}
+
+	entry := s.newTimelineEntry(incident, constants.IncidentEventNote, &actorID, message)
+	if err := s.incidentRepo.AddTimelineEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toTimelineEntryResponse(entry), nil
+}
+
+// LinkIssue links an issue of the project to an incident
+func (s *Service) LinkIssue(ctx context.Context, id uuid.UUID, req dto.LinkIncidentIssueRequest, projectID uuid.UUID, actorID uuid.UUID) error {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return err
+	}
+	issue, err := s.incidentRepo.GetIssueInProject(ctx, req.IssueID, projectID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	link := &models.IncidentIssue{IncidentID: incident.ID, IssueID: issue.ID, LinkedByID: &actorID, CreatedAt: now}
+	entry := s.newTimelineEntry(incident, constants.IncidentEventIssueLinked, &actorID, fmt.Sprintf("Linked issue %q", issue.Title))
+
+	linked, err := s.incidentRepo.LinkIssue(ctx, link, entry)
+	if err != nil {
+		return err
+	}
+	if !linked {
+		return errors.NewConflictError("Issue is already linked to the incident", "Issue ID: "+issue.ID.String())
+	}
+	return nil
+}
+
+// UnlinkIssue removes an issue from an incident
+func (s *Service) UnlinkIssue(ctx context.Context, id uuid.UUID, issueID uuid.UUID, projectID uuid.UUID, actorID uuid.UUID) error {
+	incident, err := s.getProjectIncident(ctx, id, projectID)
+	if err != nil {
+		return err
+	}
+	issue, err := s.incidentRepo.GetIssueInProject(ctx, issueID, projectID)
+	if err != nil {
+		return err
+	}
+
+	entry := s.newTimelineEntry(incident, constants.IncidentEventIssueUnlinked, &actorID, fmt.Sprintf("Unlinked issue %q", issue.Title))
+
+	unlinked, err := s.incidentRepo.UnlinkIssue(ctx, incident.ID, issue.ID, entry)
+	if err != nil {
+		return err
+	}
+	if !unlinked {
+		return errors.NewNotFoundError("Incident issue", issueID.String())
+	}
+	return nil
+}
+
+// getProjectIncident retrieves an incident, reporting incidents of other projects as missing
+func (s *Service) getProjectIncident(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.Incident, error) {
+	incident, err := s.incidentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.ProjectID != projectID {
+		return nil, errors.NewNotFoundError("Incident", id.String())
+	}
+	return incident, nil
+}
+
+// validateIncident validates an incident's title and pinned log query range
+func (s *Service) validateIncident(incident *models.Incident) error {
+	if incident.Title == "" {
+		return errors.NewValidationError("Incident title is required")
+	}
+	if utf8.RuneCountInString(incident.Title) > constants.MaxIncidentTitleLength {
+		return errors.NewValidationError(fmt.Sprintf("Incident title must be at most %d characters", constants.MaxIncidentTitleLength))
+	}
+	if incident.LogQueryTo != nil && !incident.LogQueryTo.After(incident.LogQueryFrom) {
+		return errors.NewValidationError("Log query end must be after its start")
+	}
+	return nil
+}
+
+// newTimelineEntry builds a timeline entry for an incident
+func (s *Service) newTimelineEntry(incident *models.Incident, kind string, actorID *uuid.UUID, message string) *models.IncidentTimelineEntry {
+	return &models.IncidentTimelineEntry{
+		ID:         uuid.New(),
+		IncidentID: incident.ID,
+		Kind:       kind,
+		ActorID:    actorID,
+		Message:    message,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// describeValue formats a notification's value and threshold for the timeline
+func describeValue(notification alert.Notification) string {
+	return fmt.Sprintf("%s %g, threshold %s %g", notification.Aggregation, notification.Value, notification.Comparison, notification.Threshold)
+}
+
+// describeLogQuery formats an incident's pinned log query for the timeline
+func describeLogQuery(incident *models.Incident) string {
+	return fmt.Sprintf("%q%s from %s to %s", incident.LogQuery, describeSavedSearch(incident.SavedSearchID),
+		formatTime(&incident.LogQueryFrom), formatTime(incident.LogQueryTo))
+}
+
+// describeSavedSearch formats the saved search a pinned log query was copied from, if any
+func describeSavedSearch(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return " (saved search " + id.String() + ")"
+}
+
+// formatTime formats an optional timestamp for the timeline
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "now"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// toIncidentResponse converts an incident model to response DTO
+func (s *Service) toIncidentResponse(incident *models.Incident) *dto.IncidentResponse {
+	return &dto.IncidentResponse{
+		ID:          incident.ID,
+		ProjectID:   incident.ProjectID,
+		AlertRuleID: incident.AlertRuleID,
+		Title:       incident.Title,
+		Status:      incident.Status,
+		LogQuery: dto.IncidentLogQuery{
+			Query:         incident.LogQuery,
+			SavedSearchID: incident.SavedSearchID,
+			From:          incident.LogQueryFrom,
+			To:            incident.LogQueryTo,
+		},
+		StartedAt:        incident.StartedAt,
+		AcknowledgedAt:   incident.AcknowledgedAt,
+		AcknowledgedByID: incident.AcknowledgedByID,
+		ResolvedAt:       incident.ResolvedAt,
+		ResolvedByID:     incident.ResolvedByID,
+		CreatedByID:      incident.CreatedByID,
+		CreatedAt:        incident.CreatedAt,
+		UpdatedAt:        incident.UpdatedAt,
+	}
+}
+
+// toTimelineEntryResponse converts a timeline entry model to response DTO
+func (s *Service) toTimelineEntryResponse(entry *models.IncidentTimelineEntry) *dto.IncidentTimelineEntryResponse {
+	return &dto.IncidentTimelineEntryResponse{
+		ID:        entry.ID,
+		Kind:      entry.Kind,
+		ActorID:   entry.ActorID,
+		Message:   entry.Message,
+		CreatedAt: entry.CreatedAt,
+	}
+}
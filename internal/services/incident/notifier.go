@@ -0,0 +1,32 @@
+package incident
+
+import (
+	"context"
+	"log"
+
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+)
+
+// Notifier records alert state changes on incidents before passing them on
+// Incidents are recorded even when a silence holds the notification back
+type Notifier struct {
+	incidentService *Service
+	next            alert.Notifier
+}
+
+// NewNotifier creates a notifier that records incidents before notifying through next
+func NewNotifier(incidentService *Service, next alert.Notifier) *Notifier {
+	return &Notifier{
+		incidentService: incidentService,
+		next:            next,
+	}
+}
+
+// Notify records the state change on the rule's incident and forwards the notification
+// A failure to record the incident never stops the notification
+func (n *Notifier) Notify(ctx context.Context, notification alert.Notification) error {
+	if err := n.incidentService.RecordAlert(ctx, notification); err != nil {
+		log.Printf("Failed to record incident for alert rule %s: %v", notification.RuleID, err)
+	}
+	return n.next.Notify(ctx, notification)
+}
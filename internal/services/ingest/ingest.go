@@ -0,0 +1,292 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	artifactService "github.com/nihar-hegde/valtro-backend/internal/services/artifact"
+	issueService "github.com/nihar-hegde/valtro-backend/internal/services/issue"
+	patternService "github.com/nihar-hegde/valtro-backend/internal/services/pattern"
+	releaseService "github.com/nihar-hegde/valtro-backend/internal/services/release"
+	usageService "github.com/nihar-hegde/valtro-backend/internal/services/usage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
+)
+
+// validLevels are the log levels an event may have
+var validLevels = map[string]bool{
+	constants.LogLevelTrace: true,
+	constants.LogLevelDebug: true,
+	constants.LogLevelInfo:  true,
+	constants.LogLevelWarn:  true,
+	constants.LogLevelError: true,
+	constants.LogLevelFatal: true,
+}
+
+// Service handles log ingestion
+// Accepted events are assigned a pattern, stored in the log store, counted towards usage and
+// their release, and error-level events are symbolicated and grouped into issues
+type Service struct {
+	logStore        logstore.LogStore
+	projectRepo     *projectRepo.Repository
+	patternService  *patternService.Service
+	usageService    *usageService.Service
+	releaseService  *releaseService.Service
+	issueService    *issueService.Service
+	artifactService *artifactService.Service
+}
+
+// NewService creates a new ingest service
+func NewService(logStore logstore.LogStore, projectRepo *projectRepo.Repository, patternService *patternService.Service, usageService *usageService.Service, releaseService *releaseService.Service, issueService *issueService.Service, artifactService *artifactService.Service) *Service {
+	return &Service{
+		logStore:        logStore,
+		projectRepo:     projectRepo,
+		patternService:  patternService,
+		usageService:    usageService,
+		releaseService:  releaseService,
+		issueService:    issueService,
+		artifactService: artifactService,
+	}
+}
+
+// received is an accepted event together with what the SDK sent for it
+type received struct {
+	event    *logstore.Event
+	source   dto.LogEvent
+	rawBytes int64
+}
+
+// IngestLogs validates and stores a batch of log events
+// The whole batch is rejected if any event is invalid, so SDKs can safely retry it.
+// Once the events are stored, failures to update patterns, usage, releases or issues are logged
+// rather than returned, because a retry would store the events twice
+func (s *Service) IngestLogs(ctx context.Context, projectID uuid.UUID, req dto.IngestLogsRequest) (*dto.IngestLogsResponse, error) {
+	if len(req.Events) == 0 {
+		return nil, errors.NewValidationError("At least one event is required")
+	}
+	if len(req.Events) > constants.MaxIngestBatchSize {
+		return nil, errors.NewValidationError(fmt.Sprintf("A batch can have at most %d events", constants.MaxIngestBatchSize))
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	batch := make([]*received, 0, len(req.Events))
+	for i, source := range req.Events {
+		event, err := s.toEvent(projectID, i, source, now)
+		if err != nil {
+			return nil, err
+		}
+		raw, _ := json.Marshal(source)
+		batch = append(batch, &received{event: event, source: source, rawBytes: int64(len(raw))})
+	}
+
+	events := make([]*logstore.Event, len(batch))
+	ids := make([]uuid.UUID, len(batch))
+	matches := make([]*models.LogPattern, len(batch))
+	for i, item := range batch {
+		match, err := s.patternService.MatchMessage(ctx, projectID, item.event.Message, item.event.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		matches[i] = match
+		item.event.PatternID = &match.ID
+		item.event.SearchText = searchText(item.event, project.IndexedAttributes)
+		events[i] = item.event
+		ids[i] = item.event.ID
+	}
+
+	if err := s.logStore.Append(ctx, events); err != nil {
+		return nil, errors.NewInternalError("Failed to store log events", err.Error())
+	}
+
+	if err := s.patternService.RecordMatches(ctx, matches); err != nil {
+		log.Printf("Failed to record log patterns for project %s: %v", projectID, err)
+	}
+	s.recordUsage(ctx, projectID, batch)
+	s.recordReleases(ctx, projectID, batch)
+	s.recordIssues(ctx, projectID, batch)
+
+	return &dto.IngestLogsResponse{Accepted: len(events), EventIDs: ids}, nil
+}
+
+// toEvent validates an event sent by an SDK and converts it for storage
+// Errors name the event by its index in the batch
+func (s *Service) toEvent(projectID uuid.UUID, index int, source dto.LogEvent, now time.Time) (*logstore.Event, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return errors.NewValidationError(fmt.Sprintf("Event %d: ", index) + fmt.Sprintf(format, args...))
+	}
+
+	level := strings.ToLower(strings.TrimSpace(source.Level))
+	if level == "" {
+		level = constants.LogLevelInfo
+	}
+	if !validLevels[level] {
+		return nil, invalid("unknown level %q", source.Level)
+	}
+
+	timestamp := source.Timestamp.UTC()
+	if source.Timestamp.IsZero() {
+		timestamp = now
+	}
+	if timestamp.Before(now.Add(-constants.MaxLogEventAgeSeconds * time.Second)) {
+		return nil, invalid("timestamp is more than %s in the past", time.Duration(constants.MaxLogEventAgeSeconds)*time.Second)
+	}
+	if timestamp.After(now.Add(constants.MaxLogEventSkewSeconds * time.Second)) {
+		return nil, invalid("timestamp is more than %s in the future", time.Duration(constants.MaxLogEventSkewSeconds)*time.Second)
+	}
+
+	message := source.Message
+	if strings.TrimSpace(message) == "" {
+		if source.Exception == nil {
+			return nil, invalid("message is required")
+		}
+		message = strings.TrimSpace(source.Exception.Type + ": " + source.Exception.Value)
+	}
+	if len(message) > constants.MaxLogMessageLength {
+		return nil, invalid("message must be at most %d bytes", constants.MaxLogMessageLength)
+	}
+
+	event := &logstore.Event{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		Timestamp:  timestamp,
+		ReceivedAt: now,
+		Level:      level,
+		Message:    message,
+		Host:       strings.TrimSpace(source.Host),
+		Service:    strings.TrimSpace(source.Service),
+		Stream:     strings.TrimSpace(source.Stream),
+		Release:    strings.TrimSpace(source.Release),
+	}
+	for name, value := range map[string]string{"host": event.Host, "service": event.Service, "stream": event.Stream} {
+		if len(value) > constants.MaxLogFieldLength {
+			return nil, invalid("%s must be at most %d characters", name, constants.MaxLogFieldLength)
+		}
+	}
+	if event.Release != "" {
+		if err := releaseService.ValidateVersion(event.Release); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("Event %d: invalid release", index), err.Error())
+		}
+	}
+
+	if len(source.Attributes) > 0 {
+		attributes, err := validator.ValidateLabels("Attributes", source.Attributes, constants.MaxLogAttributes)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("Event %d: invalid attributes", index), err.Error())
+		}
+		event.Attributes = attributes
+	}
+
+	return event, nil
+}
+
+// searchText joins the values of the project's indexed attributes, which full-text searches
+// match besides the message
+func searchText(event *logstore.Event, indexed []string) string {
+	values := make([]string, 0, len(indexed))
+	for _, name := range indexed {
+		if value := event.Attributes[name]; value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " ")
+}
+
+// recordUsage adds the batch to the project's hourly usage counters, per hour and level
+func (s *Service) recordUsage(ctx context.Context, projectID uuid.UUID, batch []*received) {
+	type key struct {
+		hour  time.Time
+		level string
+	}
+	type counters struct {
+		events, rawBytes, storedBytes int64
+	}
+
+	totals := make(map[key]*counters)
+	for _, item := range batch {
+		k := key{hour: item.event.Timestamp.Truncate(time.Hour), level: item.event.Level}
+		if totals[k] == nil {
+			totals[k] = &counters{}
+		}
+		totals[k].events++
+		totals[k].rawBytes += item.rawBytes
+		totals[k].storedBytes += item.event.Size()
+	}
+
+	for k, total := range totals {
+		if err := s.usageService.RecordIngest(ctx, projectID, k.level, total.events, total.rawBytes, total.storedBytes, k.hour); err != nil {
+			log.Printf("Failed to record usage for project %s: %v", projectID, err)
+		}
+	}
+}
+
+// recordReleases counts the batch's events against the releases they report, per release and level
+func (s *Service) recordReleases(ctx context.Context, projectID uuid.UUID, batch []*received) {
+	type key struct {
+		release string
+		level   string
+	}
+	type counters struct {
+		events int64
+		latest time.Time
+	}
+
+	totals := make(map[key]*counters)
+	for _, item := range batch {
+		if item.event.Release == "" {
+			continue
+		}
+		k := key{release: item.event.Release, level: item.event.Level}
+		if totals[k] == nil {
+			totals[k] = &counters{}
+		}
+		totals[k].events++
+		if item.event.Timestamp.After(totals[k].latest) {
+			totals[k].latest = item.event.Timestamp
+		}
+	}
+
+	for k, total := range totals {
+		if err := s.releaseService.RecordEvents(ctx, projectID, k.release, k.level, total.events, total.latest); err != nil {
+			log.Printf("Failed to record events for release %q of project %s: %v", k.release, projectID, err)
+		}
+	}
+}
+
+// recordIssues groups the batch's error and fatal events into issues
+func (s *Service) recordIssues(ctx context.Context, projectID uuid.UUID, batch []*received) {
+	for _, item := range batch {
+		if item.event.Level != constants.LogLevelError && item.event.Level != constants.LogLevelFatal {
+			continue
+		}
+		// Group by the original frames so each new build of a bundle joins the same issues;
+		// frames without a source map for the release keep their minified positions
+		s.artifactService.SymbolicateException(ctx, projectID, item.event.Release, item.source.Exception)
+		if _, err := s.issueService.RecordErrorEvent(ctx, projectID, dto.ErrorEvent{
+			EventID:     item.event.ID.String(),
+			Timestamp:   item.event.Timestamp,
+			Level:       item.event.Level,
+			Host:        item.event.Host,
+			Release:     item.event.Release,
+			Message:     item.event.Message,
+			Exception:   item.source.Exception,
+			Fingerprint: item.source.Fingerprint,
+		}); err != nil {
+			log.Printf("Failed to record issue for event %s of project %s: %v", item.event.ID, projectID, err)
+		}
+	}
+}
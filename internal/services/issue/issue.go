@@ -0,0 +1,583 @@
+package issue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	issueRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/issue"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/drain"
+)
+
+var (
+	// volatilePattern matches build hashes and numbers in file names that change between releases
+	volatilePattern = regexp.MustCompile(`\b[0-9a-fA-F]{8,}\b|[0-9]+`)
+
+	// digitsPattern matches numbering in generated function names (e.g. "func1", "lambda$3")
+	digitsPattern = regexp.MustCompile(`[0-9]+`)
+
+	// urlPrefixPattern matches the scheme and host of frame file URLs
+	urlPrefixPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://[^/]*`)
+)
+
+// issueOrder maps the supported sort keys to their ORDER BY clause
+var issueOrder = map[string]string{
+	constants.IssueSortRecency:   "last_seen_at DESC",
+	constants.IssueSortFrequency: "event_count DESC",
+	constants.IssueSortNew:       "first_seen_at DESC",
+}
+
+// issueStatuses lists the valid issue statuses
+var issueStatuses = map[string]bool{
+	constants.IssueStatusUnresolved:            true,
+	constants.IssueStatusResolved:              true,
+	constants.IssueStatusResolvedInNextRelease: true,
+	constants.IssueStatusIgnored:               true,
+}
+
+// Service handles issue grouping, triage and retrieval
+type Service struct {
+	issueRepo   *issueRepo.Repository
+	projectRepo *projectRepo.Repository
+}
+
+// NewService creates a new issue service
+func NewService(issueRepo *issueRepo.Repository, projectRepo *projectRepo.Repository) *Service {
+	return &Service{
+		issueRepo:   issueRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// RecordErrorEvent groups an error event into its issue, creating the issue on first sight
+// The ingestion pipeline calls this for every error-level event
+func (s *Service) RecordErrorEvent(ctx context.Context, projectID uuid.UUID, event dto.ErrorEvent) (*dto.IssueResponse, error) {
+	// Validate business rules
+	if event.Exception == nil && strings.TrimSpace(event.Message) == "" {
+		return nil, errors.NewValidationError("Error event needs an exception or a message")
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	level := strings.ToLower(event.Level)
+	if level == "" {
+		level = constants.LogLevelError
+	}
+
+	exception := s.toExceptionModel(event.Exception)
+	now := time.Now()
+	seed := &models.Issue{
+		ID:           uuid.New(),
+		ProjectID:    projectID,
+		Fingerprint:  Fingerprint(event.Fingerprint, exception, event.Message),
+		Title:        s.title(exception, event.Message),
+		Culprit:      s.culprit(exception),
+		Level:        level,
+		Hosts:        []string{},
+		SampleEvents: []models.IssueSample{},
+		FirstSeenAt:  event.Timestamp,
+		LastSeenAt:   event.Timestamp,
+		FirstRelease: event.Release,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	sample := models.IssueSample{
+		EventID:   event.EventID,
+		Timestamp: event.Timestamp,
+		Host:      event.Host,
+		Release:   event.Release,
+		Message:   event.Message,
+		Exception: exception,
+	}
+
+	// Apply the event and any automatic status change while the issue is locked
+	issue, err := s.issueRepo.RecordOccurrence(ctx, seed, func(issue *models.Issue) []*models.IssueActivity {
+		s.applyEvent(issue, sample, event.Release, now)
+		return s.automaticTransitions(issue, sample.Timestamp, event.Release, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toIssueResponse(issue, false), nil
+}
+
+// GetIssuesByProject lists a project's issues sorted by recency, frequency or newest first
+// An empty status lists issues in every status
+func (s *Service) GetIssuesByProject(ctx context.Context, projectID uuid.UUID, status string, sort string, limit int) ([]*dto.IssueResponse, error) {
+	if status != "" && !issueStatuses[status] {
+		return nil, errors.NewValidationError("Status must be one of 'unresolved', 'resolved', 'resolved_in_next_release' or 'ignored'")
+	}
+
+	if sort == "" {
+		sort = constants.IssueSortRecency
+	}
+	orderBy, ok := issueOrder[sort]
+	if !ok {
+		return nil, errors.NewValidationError("Sort must be one of 'recency', 'frequency' or 'new'")
+	}
+
+	if limit == 0 {
+		limit = constants.DefaultIssueLimit
+	}
+	if limit < 1 || limit > constants.MaxIssueLimit {
+		return nil, errors.NewValidationError("Limit must be between 1 and 1000")
+	}
+
+	issues, err := s.issueRepo.List(ctx, projectID, status, orderBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.IssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		responses = append(responses, s.toIssueResponse(issue, false))
+	}
+
+	return responses, nil
+}
+
+// GetIssueByID retrieves a single issue of a project, including its sample events
+func (s *Service) GetIssueByID(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.IssueResponse, error) {
+	issue, err := s.getProjectIssue(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toIssueResponse(issue, true), nil
+}
+
+// UpdateIssueStatus moves an issue to a new status and records the change in its activity log
+func (s *Service) UpdateIssueStatus(ctx context.Context, id uuid.UUID, req dto.UpdateIssueStatusRequest, projectID uuid.UUID, actorID uuid.UUID) (*dto.IssueResponse, error) {
+	issue, err := s.getProjectIssue(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate business rules
+	if err := s.validateStatusUpdate(issue, req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fromStatus := issue.Status
+	issue.Status = req.Status
+	issue.ResolvedAt = nil
+	issue.ResolvedRelease = ""
+	issue.IgnoreUntilCount = nil
+	issue.IgnoreUntil = nil
+	issue.UpdatedAt = now
+
+	details := "Status changed from " + fromStatus + " to " + req.Status
+	switch req.Status {
+	case constants.IssueStatusResolved:
+		issue.ResolvedAt = &now
+	case constants.IssueStatusResolvedInNextRelease:
+		issue.ResolvedAt = &now
+		issue.ResolvedRelease = issue.LastRelease
+		if issue.LastRelease != "" {
+			details += " (current release " + issue.LastRelease + ")"
+		}
+	case constants.IssueStatusIgnored:
+		if req.IgnoreCount != nil {
+			untilCount := issue.EventCount + *req.IgnoreCount
+			issue.IgnoreUntilCount = &untilCount
+			details += fmt.Sprintf(" until %d more events", *req.IgnoreCount)
+		}
+		if req.IgnoreUntil != nil {
+			issue.IgnoreUntil = req.IgnoreUntil
+			details += " until " + req.IgnoreUntil.Format(time.RFC3339)
+		}
+	}
+
+	activity := s.newActivity(issue, constants.IssueActivityStatusChanged, &actorID, fromStatus, details, now)
+
+	// Save status and activity together
+	if err := s.issueRepo.UpdateTriage(ctx, issue, activity); err != nil {
+		return nil, err
+	}
+
+	return s.toIssueResponse(issue, false), nil
+}
+
+// AssignIssue assigns an issue to a member of the project's organization, or unassigns it
+func (s *Service) AssignIssue(ctx context.Context, id uuid.UUID, req dto.AssignIssueRequest, projectID uuid.UUID, actorID uuid.UUID) (*dto.IssueResponse, error) {
+	issue, err := s.getProjectIssue(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sameAssignee(issue.AssigneeID, req.AssigneeID) {
+		return nil, errors.NewValidationError("Issue already has this assignee")
+	}
+
+	// Organizations have a single member today: their owner
+	action := constants.IssueActivityUnassigned
+	details := "Issue unassigned"
+	if req.AssigneeID != nil {
+		project, err := s.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if *req.AssigneeID != project.Organization.OwnerID {
+			return nil, errors.NewValidationError("Assignee must be a member of the project's organization")
+		}
+		action = constants.IssueActivityAssigned
+		details = "Issue assigned to " + req.AssigneeID.String()
+	}
+
+	now := time.Now()
+	issue.AssigneeID = req.AssigneeID
+	issue.UpdatedAt = now
+
+	activity := s.newActivity(issue, action, &actorID, issue.Status, details, now)
+
+	// Save assignment and activity together
+	if err := s.issueRepo.UpdateTriage(ctx, issue, activity); err != nil {
+		return nil, err
+	}
+
+	return s.toIssueResponse(issue, false), nil
+}
+
+// GetIssueActivity retrieves an issue's activity log, oldest first
+func (s *Service) GetIssueActivity(ctx context.Context, id uuid.UUID, projectID uuid.UUID) ([]*dto.IssueActivityResponse, error) {
+	if _, err := s.getProjectIssue(ctx, id, projectID); err != nil {
+		return nil, err
+	}
+
+	activities, err := s.issueRepo.GetActivityByIssueID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.IssueActivityResponse, 0, len(activities))
+	for _, activity := range activities {
+		responses = append(responses, s.toIssueActivityResponse(activity))
+	}
+
+	return responses, nil
+}
+
+// getProjectIssue retrieves an issue and checks that it belongs to the project
+func (s *Service) getProjectIssue(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.Issue, error) {
+	issue, err := s.issueRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if issue belongs to the specified project
+	if issue.ProjectID != projectID {
+		return nil, errors.NewNotFoundError("Issue", "Issue with ID "+id.String()+" not found in project")
+	}
+
+	return issue, nil
+}
+
+// validateStatusUpdate validates the update issue status request
+func (s *Service) validateStatusUpdate(issue *models.Issue, req dto.UpdateIssueStatusRequest) error {
+	if !issueStatuses[req.Status] {
+		return errors.NewValidationError("Status must be one of 'unresolved', 'resolved', 'resolved_in_next_release' or 'ignored'")
+	}
+	if req.Status != constants.IssueStatusIgnored && (req.IgnoreCount != nil || req.IgnoreUntil != nil) {
+		return errors.NewValidationError("Ignore conditions are only allowed when ignoring an issue")
+	}
+	if req.Status == issue.Status && req.Status != constants.IssueStatusIgnored {
+		return errors.NewValidationError("Issue is already " + req.Status)
+	}
+	if req.IgnoreCount != nil && *req.IgnoreCount < 1 {
+		return errors.NewValidationError("Ignore count must be at least 1")
+	}
+	if req.IgnoreUntil != nil && !req.IgnoreUntil.After(time.Now()) {
+		return errors.NewValidationError("Ignore until must be in the future")
+	}
+	return nil
+}
+
+// applyEvent adds an event's counters, host and sample to the issue
+func (s *Service) applyEvent(issue *models.Issue, sample models.IssueSample, release string, now time.Time) {
+	if release != "" && !sample.Timestamp.Before(issue.LastSeenAt) {
+		issue.LastRelease = release
+	}
+
+	issue.EventCount++
+	if sample.Timestamp.Before(issue.FirstSeenAt) {
+		issue.FirstSeenAt = sample.Timestamp
+	}
+	if sample.Timestamp.After(issue.LastSeenAt) {
+		issue.LastSeenAt = sample.Timestamp
+	}
+	if sample.Host != "" && len(issue.Hosts) < constants.MaxIssueHosts && !slices.Contains(issue.Hosts, sample.Host) {
+		issue.Hosts = append(issue.Hosts, sample.Host)
+	}
+	issue.SampleEvents = append(issue.SampleEvents, sample)
+	if len(issue.SampleEvents) > constants.MaxIssueSamples {
+		issue.SampleEvents = issue.SampleEvents[len(issue.SampleEvents)-constants.MaxIssueSamples:]
+	}
+	issue.UpdatedAt = now
+}
+
+// automaticTransitions reopens resolved issues that regressed and ends expired ignores
+// Events timestamped before the resolution never count as a regression
+func (s *Service) automaticTransitions(issue *models.Issue, at time.Time, release string, now time.Time) []*models.IssueActivity {
+	fromStatus := issue.Status
+	afterResolution := issue.ResolvedAt == nil || at.After(*issue.ResolvedAt)
+
+	var action, details string
+	switch issue.Status {
+	case constants.IssueStatusResolved:
+		if afterResolution {
+			action, details = constants.IssueActivityRegressed, "Regression: new event after the issue was resolved"
+		}
+	case constants.IssueStatusResolvedInNextRelease:
+		if afterResolution && release != "" && release != issue.ResolvedRelease {
+			action, details = constants.IssueActivityRegressed, "Regression: new event in release "+release
+		}
+	case constants.IssueStatusIgnored:
+		if issue.IgnoreUntilCount != nil && issue.EventCount >= *issue.IgnoreUntilCount {
+			action, details = constants.IssueActivityUnignored, "Ignore ended: event count threshold reached"
+		} else if issue.IgnoreUntil != nil && !now.Before(*issue.IgnoreUntil) {
+			action, details = constants.IssueActivityUnignored, "Ignore ended: ignore period expired"
+		}
+	}
+	if action == "" {
+		return nil
+	}
+
+	issue.Status = constants.IssueStatusUnresolved
+	issue.ResolvedAt = nil
+	issue.ResolvedRelease = ""
+	issue.IgnoreUntilCount = nil
+	issue.IgnoreUntil = nil
+
+	return []*models.IssueActivity{s.newActivity(issue, action, nil, fromStatus, details, now)}
+}
+
+// newActivity builds an activity entry for the issue's current status
+func (s *Service) newActivity(issue *models.Issue, action string, actorID *uuid.UUID, fromStatus string, details string, now time.Time) *models.IssueActivity {
+	return &models.IssueActivity{
+		ID:         uuid.New(),
+		IssueID:    issue.ID,
+		Action:     action,
+		ActorID:    actorID,
+		FromStatus: fromStatus,
+		ToStatus:   issue.Status,
+		Details:    details,
+		CreatedAt:  now,
+	}
+}
+
+// sameAssignee reports whether two optional assignees are the same
+func sameAssignee(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Fingerprint computes the grouping fingerprint of an error event
+// An SDK-supplied fingerprint replaces the default components, except where it contains "{{ default }}"
+func Fingerprint(custom []string, exception *models.Exception, message string) string {
+	defaults := defaultComponents(exception, message)
+
+	components := defaults
+	if len(custom) > 0 {
+		components = make([]string, 0, len(custom)+len(defaults))
+		for _, part := range custom {
+			if part == constants.FingerprintDefaultTemplate {
+				components = append(components, defaults...)
+				continue
+			}
+			components = append(components, part)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(components, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// defaultComponents returns the normalized exception type and in-app frames
+// Frames fall back to all frames when none are in-app, and to the message template when there are none
+func defaultComponents(exception *models.Exception, message string) []string {
+	if exception == nil {
+		return []string{strings.Join(drain.Tokenize(message), " ")}
+	}
+
+	components := []string{strings.TrimSpace(exception.Type)}
+	frames := inAppFrames(exception.Frames)
+	if len(frames) == 0 {
+		return append(components, strings.Join(drain.Tokenize(exception.Value), " "))
+	}
+
+	for _, frame := range frames {
+		components = append(components, normalizeLocation(frame)+" "+digitsPattern.ReplaceAllString(frame.Function, ""))
+	}
+	return components
+}
+
+// inAppFrames returns the in-app frames, or every frame if none are marked in-app
+func inAppFrames(frames []models.StackFrame) []models.StackFrame {
+	var inApp []models.StackFrame
+	for _, frame := range frames {
+		if frame.InApp {
+			inApp = append(inApp, frame)
+		}
+	}
+	if len(inApp) == 0 {
+		return frames
+	}
+	return inApp
+}
+
+// normalizeLocation returns the frame's module, or its file without host, query string or build hashes
+// Line and column numbers are left out because they shift with unrelated edits
+func normalizeLocation(frame models.StackFrame) string {
+	if frame.Module != "" {
+		return frame.Module
+	}
+
+	file := urlPrefixPattern.ReplaceAllString(frame.File, "")
+	if i := strings.IndexAny(file, "?#"); i >= 0 {
+		file = file[:i]
+	}
+	return volatilePattern.ReplaceAllString(file, "")
+}
+
+// title builds the issue title from the exception, or the first line of the message
+func (s *Service) title(exception *models.Exception, message string) string {
+	title := firstLine(message)
+	if exception != nil {
+		title = exception.Type
+		if value := firstLine(exception.Value); value != "" {
+			title += ": " + value
+		}
+	}
+
+	if runes := []rune(title); len(runes) > constants.MaxIssueTitleLength {
+		title = string(runes[:constants.MaxIssueTitleLength])
+	}
+	return title
+}
+
+// culprit names the innermost in-app frame
+func (s *Service) culprit(exception *models.Exception) string {
+	if exception == nil {
+		return ""
+	}
+
+	frames := inAppFrames(exception.Frames)
+	if len(frames) == 0 {
+		return ""
+	}
+
+	frame := frames[len(frames)-1]
+	location := frame.Module
+	if location == "" {
+		location = frame.File
+	}
+	if frame.Function == "" {
+		return location
+	}
+	return frame.Function + " in " + location
+}
+
+// firstLine returns the trimmed first line of text
+func firstLine(text string) string {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// toExceptionModel converts an SDK exception to its stored form
+func (s *Service) toExceptionModel(exception *dto.Exception) *models.Exception {
+	if exception == nil {
+		return nil
+	}
+
+	frames := make([]models.StackFrame, len(exception.Frames))
+	for i, frame := range exception.Frames {
+		frames[i] = models.StackFrame(frame)
+	}
+	return &models.Exception{Type: exception.Type, Value: exception.Value, Frames: frames}
+}
+
+// toExceptionDTO converts a stored exception to its DTO
+func (s *Service) toExceptionDTO(exception *models.Exception) *dto.Exception {
+	if exception == nil {
+		return nil
+	}
+
+	frames := make([]dto.StackFrame, len(exception.Frames))
+	for i, frame := range exception.Frames {
+		frames[i] = dto.StackFrame(frame)
+	}
+	return &dto.Exception{Type: exception.Type, Value: exception.Value, Frames: frames}
+}
+
+// toIssueResponse converts an issue model to response DTO, optionally with its sample events
+func (s *Service) toIssueResponse(issue *models.Issue, withSamples bool) *dto.IssueResponse {
+	response := &dto.IssueResponse{
+		ID:           issue.ID,
+		ProjectID:    issue.ProjectID,
+		Fingerprint:  issue.Fingerprint,
+		Title:        issue.Title,
+		Culprit:      issue.Culprit,
+		Level:        issue.Level,
+		EventCount:   issue.EventCount,
+		Hosts:        issue.Hosts,
+		FirstSeenAt:  issue.FirstSeenAt,
+		LastSeenAt:   issue.LastSeenAt,
+		FirstRelease: issue.FirstRelease,
+		LastRelease:  issue.LastRelease,
+		Status:       issue.Status,
+		AssigneeID:   issue.AssigneeID,
+		ResolvedAt:   issue.ResolvedAt,
+		IgnoreCount:  issue.IgnoreUntilCount,
+		IgnoreUntil:  issue.IgnoreUntil,
+	}
+
+	if withSamples {
+		response.SampleEvents = make([]dto.IssueSampleResponse, len(issue.SampleEvents))
+		for i, sample := range issue.SampleEvents {
+			response.SampleEvents[i] = dto.IssueSampleResponse{
+				EventID:   sample.EventID,
+				Timestamp: sample.Timestamp,
+				Host:      sample.Host,
+				Release:   sample.Release,
+				Message:   sample.Message,
+				Exception: s.toExceptionDTO(sample.Exception),
+			}
+		}
+	}
+
+	return response
+}
+
+// toIssueActivityResponse converts an issue activity model to response DTO
+func (s *Service) toIssueActivityResponse(activity *models.IssueActivity) *dto.IssueActivityResponse {
+	return &dto.IssueActivityResponse{
+		ID:         activity.ID,
+		IssueID:    activity.IssueID,
+		Action:     activity.Action,
+		ActorID:    activity.ActorID,
+		FromStatus: activity.FromStatus,
+		ToStatus:   activity.ToStatus,
+		Details:    activity.Details,
+		CreatedAt:  activity.CreatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package issue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+)
+
+// fingerprintInput holds the arguments of one Fingerprint call
+type fingerprintInput struct {
+	custom    []string
+	exception *models.Exception
+	message   string
+}
+
+func (in fingerprintInput) fingerprint() string {
+	return Fingerprint(in.custom, in.exception, in.message)
+}
+
+func TestFingerprintGrouping(t *testing.T) {
+	frames := func(frames ...models.StackFrame) *models.Exception {
+		return &models.Exception{Type: "TypeError", Value: "x is undefined", Frames: frames}
+	}
+
+	tests := []struct {
+		name string
+		a, b fingerprintInput
+		same bool
+	}{
+		{
+			name: "messages differing only in numbers group together",
+			a:    fingerprintInput{message: "timeout after 30s talking to shard 4"},
+			b:    fingerprintInput{message: "timeout after 45s talking to shard 9"},
+			same: true,
+		},
+		{
+			name: "different messages are kept apart",
+			a:    fingerprintInput{message: "connection refused"},
+			b:    fingerprintInput{message: "connection reset"},
+			same: false,
+		},
+		{
+			name: "line and column numbers are ignored",
+			a:    fingerprintInput{exception: frames(models.StackFrame{File: "src/app.js", Function: "render", Line: 10, Column: 4, InApp: true})},
+			b:    fingerprintInput{exception: frames(models.StackFrame{File: "src/app.js", Function: "render", Line: 12, Column: 8, InApp: true})},
+			same: true,
+		},
+		{
+			name: "host, query string and build hashes are stripped from files",
+			a:    fingerprintInput{exception: frames(models.StackFrame{File: "https://cdn.example.com/static/main.3f9a1c2e.js?v=1", Function: "render", InApp: true})},
+			b:    fingerprintInput{exception: frames(models.StackFrame{File: "https://other.example.com/static/main.b81d07aa.js#top", Function: "render", InApp: true})},
+			same: true,
+		},
+		{
+			name: "digits in function names are ignored",
+			a:    fingerprintInput{exception: frames(models.StackFrame{Module: "app.jobs", Function: "lambda$run$12", InApp: true})},
+			b:    fingerprintInput{exception: frames(models.StackFrame{Module: "app.jobs", Function: "lambda$run$7", InApp: true})},
+			same: true,
+		},
+		{
+			name: "library frames are ignored when there are in-app frames",
+			a: fingerprintInput{exception: frames(
+				models.StackFrame{Module: "app.handlers", Function: "checkout", InApp: true},
+				models.StackFrame{Module: "lib.http", Function: "send"},
+			)},
+			b: fingerprintInput{exception: frames(
+				models.StackFrame{Module: "app.handlers", Function: "checkout", InApp: true},
+				models.StackFrame{Module: "lib.http", Function: "retry"},
+			)},
+			same: true,
+		},
+		{
+			name: "library frames count when no frame is in-app",
+			a:    fingerprintInput{exception: frames(models.StackFrame{Module: "lib.http", Function: "send"})},
+			b:    fingerprintInput{exception: frames(models.StackFrame{Module: "lib.http", Function: "retry"})},
+			same: false,
+		},
+		{
+			name: "different exception types are kept apart",
+			a:    fingerprintInput{exception: &models.Exception{Type: "TypeError", Value: "x is undefined"}},
+			b:    fingerprintInput{exception: &models.Exception{Type: "RangeError", Value: "x is undefined"}},
+			same: false,
+		},
+		{
+			name: "exceptions without frames group by value template",
+			a:    fingerprintInput{exception: &models.Exception{Type: "KeyError", Value: "user 42 not found"}},
+			b:    fingerprintInput{exception: &models.Exception{Type: "KeyError", Value: "user 7 not found"}},
+			same: true,
+		},
+		{
+			name: "a custom fingerprint replaces the default components",
+			a:    fingerprintInput{custom: []string{"payment-gateway"}, message: "card declined"},
+			b:    fingerprintInput{custom: []string{"payment-gateway"}, message: "gateway timeout"},
+			same: true,
+		},
+		{
+			name: "the default template keeps the default components",
+			a:    fingerprintInput{custom: []string{"{{ default }}", "eu"}, message: "card declined"},
+			b:    fingerprintInput{custom: []string{"{{ default }}", "eu"}, message: "gateway timeout"},
+			same: false,
+		},
+		{
+			name: "extra components next to the default template still count",
+			a:    fingerprintInput{custom: []string{"{{ default }}", "eu"}, message: "card declined"},
+			b:    fingerprintInput{custom: []string{"{{ default }}", "us"}, message: "card declined"},
+			same: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a.fingerprint(), tt.b.fingerprint()
+			if (a == b) != tt.same {
+				t.Errorf("fingerprints equal = %v, want %v (%s, %s)", a == b, tt.same, a, b)
+			}
+		})
+	}
+}
+
+func TestFingerprintCustom(t *testing.T) {
+	sum := sha256.Sum256([]byte("payment-gateway\ndeclined"))
+	want := hex.EncodeToString(sum[:])
+
+	if got := Fingerprint([]string{"payment-gateway", "declined"}, nil, "ignored"); got != want {
+		t.Errorf("Fingerprint() = %s, want %s", got, want)
+	}
+}
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame models.StackFrame
+		want  string
+	}{
+		{name: "module wins over file", frame: models.StackFrame{Module: "app.views", File: "app/views.py"}, want: "app.views"},
+		{name: "plain file", frame: models.StackFrame{File: "src/app.js"}, want: "src/app.js"},
+		{name: "url host is removed", frame: models.StackFrame{File: "https://cdn.example.com/js/app.js"}, want: "/js/app.js"},
+		{name: "query string is removed", frame: models.StackFrame{File: "/js/app.js?v=3"}, want: "/js/app.js"},
+		{name: "fragment is removed", frame: models.StackFrame{File: "/js/app.js#L10"}, want: "/js/app.js"},
+		{name: "build hash is removed", frame: models.StackFrame{File: "/js/chunk.a1b2c3d4e5.js"}, want: "/js/chunk..js"},
+		{name: "digits are removed", frame: models.StackFrame{File: "/js/vendor-2.js"}, want: "/js/vendor-.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeLocation(tt.frame); got != tt.want {
+				t.Errorf("normalizeLocation(%+v) = %q, want %q", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package legalhold
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	legalHoldRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/legalhold"
+)
+
+// Service handles legal hold business logic
+type Service struct {
+	legalHoldRepo *legalHoldRepo.Repository
+}
+
+// NewService creates a new legal hold service
+func NewService(legalHoldRepo *legalHoldRepo.Repository) *Service {
+	return &Service{
+		legalHoldRepo: legalHoldRepo,
+	}
+}
+
+// PlaceLegalHold places a new legal hold on a project and records it in the audit trail
+func (s *Service) PlaceLegalHold(ctx context.Context, projectID uuid.UUID, req dto.CreateLegalHoldRequest, actorID uuid.UUID) (*dto.LegalHoldResponse, error) {
+	// Validate business rules
+	if err := s.validateCreateLegalHold(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold := &models.LegalHold{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		Reason:      strings.TrimSpace(req.Reason),
+		RangeStart:  req.RangeStart,
+		RangeEnd:    req.RangeEnd,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedByID: actorID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	entry := s.newAuditEntry(hold, constants.LegalHoldActionCreated, actorID, "Hold placed: "+s.describeHold(hold))
+
+	// Save hold and audit entry together
+	if err := s.legalHoldRepo.Create(ctx, hold, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toLegalHoldResponse(hold), nil
+}
+
+// GetLegalHoldsByProject retrieves all legal holds for a project
+func (s *Service) GetLegalHoldsByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.LegalHoldResponse, error) {
+	holds, err := s.legalHoldRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.LegalHoldResponse, 0, len(holds))
+	for _, hold := range holds {
+		responses = append(responses, s.toLegalHoldResponse(hold))
+	}
+
+	return responses, nil
+}
+
+// UpdateLegalHold changes the reason or expiry of an active legal hold
+func (s *Service) UpdateLegalHold(ctx context.Context, id uuid.UUID, req dto.UpdateLegalHoldRequest, projectID uuid.UUID, actorID uuid.UUID) (*dto.LegalHoldResponse, error) {
+	hold, err := s.getActiveHold(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided, describing each change for the audit trail
+	var changes []string
+	if req.Reason != nil {
+		reason := strings.TrimSpace(*req.Reason)
+		if reason == "" {
+			return nil, errors.NewValidationError("Legal hold reason cannot be empty")
+		}
+		if reason != hold.Reason {
+			changes = append(changes, fmt.Sprintf("reason %q -> %q", hold.Reason, reason))
+			hold.Reason = reason
+		}
+	}
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return nil, errors.NewValidationError("Legal hold expiry must be in the future")
+		}
+		changes = append(changes, fmt.Sprintf("expires_at %s -> %s", s.formatTime(hold.ExpiresAt), s.formatTime(req.ExpiresAt)))
+		hold.ExpiresAt = req.ExpiresAt
+	}
+
+	if len(changes) == 0 {
+		return s.toLegalHoldResponse(hold), nil
+	}
+
+	hold.UpdatedAt = time.Now()
+	entry := s.newAuditEntry(hold, constants.LegalHoldActionUpdated, actorID, "Hold updated: "+strings.Join(changes, "; "))
+
+	// Save hold and audit entry together
+	if err := s.legalHoldRepo.Update(ctx, hold, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toLegalHoldResponse(hold), nil
+}
+
+// ReleaseLegalHold releases an active legal hold so retention applies again
+func (s *Service) ReleaseLegalHold(ctx context.Context, id uuid.UUID, projectID uuid.UUID, actorID uuid.UUID) (*dto.LegalHoldResponse, error) {
+	hold, err := s.getActiveHold(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold.ReleasedAt = &now
+	hold.ReleasedByID = &actorID
+	hold.UpdatedAt = now
+
+	entry := s.newAuditEntry(hold, constants.LegalHoldActionReleased, actorID, "Hold released: "+s.describeHold(hold))
+
+	// Save hold and audit entry together
+	if err := s.legalHoldRepo.Update(ctx, hold, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toLegalHoldResponse(hold), nil
+}
+
+// GetAuditTrailByProject retrieves the legal hold audit trail for a project
+func (s *Service) GetAuditTrailByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.LegalHoldAuditEntryResponse, error) {
+	entries, err := s.legalHoldRepo.GetAuditTrailByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.LegalHoldAuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, &dto.LegalHoldAuditEntryResponse{
+			ID:          entry.ID,
+			LegalHoldID: entry.LegalHoldID,
+			ProjectID:   entry.ProjectID,
+			Action:      entry.Action,
+			ActorID:     entry.ActorID,
+			Details:     entry.Details,
+			CreatedAt:   entry.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// IsRangeHeld reports whether any active hold covers part of [from, to] for a project
+// Retention purge and archive-delete jobs must skip any range for which this returns true
+func (s *Service) IsRangeHeld(ctx context.Context, projectID uuid.UUID, from, to time.Time) (bool, error) {
+	return s.legalHoldRepo.HasActiveHoldInRange(ctx, projectID, from, to, time.Now())
+}
+
+// HeldRange is a time range of a project's logs protected by an active hold, bounds included
+// A zero bound leaves that end open
+type HeldRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// GetHeldRanges returns the ranges of a project's logs that active holds protect
+func (s *Service) GetHeldRanges(ctx context.Context, projectID uuid.UUID) ([]HeldRange, error) {
+	holds, err := s.legalHoldRepo.GetActiveByProjectID(ctx, projectID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]HeldRange, 0, len(holds))
+	for _, hold := range holds {
+		var held HeldRange
+		if hold.RangeStart != nil {
+			held.From = *hold.RangeStart
+		}
+		if hold.RangeEnd != nil {
+			held.To = *hold.RangeEnd
+		}
+		ranges = append(ranges, held)
+	}
+	return ranges, nil
+}
+
+// getActiveHold retrieves a hold, verifies it belongs to the project and is still in force
+func (s *Service) getActiveHold(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.LegalHold, error) {
+	hold, err := s.legalHoldRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.ProjectID != projectID {
+		return nil, errors.NewForbiddenError("Unauthorized: legal hold doesn't belong to this project", "Legal hold ID: "+id.String())
+	}
+
+	if !hold.IsActive(time.Now()) {
+		return nil, errors.NewConflictError("Legal hold is no longer active", "Legal hold ID: "+id.String())
+	}
+
+	return hold, nil
+}
+
+// newAuditEntry builds an audit entry for a change to a hold
+func (s *Service) newAuditEntry(hold *models.LegalHold, action string, actorID uuid.UUID, details string) *models.LegalHoldAuditEntry {
+	return &models.LegalHoldAuditEntry{
+		ID:          uuid.New(),
+		LegalHoldID: hold.ID,
+		ProjectID:   hold.ProjectID,
+		Action:      action,
+		ActorID:     actorID,
+		Details:     details,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// describeHold summarizes a hold's reason, range and expiry for the audit trail
+func (s *Service) describeHold(hold *models.LegalHold) string {
+	return fmt.Sprintf("reason %q, range %s to %s, expires_at %s",
+		hold.Reason, s.formatTime(hold.RangeStart), s.formatTime(hold.RangeEnd), s.formatTime(hold.ExpiresAt))
+}
+
+// formatTime formats an optional timestamp for the audit trail
+func (s *Service) formatTime(t *time.Time) string {
+	if t == nil {
+		return "none"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// validateCreateLegalHold validates the create legal hold request
+func (s *Service) validateCreateLegalHold(req dto.CreateLegalHoldRequest) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return errors.NewValidationError("Legal hold reason is required")
+	}
+	if req.RangeStart != nil && req.RangeEnd != nil && req.RangeStart.After(*req.RangeEnd) {
+		return errors.NewValidationError("Legal hold range start must be before range end")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return errors.NewValidationError("Legal hold expiry must be in the future")
+	}
+	return nil
+}
+
+// toLegalHoldResponse converts a legal hold model to response DTO
+func (s *Service) toLegalHoldResponse(hold *models.LegalHold) *dto.LegalHoldResponse {
+	return &dto.LegalHoldResponse{
+		ID:           hold.ID,
+		ProjectID:    hold.ProjectID,
+		Reason:       hold.Reason,
+		RangeStart:   hold.RangeStart,
+		RangeEnd:     hold.RangeEnd,
+		ExpiresAt:    hold.ExpiresAt,
+		Active:       hold.IsActive(time.Now()),
+		CreatedByID:  hold.CreatedByID,
+		ReleasedAt:   hold.ReleasedAt,
+		ReleasedByID: hold.ReleasedByID,
+		CreatedAt:    hold.CreatedAt,
+		UpdatedAt:    hold.UpdatedAt,
+	}
+}
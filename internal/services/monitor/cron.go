@@ -0,0 +1,269 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros maps the supported shorthands to their five-field schedules
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// monthNames and dayNames are the names accepted in the month and day-of-week fields
+var (
+	monthNames = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+	dayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+)
+
+// cronSearchYears bounds how far ahead the next run is searched for, so a schedule such as
+// "0 0 30 2 *" that never runs does not loop forever
+const cronSearchYears = 5
+
+// allHours is the hour field of a schedule that runs in every hour
+const allHours = 1<<24 - 1
+
+// cronField describes the range and names of one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+// cronFields lists the fields of a cron expression in order
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// cronSchedule is a parsed five-field cron expression
+// Each field is a bit set of the values it matches
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day fields are "*"; as in standard cron, a day
+	// matches either day field when both are restricted, and both when one is "*"
+	domAny, dowAny bool
+}
+
+// parseCron parses a standard five-field cron expression (minute, hour, day of month,
+// month and day of week) or one of the @yearly, @monthly, @weekly, @daily and @hourly shorthands
+// Fields accept "*", values, ranges ("1-5"), steps ("*/15", "0-30/10"), lists ("1,15")
+// and month or day names ("JAN", "MON"); 7 is Sunday like 0
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields (minute hour day-of-month month day-of-week), got %d", len(cronFields), len(parts))
+	}
+
+	sets := make([]uint64, len(parts))
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 0 or 7
+	dow := sets[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+
+	return &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    dow,
+		domAny: parts[2] == "*" || parts[2] == "?",
+		dowAny: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// parseCronField parses one comma-separated field into a bit set
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, field.name)
+			}
+			step = parsed
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			low, high = field.min, field.max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(lowPart, field); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(highPart, field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, field.name)
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			high = low
+			if hasStep {
+				high = field.max
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a single number or name of a field and checks its range
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, field.name)
+	}
+	if n < field.min || n > field.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d in %s field", n, field.min, field.max, field.name)
+	}
+	return n, nil
+}
+
+// next returns the first time strictly after the given time that matches the schedule,
+// in the given time's location; the zero time if there is none within cronSearchYears
+// As in standard (Vixie) cron, unless the schedule runs every hour, times skipped when the
+// clock is turned forward run once right after the change, and times repeated when it is
+// turned back run once. Schedules running every hour follow the wall clock, so they skip
+// the missing hour and run in both repeated ones
+func (c *cronSchedule) next(after time.Time) time.Time {
+	t := c.nextOnWallClock(after)
+	if c.hour == allHours {
+		return t
+	}
+
+	// Run right after the first forward change before t that skipped a matching time
+	for _, end := after.ZoneBounds(); !end.IsZero() && (t.IsZero() || end.Before(t)); _, end = end.ZoneBounds() {
+		if end.Year() > after.Year()+cronSearchYears {
+			break
+		}
+		if c.skippedByChange(end) {
+			return end
+		}
+	}
+	return t
+}
+
+// nextOnWallClock returns the first time strictly after the given time whose wall clock
+// reading matches the schedule, skipping the second of two repeated readings unless the
+// schedule runs every hour
+func (c *cronSchedule) nextOnWallClock(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		year, month, day := t.Date()
+		switch {
+		case c.month&(1<<uint(month)) == 0:
+			t = advance(t, time.Date(year, month+1, 1, 0, 0, 0, 0, loc))
+		case !c.dayMatches(t):
+			t = advance(t, time.Date(year, month, day+1, 0, 0, 0, 0, loc))
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = advance(t, time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc))
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		case c.hour != allHours && repeatedWallClock(t):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of month and day of week fields match the time's day
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// skippedByChange reports whether the clock was turned forward at the given time, the start
+// of a zone period, skipping a wall clock reading that matches the schedule
+func (c *cronSchedule) skippedByChange(change time.Time) bool {
+	_, offset := change.Zone()
+	_, before := change.Add(-time.Second).Zone()
+	if offset <= before {
+		return false
+	}
+
+	// Walk the skipped readings on a clock without transitions
+	year, month, day := change.Date()
+	end := time.Date(year, month, day, change.Hour(), change.Minute(), 0, 0, time.UTC)
+	for t := end.Add(-time.Duration(offset-before) * time.Second); t.Before(end); t = t.Add(time.Minute) {
+		if c.month&(1<<uint(t.Month())) != 0 && c.dayMatches(t) &&
+			c.hour&(1<<uint(t.Hour())) != 0 && c.minute&(1<<uint(t.Minute())) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// repeatedWallClock reports whether the time's wall clock reading already occurred earlier,
+// as in the hour repeated when daylight saving time ends
+func repeatedWallClock(t time.Time) bool {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return false
+	}
+
+	_, offset := t.Zone()
+	_, before := start.Add(-time.Second).Zone()
+	return before > offset && t.Sub(start) < time.Duration(before-offset)*time.Second
+}
+
+// advance moves to the candidate time, or by a minute if a daylight saving transition
+// would otherwise keep the search in place
+func advance(current, candidate time.Time) time.Time {
+	if !candidate.After(current) {
+		return current.Add(time.Minute)
+	}
+	return candidate
+}
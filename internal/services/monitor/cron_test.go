@@ -0,0 +1,166 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// bits returns the bit set of the given values
+func bits(values ...int) uint64 {
+	var set uint64
+	for _, v := range values {
+		set |= 1 << v
+	}
+	return set
+}
+
+// span returns the bit set of the values from low to high
+func span(low, high int) uint64 {
+	var set uint64
+	for v := low; v <= high; v++ {
+		set |= 1 << v
+	}
+	return set
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want cronSchedule
+	}{
+		{
+			name: "wildcards",
+			expr: "* * * * *",
+			want: cronSchedule{minute: span(0, 59), hour: span(0, 23), dom: span(1, 31), month: span(1, 12), dow: span(0, 7), domAny: true, dowAny: true},
+		},
+		{
+			name: "lists, steps, ranges and names",
+			expr: "1,15 */6 1-5 JAN-mar MON",
+			want: cronSchedule{minute: bits(1, 15), hour: bits(0, 6, 12, 18), dom: span(1, 5), month: span(1, 3), dow: bits(1)},
+		},
+		{
+			name: "step from a value runs to the end of the field",
+			expr: "10/20 0-12/4 ? * ?",
+			want: cronSchedule{minute: bits(10, 30, 50), hour: bits(0, 4, 8, 12), dom: span(1, 31), month: span(1, 12), dow: span(0, 7), domAny: true, dowAny: true},
+		},
+		{
+			name: "seven is Sunday",
+			expr: "0 0 * * 7",
+			want: cronSchedule{minute: bits(0), hour: bits(0), dom: span(1, 31), month: span(1, 12), dow: bits(0, 7), domAny: true},
+		},
+		{
+			name: "shorthand",
+			expr: " @Daily ",
+			want: cronSchedule{minute: bits(0), hour: bits(0), dom: span(1, 31), month: span(1, 12), dow: span(0, 7), domAny: true, dowAny: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q) failed: %v", tt.expr, err)
+			}
+			if *got != tt.want {
+				t.Errorf("parseCron(%q) = %+v, want %+v", tt.expr, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"abc * * * *",
+		"* * * FOO *",
+		"@every 5m",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if schedule, err := parseCron(expr); err == nil {
+				t.Errorf("parseCron(%q) = %+v, want an error", expr, *schedule)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+	utc := func(year int, month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	}
+	ny := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, newYork)
+	}
+	// nyAt names a New York time by its UTC time, for the ambiguous 01:00-01:59 on 2024-11-03
+	nyAt := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return utc(year, month, day, hour, minute, 0).In(newYork)
+	}
+
+	// In New York clocks go forward from 02:00 to 03:00 on 2024-03-10 and back from
+	// 02:00 to 01:00 on 2024-11-03, so 01:00-01:59 happens twice that day
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time // zero when the schedule never runs
+	}{
+		{name: "every fifteen minutes", expr: "*/15 * * * *", after: utc(2024, 1, 1, 10, 7, 0), want: utc(2024, 1, 1, 10, 15, 0)},
+		{name: "strictly after a matching time", expr: "0 10 * * *", after: utc(2024, 1, 1, 10, 0, 0), want: utc(2024, 1, 2, 10, 0, 0)},
+		{name: "seconds are ignored", expr: "* * * * *", after: utc(2024, 1, 1, 10, 0, 30), want: utc(2024, 1, 1, 10, 1, 0)},
+		{name: "shorthand", expr: "@hourly", after: utc(2024, 1, 1, 10, 59, 59), want: utc(2024, 1, 1, 11, 0, 0)},
+		{name: "weekdays skip the weekend", expr: "0 9 * * MON-FRI", after: utc(2024, 1, 5, 9, 0, 0), want: utc(2024, 1, 8, 9, 0, 0)},
+		{name: "Sunday written as seven", expr: "0 12 * * 7", after: utc(2024, 1, 1, 0, 0, 0), want: utc(2024, 1, 7, 12, 0, 0)},
+		{name: "day of month alone", expr: "0 0 13 * *", after: utc(2024, 1, 1, 0, 0, 0), want: utc(2024, 1, 13, 0, 0, 0)},
+		{name: "restricted day fields match either day", expr: "0 0 13 * FRI", after: utc(2024, 1, 1, 0, 0, 0), want: utc(2024, 1, 5, 0, 0, 0)},
+		{name: "next year", expr: "0 0 1 JAN *", after: utc(2024, 6, 1, 0, 0, 0), want: utc(2025, 1, 1, 0, 0, 0)},
+		{name: "leap day", expr: "0 0 29 2 *", after: utc(2024, 3, 1, 0, 0, 0), want: utc(2028, 2, 29, 0, 0, 0)},
+		{name: "never runs", expr: "0 0 30 2 *", after: utc(2024, 1, 1, 0, 0, 0)},
+		{name: "in the given location", expr: "0 9 * * *", after: ny(2024, 1, 1, 10, 0), want: ny(2024, 1, 2, 9, 0)},
+		{name: "time skipped by the clock going forward runs right after the change", expr: "30 2 * * *", after: ny(2024, 3, 9, 12, 0), want: ny(2024, 3, 10, 3, 0)},
+		{name: "skipped time runs once", expr: "30 2 * * *", after: ny(2024, 3, 10, 3, 0), want: ny(2024, 3, 11, 2, 30)},
+		{name: "several skipped times run once", expr: "*/15 2 * * *", after: ny(2024, 3, 10, 1, 50), want: ny(2024, 3, 10, 3, 0)},
+		{name: "time before the change runs first", expr: "45 1,2 * * *", after: ny(2024, 3, 10, 1, 0), want: ny(2024, 3, 10, 1, 45)},
+		{name: "skipped time on another day does not run", expr: "30 2 * * SAT", after: ny(2024, 3, 9, 12, 0), want: ny(2024, 3, 16, 2, 30)},
+		{name: "times outside the skipped hour are unaffected", expr: "0 4 * * *", after: ny(2024, 3, 9, 12, 0), want: ny(2024, 3, 10, 4, 0)},
+		{name: "hourly skips the hour the clock goes forward over", expr: "0 * * * *", after: ny(2024, 3, 10, 1, 30), want: ny(2024, 3, 10, 3, 0)},
+		{name: "first of the repeated times runs", expr: "30 1 * * *", after: ny(2024, 11, 3, 0, 0), want: nyAt(2024, 11, 3, 5, 30)},
+		{name: "second of the repeated times does not run", expr: "30 1 * * *", after: nyAt(2024, 11, 3, 5, 30), want: ny(2024, 11, 4, 1, 30)},
+		{name: "repeated hour is skipped for several runs an hour", expr: "*/30 1 * * *", after: nyAt(2024, 11, 3, 5, 30), want: ny(2024, 11, 4, 1, 0)},
+		{name: "hourly runs in both repeated hours", expr: "0 * * * *", after: nyAt(2024, 11, 3, 5, 0), want: nyAt(2024, 11, 3, 6, 0)},
+		{name: "after the repeated hour", expr: "0 2 * * *", after: ny(2024, 11, 3, 0, 0), want: ny(2024, 11, 3, 2, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q) failed: %v", tt.expr, err)
+			}
+
+			got := schedule.next(tt.after)
+			if !got.Equal(tt.want) {
+				t.Errorf("next(%s) = %s, want %s", tt.after, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.after.Location() {
+				t.Errorf("next(%s) is in %s, want %s", tt.after, got.Location(), tt.after.Location())
+			}
+		})
+	}
+}
@@ -0,0 +1,825 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed the time zone database so cron schedules work on hosts without one
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	monitorRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/monitor"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
+)
+
+// slugPattern matches valid monitor slugs
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// slugSeparators matches the runs of characters replaced by a dash when deriving a slug from a name
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// checkInStatuses lists the statuses a job can check in with
+var checkInStatuses = map[string]bool{
+	constants.CheckInStatusInProgress: true,
+	constants.CheckInStatusOK:         true,
+	constants.CheckInStatusError:      true,
+}
+
+// runStatuses lists every status a run can have
+var runStatuses = map[string]bool{
+	constants.CheckInStatusInProgress: true,
+	constants.CheckInStatusOK:         true,
+	constants.CheckInStatusError:      true,
+	constants.CheckInStatusMissed:     true,
+	constants.CheckInStatusTimeout:    true,
+}
+
+// Notification describes a monitor changing status
+type Notification struct {
+	MonitorID   uuid.UUID
+	ProjectID   uuid.UUID
+	MonitorName string
+	MonitorSlug string
+	Status      string // "missed", "failed" or "ok" (recovered)
+	CheckInID   uuid.UUID
+	ExpectedAt  *time.Time
+	Message     string
+	OccurredAt  time.Time
+	ChannelIDs  []uuid.UUID       // Notification channels picked by the monitor
+	Labels      map[string]string // Labels silences match on
+}
+
+// Notifier delivers monitor notifications
+type Notifier interface {
+	NotifyMonitor(ctx context.Context, notification Notification) error
+}
+
+// LogNotifier writes monitor notifications to the application log
+type LogNotifier struct{}
+
+// NotifyMonitor logs the notification
+func (LogNotifier) NotifyMonitor(ctx context.Context, notification Notification) error {
+	log.Printf("Monitor %q (%s) for project %s is %s", notification.MonitorSlug, notification.MonitorID,
+		notification.ProjectID, notification.Status)
+	return nil
+}
+
+// Service handles monitor management, check-ins and missed run detection
+type Service struct {
+	monitorRepo      *monitorRepo.Repository
+	notificationRepo *notificationRepo.Repository
+	notifier         Notifier
+}
+
+// NewService creates a new monitor service
+func NewService(monitorRepo *monitorRepo.Repository, notificationRepo *notificationRepo.Repository, notifier Notifier) *Service {
+	return &Service{
+		monitorRepo:      monitorRepo,
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+	}
+}
+
+// CreateMonitor creates a monitor, expecting its first run at the next scheduled time
+func (s *Service) CreateMonitor(ctx context.Context, projectID uuid.UUID, req dto.CreateMonitorRequest, createdByID uuid.UUID) (*dto.MonitorResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.validateName(name); err != nil {
+		return nil, err
+	}
+
+	slug := strings.TrimSpace(req.Slug)
+	if slug == "" {
+		slug = strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+		if len(slug) > constants.MaxMonitorSlugLength {
+			slug = strings.TrimRight(slug[:constants.MaxMonitorSlugLength], "-")
+		}
+	}
+	if err := s.validateSlug(slug); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	monitor := &models.Monitor{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Name:           name,
+		Slug:           slug,
+		ScheduleType:   req.ScheduleType,
+		CronExpression: strings.TrimSpace(req.CronExpression),
+		Timezone:       strings.TrimSpace(req.Timezone),
+		MarginSeconds:  constants.DefaultMonitorMarginSeconds,
+		Enabled:        req.Enabled == nil || *req.Enabled,
+		Status:         constants.MonitorStatusPending,
+		CreatedByID:    createdByID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	var err error
+	if req.Interval != "" {
+		monitor.IntervalSeconds, err = parseSeconds("Interval", req.Interval, constants.MinMonitorIntervalSeconds, constants.MaxMonitorIntervalSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Margin != "" {
+		monitor.MarginSeconds, err = parseSeconds("Margin", req.Margin, 0, constants.MaxMonitorMarginSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.MaxRuntime != "" {
+		monitor.MaxRuntimeSeconds, err = parseSeconds("Max runtime", req.MaxRuntime, 1, constants.MaxMonitorRuntimeSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	monitor.ChannelIDs, err = s.validateChannels(ctx, projectID, req.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	monitor.Labels, err = validator.ValidateLabels("Monitor labels", req.Labels, constants.MaxMonitorLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the schedule and work out when the first run is expected
+	if err := s.validateSchedule(monitor); err != nil {
+		return nil, err
+	}
+	if err := s.reschedule(monitor, now); err != nil {
+		return nil, err
+	}
+
+	slugExists, err := s.monitorRepo.SlugExistsForProject(ctx, slug, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if slugExists {
+		return nil, errors.NewConflictError("Monitor with this slug already exists in project", "Slug: "+slug)
+	}
+
+	if err := s.monitorRepo.Create(ctx, monitor); err != nil {
+		return nil, err
+	}
+
+	return s.toMonitorResponse(monitor), nil
+}
+
+// GetMonitorByID retrieves a monitor of a project
+func (s *Service) GetMonitorByID(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*dto.MonitorResponse, error) {
+	monitor, err := s.getProjectMonitor(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toMonitorResponse(monitor), nil
+}
+
+// GetMonitorsByProject lists a project's monitors
+func (s *Service) GetMonitorsByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.MonitorResponse, error) {
+	monitors, err := s.monitorRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.MonitorResponse, 0, len(monitors))
+	for _, monitor := range monitors {
+		responses = append(responses, s.toMonitorResponse(monitor))
+	}
+
+	return responses, nil
+}
+
+// UpdateMonitor updates a monitor's definition
+// Changing the schedule or enabling the monitor expects the next run from now on;
+// changing only the margin moves the deadline of the run already expected
+func (s *Service) UpdateMonitor(ctx context.Context, id uuid.UUID, req dto.UpdateMonitorRequest, projectID uuid.UUID) (*dto.MonitorResponse, error) {
+	monitor, err := s.getProjectMonitor(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := s.validateName(name); err != nil {
+			return nil, err
+		}
+		monitor.Name = name
+	}
+	if req.Slug != nil {
+		slug := strings.TrimSpace(*req.Slug)
+		if err := s.validateSlug(slug); err != nil {
+			return nil, err
+		}
+
+		// Check if new slug already exists for this project (excluding current monitor)
+		slugExists, err := s.monitorRepo.SlugExistsForProject(ctx, slug, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if slugExists && monitor.Slug != slug {
+			return nil, errors.NewConflictError("Monitor with this slug already exists in project", "Slug: "+slug)
+		}
+
+		monitor.Slug = slug
+	}
+
+	rescheduled := false
+	if req.ScheduleType != nil && *req.ScheduleType != monitor.ScheduleType {
+		monitor.ScheduleType = *req.ScheduleType
+		rescheduled = true
+	}
+	if req.CronExpression != nil {
+		monitor.CronExpression = strings.TrimSpace(*req.CronExpression)
+		rescheduled = true
+	}
+	if req.Timezone != nil {
+		monitor.Timezone = strings.TrimSpace(*req.Timezone)
+		rescheduled = true
+	}
+	if req.Interval != nil {
+		monitor.IntervalSeconds, err = parseSeconds("Interval", *req.Interval, constants.MinMonitorIntervalSeconds, constants.MaxMonitorIntervalSeconds)
+		if err != nil {
+			return nil, err
+		}
+		rescheduled = true
+	}
+	if req.Margin != nil {
+		monitor.MarginSeconds, err = parseSeconds("Margin", *req.Margin, 0, constants.MaxMonitorMarginSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.ClearMaxRuntime {
+		monitor.MaxRuntimeSeconds = 0
+	} else if req.MaxRuntime != nil {
+		monitor.MaxRuntimeSeconds, err = parseSeconds("Max runtime", *req.MaxRuntime, 1, constants.MaxMonitorRuntimeSeconds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.ChannelIDs != nil {
+		monitor.ChannelIDs, err = s.validateChannels(ctx, projectID, *req.ChannelIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Labels != nil {
+		monitor.Labels, err = validator.ValidateLabels("Monitor labels", req.Labels, constants.MaxMonitorLabels)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Enabled != nil && *req.Enabled != monitor.Enabled {
+		monitor.Enabled = *req.Enabled
+		rescheduled = true
+	}
+
+	// Validate the schedule
+	if err := s.validateSchedule(monitor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if rescheduled || (monitor.Enabled && monitor.NextCheckInAt == nil) {
+		if err := s.reschedule(monitor, now); err != nil {
+			return nil, err
+		}
+	} else if monitor.NextCheckInAt != nil {
+		s.setSchedule(monitor, *monitor.NextCheckInAt)
+	}
+	monitor.UpdatedAt = now
+
+	// Save changes
+	if err := s.monitorRepo.Update(ctx, monitor); err != nil {
+		return nil, err
+	}
+
+	return s.toMonitorResponse(monitor), nil
+}
+
+// DeleteMonitor deletes a monitor and its run history
+func (s *Service) DeleteMonitor(ctx context.Context, id uuid.UUID, projectID uuid.UUID) error {
+	if _, err := s.getProjectMonitor(ctx, id, projectID); err != nil {
+		return err
+	}
+	return s.monitorRepo.Delete(ctx, id)
+}
+
+// GetCheckIns retrieves a monitor's run history, newest first
+// Status filters to runs with that status; empty returns every run
+func (s *Service) GetCheckIns(ctx context.Context, id uuid.UUID, projectID uuid.UUID, status string, limit int) ([]*dto.MonitorCheckInResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultCheckInLimit
+	}
+	if limit < 1 || limit > constants.MaxCheckInLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxCheckInLimit))
+	}
+	if status != "" && !runStatuses[status] {
+		return nil, errors.NewValidationError("Status must be one of 'in_progress', 'ok', 'error', 'missed' or 'timeout'")
+	}
+
+	if _, err := s.getProjectMonitor(ctx, id, projectID); err != nil {
+		return nil, err
+	}
+
+	checkIns, err := s.monitorRepo.GetCheckIns(ctx, id, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.MonitorCheckInResponse, 0, len(checkIns))
+	for _, checkIn := range checkIns {
+		responses = append(responses, s.toCheckInResponse(checkIn))
+	}
+
+	return responses, nil
+}
+
+// CheckIn records a check-in sent by a monitored job
+//
+// An "in_progress" check-in starts a run; the "ok" or "error" check-in that follows closes it
+// and records its duration. A finishing check-in without a run to close records the whole run.
+// A run that starts no earlier than the margin before the expected time counts as the expected
+// run, and the next run is expected from then on; other runs are recorded without moving the
+// schedule of cron monitors. Check-ins of disabled monitors are recorded but change nothing.
+func (s *Service) CheckIn(ctx context.Context, projectID uuid.UUID, slug string, req dto.CheckInRequest) (*dto.MonitorCheckInResponse, error) {
+	status := req.Status
+	if status == "" {
+		status = constants.CheckInStatusOK
+	}
+	if !checkInStatuses[status] {
+		return nil, errors.NewValidationError("Status must be one of 'in_progress', 'ok' or 'error'")
+	}
+	message := strings.TrimSpace(req.Message)
+	if len(message) > constants.MaxCheckInMessageLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("Message must be at most %d characters", constants.MaxCheckInMessageLength))
+	}
+	if req.DurationMs != nil && *req.DurationMs < 0 {
+		return nil, errors.NewValidationError("Duration cannot be negative")
+	}
+
+	monitor, err := s.monitorRepo.GetBySlug(ctx, projectID, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// A finishing check-in closes the given run, or the latest run still in progress
+	if status != constants.CheckInStatusInProgress {
+		var open *models.MonitorCheckIn
+		if req.CheckInID != nil {
+			open, err = s.monitorRepo.GetCheckIn(ctx, monitor.ID, *req.CheckInID)
+			if err != nil {
+				return nil, err
+			}
+			if open.Status != constants.CheckInStatusInProgress {
+				return nil, errors.NewConflictError("Check-in has already finished", "Status: "+open.Status)
+			}
+		} else {
+			open, err = s.monitorRepo.GetLatestInProgress(ctx, monitor.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if open != nil {
+			return s.finishCheckIn(ctx, monitor, open, status, message, req.DurationMs, now)
+		}
+	}
+
+	checkIn := &models.MonitorCheckIn{
+		ID:        uuid.New(),
+		MonitorID: monitor.ID,
+		Status:    status,
+		Message:   message,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if status == constants.CheckInStatusInProgress {
+		checkIn.StartedAt = &now
+		if monitor.Enabled && monitor.MaxRuntimeSeconds > 0 {
+			timeoutAt := now.Add(time.Duration(monitor.MaxRuntimeSeconds) * time.Second)
+			checkIn.TimeoutAt = &timeoutAt
+		}
+	} else {
+		checkIn.FinishedAt = &now
+		if req.DurationMs != nil {
+			startedAt := now.Add(-time.Duration(*req.DurationMs) * time.Millisecond)
+			checkIn.StartedAt = &startedAt
+			checkIn.DurationMs = req.DurationMs
+		}
+	}
+
+	previous := monitor.Status
+	monitor.LastCheckInAt = &now
+	if monitor.Enabled {
+		// Count the run as the expected one and expect the next run
+		margin := time.Duration(monitor.MarginSeconds) * time.Second
+		if monitor.NextCheckInAt != nil && !now.Before(monitor.NextCheckInAt.Add(-margin)) {
+			expectedAt := *monitor.NextCheckInAt
+			checkIn.ExpectedAt = &expectedAt
+		}
+		if checkIn.ExpectedAt != nil || monitor.ScheduleType == constants.MonitorScheduleInterval {
+			after := now
+			if checkIn.ExpectedAt != nil && checkIn.ExpectedAt.After(now) && monitor.ScheduleType == constants.MonitorScheduleCron {
+				after = *checkIn.ExpectedAt
+			}
+			next, err := s.nextExpected(monitor, after)
+			if err != nil {
+				return nil, err
+			}
+			s.setSchedule(monitor, next)
+		}
+		s.applyRunStatus(monitor, status, now)
+	}
+
+	if err := s.monitorRepo.RecordCheckIn(ctx, monitor, checkIn); err != nil {
+		return nil, err
+	}
+	s.notifyChange(ctx, monitor, previous, checkIn, now)
+
+	return s.toCheckInResponse(checkIn), nil
+}
+
+// RecordMissed records the run a claimed monitor expected as missed and expects the next run
+// If the scheduler fell behind, the runs whose deadlines have also passed are skipped, so an
+// outage is recorded as a single missed run
+func (s *Service) RecordMissed(ctx context.Context, monitor *models.Monitor, now time.Time) error {
+	if monitor.NextCheckInAt == nil {
+		return nil
+	}
+	expectedAt := *monitor.NextCheckInAt
+	margin := time.Duration(monitor.MarginSeconds) * time.Second
+
+	next, err := s.nextExpected(monitor, expectedAt)
+	if err == nil && !next.Add(margin).After(now) {
+		next, err = s.nextExpected(monitor, now.Add(-margin))
+	}
+	if err != nil {
+		// The schedule no longer produces runs; stop expecting any
+		log.Printf("Monitor %s has no next run: %v", monitor.ID, err)
+		monitor.NextCheckInAt = nil
+		monitor.DeadlineAt = nil
+	} else {
+		s.setSchedule(monitor, next)
+	}
+
+	checkIn := &models.MonitorCheckIn{
+		ID:         uuid.New(),
+		MonitorID:  monitor.ID,
+		Status:     constants.CheckInStatusMissed,
+		ExpectedAt: &expectedAt,
+		Message:    fmt.Sprintf("No check-in within %s of the expected time", formatSeconds(monitor.MarginSeconds)),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	previous := monitor.Status
+	s.applyRunStatus(monitor, checkIn.Status, now)
+
+	recorded, err := s.monitorRepo.RecordMissed(ctx, monitor, checkIn, expectedAt)
+	if err != nil {
+		return err
+	}
+	if recorded {
+		s.notifyChange(ctx, monitor, previous, checkIn, now)
+	}
+	return nil
+}
+
+// TimeOut closes a run that is still in progress past the monitor's maximum runtime
+// The run's monitor must be loaded
+func (s *Service) TimeOut(ctx context.Context, checkIn *models.MonitorCheckIn, now time.Time) error {
+	monitor := &checkIn.Monitor
+
+	checkIn.Status = constants.CheckInStatusTimeout
+	checkIn.Message = fmt.Sprintf("Run did not finish within %s", formatSeconds(monitor.MaxRuntimeSeconds))
+	checkIn.UpdatedAt = now
+
+	previous := monitor.Status
+	if monitor.Enabled {
+		s.applyRunStatus(monitor, checkIn.Status, now)
+	}
+
+	finished, err := s.monitorRepo.FinishCheckIn(ctx, monitor, checkIn)
+	if err != nil {
+		return err
+	}
+	if finished {
+		s.notifyChange(ctx, monitor, previous, checkIn, now)
+	}
+	return nil
+}
+
+// finishCheckIn closes a run in progress with the outcome the job reported
+func (s *Service) finishCheckIn(ctx context.Context, monitor *models.Monitor, checkIn *models.MonitorCheckIn, status string, message string, durationMs *int64, now time.Time) (*dto.MonitorCheckInResponse, error) {
+	checkIn.Status = status
+	checkIn.FinishedAt = &now
+	checkIn.TimeoutAt = nil
+	checkIn.UpdatedAt = now
+	if message != "" {
+		checkIn.Message = message
+	}
+	switch {
+	case durationMs != nil:
+		checkIn.DurationMs = durationMs
+	case checkIn.StartedAt != nil:
+		duration := now.Sub(*checkIn.StartedAt).Milliseconds()
+		checkIn.DurationMs = &duration
+	}
+
+	previous := monitor.Status
+	monitor.LastCheckInAt = &now
+	if monitor.Enabled {
+		s.applyRunStatus(monitor, status, now)
+	}
+
+	finished, err := s.monitorRepo.FinishCheckIn(ctx, monitor, checkIn)
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		return nil, errors.NewConflictError("Check-in has already finished", "ID: "+checkIn.ID.String())
+	}
+	s.notifyChange(ctx, monitor, previous, checkIn, now)
+
+	return s.toCheckInResponse(checkIn), nil
+}
+
+// applyRunStatus sets the monitor status that follows from a run's status
+// Runs in progress leave the status unchanged
+func (s *Service) applyRunStatus(monitor *models.Monitor, runStatus string, now time.Time) {
+	status := monitor.Status
+	switch runStatus {
+	case constants.CheckInStatusOK:
+		status = constants.MonitorStatusOK
+	case constants.CheckInStatusError, constants.CheckInStatusTimeout:
+		status = constants.MonitorStatusFailed
+	case constants.CheckInStatusMissed:
+		status = constants.MonitorStatusMissed
+	}
+
+	if status != monitor.Status {
+		monitor.Status = status
+		monitor.StatusChangedAt = &now
+	}
+}
+
+// notifyChange notifies the monitor's channels when it starts missing or failing runs,
+// and when it recovers; failures to notify are logged so the check-in is never lost
+func (s *Service) notifyChange(ctx context.Context, monitor *models.Monitor, previous string, checkIn *models.MonitorCheckIn, now time.Time) {
+	if !monitor.Enabled || monitor.Status == previous {
+		return
+	}
+	recovered := monitor.Status == constants.MonitorStatusOK &&
+		(previous == constants.MonitorStatusMissed || previous == constants.MonitorStatusFailed)
+	if monitor.Status == constants.MonitorStatusOK && !recovered {
+		return
+	}
+
+	notification := Notification{
+		MonitorID:   monitor.ID,
+		ProjectID:   monitor.ProjectID,
+		MonitorName: monitor.Name,
+		MonitorSlug: monitor.Slug,
+		Status:      monitor.Status,
+		CheckInID:   checkIn.ID,
+		ExpectedAt:  checkIn.ExpectedAt,
+		Message:     checkIn.Message,
+		OccurredAt:  now,
+		ChannelIDs:  monitor.ChannelIDs,
+		Labels:      monitor.Labels,
+	}
+	if err := s.notifier.NotifyMonitor(ctx, notification); err != nil {
+		log.Printf("Failed to send notification for monitor %s: %v", monitor.ID, err)
+	}
+}
+
+// reschedule expects the monitor's next run from now on, or nothing while it is disabled
+// Interval monitors keep expecting the run due after their last check-in if it is still ahead
+func (s *Service) reschedule(monitor *models.Monitor, now time.Time) error {
+	if !monitor.Enabled {
+		monitor.NextCheckInAt = nil
+		monitor.DeadlineAt = nil
+		return nil
+	}
+
+	after := now
+	if monitor.ScheduleType == constants.MonitorScheduleInterval && monitor.LastCheckInAt != nil {
+		interval := time.Duration(monitor.IntervalSeconds) * time.Second
+		if monitor.LastCheckInAt.Add(interval).After(now) {
+			after = *monitor.LastCheckInAt
+		}
+	}
+
+	next, err := s.nextExpected(monitor, after)
+	if err != nil {
+		return err
+	}
+	s.setSchedule(monitor, next)
+	return nil
+}
+
+// setSchedule expects the monitor's next run at the given time
+func (s *Service) setSchedule(monitor *models.Monitor, next time.Time) {
+	deadline := next.Add(time.Duration(monitor.MarginSeconds) * time.Second)
+	monitor.NextCheckInAt = &next
+	monitor.DeadlineAt = &deadline
+}
+
+// nextExpected returns when the run following the given time is expected
+func (s *Service) nextExpected(monitor *models.Monitor, after time.Time) (time.Time, error) {
+	if monitor.ScheduleType == constants.MonitorScheduleInterval {
+		return after.Add(time.Duration(monitor.IntervalSeconds) * time.Second), nil
+	}
+
+	schedule, err := parseCron(monitor.CronExpression)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError("Cron expression is not valid", err.Error())
+	}
+	location, err := time.LoadLocation(monitor.Timezone)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError("Timezone must be an IANA time zone such as Europe/Berlin", err.Error())
+	}
+
+	next := schedule.next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, errors.NewValidationError("Cron expression never runs")
+	}
+	return next, nil
+}
+
+// getProjectMonitor retrieves a monitor, reporting monitors of other projects as missing
+func (s *Service) getProjectMonitor(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (*models.Monitor, error) {
+	monitor, err := s.monitorRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.ProjectID != projectID {
+		return nil, errors.NewNotFoundError("Monitor", id.String())
+	}
+	return monitor, nil
+}
+
+// validateName validates a monitor name
+func (s *Service) validateName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("Monitor name is required")
+	}
+	if len(name) > constants.MaxMonitorNameLength {
+		return errors.NewValidationError(fmt.Sprintf("Monitor name must be at most %d characters", constants.MaxMonitorNameLength))
+	}
+	return nil
+}
+
+// validateSlug validates a monitor slug
+func (s *Service) validateSlug(slug string) error {
+	if slug == "" {
+		return errors.NewValidationError("Monitor slug is required when the name has no letters or digits")
+	}
+	if !slugPattern.MatchString(slug) || len(slug) > constants.MaxMonitorSlugLength {
+		return errors.NewValidationError(fmt.Sprintf("Monitor slug must start with a lowercase letter or digit, contain only lowercase letters, digits, '_' or '-', and be at most %d characters",
+			constants.MaxMonitorSlugLength))
+	}
+	return nil
+}
+
+// validateSchedule validates the schedule type and the settings it needs
+// Settings of the other schedule type are cleared
+func (s *Service) validateSchedule(monitor *models.Monitor) error {
+	switch monitor.ScheduleType {
+	case constants.MonitorScheduleCron:
+		if monitor.CronExpression == "" {
+			return errors.NewValidationError("Cron expression is required for 'cron' monitors")
+		}
+		if monitor.Timezone == "" {
+			monitor.Timezone = "UTC"
+		}
+		monitor.IntervalSeconds = 0
+
+		// Parses the expression and checks that it runs
+		if _, err := s.nextExpected(monitor, time.Now()); err != nil {
+			return err
+		}
+	case constants.MonitorScheduleInterval:
+		if monitor.IntervalSeconds == 0 {
+			return errors.NewValidationError("Interval is required for 'interval' monitors")
+		}
+		monitor.CronExpression = ""
+		monitor.Timezone = "UTC"
+	default:
+		return errors.NewValidationError("Schedule type must be one of 'cron' or 'interval'")
+	}
+	return nil
+}
+
+// validateChannels checks that the channels exist in the project's organization,
+// returning them without duplicates
+func (s *Service) validateChannels(ctx context.Context, projectID uuid.UUID, channelIDs []uuid.UUID) ([]uuid.UUID, error) {
+	unique := make([]uuid.UUID, 0, len(channelIDs))
+	seen := make(map[uuid.UUID]bool, len(channelIDs))
+	for _, id := range channelIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return unique, nil
+	}
+	if len(unique) > constants.MaxMonitorChannels {
+		return nil, errors.NewValidationError(fmt.Sprintf("A monitor can notify at most %d channels", constants.MaxMonitorChannels))
+	}
+
+	count, err := s.notificationRepo.CountForProject(ctx, projectID, unique)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(unique)) {
+		return nil, errors.NewValidationError("Notification channels must exist in the project's organization")
+	}
+
+	return unique, nil
+}
+
+// parseSeconds parses a duration such as "5m" into whole seconds within [lower, upper]
+func parseSeconds(field string, value string, lower, upper int) (int, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.NewValidationError(field+" must be a duration such as 5m or 1h", err.Error())
+	}
+	if duration%time.Second != 0 {
+		return 0, errors.NewValidationError(field + " must be a whole number of seconds")
+	}
+
+	seconds := int(duration / time.Second)
+	if seconds < lower || seconds > upper {
+		return 0, errors.NewValidationError(fmt.Sprintf("%s must be between %s and %s", field,
+			formatSeconds(lower), formatSeconds(upper)))
+	}
+	return seconds, nil
+}
+
+// formatSeconds renders a number of seconds as a duration string such as "5m0s"
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// toMonitorResponse converts a monitor model to response DTO
+func (s *Service) toMonitorResponse(monitor *models.Monitor) *dto.MonitorResponse {
+	response := &dto.MonitorResponse{
+		ID:              monitor.ID,
+		ProjectID:       monitor.ProjectID,
+		Name:            monitor.Name,
+		Slug:            monitor.Slug,
+		ScheduleType:    monitor.ScheduleType,
+		CronExpression:  monitor.CronExpression,
+		Timezone:        monitor.Timezone,
+		Margin:          formatSeconds(monitor.MarginSeconds),
+		ChannelIDs:      monitor.ChannelIDs,
+		Labels:          monitor.Labels,
+		Enabled:         monitor.Enabled,
+		Status:          monitor.Status,
+		StatusChangedAt: monitor.StatusChangedAt,
+		LastCheckInAt:   monitor.LastCheckInAt,
+		NextCheckInAt:   monitor.NextCheckInAt,
+		DeadlineAt:      monitor.DeadlineAt,
+		CreatedByID:     monitor.CreatedByID,
+		CreatedAt:       monitor.CreatedAt,
+		UpdatedAt:       monitor.UpdatedAt,
+	}
+	if monitor.IntervalSeconds > 0 {
+		response.Interval = formatSeconds(monitor.IntervalSeconds)
+	}
+	if monitor.MaxRuntimeSeconds > 0 {
+		response.MaxRuntime = formatSeconds(monitor.MaxRuntimeSeconds)
+	}
+	return response
+}
+
+// toCheckInResponse converts a monitor check-in model to response DTO
+func (s *Service) toCheckInResponse(checkIn *models.MonitorCheckIn) *dto.MonitorCheckInResponse {
+	return &dto.MonitorCheckInResponse{
+		ID:         checkIn.ID,
+		MonitorID:  checkIn.MonitorID,
+		Status:     checkIn.Status,
+		ExpectedAt: checkIn.ExpectedAt,
+		StartedAt:  checkIn.StartedAt,
+		FinishedAt: checkIn.FinishedAt,
+		DurationMs: checkIn.DurationMs,
+		TimeoutAt:  checkIn.TimeoutAt,
+		Message:    checkIn.Message,
+		CreatedAt:  checkIn.CreatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	monitorRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/monitor"
+)
+
+// Default scheduler settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval     = 30 * time.Second
+	defaultBatchSize        = 100
+	defaultCheckInRetention = 90 * 24 * time.Hour
+)
+
+// claimLease is how long a claimed monitor is hidden from other schedulers while its missed
+// run is recorded; a scheduler that dies mid-batch leaves the monitor due again afterwards
+const claimLease = time.Minute
+
+// SchedulerConfig controls how often missed and timed out runs are looked for
+type SchedulerConfig struct {
+	// TickInterval is how often the scheduler looks for missed and timed out runs
+	TickInterval time.Duration
+
+	// BatchSize caps how many monitors and runs are handled per batch
+	BatchSize int
+
+	// CheckInRetention is how long run history is kept
+	CheckInRetention time.Duration
+}
+
+// SchedulerConfigFromEnv reads MONITOR_TICK_INTERVAL, MONITOR_BATCH_SIZE and MONITOR_CHECK_IN_RETENTION
+func SchedulerConfigFromEnv() SchedulerConfig {
+	config := SchedulerConfig{
+		TickInterval:     envDuration("MONITOR_TICK_INTERVAL", defaultTickInterval),
+		BatchSize:        defaultBatchSize,
+		CheckInRetention: envDuration("MONITOR_CHECK_IN_RETENTION", defaultCheckInRetention),
+	}
+
+	if value := os.Getenv("MONITOR_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Printf("Invalid MONITOR_BATCH_SIZE %q, using default of %d", value, defaultBatchSize)
+		} else {
+			config.BatchSize = size
+		}
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "30s" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// Scheduler periodically records missed and timed out runs, notifying on status changes
+// Monitors are claimed with row locks, so several API instances can run schedulers side by side
+type Scheduler struct {
+	monitorService *Service
+	monitorRepo    *monitorRepo.Repository
+	config         SchedulerConfig
+}
+
+// NewScheduler creates a new monitor scheduler
+func NewScheduler(monitorService *Service, monitorRepo *monitorRepo.Repository, config SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		monitorService: monitorService,
+		monitorRepo:    monitorRepo,
+		config:         config,
+	}
+}
+
+// Start looks for missed and timed out runs on every tick until the context is cancelled
+// Run history older than the retention period is pruned about once an hour
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("Monitor scheduler started (tick %s, batch size %d, history retention %s)",
+		s.config.TickInterval, s.config.BatchSize, s.config.CheckInRetention)
+
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		if err := s.RunDue(ctx); err != nil {
+			log.Printf("Monitor scheduler failed: %v", err)
+		}
+
+		if time.Since(lastPrune) >= time.Hour {
+			lastPrune = time.Now()
+			if pruned, err := s.monitorRepo.DeleteCheckInsBefore(ctx, lastPrune.Add(-s.config.CheckInRetention)); err != nil {
+				log.Printf("Failed to prune monitor check-ins: %v", err)
+			} else if pruned > 0 {
+				log.Printf("Pruned %d monitor check-ins", pruned)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue records every missed run, batch by batch, then times out one batch of overdue runs
+func (s *Scheduler) RunDue(ctx context.Context) error {
+	for {
+		now := time.Now()
+		monitors, err := s.monitorRepo.ClaimMissed(ctx, now, claimLease, s.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, monitor := range monitors {
+			if err := s.monitorService.RecordMissed(ctx, monitor, now); err != nil {
+				log.Printf("Failed to record missed run of monitor %s: %v", monitor.ID, err)
+			}
+		}
+
+		if len(monitors) < s.config.BatchSize {
+			break
+		}
+	}
+
+	now := time.Now()
+	checkIns, err := s.monitorRepo.GetTimedOut(ctx, now, s.config.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, checkIn := range checkIns {
+		if err := s.monitorService.TimeOut(ctx, checkIn, now); err != nil {
+			log.Printf("Failed to time out check-in %s of monitor %s: %v", checkIn.ID, checkIn.MonitorID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+// blockedPrefixes lists special-purpose ranges that the standard library's IP
+// classification does not cover but that must not be reachable from channel URLs
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT, also used for cloud metadata
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can map to any IPv4 address
+	netip.MustParsePrefix("2002::/16"),     // 6to4, which embeds an IPv4 address
+}
+
+// errBlockedAddress is returned when a channel URL resolves to an internal address
+var errBlockedAddress = goerrors.New("URL resolves to a loopback, private, link-local or otherwise internal address")
+
+// isPublicAddress reports whether notifications may be sent to addr
+// Loopback, private, link-local (including the 169.254.169.254 metadata service),
+// multicast, unspecified and other special-purpose addresses are rejected
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHost resolves a channel URL's host and rejects it if any of its addresses is internal
+// It gives early feedback when a channel is saved; the dialer repeats the check on every
+// connection because DNS answers can change between the two
+func checkHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublicAddress(addr) {
+			return errBlockedAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("URL host %q could not be resolved", host)
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return errBlockedAddress
+		}
+	}
+	return nil
+}
+
+// dialControl runs after DNS resolution and before each connection is made, so it sees the
+// exact address being dialed; checking here rather than only when the URL is saved blocks DNS
+// rebinding, where a host first resolves to a public address and later to an internal one
+func dialControl(network, address string, conn syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected dial address %q: %w", address, err)
+	}
+	if !isPublicAddress(addrPort.Addr()) {
+		return errBlockedAddress
+	}
+	return nil
+}
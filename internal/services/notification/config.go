@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default settings used when the corresponding environment variables are not set
+const (
+	defaultTickInterval      = 10 * time.Second
+	defaultMaxAttempts       = 8
+	defaultRetryDelay        = 30 * time.Second
+	defaultMaxRetryDelay     = time.Hour
+	defaultDeliveryRetention = 30 * 24 * time.Hour
+	defaultSendTimeout       = 10 * time.Second
+	defaultSMTPPort          = "587"
+)
+
+// SMTP TLS modes
+const (
+	SMTPTLSStartTLS = "starttls" // Upgrade with STARTTLS when the server offers it
+	SMTPTLSImplicit = "implicit" // Connect over TLS, typically on port 465
+	SMTPTLSNone     = "none"     // Plain connection, for local SMTP stand-ins
+)
+
+// Config controls how notifications are sent and retried
+type Config struct {
+	// TickInterval is how often the dispatcher looks for due deliveries
+	TickInterval time.Duration
+
+	// MaxAttempts is how many times a delivery is attempted before it is marked failed
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry; it doubles with every attempt
+	// up to MaxRetryDelay
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// DeliveryRetention is how long the delivery log is kept
+	DeliveryRetention time.Duration
+
+	// SendTimeout bounds a single webhook request or SMTP session
+	SendTimeout time.Duration
+
+	// AllowHTTP permits plain http:// channel URLs, for local webhook stand-ins
+	AllowHTTP bool
+
+	// AllowPrivateNetworks permits channel URLs that resolve to loopback, private or link-local
+	// addresses, for local development only; it must stay off wherever users can create channels
+	AllowPrivateNetworks bool
+
+	// SMTP server used by email channels
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTLS      string
+}
+
+// ConfigFromEnv reads the NOTIFICATION_* and SMTP_* environment variables
+func ConfigFromEnv() Config {
+	config := Config{
+		TickInterval:         envDuration("NOTIFICATION_TICK_INTERVAL", defaultTickInterval),
+		MaxAttempts:          defaultMaxAttempts,
+		RetryDelay:           envDuration("NOTIFICATION_RETRY_DELAY", defaultRetryDelay),
+		MaxRetryDelay:        envDuration("NOTIFICATION_MAX_RETRY_DELAY", defaultMaxRetryDelay),
+		DeliveryRetention:    envDuration("NOTIFICATION_DELIVERY_RETENTION", defaultDeliveryRetention),
+		SendTimeout:          envDuration("NOTIFICATION_SEND_TIMEOUT", defaultSendTimeout),
+		AllowHTTP:            os.Getenv("NOTIFICATION_ALLOW_HTTP") == "true",
+		AllowPrivateNetworks: os.Getenv("NOTIFICATION_ALLOW_PRIVATE_NETWORKS") == "true",
+		SMTPHost:             os.Getenv("SMTP_HOST"),
+		SMTPPort:             os.Getenv("SMTP_PORT"),
+		SMTPUsername:         os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:         os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:             os.Getenv("SMTP_FROM"),
+		SMTPTLS:              strings.ToLower(os.Getenv("SMTP_TLS")),
+	}
+
+	if value := os.Getenv("NOTIFICATION_MAX_ATTEMPTS"); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts <= 0 {
+			log.Printf("Invalid NOTIFICATION_MAX_ATTEMPTS %q, using default of %d", value, defaultMaxAttempts)
+		} else {
+			config.MaxAttempts = attempts
+		}
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		log.Printf("NOTIFICATION_MAX_RETRY_DELAY is below NOTIFICATION_RETRY_DELAY, using %s", config.RetryDelay)
+		config.MaxRetryDelay = config.RetryDelay
+	}
+
+	if config.SMTPPort == "" {
+		config.SMTPPort = defaultSMTPPort
+	}
+	switch config.SMTPTLS {
+	case "":
+		config.SMTPTLS = SMTPTLSStartTLS
+	case SMTPTLSStartTLS, SMTPTLSImplicit, SMTPTLSNone:
+	default:
+		log.Printf("Invalid SMTP_TLS %q, using %s", config.SMTPTLS, SMTPTLSStartTLS)
+		config.SMTPTLS = SMTPTLSStartTLS
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "30s" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+)
+
+// Dispatcher settings
+const (
+	// dispatchBatchSize caps how many deliveries are claimed and sent concurrently
+	dispatchBatchSize = 50
+
+	// deliveryLease is how long a claimed delivery is hidden from other dispatchers;
+	// it must comfortably exceed the send timeout
+	deliveryLease = 5 * time.Minute
+)
+
+// Dispatcher periodically sends queued and retried notification deliveries
+// Deliveries are claimed with row locks, so several API instances can run dispatchers side by side
+type Dispatcher struct {
+	notificationService *Service
+	notificationRepo    *notificationRepo.Repository
+}
+
+// NewDispatcher creates a new notification dispatcher
+func NewDispatcher(notificationService *Service, notificationRepo *notificationRepo.Repository) *Dispatcher {
+	return &Dispatcher{
+		notificationService: notificationService,
+		notificationRepo:    notificationRepo,
+	}
+}
+
+// Start sends due deliveries on every tick until the context is cancelled
+// Finished deliveries older than the retention period are pruned about once an hour
+func (d *Dispatcher) Start(ctx context.Context) {
+	config := d.notificationService.config
+	log.Printf("Notification dispatcher started (tick %s, %d attempts, retry delay %s up to %s, log retention %s)",
+		config.TickInterval, config.MaxAttempts, config.RetryDelay, config.MaxRetryDelay, config.DeliveryRetention)
+
+	ticker := time.NewTicker(config.TickInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		if err := d.RunDue(ctx); err != nil {
+			log.Printf("Notification dispatcher failed: %v", err)
+		}
+
+		if time.Since(lastPrune) >= time.Hour {
+			lastPrune = time.Now()
+			if pruned, err := d.notificationRepo.DeleteDeliveriesBefore(ctx, lastPrune.Add(-config.DeliveryRetention)); err != nil {
+				log.Printf("Failed to prune notification deliveries: %v", err)
+			} else if pruned > 0 {
+				log.Printf("Pruned %d notification deliveries", pruned)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue sends every delivery that is due, batch by batch
+func (d *Dispatcher) RunDue(ctx context.Context) error {
+	for {
+		deliveries, err := d.notificationRepo.ClaimDueDeliveries(ctx, time.Now(), deliveryLease, dispatchBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		channels, err := d.loadChannels(ctx, deliveries)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			channel, ok := channels[delivery.ChannelID]
+			if !ok {
+				continue // Channel deleted since the delivery was claimed; its log went with it
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := d.notificationService.deliver(ctx, channel, delivery, d.notificationService.config.MaxAttempts); err != nil {
+					log.Printf("Failed to record notification delivery %s: %v", delivery.ID, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < dispatchBatchSize {
+			return nil
+		}
+	}
+}
+
+// loadChannels fetches the channels of a batch of deliveries, keyed by ID
+func (d *Dispatcher) loadChannels(ctx context.Context, deliveries []*models.NotificationDelivery) (map[uuid.UUID]*models.NotificationChannel, error) {
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	seen := make(map[uuid.UUID]bool, len(deliveries))
+	for _, delivery := range deliveries {
+		if !seen[delivery.ChannelID] {
+			seen[delivery.ChannelID] = true
+			ids = append(ids, delivery.ChannelID)
+		}
+	}
+
+	channels, err := d.notificationRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*models.NotificationChannel, len(channels))
+	for _, channel := range channels {
+		byID[channel.ID] = channel
+	}
+	return byID, nil
+}
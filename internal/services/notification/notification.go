@@ -0,0 +1,594 @@
+package notification
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	notificationRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/notification"
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/services/monitor"
+)
+
+// channelTypes lists the supported channel types
+var channelTypes = map[string]bool{
+	constants.NotificationChannelWebhook: true,
+	constants.NotificationChannelSlack:   true,
+	constants.NotificationChannelEmail:   true,
+}
+
+// Service handles notification channel management and notification delivery
+// It implements alert.Notifier and monitor.Notifier, queueing one delivery per channel
+// picked by the rule or monitor
+type Service struct {
+	notificationRepo *notificationRepo.Repository
+	sender           *Sender
+	config           Config
+}
+
+// NewService creates a new notification channel service
+func NewService(notificationRepo *notificationRepo.Repository, config Config) *Service {
+	return &Service{
+		notificationRepo: notificationRepo,
+		sender:           NewSender(config),
+		config:           config,
+	}
+}
+
+// CreateChannel creates a notification channel, issuing a signing secret for webhook channels
+func (s *Service) CreateChannel(ctx context.Context, organizationID uuid.UUID, req dto.CreateNotificationChannelRequest, createdByID uuid.UUID) (*dto.NotificationChannelResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.validateName(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	channel := &models.NotificationChannel{
+		ID:              uuid.New(),
+		OrganizationID:  organizationID,
+		Name:            name,
+		Type:            req.Type,
+		URL:             strings.TrimSpace(req.URL),
+		Recipients:      normalizeRecipients(req.Recipients),
+		SubjectTemplate: req.SubjectTemplate,
+		BodyTemplate:    req.BodyTemplate,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+		CreatedByID:     createdByID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	// Validate business rules
+	if err := s.validateChannel(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	nameExists, err := s.notificationRepo.NameExistsForOrganization(ctx, name, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if nameExists {
+		return nil, errors.NewConflictError("Notification channel with this name already exists in organization", "Name: "+name)
+	}
+
+	if channel.Type == constants.NotificationChannelWebhook {
+		if channel.Secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.notificationRepo.Create(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	return s.toChannelResponse(channel, true), nil
+}
+
+// GetChannelByID retrieves a notification channel of an organization
+func (s *Service) GetChannelByID(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.NotificationChannelResponse, error) {
+	channel, err := s.getOrganizationChannel(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toChannelResponse(channel, false), nil
+}
+
+// GetChannelsByOrganization lists an organization's notification channels
+func (s *Service) GetChannelsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*dto.NotificationChannelResponse, error) {
+	channels, err := s.notificationRepo.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.NotificationChannelResponse, 0, len(channels))
+	for _, channel := range channels {
+		responses = append(responses, s.toChannelResponse(channel, false))
+	}
+
+	return responses, nil
+}
+
+// UpdateChannel updates a notification channel
+// The new secret is included in the response when RotateSecret is set
+func (s *Service) UpdateChannel(ctx context.Context, id uuid.UUID, req dto.UpdateNotificationChannelRequest, organizationID uuid.UUID) (*dto.NotificationChannelResponse, error) {
+	channel, err := s.getOrganizationChannel(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := s.validateName(name); err != nil {
+			return nil, err
+		}
+
+		// Check if new name already exists for this organization (excluding current channel)
+		nameExists, err := s.notificationRepo.NameExistsForOrganization(ctx, name, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if nameExists && channel.Name != name {
+			return nil, errors.NewConflictError("Notification channel with this name already exists in organization", "Name: "+name)
+		}
+
+		channel.Name = name
+	}
+	if req.URL != nil {
+		channel.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Recipients != nil {
+		channel.Recipients = normalizeRecipients(req.Recipients)
+	}
+	if req.SubjectTemplate != nil {
+		channel.SubjectTemplate = *req.SubjectTemplate
+	}
+	if req.BodyTemplate != nil {
+		channel.BodyTemplate = *req.BodyTemplate
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+
+	// Validate business rules
+	if err := s.validateChannel(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	if req.RotateSecret {
+		if channel.Type != constants.NotificationChannelWebhook {
+			return nil, errors.NewValidationError("Only webhook channels have a signing secret")
+		}
+		if channel.Secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	channel.UpdatedAt = time.Now()
+
+	// Save changes
+	if err := s.notificationRepo.Update(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	return s.toChannelResponse(channel, req.RotateSecret), nil
+}
+
+// DeleteChannel deletes a notification channel and its delivery log
+// Alert rules that picked the channel stop notifying it
+func (s *Service) DeleteChannel(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) error {
+	if _, err := s.getOrganizationChannel(ctx, id, organizationID); err != nil {
+		return err
+	}
+	return s.notificationRepo.Delete(ctx, id)
+}
+
+// SendTest sends a sample notification to the channel right away, without retries
+// The attempt is recorded in the delivery log like any other delivery
+func (s *Service) SendTest(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.NotificationDeliveryResponse, error) {
+	channel, err := s.getOrganizationChannel(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.newDelivery(channel, sampleTemplateData(time.Now()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the dispatcher from picking the delivery up while it is being sent here
+	delivery.NextAttemptAt = nil
+	if err := s.notificationRepo.CreateDeliveries(ctx, []*models.NotificationDelivery{delivery}); err != nil {
+		return nil, err
+	}
+
+	if err := s.deliver(ctx, channel, delivery, 1); err != nil {
+		return nil, err
+	}
+
+	return s.toDeliveryResponse(delivery), nil
+}
+
+// GetDeliveries retrieves a channel's delivery log, newest first
+func (s *Service) GetDeliveries(ctx context.Context, id uuid.UUID, organizationID uuid.UUID, limit int) ([]*dto.NotificationDeliveryResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultDeliveryLimit
+	}
+	if limit < 1 || limit > constants.MaxDeliveryLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxDeliveryLimit))
+	}
+
+	if _, err := s.getOrganizationChannel(ctx, id, organizationID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.notificationRepo.GetDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.NotificationDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, s.toDeliveryResponse(delivery))
+	}
+
+	return responses, nil
+}
+
+// Notify queues a delivery of the alert notification to each enabled channel the rule picked
+// The dispatcher sends queued deliveries; the state change is also written to the log
+func (s *Service) Notify(ctx context.Context, notification alert.Notification) error {
+	alert.LogNotifier{}.Notify(ctx, notification)
+
+	channels, err := s.notificationRepo.GetByIDs(ctx, notification.ChannelIDs)
+	if err != nil {
+		return err
+	}
+
+	event := constants.NotificationEventAlertFiring
+	if notification.State == constants.AlertStateOK {
+		event = constants.NotificationEventAlertResolved
+	}
+	data := TemplateData{
+		Event:       event,
+		RuleID:      notification.RuleID,
+		RuleName:    notification.RuleName,
+		ProjectID:   notification.ProjectID,
+		State:       notification.State,
+		Value:       notification.Value,
+		Aggregation: notification.Aggregation,
+		Comparison:  notification.Comparison,
+		Threshold:   notification.Threshold,
+		EvaluatedAt: notification.EvaluatedAt,
+	}
+
+	deliveries := make([]*models.NotificationDelivery, 0, len(channels))
+	for _, channel := range channels {
+		if !channel.Enabled {
+			continue
+		}
+
+		delivery, err := s.newDelivery(channel, data, &notification.RuleID)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return s.notificationRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// NotifyMonitor queues a delivery of the monitor notification to each enabled channel the monitor picked
+// The dispatcher sends queued deliveries; the status change is also written to the log
+func (s *Service) NotifyMonitor(ctx context.Context, notification monitor.Notification) error {
+	monitor.LogNotifier{}.NotifyMonitor(ctx, notification)
+
+	channels, err := s.notificationRepo.GetByIDs(ctx, notification.ChannelIDs)
+	if err != nil {
+		return err
+	}
+
+	event := constants.NotificationEventMonitorRecovered
+	switch notification.Status {
+	case constants.MonitorStatusMissed:
+		event = constants.NotificationEventMonitorMissed
+	case constants.MonitorStatusFailed:
+		event = constants.NotificationEventMonitorFailed
+	}
+	data := TemplateData{
+		Event:       event,
+		ProjectID:   notification.ProjectID,
+		State:       notification.Status,
+		EvaluatedAt: notification.OccurredAt,
+		MonitorID:   notification.MonitorID,
+		MonitorName: notification.MonitorName,
+		MonitorSlug: notification.MonitorSlug,
+		CheckInID:   notification.CheckInID,
+		ExpectedAt:  notification.ExpectedAt,
+		Message:     notification.Message,
+	}
+
+	deliveries := make([]*models.NotificationDelivery, 0, len(channels))
+	for _, channel := range channels {
+		if !channel.Enabled {
+			continue
+		}
+
+		delivery, err := s.newDelivery(channel, data, nil)
+		if err != nil {
+			return err
+		}
+		delivery.MonitorID = &notification.MonitorID
+		deliveries = append(deliveries, delivery)
+	}
+
+	return s.notificationRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// deliver makes one attempt at a delivery and records the outcome
+// Failed attempts are retried with exponential backoff until maxAttempts is reached,
+// unless the failure is permanent (e.g. the endpoint rejected the request)
+func (s *Service) deliver(ctx context.Context, channel *models.NotificationChannel, delivery *models.NotificationDelivery, maxAttempts int) error {
+	var status *int
+	var err error
+	if channel.Enabled {
+		status, err = s.sender.Send(ctx, channel, delivery)
+	} else {
+		err = &permanentError{fmt.Errorf("channel is disabled")}
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = constants.DeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case isPermanent(err) || delivery.Attempts >= maxAttempts:
+		delivery.Status = constants.DeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(s.backoff(delivery.Attempts))
+		delivery.Status = constants.DeliveryStatusPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+
+	return s.notificationRepo.UpdateDelivery(ctx, delivery)
+}
+
+// backoff returns the delay before the retry following the given attempt
+// The delay doubles with every attempt up to the maximum, less up to 20% jitter so
+// deliveries that failed together do not all retry at the same moment
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := s.config.RetryDelay
+	for i := 1; i < attempts && delay < s.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.config.MaxRetryDelay)
+	return delay - rand.N(delay/5+1)
+}
+
+// newDelivery renders a notification for a channel into a pending delivery
+// A template that fails at send time falls back to the default, so the alert is never lost
+func (s *Service) newDelivery(channel *models.NotificationChannel, data TemplateData, ruleID *uuid.UUID) (*models.NotificationDelivery, error) {
+	defaultSubject, defaultBody := defaultTemplates(data.Event)
+	message, err := render("body", channel.BodyTemplate, defaultBody, data)
+	if err != nil {
+		log.Printf("Body template of notification channel %s failed, using the default: %v", channel.ID, err)
+		message, _ = render("body", "", defaultBody, data)
+	}
+
+	now := time.Now()
+	delivery := &models.NotificationDelivery{
+		ID:            uuid.New(),
+		ChannelID:     channel.ID,
+		AlertRuleID:   ruleID,
+		Event:         data.Event,
+		Status:        constants.DeliveryStatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	var body []byte
+	switch channel.Type {
+	case constants.NotificationChannelWebhook:
+		payload := dto.NotificationWebhookPayload{
+			ID:      delivery.ID,
+			Event:   data.Event,
+			Test:    data.Test,
+			Message: message,
+		}
+		if isMonitorEvent(data.Event) {
+			payload.Monitor = &dto.NotificationMonitor{
+				MonitorID:   data.MonitorID,
+				MonitorName: data.MonitorName,
+				MonitorSlug: data.MonitorSlug,
+				ProjectID:   data.ProjectID,
+				Status:      data.State,
+				CheckInID:   data.CheckInID,
+				ExpectedAt:  data.ExpectedAt,
+				Message:     data.Message,
+				OccurredAt:  data.EvaluatedAt,
+			}
+		} else {
+			payload.Alert = &dto.NotificationAlert{
+				RuleID:      data.RuleID,
+				RuleName:    data.RuleName,
+				ProjectID:   data.ProjectID,
+				State:       data.State,
+				Value:       data.Value,
+				Aggregation: data.Aggregation,
+				Comparison:  data.Comparison,
+				Threshold:   data.Threshold,
+				EvaluatedAt: data.EvaluatedAt,
+			}
+		}
+		body, err = json.Marshal(payload)
+	case constants.NotificationChannelSlack:
+		body, err = json.Marshal(dto.SlackMessage{Text: message})
+	case constants.NotificationChannelEmail:
+		body = []byte(message)
+		delivery.Subject, err = render("subject", channel.SubjectTemplate, defaultSubject, data)
+		if err != nil {
+			log.Printf("Subject template of notification channel %s failed, using the default: %v", channel.ID, err)
+			delivery.Subject, err = render("subject", "", defaultSubject, data)
+		}
+	}
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to render notification", err.Error())
+	}
+	delivery.Body = string(body)
+
+	return delivery, nil
+}
+
+// getOrganizationChannel retrieves a channel, reporting channels of other organizations as missing
+func (s *Service) getOrganizationChannel(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*models.NotificationChannel, error) {
+	channel, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if channel.OrganizationID != organizationID {
+		return nil, errors.NewNotFoundError("Notification channel", id.String())
+	}
+	return channel, nil
+}
+
+// validateName validates a notification channel name
+func (s *Service) validateName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("Notification channel name is required")
+	}
+	if len(name) > constants.MaxNotificationChannelNameLength {
+		return errors.NewValidationError(fmt.Sprintf("Notification channel name must be at most %d characters", constants.MaxNotificationChannelNameLength))
+	}
+	return nil
+}
+
+// validateChannel validates the type-specific settings and templates of a channel
+func (s *Service) validateChannel(ctx context.Context, channel *models.NotificationChannel) error {
+	if !channelTypes[channel.Type] {
+		return errors.NewValidationError("Type must be one of 'webhook', 'slack' or 'email'")
+	}
+
+	if channel.Type == constants.NotificationChannelEmail {
+		if channel.URL != "" {
+			return errors.NewValidationError("Email channels do not take a URL")
+		}
+		if len(channel.Recipients) == 0 {
+			return errors.NewValidationError("Email channels need at least one recipient")
+		}
+		if len(channel.Recipients) > constants.MaxEmailRecipients {
+			return errors.NewValidationError(fmt.Sprintf("Email channels can have at most %d recipients", constants.MaxEmailRecipients))
+		}
+		for _, recipient := range channel.Recipients {
+			if address, err := mail.ParseAddress(recipient); err != nil || address.Address != recipient {
+				return errors.NewValidationError("Invalid recipient email address: " + recipient)
+			}
+		}
+	} else {
+		if len(channel.Recipients) > 0 {
+			return errors.NewValidationError("Only email channels take recipients")
+		}
+		if channel.URL == "" {
+			return errors.NewValidationError("URL is required for " + channel.Type + " channels")
+		}
+		if err := s.sender.ValidateURL(ctx, channel.URL); err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+	}
+
+	if err := validateTemplate("Subject template", channel.SubjectTemplate, defaultSubjectTemplate); err != nil {
+		return err
+	}
+	return validateTemplate("Body template", channel.BodyTemplate, defaultBodyTemplate)
+}
+
+// normalizeRecipients trims recipient addresses and drops blanks and duplicates
+func normalizeRecipients(recipients []string) []string {
+	normalized := make([]string, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		recipient = strings.TrimSpace(recipient)
+		key := strings.ToLower(recipient)
+		if recipient == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, recipient)
+	}
+	return normalized
+}
+
+// generateSecret returns a random 256-bit webhook signing secret, hex encoded
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := cryptorand.Read(secret); err != nil {
+		return "", errors.NewInternalError("Failed to generate signing secret", err.Error())
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// toChannelResponse converts a notification channel model to response DTO
+// The signing secret is only included when it has just been issued
+func (s *Service) toChannelResponse(channel *models.NotificationChannel, includeSecret bool) *dto.NotificationChannelResponse {
+	response := &dto.NotificationChannelResponse{
+		ID:              channel.ID,
+		OrganizationID:  channel.OrganizationID,
+		Name:            channel.Name,
+		Type:            channel.Type,
+		URL:             channel.URL,
+		Recipients:      channel.Recipients,
+		SubjectTemplate: channel.SubjectTemplate,
+		BodyTemplate:    channel.BodyTemplate,
+		Enabled:         channel.Enabled,
+		CreatedByID:     channel.CreatedByID,
+		CreatedAt:       channel.CreatedAt,
+		UpdatedAt:       channel.UpdatedAt,
+	}
+	if includeSecret {
+		response.Secret = channel.Secret
+	}
+	return response
+}
+
+// toDeliveryResponse converts a notification delivery model to response DTO
+func (s *Service) toDeliveryResponse(delivery *models.NotificationDelivery) *dto.NotificationDeliveryResponse {
+	return &dto.NotificationDeliveryResponse{
+		ID:             delivery.ID,
+		ChannelID:      delivery.ChannelID,
+		AlertRuleID:    delivery.AlertRuleID,
+		MonitorID:      delivery.MonitorID,
+		Event:          delivery.Event,
+		Subject:        delivery.Subject,
+		Body:           delivery.Body,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastError:      delivery.LastError,
+		ResponseStatus: delivery.ResponseStatus,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,308 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+)
+
+// Headers sent with webhook and slack requests
+// Receivers verify X-Valtro-Signature, which is "sha256=" followed by the hex HMAC-SHA256
+// of "{X-Valtro-Timestamp}.{body}" keyed with the channel secret
+const (
+	headerEvent     = "X-Valtro-Event"
+	headerDelivery  = "X-Valtro-Delivery"
+	headerTimestamp = "X-Valtro-Timestamp"
+	headerSignature = "X-Valtro-Signature"
+	userAgent       = "Valtro-Notifications/1.0"
+)
+
+// permanentError marks a failure that retrying cannot fix, such as a rejected request
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// isPermanent reports whether a send error should not be retried
+func isPermanent(err error) bool {
+	var permanent *permanentError
+	return goerrors.As(err, &permanent)
+}
+
+// Sender delivers rendered notifications over HTTP and SMTP
+type Sender struct {
+	config Config
+	client *http.Client
+}
+
+// NewSender creates a sender; redirects are not followed so a channel cannot be bounced elsewhere
+// Connections to internal addresses are refused at dial time unless AllowPrivateNetworks is set,
+// and proxies from the environment are ignored so the check always sees the real destination
+func NewSender(config Config) *Sender {
+	dialer := &net.Dialer{Timeout: config.SendTimeout}
+	if !config.AllowPrivateNetworks {
+		dialer.Control = dialControl
+	}
+
+	return &Sender{
+		config: config,
+		client: &http.Client{
+			Timeout: config.SendTimeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: config.SendTimeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// ValidateURL checks that a webhook or slack URL can be posted to, including that its host
+// does not resolve to an internal address
+func (s *Sender) ValidateURL(ctx context.Context, raw string) error {
+	parsed, err := s.parseURL(raw)
+	if err != nil {
+		return err
+	}
+	if s.config.AllowPrivateNetworks {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.SendTimeout)
+	defer cancel()
+	return checkHost(ctx, parsed.Hostname())
+}
+
+// parseURL parses a webhook or slack URL and checks its scheme and credentials
+func (s *Sender) parseURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return nil, goerrors.New("URL must be an absolute https URL")
+	}
+	switch {
+	case parsed.Scheme == "https":
+	case parsed.Scheme == "http" && s.config.AllowHTTP:
+	default:
+		return nil, goerrors.New("URL must use https")
+	}
+	if parsed.User != nil {
+		return nil, goerrors.New("URL cannot contain credentials")
+	}
+	return parsed, nil
+}
+
+// Send makes one delivery attempt, returning the HTTP status for webhook and slack channels
+func (s *Sender) Send(ctx context.Context, channel *models.NotificationChannel, delivery *models.NotificationDelivery) (*int, error) {
+	switch channel.Type {
+	case constants.NotificationChannelWebhook, constants.NotificationChannelSlack:
+		return s.post(ctx, channel, delivery)
+	case constants.NotificationChannelEmail:
+		return nil, s.sendEmail(ctx, channel, delivery)
+	default:
+		return nil, &permanentError{fmt.Errorf("unsupported channel type %q", channel.Type)}
+	}
+}
+
+// post sends the delivery body as JSON, signing it for webhook channels
+func (s *Sender) post(ctx context.Context, channel *models.NotificationChannel, delivery *models.NotificationDelivery) (*int, error) {
+	// Addresses are checked by the dialer; resolving here as well would only add a lookup
+	if _, err := s.parseURL(channel.URL); err != nil {
+		return nil, &permanentError{err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.URL, strings.NewReader(delivery.Body))
+	if err != nil {
+		return nil, &permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	if channel.Type == constants.NotificationChannelWebhook {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(headerEvent, delivery.Event)
+		req.Header.Set(headerDelivery, delivery.ID.String())
+		req.Header.Set(headerTimestamp, timestamp)
+		req.Header.Set(headerSignature, "sha256="+sign(channel.Secret, timestamp, delivery.Body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// Drop the URL from the error, it may carry a secret token (as Slack webhook URLs do)
+		var urlErr *url.Error
+		if goerrors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		if goerrors.Is(err, errBlockedAddress) {
+			return nil, &permanentError{errBlockedAddress}
+		}
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The body is drained so the connection can be reused, but never read into the error:
+	// errors end up in the delivery log, and echoing receiver responses back to API callers
+	// would let channels be used to read internal services
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	status := resp.StatusCode
+	if status >= 200 && status < 300 {
+		return &status, nil
+	}
+
+	err = fmt.Errorf("endpoint responded with status %d", status)
+
+	// Client errors other than timeouts and rate limiting will fail again the same way
+	if status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return &status, &permanentError{err}
+	}
+	return &status, err
+}
+
+// sign computes the hex HMAC-SHA256 of "{timestamp}.{body}"
+func sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendEmail sends the delivery as a plain text email over one SMTP session
+func (s *Sender) sendEmail(ctx context.Context, channel *models.NotificationChannel, delivery *models.NotificationDelivery) error {
+	if s.config.SMTPHost == "" || s.config.SMTPFrom == "" {
+		return &permanentError{goerrors.New("email is not configured (SMTP_HOST and SMTP_FROM are required)")}
+	}
+
+	message, err := buildEmail(s.config.SMTPFrom, channel.Recipients, delivery)
+	if err != nil {
+		return &permanentError{err}
+	}
+
+	conn, err := s.dialSMTP(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	client, err := smtp.NewClient(conn, s.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if s.config.SMTPTLS == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.config.SMTPHost}); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		}
+	}
+	if s.config.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword, s.config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return smtpError("authentication failed", err)
+		}
+	}
+
+	if err := client.Mail(s.config.SMTPFrom); err != nil {
+		return smtpError("sender rejected", err)
+	}
+	for _, recipient := range channel.Recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return smtpError("recipient "+recipient+" rejected", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return smtpError("message rejected", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError("message rejected", err)
+	}
+
+	return client.Quit()
+}
+
+// dialSMTP connects to the SMTP server, over TLS in implicit mode, with the send timeout
+// applied to the whole session
+func (s *Sender) dialSMTP(ctx context.Context) (net.Conn, error) {
+	address := net.JoinHostPort(s.config.SMTPHost, s.config.SMTPPort)
+	dialer := &net.Dialer{Timeout: s.config.SendTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.config.SMTPTLS == SMTPTLSImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.config.SMTPHost}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(s.config.SendTimeout))
+	return conn, nil
+}
+
+// smtpError wraps an SMTP failure, marking permanent (5xx) replies as such
+func smtpError(action string, err error) error {
+	wrapped := fmt.Errorf("%s: %w", action, err)
+	var reply *textproto.Error
+	if goerrors.As(err, &reply) && reply.Code >= 500 {
+		return &permanentError{wrapped}
+	}
+	return wrapped
+}
+
+// buildEmail renders the delivery as a quoted-printable plain text message
+func buildEmail(from string, recipients []string, delivery *models.NotificationDelivery) ([]byte, error) {
+	// Templates may render line breaks into the subject; they must not reach the headers
+	subject := strings.Join(strings.Fields(delivery.Subject), " ")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@valtro>\r\n", delivery.ID)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	b.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(delivery.Body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
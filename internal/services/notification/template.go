@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"bytes"
+	goerrors "errors"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+)
+
+// Default templates used when a channel does not define its own
+const (
+	defaultSubjectTemplate        = `[{{.State}}] {{.RuleName}}`
+	defaultBodyTemplate           = `Alert "{{.RuleName}}" is {{.State}}: {{.Aggregation}} is {{.Value}}, threshold {{.Comparison}} {{.Threshold}} (evaluated at {{.EvaluatedAt.Format "2006-01-02 15:04:05 MST"}})`
+	defaultMonitorSubjectTemplate = `[{{.State}}] {{.MonitorName}}`
+	defaultMonitorBodyTemplate    = `Monitor "{{.MonitorName}}" is {{.State}}{{if .Message}}: {{.Message}}{{end}} (at {{.EvaluatedAt.Format "2006-01-02 15:04:05 MST"}})`
+)
+
+// maxRenderedSize caps the output of a template, so a template cannot produce huge messages
+const maxRenderedSize = 64 << 10
+
+// errRenderedTooLarge reports a template whose output exceeds maxRenderedSize
+var errRenderedTooLarge = goerrors.New("rendered message is too large")
+
+// TemplateData is the data available to message templates, e.g. {{.RuleName}} or {{.Value}}
+// Monitor notifications fill the Monitor fields, CheckInID, ExpectedAt and Message instead of the
+// rule fields; State is then the monitor's status and EvaluatedAt when it changed
+type TemplateData struct {
+	Event       string
+	Test        bool
+	RuleID      uuid.UUID
+	RuleName    string
+	ProjectID   uuid.UUID
+	State       string
+	Value       float64
+	Aggregation string
+	Comparison  string
+	Threshold   float64
+	EvaluatedAt time.Time
+	MonitorID   uuid.UUID
+	MonitorName string
+	MonitorSlug string
+	CheckInID   uuid.UUID
+	ExpectedAt  *time.Time
+	Message     string
+}
+
+// isMonitorEvent reports whether the notification is about a monitor rather than an alert rule
+func isMonitorEvent(event string) bool {
+	return strings.HasPrefix(event, "monitor.")
+}
+
+// defaultTemplates returns the default subject and body templates for an event
+func defaultTemplates(event string) (string, string) {
+	if isMonitorEvent(event) {
+		return defaultMonitorSubjectTemplate, defaultMonitorBodyTemplate
+	}
+	return defaultSubjectTemplate, defaultBodyTemplate
+}
+
+// sampleTemplateData returns the data used to validate templates and for test notifications
+func sampleTemplateData(now time.Time) TemplateData {
+	return TemplateData{
+		Event:       constants.NotificationEventTest,
+		Test:        true,
+		RuleID:      uuid.Nil,
+		RuleName:    "Test alert",
+		State:       constants.AlertStateFiring,
+		Value:       42,
+		Aggregation: constants.AlertAggregationCount,
+		Comparison:  constants.AlertComparisonGT,
+		Threshold:   10,
+		EvaluatedAt: now,
+	}
+}
+
+// parseTemplate parses a message template, falling back to the default when empty
+func parseTemplate(name string, text string, fallback string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = fallback
+	}
+	return template.New(name).Parse(text)
+}
+
+// validateTemplate checks that a template parses and renders against sample data
+func validateTemplate(field string, text string, fallback string) error {
+	if len(text) > constants.MaxNotificationTemplateLength {
+		return errors.NewValidationError(field + " is too long")
+	}
+	if _, err := render(field, text, fallback, sampleTemplateData(time.Now())); err != nil {
+		return errors.NewValidationError(field+" is not a valid template", err.Error())
+	}
+	return nil
+}
+
+// render executes a message template with the given data
+func render(name string, text string, fallback string, data TemplateData) (string, error) {
+	tmpl, err := parseTemplate(name, text, fallback)
+	if err != nil {
+		return "", err
+	}
+
+	out := &limitedBuffer{limit: maxRenderedSize}
+	if err := tmpl.Execute(out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// limitedBuffer is a bytes.Buffer that refuses writes beyond its limit
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+// Write appends to the buffer unless that would exceed the limit
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errRenderedTooLarge
+	}
+	return b.Buffer.Write(p)
+}
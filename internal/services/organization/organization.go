@@ -1,14 +1,18 @@
 package organization
 
 import (
+	"context"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
 	"github.com/nihar-hegde/valtro-backend/internal/dto"
 	"github.com/nihar-hegde/valtro-backend/internal/errors"
 	"github.com/nihar-hegde/valtro-backend/internal/models"
 	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/softdelete"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
 )
 
 // Service handles organization business logic
@@ -24,14 +28,14 @@ func NewService(orgRepo *orgRepo.Repository) *Service {
 }
 
 // CreateOrganization creates a new organization with business logic validation
-func (s *Service) CreateOrganization(req dto.CreateOrganizationRequest, ownerID uuid.UUID) (*dto.OrganizationResponse, error) {
+func (s *Service) CreateOrganization(ctx context.Context, req dto.CreateOrganizationRequest, ownerID uuid.UUID) (*dto.OrganizationResponse, error) {
 	// Validate business rules
 	if err := s.validateCreateOrganization(req); err != nil {
 		return nil, err
 	}
 
 	// Check if organization name already exists for this owner
-	nameExists, err := s.orgRepo.NameExistsForOwner(req.Name, ownerID)
+	nameExists, err := s.orgRepo.NameExistsForOwner(ctx, req.Name, ownerID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -41,15 +45,19 @@ func (s *Service) CreateOrganization(req dto.CreateOrganizationRequest, ownerID
 
 	// Create organization model
 	organization := &models.Organization{
-		ID:        uuid.New(),
-		Name:      strings.TrimSpace(req.Name),
-		OwnerID:   ownerID,
+		ID:      uuid.New(),
+		Name:    strings.TrimSpace(req.Name),
+		OwnerID: ownerID,
+		RetentionPolicy: models.RetentionPolicy{
+			DefaultDays: constants.DefaultRetentionDays,
+		},
+		Plan:      constants.PlanFree,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	// Save to database
-	if err := s.orgRepo.Create(organization); err != nil {
+	if err := s.orgRepo.Create(ctx, organization); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
@@ -58,8 +66,8 @@ func (s *Service) CreateOrganization(req dto.CreateOrganizationRequest, ownerID
 }
 
 // GetOrganizationByID retrieves an organization by ID
-func (s *Service) GetOrganizationByID(id uuid.UUID) (*dto.OrganizationResponse, error) {
-	organization, err := s.orgRepo.GetByID(id)
+func (s *Service) GetOrganizationByID(ctx context.Context, id uuid.UUID) (*dto.OrganizationResponse, error) {
+	organization, err := s.orgRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +75,8 @@ func (s *Service) GetOrganizationByID(id uuid.UUID) (*dto.OrganizationResponse,
 }
 
 // GetOrganizationsByOwner retrieves all organizations for a specific owner
-func (s *Service) GetOrganizationsByOwner(ownerID uuid.UUID) ([]*dto.OrganizationResponse, error) {
-	organizations, err := s.orgRepo.GetByOwnerID(ownerID)
+func (s *Service) GetOrganizationsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*dto.OrganizationResponse, error) {
+	organizations, err := s.orgRepo.GetByOwnerID(ctx, ownerID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -83,41 +91,20 @@ func (s *Service) GetOrganizationsByOwner(ownerID uuid.UUID) ([]*dto.Organizatio
 }
 
 // GetOrganizationWithProjects retrieves an organization with its projects
-func (s *Service) GetOrganizationWithProjects(ownerID uuid.UUID) (*dto.OrganizationWithProjectsResponse, error) {
-	organization, err := s.orgRepo.GetByOwnerIDWithProjects(ownerID)
+func (s *Service) GetOrganizationWithProjects(ctx context.Context, ownerID uuid.UUID) (*dto.OrganizationWithProjectsResponse, error) {
+	organization, err := s.orgRepo.GetByOwnerIDWithProjects(ctx, ownerID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert to response DTO with projects
-	response := &dto.OrganizationWithProjectsResponse{
-		ID:        organization.ID,
-		Name:      organization.Name,
-		OwnerID:   organization.OwnerID,
-		CreatedAt: organization.CreatedAt,
-		UpdatedAt: organization.UpdatedAt,
-		Projects:  make([]dto.ProjectResponse, len(organization.Projects)),
-	}
-
-	// Convert projects to DTOs
-	for i, project := range organization.Projects {
-		response.Projects[i] = dto.ProjectResponse{
-			ID:             project.ID,
-			OrganizationID: project.OrganizationID,
-			Name:           project.Name,
-			APIKey:         project.APIKey,
-			CreatedAt:      project.CreatedAt,
-			UpdatedAt:      project.UpdatedAt,
-		}
-	}
-
-	return response, nil
+	return s.toOrganizationWithProjectsResponse(organization), nil
 }
 
 // UpdateOrganization updates an organization
-func (s *Service) UpdateOrganization(id uuid.UUID, req dto.UpdateOrganizationRequest, ownerID uuid.UUID) (*dto.OrganizationResponse, error) {
+func (s *Service) UpdateOrganization(ctx context.Context, id uuid.UUID, req dto.UpdateOrganizationRequest, ownerID uuid.UUID) (*dto.OrganizationResponse, error) {
 	// Get existing organization
-	organization, err := s.orgRepo.GetByID(id)
+	organization, err := s.orgRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +122,7 @@ func (s *Service) UpdateOrganization(id uuid.UUID, req dto.UpdateOrganizationReq
 		}
 
 		// Check if new name already exists for this owner (excluding current organization)
-		nameExists, err := s.orgRepo.NameExistsForOwner(trimmedName, ownerID)
+		nameExists, err := s.orgRepo.NameExistsForOwner(ctx, trimmedName, ownerID)
 		if err != nil {
 			return nil, err // Repository now returns structured errors
 		}
@@ -145,21 +132,30 @@ func (s *Service) UpdateOrganization(id uuid.UUID, req dto.UpdateOrganizationReq
 
 		organization.Name = trimmedName
 	}
+	if req.DefaultRetention != nil {
+		if err := validator.ValidateRetentionPolicy(req.DefaultRetention.DefaultDays, req.DefaultRetention.LevelDays, false); err != nil {
+			return nil, errors.NewValidationError("Invalid retention policy", err.Error())
+		}
+		organization.RetentionPolicy = models.RetentionPolicy{
+			DefaultDays: req.DefaultRetention.DefaultDays,
+			LevelDays:   req.DefaultRetention.LevelDays,
+		}
+	}
 
 	organization.UpdatedAt = time.Now()
 
 	// Save changes
-	if err := s.orgRepo.Update(organization); err != nil {
+	if err := s.orgRepo.Update(ctx, organization); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
 	return s.toOrganizationResponse(organization), nil
 }
 
-// DeleteOrganization soft deletes an organization
-func (s *Service) DeleteOrganization(id uuid.UUID, ownerID uuid.UUID) error {
+// DeleteOrganization soft deletes an organization together with its projects
+func (s *Service) DeleteOrganization(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
 	// Get existing organization
-	organization, err := s.orgRepo.GetByID(id)
+	organization, err := s.orgRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -169,17 +165,84 @@ func (s *Service) DeleteOrganization(id uuid.UUID, ownerID uuid.UUID) error {
 		return errors.NewForbiddenError("Unauthorized: you don't own this organization", "Organization ID: "+id.String())
 	}
 
-	// Soft delete
-	if err := s.orgRepo.Delete(id); err != nil {
+	// Soft delete organization and projects in one transaction
+	if err := s.orgRepo.Delete(ctx, id); err != nil {
 		return err // Repository now returns structured errors
 	}
 
 	return nil
 }
 
+// GetDeletedOrganizationsByOwner retrieves the soft-deleted organizations a user can still restore
+func (s *Service) GetDeletedOrganizationsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*dto.OrganizationResponse, error) {
+	organizations, err := s.orgRepo.GetDeletedByOwnerID(ctx, ownerID, softdelete.Cutoff(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.OrganizationResponse, 0, len(organizations))
+	for _, org := range organizations {
+		responses = append(responses, s.toOrganizationResponse(org))
+	}
+
+	return responses, nil
+}
+
+// RestoreOrganization restores a soft-deleted organization along with the projects deleted with it
+// If the name has been taken since deletion the caller must supply a new one
+func (s *Service) RestoreOrganization(ctx context.Context, id uuid.UUID, req dto.RestoreOrganizationRequest, ownerID uuid.UUID) (*dto.OrganizationWithProjectsResponse, error) {
+	// Get deleted organization
+	organization, err := s.orgRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user owns this organization
+	if organization.OwnerID != ownerID {
+		return nil, errors.NewForbiddenError("Unauthorized: you don't own this organization", "Organization ID: "+id.String())
+	}
+
+	// Past the grace period the organization is waiting to be purged and can no longer be restored
+	if softdelete.Expired(organization.DeletedAt.Time, time.Now()) {
+		return nil, errors.NewGoneError("Organization can no longer be restored; its grace period has ended", "Organization ID: "+id.String())
+	}
+
+	// Resolve the name to restore under
+	name := organization.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if err := s.validateCreateOrganization(dto.CreateOrganizationRequest{Name: name}); err != nil {
+			return nil, err
+		}
+	}
+
+	nameExists, err := s.orgRepo.NameExistsForOwner(ctx, name, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if nameExists {
+		return nil, errors.NewConflictError("Organization with this name already exists for user; provide a new name to restore it", "Name: "+name)
+	}
+
+	organization.Name = name
+
+	// Restore organization and its projects together
+	if err := s.orgRepo.Restore(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.orgRepo.GetByIDWithProjects(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toOrganizationWithProjectsResponse(restored), nil
+}
+
 // CheckUserOrganization checks if a user has an organization
-func (s *Service) CheckUserOrganization(ownerID uuid.UUID) (*dto.UserOrganizationCheckResponse, error) {
-	hasOrg, err := s.orgRepo.HasOrganization(ownerID)
+func (s *Service) CheckUserOrganization(ctx context.Context, ownerID uuid.UUID) (*dto.UserOrganizationCheckResponse, error) {
+	hasOrg, err := s.orgRepo.HasOrganization(ctx, ownerID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -189,7 +252,7 @@ func (s *Service) CheckUserOrganization(ownerID uuid.UUID) (*dto.UserOrganizatio
 	}
 
 	if hasOrg {
-		organizations, err := s.orgRepo.GetByOwnerID(ownerID)
+		organizations, err := s.orgRepo.GetByOwnerID(ctx, ownerID)
 		if err != nil {
 			return nil, err // Repository now returns structured errors
 		}
@@ -217,11 +280,59 @@ func (s *Service) validateCreateOrganization(req dto.CreateOrganizationRequest)
 
 // toOrganizationResponse converts an organization model to response DTO
 func (s *Service) toOrganizationResponse(organization *models.Organization) *dto.OrganizationResponse {
-	return &dto.OrganizationResponse{
-		ID:        organization.ID,
-		Name:      organization.Name,
-		OwnerID:   organization.OwnerID,
-		CreatedAt: organization.CreatedAt,
-		UpdatedAt: organization.UpdatedAt,
+	response := &dto.OrganizationResponse{
+		ID:               organization.ID,
+		Name:             organization.Name,
+		OwnerID:          organization.OwnerID,
+		DefaultRetention: s.toRetentionPolicyDTO(organization.RetentionPolicy),
+		Plan:             organization.Plan,
+		CreatedAt:        organization.CreatedAt,
+		UpdatedAt:        organization.UpdatedAt,
+	}
+	if organization.DeletedAt.Valid {
+		response.DeletedAt = &organization.DeletedAt.Time
+	}
+	return response
+}
+
+// toOrganizationWithProjectsResponse converts an organization with preloaded projects to response DTO
+func (s *Service) toOrganizationWithProjectsResponse(organization *models.Organization) *dto.OrganizationWithProjectsResponse {
+	response := &dto.OrganizationWithProjectsResponse{
+		ID:               organization.ID,
+		Name:             organization.Name,
+		OwnerID:          organization.OwnerID,
+		DefaultRetention: s.toRetentionPolicyDTO(organization.RetentionPolicy),
+		Plan:             organization.Plan,
+		CreatedAt:        organization.CreatedAt,
+		UpdatedAt:        organization.UpdatedAt,
+		Projects:         make([]dto.ProjectResponse, len(organization.Projects)),
+	}
+
+	// Convert projects to DTOs
+	for i, project := range organization.Projects {
+		effective := organization.RetentionPolicy.WithOverride(project.RetentionPolicy)
+		response.Projects[i] = dto.ProjectResponse{
+			ID:             project.ID,
+			OrganizationID: project.OrganizationID,
+			Name:           project.Name,
+			APIKey:         project.APIKey,
+			Retention: dto.ProjectRetentionResponse{
+				DefaultDays: effective.DefaultDays,
+				LevelDays:   effective.LevelDays,
+				Inherited:   project.RetentionPolicy == nil,
+			},
+			CreatedAt: project.CreatedAt,
+			UpdatedAt: project.UpdatedAt,
+		}
+	}
+
+	return response
+}
+
+// toRetentionPolicyDTO converts a retention policy model to its DTO
+func (s *Service) toRetentionPolicyDTO(policy models.RetentionPolicy) dto.RetentionPolicy {
+	return dto.RetentionPolicy{
+		DefaultDays: policy.DefaultDays,
+		LevelDays:   policy.LevelDays,
 	}
 }
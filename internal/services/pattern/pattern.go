@@ -0,0 +1,167 @@
+package pattern
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	patternRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/pattern"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/drain"
+)
+
+// patternOrder maps the supported sort keys to their ORDER BY clause
+var patternOrder = map[string]string{
+	constants.PatternSortLastSeen:  "last_seen_at DESC",
+	constants.PatternSortFirstSeen: "first_seen_at DESC",
+	constants.PatternSortCount:     "event_count DESC",
+}
+
+// Service handles log pattern mining and retrieval
+// Miners are kept in memory per project and seeded from stored patterns on first use,
+// so the ingestion pipeline should share a single Service instance
+type Service struct {
+	patternRepo *patternRepo.Repository
+
+	mu     sync.Mutex
+	miners map[uuid.UUID]*drain.Miner
+}
+
+// NewService creates a new log pattern service
+func NewService(patternRepo *patternRepo.Repository) *Service {
+	return &Service{
+		patternRepo: patternRepo,
+		miners:      make(map[uuid.UUID]*drain.Miner),
+	}
+}
+
+// MatchMessage assigns a log message to a pattern and returns the match, counting one event
+// The ingestion pipeline stores the pattern ID on the event, then passes its matches to
+// RecordMatches once the events are stored, so a failed append leaves the counters alone
+func (s *Service) MatchMessage(ctx context.Context, projectID uuid.UUID, message string, at time.Time) (*models.LogPattern, error) {
+	miner, err := s.minerFor(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, _ := miner.Add(message, uuid.NewString)
+	patternID, err := uuid.Parse(cluster.ID)
+	if err != nil {
+		return nil, errors.NewInternalError("Invalid pattern ID", err.Error())
+	}
+
+	return &models.LogPattern{
+		ID:          patternID,
+		ProjectID:   projectID,
+		Template:    cluster.Template(),
+		EventCount:  1,
+		FirstSeenAt: at,
+		LastSeenAt:  at,
+	}, nil
+}
+
+// RecordMatches adds matches returned by MatchMessage to the stored patterns' counters
+// Matches of the same pattern are merged first, keeping the latest template since the miner
+// only ever generalizes it
+func (s *Service) RecordMatches(ctx context.Context, matches []*models.LogPattern) error {
+	merged := make(map[uuid.UUID]*models.LogPattern)
+	var order []uuid.UUID
+	for _, match := range matches {
+		pattern, ok := merged[match.ID]
+		if !ok {
+			copied := *match
+			merged[match.ID] = &copied
+			order = append(order, match.ID)
+			continue
+		}
+		pattern.Template = match.Template
+		pattern.EventCount += match.EventCount
+		if match.FirstSeenAt.Before(pattern.FirstSeenAt) {
+			pattern.FirstSeenAt = match.FirstSeenAt
+		}
+		if match.LastSeenAt.After(pattern.LastSeenAt) {
+			pattern.LastSeenAt = match.LastSeenAt
+		}
+	}
+
+	now := time.Now()
+	for _, id := range order {
+		pattern := merged[id]
+		pattern.UpdatedAt = now
+		if err := s.patternRepo.Record(ctx, pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPatternsByProject lists a project's patterns sorted by last_seen, first_seen or count
+// Passing since with the first_seen sort answers "which patterns are new"
+func (s *Service) GetPatternsByProject(ctx context.Context, projectID uuid.UUID, sort string, since *time.Time, limit int) ([]*dto.LogPatternResponse, error) {
+	if sort == "" {
+		sort = constants.PatternSortLastSeen
+	}
+	orderBy, ok := patternOrder[sort]
+	if !ok {
+		return nil, errors.NewValidationError("Sort must be one of 'last_seen', 'first_seen' or 'count'")
+	}
+
+	if limit == 0 {
+		limit = constants.DefaultPatternLimit
+	}
+	if limit < 1 || limit > constants.MaxPatternLimit {
+		return nil, errors.NewValidationError("Limit must be between 1 and 1000")
+	}
+
+	patterns, err := s.patternRepo.List(ctx, projectID, orderBy, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.LogPatternResponse, 0, len(patterns))
+	for _, pattern := range patterns {
+		responses = append(responses, s.toLogPatternResponse(pattern))
+	}
+
+	return responses, nil
+}
+
+// minerFor returns the project's miner, loading its stored patterns the first time
+func (s *Service) minerFor(ctx context.Context, projectID uuid.UUID) (*drain.Miner, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if miner, ok := s.miners[projectID]; ok {
+		return miner, nil
+	}
+
+	patterns, err := s.patternRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	miner := drain.New(drain.Config{})
+	for _, pattern := range patterns {
+		miner.Load(pattern.ID.String(), pattern.Template)
+	}
+	s.miners[projectID] = miner
+
+	return miner, nil
+}
+
+// toLogPatternResponse converts a log pattern model to response DTO
+func (s *Service) toLogPatternResponse(pattern *models.LogPattern) *dto.LogPatternResponse {
+	return &dto.LogPatternResponse{
+		ID:          pattern.ID,
+		ProjectID:   pattern.ProjectID,
+		Template:    pattern.Template,
+		EventCount:  pattern.EventCount,
+		FirstSeenAt: pattern.FirstSeenAt,
+		LastSeenAt:  pattern.LastSeenAt,
+	}
+}
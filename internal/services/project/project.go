@@ -1,8 +1,10 @@
 package project
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/nihar-hegde/valtro-backend/internal/errors"
 	"github.com/nihar-hegde/valtro-backend/internal/models"
 	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/softdelete"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
 )
 
 // Service handles project business logic
@@ -27,14 +31,14 @@ func NewService(projectRepo *projectRepo.Repository) *Service {
 }
 
 // CreateProject creates a new project with business logic validation
-func (s *Service) CreateProject(req dto.CreateProjectRequest) (*dto.ProjectResponse, error) {
+func (s *Service) CreateProject(ctx context.Context, req dto.CreateProjectRequest) (*dto.ProjectResponse, error) {
 	// Validate business rules
 	if err := s.validateCreateProject(req); err != nil {
 		return nil, err
 	}
 
 	// Check if project name already exists for this organization
-	nameExists, err := s.projectRepo.NameExistsForOrganization(req.Name, req.OrganizationID)
+	nameExists, err := s.projectRepo.NameExistsForOrganization(ctx, req.Name, req.OrganizationID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -43,33 +47,40 @@ func (s *Service) CreateProject(req dto.CreateProjectRequest) (*dto.ProjectRespo
 	}
 
 	// Generate unique API key
-	apiKey, err := s.generateUniqueAPIKey()
+	apiKey, err := s.generateUniqueAPIKey(ctx)
 	if err != nil {
 		return nil, errors.NewInternalError("Failed to generate API key", err.Error())
 	}
 
 	// Create project model
 	project := &models.Project{
-		ID:             uuid.New(),
-		OrganizationID: req.OrganizationID,
-		Name:           strings.TrimSpace(req.Name),
-		APIKey:         apiKey,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		ID:                uuid.New(),
+		OrganizationID:    req.OrganizationID,
+		Name:              strings.TrimSpace(req.Name),
+		APIKey:            apiKey,
+		IndexedAttributes: []string{},
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
 	}
 
 	// Save to database
-	if err := s.projectRepo.Create(project); err != nil {
+	if err := s.projectRepo.Create(ctx, project); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
+	// Reload with its organization so the response carries the inherited retention policy
+	created, err := s.projectRepo.GetByID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert to response DTO
-	return s.toProjectResponse(project), nil
+	return s.toProjectResponse(created), nil
 }
 
 // GetProjectByID retrieves a project by ID
-func (s *Service) GetProjectByID(id uuid.UUID) (*dto.ProjectResponse, error) {
-	project, err := s.projectRepo.GetByID(id)
+func (s *Service) GetProjectByID(ctx context.Context, id uuid.UUID) (*dto.ProjectResponse, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +88,8 @@ func (s *Service) GetProjectByID(id uuid.UUID) (*dto.ProjectResponse, error) {
 }
 
 // GetProjectByAPIKey retrieves a project by API key
-func (s *Service) GetProjectByAPIKey(apiKey string) (*dto.ProjectResponse, error) {
-	project, err := s.projectRepo.GetByAPIKey(apiKey)
+func (s *Service) GetProjectByAPIKey(ctx context.Context, apiKey string) (*dto.ProjectResponse, error) {
+	project, err := s.projectRepo.GetByAPIKey(ctx, apiKey)
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +97,8 @@ func (s *Service) GetProjectByAPIKey(apiKey string) (*dto.ProjectResponse, error
 }
 
 // GetProjectsByOrganization retrieves all projects for a specific organization
-func (s *Service) GetProjectsByOrganization(organizationID uuid.UUID) ([]*dto.ProjectResponse, error) {
-	projects, err := s.projectRepo.GetByOrganizationID(organizationID)
+func (s *Service) GetProjectsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*dto.ProjectResponse, error) {
+	projects, err := s.projectRepo.GetByOrganizationID(ctx, organizationID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -102,9 +113,9 @@ func (s *Service) GetProjectsByOrganization(organizationID uuid.UUID) ([]*dto.Pr
 }
 
 // UpdateProject updates a project
-func (s *Service) UpdateProject(id uuid.UUID, req dto.UpdateProjectRequest, organizationID uuid.UUID) (*dto.ProjectResponse, error) {
+func (s *Service) UpdateProject(ctx context.Context, id uuid.UUID, req dto.UpdateProjectRequest, organizationID uuid.UUID) (*dto.ProjectResponse, error) {
 	// Get existing project
-	project, err := s.projectRepo.GetByID(id)
+	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +133,7 @@ func (s *Service) UpdateProject(id uuid.UUID, req dto.UpdateProjectRequest, orga
 		}
 
 		// Check if new name already exists for this organization (excluding current project)
-		nameExists, err := s.projectRepo.NameExistsForOrganization(trimmedName, organizationID)
+		nameExists, err := s.projectRepo.NameExistsForOrganization(ctx, trimmedName, organizationID)
 		if err != nil {
 			return nil, err // Repository now returns structured errors
 		}
@@ -132,11 +143,33 @@ func (s *Service) UpdateProject(id uuid.UUID, req dto.UpdateProjectRequest, orga
 
 		project.Name = trimmedName
 	}
+	if req.Retention != nil {
+		if err := validator.ValidateRetentionPolicy(req.Retention.DefaultDays, req.Retention.LevelDays, true); err != nil {
+			return nil, errors.NewValidationError("Invalid retention policy", err.Error())
+		}
+
+		// An empty override resets the project to the organization default
+		override := &models.RetentionPolicy{
+			DefaultDays: req.Retention.DefaultDays,
+			LevelDays:   req.Retention.LevelDays,
+		}
+		if override.IsEmpty() {
+			override = nil
+		}
+		project.RetentionPolicy = override
+	}
+	if req.IndexedAttributes != nil {
+		attributes, err := validateIndexedAttributes(*req.IndexedAttributes)
+		if err != nil {
+			return nil, err
+		}
+		project.IndexedAttributes = attributes
+	}
 
 	project.UpdatedAt = time.Now()
 
 	// Save changes
-	if err := s.projectRepo.Update(project); err != nil {
+	if err := s.projectRepo.Update(ctx, project); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
@@ -144,9 +177,9 @@ func (s *Service) UpdateProject(id uuid.UUID, req dto.UpdateProjectRequest, orga
 }
 
 // DeleteProject soft deletes a project
-func (s *Service) DeleteProject(id uuid.UUID, organizationID uuid.UUID) error {
+func (s *Service) DeleteProject(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) error {
 	// Get existing project
-	project, err := s.projectRepo.GetByID(id)
+	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -157,17 +190,98 @@ func (s *Service) DeleteProject(id uuid.UUID, organizationID uuid.UUID) error {
 	}
 
 	// Soft delete
-	if err := s.projectRepo.Delete(id); err != nil {
+	if err := s.projectRepo.Delete(ctx, id); err != nil {
 		return err // Repository now returns structured errors
 	}
 
 	return nil
 }
 
+// GetDeletedProjectByID retrieves a soft-deleted project by ID while it can still be restored
+func (s *Service) GetDeletedProjectByID(ctx context.Context, id uuid.UUID) (*dto.ProjectResponse, error) {
+	project, err := s.getRestorableProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toProjectResponse(project), nil
+}
+
+// GetDeletedProjectsByOrganization retrieves the soft-deleted projects of an organization that can still be restored
+func (s *Service) GetDeletedProjectsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*dto.ProjectResponse, error) {
+	projects, err := s.projectRepo.GetDeletedByOrganizationID(ctx, organizationID, softdelete.Cutoff(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		responses = append(responses, s.toProjectResponse(project))
+	}
+
+	return responses, nil
+}
+
+// RestoreProject restores a soft-deleted project
+// If the name has been taken since deletion the caller must supply a new one
+func (s *Service) RestoreProject(ctx context.Context, id uuid.UUID, req dto.RestoreProjectRequest, organizationID uuid.UUID) (*dto.ProjectResponse, error) {
+	// Get deleted project
+	project, err := s.getRestorableProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if project belongs to the specified organization
+	if project.OrganizationID != organizationID {
+		return nil, errors.NewForbiddenError("Unauthorized: project doesn't belong to this organization", "Project ID: "+id.String())
+	}
+
+	// Resolve the name to restore under
+	name := project.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if err := s.validateCreateProject(dto.CreateProjectRequest{OrganizationID: organizationID, Name: name}); err != nil {
+			return nil, err
+		}
+	}
+
+	nameExists, err := s.projectRepo.NameExistsForOrganization(ctx, name, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if nameExists {
+		return nil, errors.NewConflictError("Project with this name already exists in organization; provide a new name to restore it", "Name: "+name)
+	}
+
+	project.Name = name
+	project.UpdatedAt = time.Now()
+
+	// Clear the soft delete
+	if err := s.projectRepo.Restore(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return s.toProjectResponse(project), nil
+}
+
+// getRestorableProject retrieves a soft-deleted project that is still within its grace period
+// Past it the project is waiting to be purged and can no longer be restored
+func (s *Service) getRestorableProject(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	project, err := s.projectRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if softdelete.Expired(project.DeletedAt.Time, time.Now()) {
+		return nil, errors.NewGoneError("Project can no longer be restored; its grace period has ended", "Project ID: "+id.String())
+	}
+	return project, nil
+}
+
 // RegenerateAPIKey generates a new API key for a project
-func (s *Service) RegenerateAPIKey(id uuid.UUID, organizationID uuid.UUID) (*dto.ProjectResponse, error) {
+func (s *Service) RegenerateAPIKey(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.ProjectResponse, error) {
 	// Get existing project
-	project, err := s.projectRepo.GetByID(id)
+	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +292,7 @@ func (s *Service) RegenerateAPIKey(id uuid.UUID, organizationID uuid.UUID) (*dto
 	}
 
 	// Generate new unique API key
-	apiKey, err := s.generateUniqueAPIKey()
+	apiKey, err := s.generateUniqueAPIKey(ctx)
 	if err != nil {
 		return nil, errors.NewInternalError("Failed to generate new API key", err.Error())
 	}
@@ -187,7 +301,7 @@ func (s *Service) RegenerateAPIKey(id uuid.UUID, organizationID uuid.UUID) (*dto
 	project.UpdatedAt = time.Now()
 
 	// Save changes
-	if err := s.projectRepo.Update(project); err != nil {
+	if err := s.projectRepo.Update(ctx, project); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
@@ -195,7 +309,7 @@ func (s *Service) RegenerateAPIKey(id uuid.UUID, organizationID uuid.UUID) (*dto
 }
 
 // generateUniqueAPIKey generates a unique API key
-func (s *Service) generateUniqueAPIKey() (string, error) {
+func (s *Service) generateUniqueAPIKey(ctx context.Context) (string, error) {
 	for attempt := 0; attempt < constants.APIKeyMaxAttempts; attempt++ {
 		// Generate random bytes and encode as hex
 		bytes := make([]byte, constants.APIKeyByteSize)
@@ -206,7 +320,7 @@ func (s *Service) generateUniqueAPIKey() (string, error) {
 		apiKey := constants.APIKeyPrefix + hex.EncodeToString(bytes)
 		
 		// Check if this API key already exists
-		exists, err := s.projectRepo.APIKeyExists(apiKey)
+		exists, err := s.projectRepo.APIKeyExists(ctx, apiKey)
 		if err != nil {
 			return "", err
 		}
@@ -236,14 +350,53 @@ func (s *Service) validateCreateProject(req dto.CreateProjectRequest) error {
 	return nil
 }
 
+// validateIndexedAttributes checks a list of attributes to full-text index, dropping duplicates
+func validateIndexedAttributes(names []string) ([]string, error) {
+	attributes := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, errors.NewValidationError("Indexed attribute names cannot be empty")
+		}
+		if len(name) > constants.MaxAlertLabelKeyLength {
+			return nil, errors.NewValidationError(fmt.Sprintf("Indexed attribute names must be at most %d characters", constants.MaxAlertLabelKeyLength))
+		}
+		if !seen[name] {
+			seen[name] = true
+			attributes = append(attributes, name)
+		}
+	}
+	if len(attributes) > constants.MaxIndexedAttributes {
+		return nil, errors.NewValidationError(fmt.Sprintf("At most %d attributes can be indexed", constants.MaxIndexedAttributes))
+	}
+	return attributes, nil
+}
+
 // toProjectResponse converts a project model to response DTO
 func (s *Service) toProjectResponse(project *models.Project) *dto.ProjectResponse {
-	return &dto.ProjectResponse{
-		ID:             project.ID,
-		OrganizationID: project.OrganizationID,
-		Name:           project.Name,
-		APIKey:         project.APIKey,
-		CreatedAt:      project.CreatedAt,
-		UpdatedAt:      project.UpdatedAt,
+	response := &dto.ProjectResponse{
+		ID:                project.ID,
+		OrganizationID:    project.OrganizationID,
+		Name:              project.Name,
+		APIKey:            project.APIKey,
+		Retention:         s.toRetentionResponse(project),
+		IndexedAttributes: project.IndexedAttributes,
+		CreatedAt:         project.CreatedAt,
+		UpdatedAt:         project.UpdatedAt,
+	}
+	if project.DeletedAt.Valid {
+		response.DeletedAt = &project.DeletedAt.Time
+	}
+	return response
+}
+
+// toRetentionResponse resolves a project's effective retention from its organization default and override
+func (s *Service) toRetentionResponse(project *models.Project) dto.ProjectRetentionResponse {
+	effective := project.Organization.RetentionPolicy.WithOverride(project.RetentionPolicy)
+	return dto.ProjectRetentionResponse{
+		DefaultDays: effective.DefaultDays,
+		LevelDays:   effective.LevelDays,
+		Inherited:   project.RetentionPolicy == nil,
 	}
 }
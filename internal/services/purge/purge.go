@@ -0,0 +1,314 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	purgeRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/purge"
+	"github.com/nihar-hegde/valtro-backend/internal/storage"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/softdelete"
+)
+
+// Default purge settings used when the corresponding environment variables are not set
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 100
+)
+
+// Config controls when and how soft-deleted records are permanently removed
+type Config struct {
+	// GracePeriod is how long a record stays soft-deleted (and restorable) before it is purged
+	// Shared with restore through softdelete.GracePeriod so nothing past it can be restored
+	GracePeriod time.Duration
+
+	// Interval is how often the background job runs
+	Interval time.Duration
+
+	// BatchSize caps how many records of one kind are deleted per transaction
+	BatchSize int
+
+	// DryRun reports what would be purged without deleting anything
+	DryRun bool
+}
+
+// ConfigFromEnv reads PURGE_GRACE_PERIOD, PURGE_INTERVAL, PURGE_BATCH_SIZE and PURGE_DRY_RUN
+func ConfigFromEnv() Config {
+	config := Config{
+		GracePeriod: softdelete.GracePeriod(),
+		Interval:    envDuration("PURGE_INTERVAL", defaultInterval),
+		BatchSize:   defaultBatchSize,
+	}
+
+	if value := os.Getenv("PURGE_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Printf("Invalid PURGE_BATCH_SIZE %q, using default of %d", value, defaultBatchSize)
+		} else {
+			config.BatchSize = size
+		}
+	}
+
+	if value := os.Getenv("PURGE_DRY_RUN"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Invalid PURGE_DRY_RUN %q, using default of false", value)
+		} else {
+			config.DryRun = dryRun
+		}
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "720h" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// Report summarizes a purge run
+// In dry-run mode the counts are the records that would have been purged
+type Report struct {
+	DryRun        bool
+	Cutoff        time.Time
+	Projects      int64
+	Organizations int64
+	Users         int64
+	Artifacts     int64 // Stored release artifact files removed with their projects
+	Exports       int64 // Stored log export files removed with their projects; not counted in dry-run mode
+	Archives      int64 // Stored log archive files removed with their projects; not counted in dry-run mode
+	LogEvents     int64 // Log events removed with their projects; not counted in dry-run mode
+	LogBytes      int64 // Bytes those log events took up
+}
+
+// Service permanently deletes soft-deleted records once their grace period has passed
+// Files a purged project kept in object storage and its log events are deleted with it
+type Service struct {
+	purgeRepo *purgeRepo.Repository
+	store     storage.Store
+	logStore  logstore.LogStore
+	config    Config
+}
+
+// NewService creates a new purge service
+func NewService(purgeRepo *purgeRepo.Repository, store storage.Store, logStore logstore.LogStore, config Config) *Service {
+	return &Service{
+		purgeRepo: purgeRepo,
+		store:     store,
+		logStore:  logStore,
+		config:    config,
+	}
+}
+
+// Start runs the purge job immediately and then on every interval until the context is cancelled
+func (s *Service) Start(ctx context.Context) {
+	log.Printf("Purge job started (grace period %s, interval %s, batch size %d, dry run %t)",
+		s.config.GracePeriod, s.config.Interval, s.config.BatchSize, s.config.DryRun)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.Run(ctx)
+		if err != nil {
+			log.Printf("Purge job failed: %v", err)
+		} else {
+			s.logReport(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run performs a single purge pass
+// Records are removed in dependency order: projects, then organizations, then users
+// Only records soft-deleted before the cutoff are removed; an organization goes once all of its
+// projects are gone and a user once all of their organizations are, so nothing live is deleted.
+// Anything covered by an active legal hold is skipped
+func (s *Service) Run(ctx context.Context) (*Report, error) {
+	now := time.Now()
+	report := &Report{
+		DryRun: s.config.DryRun,
+		Cutoff: now.Add(-s.config.GracePeriod),
+	}
+
+	if s.config.DryRun {
+		return s.dryRun(ctx, report, now)
+	}
+
+	if err := s.purgeProjects(ctx, report, now); err != nil {
+		return report, err
+	}
+
+	var err error
+	if report.Organizations, err = s.purgeInBatches(ctx, func(limit int) (int64, error) {
+		return s.purgeRepo.PurgeOrganizations(ctx, report.Cutoff, now, limit)
+	}); err != nil {
+		return report, err
+	}
+
+	if report.Users, err = s.purgeInBatches(ctx, func(limit int) (int64, error) {
+		return s.purgeRepo.PurgeUsers(ctx, report.Cutoff, now, limit)
+	}); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// dryRun fills the report with the number of records eligible for purging
+func (s *Service) dryRun(ctx context.Context, report *Report, now time.Time) (*Report, error) {
+	var err error
+	if report.Projects, err = s.purgeRepo.CountPurgeableProjects(ctx, report.Cutoff, now); err != nil {
+		return report, err
+	}
+	if report.Organizations, err = s.purgeRepo.CountPurgeableOrganizations(ctx, report.Cutoff, now); err != nil {
+		return report, err
+	}
+	if report.Users, err = s.purgeRepo.CountPurgeableUsers(ctx, report.Cutoff, now); err != nil {
+		return report, err
+	}
+	if report.Artifacts, err = s.purgeRepo.CountPurgeableArtifacts(ctx, report.Cutoff, now); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// purgeProjects deletes eligible projects batch by batch, removing their stored artifacts, export
+// files, log archives and log events first. A project whose files or events cannot be deleted is kept until a later run,
+// so nothing is orphaned
+func (s *Service) purgeProjects(ctx context.Context, report *Report, now time.Time) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ids, err := s.purgeRepo.GetPurgeableProjectIDs(ctx, report.Cutoff, now, s.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		keys, err := s.purgeRepo.GetArtifactStorageKeys(ctx, ids)
+		if err != nil {
+			return err
+		}
+		exportKeys, err := s.purgeRepo.GetExportStorageKeys(ctx, ids)
+		if err != nil {
+			return err
+		}
+		archiveKeys, err := s.purgeRepo.GetArchiveStorageKeys(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		deletable := make([]uuid.UUID, 0, len(ids))
+		for _, id := range ids {
+			removed, err := s.deleteObjects(ctx, keys[id])
+			report.Artifacts += removed
+			if err != nil {
+				log.Printf("Keeping project %s until its stored artifacts can be deleted: %v", id, err)
+				continue
+			}
+
+			removed, err = s.deleteObjects(ctx, exportKeys[id])
+			report.Exports += removed
+			if err != nil {
+				log.Printf("Keeping project %s until its export files can be deleted: %v", id, err)
+				continue
+			}
+
+			removed, err = s.deleteObjects(ctx, archiveKeys[id])
+			report.Archives += removed
+			if err != nil {
+				log.Printf("Keeping project %s until its log archives can be deleted: %v", id, err)
+				continue
+			}
+
+			deleted, err := s.logStore.DeleteRange(ctx, id, time.Time{}, time.Time{}, nil)
+			report.LogEvents += deleted.Events
+			report.LogBytes += deleted.Bytes
+			if err != nil {
+				log.Printf("Keeping project %s until its log events can be deleted: %v", id, err)
+				continue
+			}
+			deletable = append(deletable, id)
+		}
+
+		purged, err := s.purgeRepo.PurgeProjects(ctx, deletable, report.Cutoff, now)
+		if err != nil {
+			return err
+		}
+		report.Projects += purged
+
+		// Kept projects would be selected again, so leave them for the next run
+		if len(deletable) < len(ids) || len(ids) < s.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// deleteObjects removes stored files, returning how many were removed before any failure
+func (s *Service) deleteObjects(ctx context.Context, keys []string) (int64, error) {
+	var removed int64
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// purgeInBatches calls purge until a batch comes back short, returning the total removed
+func (s *Service) purgeInBatches(ctx context.Context, purge func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		purged, err := purge(s.config.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += purged
+
+		if purged < int64(s.config.BatchSize) {
+			return total, nil
+		}
+	}
+}
+
+// logReport writes a one-line summary of a purge run
+func (s *Service) logReport(report *Report) {
+	action := "Purged"
+	if report.DryRun {
+		action = "Dry run: would purge"
+	}
+
+	log.Printf("%s %d projects (%d stored artifacts, %d export files, %d log archives, %d log events, %d bytes), %d organizations and %d users deleted before %s",
+		action, report.Projects, report.Artifacts, report.Exports, report.Archives, report.LogEvents, report.LogBytes, report.Organizations, report.Users, report.Cutoff.Format(time.RFC3339))
+}
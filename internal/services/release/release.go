@@ -0,0 +1,313 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	releaseRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/release"
+)
+
+// commitSHAPattern matches abbreviated or full SHA-1 and SHA-256 commit hashes
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+
+// Service handles release and deploy business logic
+type Service struct {
+	releaseRepo *releaseRepo.Repository
+}
+
+// NewService creates a new release service
+func NewService(releaseRepo *releaseRepo.Repository) *Service {
+	return &Service{
+		releaseRepo: releaseRepo,
+	}
+}
+
+// CreateRelease creates a release ahead of its first events, e.g. from a CI pipeline
+func (s *Service) CreateRelease(ctx context.Context, projectID uuid.UUID, req dto.CreateReleaseRequest) (*dto.ReleaseResponse, error) {
+	version := strings.TrimSpace(req.Version)
+	commitSHA := strings.ToLower(strings.TrimSpace(req.CommitSHA))
+
+	// Validate business rules
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
+	if commitSHA != "" && !commitSHAPattern.MatchString(commitSHA) {
+		return nil, errors.NewValidationError("Commit SHA must be 7 to 64 hexadecimal characters")
+	}
+
+	exists, err := s.releaseRepo.VersionExists(ctx, projectID, version)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.NewConflictError("Release with this version already exists in project", "Version: "+version)
+	}
+
+	now := time.Now()
+	release := &models.Release{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Version:   version,
+		CommitSHA: commitSHA,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.releaseRepo.Create(ctx, release); err != nil {
+		return nil, err
+	}
+
+	return s.toReleaseResponse(release, nil, 0, nil), nil
+}
+
+// RecordEvents counts ingested events against the release version they report,
+// creating the release if this is its first event
+// The ingestion pipeline calls this for events carrying an SDK-provided release
+func (s *Service) RecordEvents(ctx context.Context, projectID uuid.UUID, version string, level string, events int64, at time.Time) error {
+	version = strings.TrimSpace(version)
+	if version == "" || events <= 0 {
+		return nil
+	}
+	if err := ValidateVersion(version); err != nil {
+		return err
+	}
+
+	var errorCount int64
+	if level = strings.ToLower(level); level == constants.LogLevelError || level == constants.LogLevelFatal {
+		errorCount = events
+	}
+
+	now := time.Now()
+	return s.releaseRepo.RecordEvents(ctx, &models.Release{
+		ID:           uuid.New(),
+		ProjectID:    projectID,
+		Version:      version,
+		EventCount:   events,
+		ErrorCount:   errorCount,
+		FirstEventAt: &at,
+		LastEventAt:  &at,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+}
+
+// GetReleasesByProject lists a project's releases, newest first, with their stats
+func (s *Service) GetReleasesByProject(ctx context.Context, projectID uuid.UUID, limit int) ([]*dto.ReleaseResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultReleaseLimit
+	}
+	if limit < 1 || limit > constants.MaxReleaseLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxReleaseLimit))
+	}
+
+	// Fetch one extra release so the oldest listed one can be compared with its predecessor
+	releases, err := s.releaseRepo.List(ctx, projectID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, len(releases))
+	releaseIDs := make([]uuid.UUID, len(releases))
+	for i, release := range releases {
+		versions[i] = release.Version
+		releaseIDs[i] = release.ID
+	}
+
+	newIssues, err := s.releaseRepo.CountNewIssues(ctx, projectID, versions)
+	if err != nil {
+		return nil, err
+	}
+	latestDeploys, err := s.releaseRepo.GetLatestDeploys(ctx, releaseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.ReleaseResponse, 0, limit)
+	for i := 0; i < len(releases) && i < limit; i++ {
+		var previous *models.Release
+		if i+1 < len(releases) {
+			previous = releases[i+1]
+		}
+		response := s.toReleaseResponse(releases[i], previous, newIssues[releases[i].Version], nil)
+		if deploy, ok := latestDeploys[releases[i].ID]; ok {
+			response.LastDeploy = s.toDeployResponse(deploy)
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// GetRelease retrieves a release with its stats and deploys
+func (s *Service) GetRelease(ctx context.Context, projectID uuid.UUID, version string) (*dto.ReleaseResponse, error) {
+	release, err := s.releaseRepo.GetByVersion(ctx, projectID, strings.TrimSpace(version))
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.releaseRepo.GetPrevious(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+
+	newIssues, err := s.releaseRepo.CountNewIssues(ctx, projectID, []string{release.Version})
+	if err != nil {
+		return nil, err
+	}
+
+	deploys, err := s.releaseRepo.GetDeploysByReleaseID(ctx, release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toReleaseResponse(release, previous, newIssues[release.Version], deploys), nil
+}
+
+// CreateDeploy records a deploy of an existing release to an environment
+// userID is nil when the deploy is recorded with the project API key, e.g. by the CLI
+func (s *Service) CreateDeploy(ctx context.Context, projectID uuid.UUID, version string, req dto.CreateDeployRequest, userID *uuid.UUID) (*dto.DeployResponse, error) {
+	release, err := s.releaseRepo.GetByVersion(ctx, projectID, strings.TrimSpace(version))
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate business rules
+	environment := strings.TrimSpace(req.Environment)
+	if environment == "" {
+		return nil, errors.NewValidationError("Environment is required")
+	}
+	if len(environment) > constants.MaxEnvironmentLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("Environment must be at most %d characters", constants.MaxEnvironmentLength))
+	}
+
+	now := time.Now()
+	deployedAt := now
+	if req.DeployedAt != nil {
+		if req.DeployedAt.After(now.Add(time.Minute)) {
+			return nil, errors.NewValidationError("Deployed at cannot be in the future")
+		}
+		deployedAt = *req.DeployedAt
+	}
+
+	deploy := &models.Deploy{
+		ID:          uuid.New(),
+		ReleaseID:   release.ID,
+		Environment: environment,
+		DeployedAt:  deployedAt,
+		CreatedByID: userID,
+		CreatedAt:   now,
+	}
+
+	if err := s.releaseRepo.CreateDeploy(ctx, deploy); err != nil {
+		return nil, err
+	}
+
+	return s.toDeployResponse(deploy), nil
+}
+
+// GetDeploys lists a release's deploys, most recent first
+func (s *Service) GetDeploys(ctx context.Context, projectID uuid.UUID, version string) ([]*dto.DeployResponse, error) {
+	release, err := s.releaseRepo.GetByVersion(ctx, projectID, strings.TrimSpace(version))
+	if err != nil {
+		return nil, err
+	}
+
+	deploys, err := s.releaseRepo.GetDeploysByReleaseID(ctx, release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.DeployResponse, 0, len(deploys))
+	for _, deploy := range deploys {
+		responses = append(responses, s.toDeployResponse(deploy))
+	}
+
+	return responses, nil
+}
+
+// ValidateVersion validates a release version
+// Exported so ingest can reject an event with an unusable release before storing it
+func ValidateVersion(version string) error {
+	if version == "" {
+		return errors.NewValidationError("Release version is required")
+	}
+	if len(version) > constants.MaxReleaseLength {
+		return errors.NewValidationError(fmt.Sprintf("Release version must be at most %d characters", constants.MaxReleaseLength))
+	}
+	if strings.ContainsAny(version, "/\n\r\t") {
+		return errors.NewValidationError("Release version cannot contain slashes, tabs or line breaks")
+	}
+	return nil
+}
+
+// errorRate returns the fraction of a release's events at error or fatal level
+func errorRate(release *models.Release) float64 {
+	if release.EventCount == 0 {
+		return 0
+	}
+	return float64(release.ErrorCount) / float64(release.EventCount)
+}
+
+// toReleaseResponse converts a release model to response DTO, comparing it with the previous release
+func (s *Service) toReleaseResponse(release *models.Release, previous *models.Release, newIssues int64, deploys []*models.Deploy) *dto.ReleaseResponse {
+	response := &dto.ReleaseResponse{
+		ID:           release.ID,
+		ProjectID:    release.ProjectID,
+		Version:      release.Version,
+		CommitSHA:    release.CommitSHA,
+		FirstEventAt: release.FirstEventAt,
+		LastEventAt:  release.LastEventAt,
+		Stats: dto.ReleaseStats{
+			EventCount: release.EventCount,
+			ErrorCount: release.ErrorCount,
+			ErrorRate:  errorRate(release),
+			NewIssues:  newIssues,
+		},
+		CreatedAt: release.CreatedAt,
+		UpdatedAt: release.UpdatedAt,
+	}
+
+	if previous != nil {
+		response.Stats.PreviousVersion = previous.Version
+		if previous.EventCount > 0 {
+			previousRate := errorRate(previous)
+			response.Stats.PreviousErrorRate = &previousRate
+			if release.EventCount > 0 {
+				delta := response.Stats.ErrorRate - previousRate
+				response.Stats.ErrorRateDelta = &delta
+			}
+		}
+	}
+
+	if len(deploys) > 0 {
+		response.Deploys = make([]dto.DeployResponse, len(deploys))
+		for i, deploy := range deploys {
+			response.Deploys[i] = *s.toDeployResponse(deploy)
+		}
+		response.LastDeploy = &response.Deploys[0]
+	}
+
+	return response
+}
+
+// toDeployResponse converts a deploy model to response DTO
+func (s *Service) toDeployResponse(deploy *models.Deploy) *dto.DeployResponse {
+	return &dto.DeployResponse{
+		ID:          deploy.ID,
+		ReleaseID:   deploy.ReleaseID,
+		Environment: deploy.Environment,
+		DeployedAt:  deploy.DeployedAt,
+		CreatedByID: deploy.CreatedByID,
+		CreatedAt:   deploy.CreatedAt,
+	}
+}
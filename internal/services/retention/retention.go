@@ -0,0 +1,249 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	projectRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/project"
+	archiveService "github.com/nihar-hegde/valtro-backend/internal/services/archive"
+	legalHoldService "github.com/nihar-hegde/valtro-backend/internal/services/legalhold"
+)
+
+// Default retention settings used when the corresponding environment variables are not set
+const defaultInterval = time.Hour
+
+// levels are the log levels retention applies to
+var levels = []string{
+	constants.LogLevelTrace,
+	constants.LogLevelDebug,
+	constants.LogLevelInfo,
+	constants.LogLevelWarn,
+	constants.LogLevelError,
+	constants.LogLevelFatal,
+}
+
+// Config controls how often expired log events are deleted
+type Config struct {
+	// Interval is how often the background job runs
+	Interval time.Duration
+}
+
+// ConfigFromEnv reads RETENTION_INTERVAL
+func ConfigFromEnv() Config {
+	return Config{
+		Interval: envDuration("RETENTION_INTERVAL", defaultInterval),
+	}
+}
+
+// envDuration reads a duration such as "1h" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// Report summarizes a retention run
+type Report struct {
+	Projects   int64 // Projects checked
+	Events     int64 // Expired events deleted
+	Archived   int64 // Expired events archived to object storage before being deleted
+	Bytes      int64 // Bytes reclaimed by deleting them
+	HeldRanges int64 // Expired ranges kept because a legal hold covers them
+	Failed     int64 // Projects whose expired events could not all be deleted
+}
+
+// Service deletes log events once they are older than their project's retention
+// Each level expires after the days the project's effective policy gives it; ranges under an
+// active legal hold are kept until the hold ends. Events are archived before they are deleted,
+// and a range whose archive fails is kept for a later run
+type Service struct {
+	projectRepo      *projectRepo.Repository
+	legalHoldService *legalHoldService.Service
+	archiveService   *archiveService.Service
+	logStore         logstore.LogStore
+	config           Config
+}
+
+// NewService creates a new retention service
+func NewService(projectRepo *projectRepo.Repository, legalHoldService *legalHoldService.Service, archiveService *archiveService.Service, logStore logstore.LogStore, config Config) *Service {
+	return &Service{
+		projectRepo:      projectRepo,
+		legalHoldService: legalHoldService,
+		archiveService:   archiveService,
+		logStore:         logStore,
+		config:           config,
+	}
+}
+
+// Start runs the retention job immediately and then on every interval until the context is cancelled
+func (s *Service) Start(ctx context.Context) {
+	log.Printf("Retention job started (interval %s)", s.config.Interval)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.Run(ctx, time.Now())
+		if err != nil {
+			log.Printf("Retention job failed: %v", err)
+		} else {
+			s.logReport(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run deletes every project's expired events
+// A failure for one project is logged and the run moves on, so one project cannot stall the rest
+func (s *Service) Run(ctx context.Context, now time.Time) (*Report, error) {
+	projects, err := s.projectRepo.GetAllLive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, project := range projects {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		report.Projects++
+		if err := s.expireProject(ctx, project, now, report); err != nil {
+			log.Printf("Failed to delete expired events of project %s: %v", project.ID, err)
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// expireProject deletes a project's events older than their level's retention
+// Levels with the same retention are deleted together
+func (s *Service) expireProject(ctx context.Context, project *models.Project, now time.Time, report *Report) error {
+	policy := project.Organization.RetentionPolicy.WithOverride(project.RetentionPolicy)
+
+	byDays := make(map[int][]string)
+	for _, level := range levels {
+		if days := policy.DaysForLevel(level); days > 0 {
+			byDays[days] = append(byDays[days], level)
+		}
+	}
+
+	for days, group := range byDays {
+		cutoff := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -days)
+
+		ranges, err := s.unheldRanges(ctx, project.ID, cutoff)
+		if err != nil {
+			return err
+		}
+		for _, r := range ranges {
+			if r.held {
+				report.HeldRanges++
+				continue
+			}
+			archived, err := s.archiveService.ArchiveRange(ctx, project.ID, r.from, r.to, group)
+			report.Archived += archived
+			if err != nil {
+				return err
+			}
+			deleted, err := s.logStore.DeleteRange(ctx, project.ID, r.from, r.to, group)
+			report.Events += deleted.Events
+			report.Bytes += deleted.Bytes
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// timeRange is a piece of the expired range [from, to); zero bounds are open
+type timeRange struct {
+	from time.Time
+	to   time.Time
+	held bool
+}
+
+// unheldRanges splits everything before cutoff into the ranges legal holds cover and the rest
+// Most projects have no holds, which a single IsRangeHeld check confirms
+func (s *Service) unheldRanges(ctx context.Context, projectID uuid.UUID, cutoff time.Time) ([]timeRange, error) {
+	held, err := s.legalHoldService.IsRangeHeld(ctx, projectID, time.Time{}, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	if !held {
+		return []timeRange{{to: cutoff}}, nil
+	}
+
+	holds, err := s.legalHoldService.GetHeldRanges(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return splitRanges(cutoff, holds), nil
+}
+
+// splitRanges splits everything before cutoff into held and unheld ranges
+// Held ranges include both bounds, so each is widened by a nanosecond to keep its end event.
+// The zero time sorts before every other time, so it serves as the open start
+func splitRanges(cutoff time.Time, holds []legalHoldService.HeldRange) []timeRange {
+	sort.Slice(holds, func(i, j int) bool { return holds[i].From.Before(holds[j].From) })
+
+	// Merge overlapping holds, clipped to the expired range
+	var merged []timeRange
+	for _, hold := range holds {
+		to := cutoff
+		if !hold.To.IsZero() && hold.To.Add(time.Nanosecond).Before(cutoff) {
+			to = hold.To.Add(time.Nanosecond)
+		}
+		if !hold.From.Before(to) {
+			continue
+		}
+		if n := len(merged); n > 0 && !hold.From.After(merged[n-1].to) {
+			if to.After(merged[n-1].to) {
+				merged[n-1].to = to
+			}
+			continue
+		}
+		merged = append(merged, timeRange{from: hold.From, to: to, held: true})
+	}
+
+	var ranges []timeRange
+	var start time.Time
+	for _, held := range merged {
+		if held.from.After(start) {
+			ranges = append(ranges, timeRange{from: start, to: held.from})
+		}
+		ranges = append(ranges, held)
+		start = held.to
+	}
+	if start.Before(cutoff) {
+		ranges = append(ranges, timeRange{from: start, to: cutoff})
+	}
+	return ranges
+}
+
+// logReport writes a one-line summary of a retention run, including the bytes reclaimed
+func (s *Service) logReport(report *Report) {
+	log.Printf("Retention deleted %d expired log events (%d archived, %d bytes reclaimed) across %d projects; %d ranges kept for legal holds, %d projects failed",
+		report.Events, report.Archived, report.Bytes, report.Projects, report.HeldRanges, report.Failed)
+}
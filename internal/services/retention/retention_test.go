@@ -0,0 +1,75 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	legalHoldService "github.com/nihar-hegde/valtro-backend/internal/services/legalhold"
+)
+
+func TestSplitRanges(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	held := func(from, to time.Time) legalHoldService.HeldRange {
+		return legalHoldService.HeldRange{From: from, To: to}
+	}
+	after := func(t time.Time) time.Time { return t.Add(time.Nanosecond) }
+	cutoff := day(20)
+
+	tests := []struct {
+		name  string
+		holds []legalHoldService.HeldRange
+		want  []timeRange
+	}{
+		{
+			name: "no holds",
+			want: []timeRange{{to: cutoff}},
+		},
+		{
+			name:  "hold in the middle",
+			holds: []legalHoldService.HeldRange{held(day(5), day(10))},
+			want: []timeRange{
+				{to: day(5)},
+				{from: day(5), to: after(day(10)), held: true},
+				{from: after(day(10)), to: cutoff},
+			},
+		},
+		{
+			name:  "hold without a range keeps everything",
+			holds: []legalHoldService.HeldRange{held(time.Time{}, time.Time{})},
+			want:  []timeRange{{to: cutoff, held: true}},
+		},
+		{
+			name:  "open-ended hold",
+			holds: []legalHoldService.HeldRange{held(day(12), time.Time{})},
+			want:  []timeRange{{to: day(12)}, {from: day(12), to: cutoff, held: true}},
+		},
+		{
+			name:  "hold after the cutoff is ignored",
+			holds: []legalHoldService.HeldRange{held(day(25), day(28))},
+			want:  []timeRange{{to: cutoff}},
+		},
+		{
+			name:  "overlapping holds are merged",
+			holds: []legalHoldService.HeldRange{held(day(8), day(15)), held(day(3), day(9))},
+			want: []timeRange{
+				{to: day(3)},
+				{from: day(3), to: after(day(15)), held: true},
+				{from: after(day(15)), to: cutoff},
+			},
+		},
+		{
+			name:  "hold crossing the cutoff",
+			holds: []legalHoldService.HeldRange{held(day(18), day(22))},
+			want:  []timeRange{{to: day(18)}, {from: day(18), to: cutoff, held: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitRanges(cutoff, tt.holds); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitRanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
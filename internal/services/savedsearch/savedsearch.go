@@ -0,0 +1,304 @@
+package savedsearch
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	savedSearchRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/savedsearch"
+)
+
+// timeRangeRegex matches relative time ranges such as 15m, 24h, 7d or 2w
+var timeRangeRegex = regexp.MustCompile(`^[1-9][0-9]*[mhdw]$`)
+
+// Service handles saved search business logic
+type Service struct {
+	savedSearchRepo *savedSearchRepo.Repository
+}
+
+// NewService creates a new saved search service
+func NewService(savedSearchRepo *savedSearchRepo.Repository) *Service {
+	return &Service{
+		savedSearchRepo: savedSearchRepo,
+	}
+}
+
+// CreateSavedSearch creates a new saved search for an organization
+func (s *Service) CreateSavedSearch(ctx context.Context, organizationID uuid.UUID, req dto.CreateSavedSearchRequest, createdByID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	// Validate business rules
+	if err := s.validateCreateSavedSearch(req); err != nil {
+		return nil, err
+	}
+
+	// Check if saved search name already exists for this organization
+	name := strings.TrimSpace(req.Name)
+	nameExists, err := s.savedSearchRepo.NameExistsForOrganization(ctx, name, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if nameExists {
+		return nil, errors.NewConflictError("Saved search with this name already exists in organization", "Name: "+name)
+	}
+
+	// Create saved search model
+	search := &models.SavedSearch{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Name:           name,
+		Query:          strings.TrimSpace(req.Query),
+		TimeRange:      req.TimeRange,
+		Columns:        s.normalizeColumns(req.Columns),
+		CreatedByID:    createdByID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	// Save to database
+	if err := s.savedSearchRepo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return s.toSavedSearchResponse(search), nil
+}
+
+// GetSavedSearchByID retrieves a saved search by ID, scoped to an organization
+func (s *Service) GetSavedSearchByID(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwnedSavedSearch(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSavedSearchResponse(search), nil
+}
+
+// GetSavedSearchByShareToken resolves a share token to its saved search
+func (s *Service) GetSavedSearchByShareToken(ctx context.Context, token string) (*dto.SavedSearchResponse, error) {
+	search, err := s.savedSearchRepo.GetByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSavedSearchResponse(search), nil
+}
+
+// GetSavedSearchesByOrganization retrieves all saved searches for an organization
+func (s *Service) GetSavedSearchesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*dto.SavedSearchResponse, error) {
+	searches, err := s.savedSearchRepo.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.SavedSearchResponse, 0, len(searches))
+	for _, search := range searches {
+		responses = append(responses, s.toSavedSearchResponse(search))
+	}
+
+	return responses, nil
+}
+
+// UpdateSavedSearch updates a saved search
+func (s *Service) UpdateSavedSearch(ctx context.Context, id uuid.UUID, req dto.UpdateSavedSearchRequest, organizationID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwnedSavedSearch(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		trimmedName := strings.TrimSpace(*req.Name)
+		if len(trimmedName) < constants.MinSavedSearchNameLength || len(trimmedName) > constants.MaxSavedSearchNameLength {
+			return nil, errors.NewValidationError("Saved search name must be between 2 and 255 characters")
+		}
+
+		// Check if new name already exists for this organization (excluding current saved search)
+		nameExists, err := s.savedSearchRepo.NameExistsForOrganization(ctx, trimmedName, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if nameExists && search.Name != trimmedName {
+			return nil, errors.NewConflictError("Saved search with this name already exists in organization", "Name: "+trimmedName)
+		}
+
+		search.Name = trimmedName
+	}
+	if req.Query != nil {
+		trimmedQuery := strings.TrimSpace(*req.Query)
+		if trimmedQuery == "" {
+			return nil, errors.NewValidationError("Saved search query cannot be empty")
+		}
+		search.Query = trimmedQuery
+	}
+	if req.TimeRange != nil {
+		if !timeRangeRegex.MatchString(*req.TimeRange) {
+			return nil, errors.NewValidationError("Invalid time range", "Expected a relative range such as 15m, 24h or 7d")
+		}
+		search.TimeRange = *req.TimeRange
+	}
+	if req.Columns != nil {
+		search.Columns = s.normalizeColumns(req.Columns)
+	}
+
+	search.UpdatedAt = time.Now()
+
+	// Save changes
+	if err := s.savedSearchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return s.toSavedSearchResponse(search), nil
+}
+
+// DeleteSavedSearch soft deletes a saved search
+func (s *Service) DeleteSavedSearch(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) error {
+	if _, err := s.getOwnedSavedSearch(ctx, id, organizationID); err != nil {
+		return err
+	}
+
+	return s.savedSearchRepo.Delete(ctx, id)
+}
+
+// ShareSavedSearch assigns a share token to a saved search, reusing the existing token if present
+func (s *Service) ShareSavedSearch(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwnedSavedSearch(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.ShareToken == nil {
+		token, err := s.generateUniqueShareToken(ctx)
+		if err != nil {
+			return nil, errors.NewInternalError("Failed to generate share token", err.Error())
+		}
+
+		search.ShareToken = &token
+		search.UpdatedAt = time.Now()
+
+		if err := s.savedSearchRepo.Update(ctx, search); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.toSavedSearchResponse(search), nil
+}
+
+// UnshareSavedSearch revokes the share token of a saved search
+func (s *Service) UnshareSavedSearch(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	search, err := s.getOwnedSavedSearch(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	search.ShareToken = nil
+	search.UpdatedAt = time.Now()
+
+	if err := s.savedSearchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return s.toSavedSearchResponse(search), nil
+}
+
+// QueryForProject returns the current query of a saved search of the organization that owns a project
+// Alert rules and incidents that reference a saved search read its query through this
+func (s *Service) QueryForProject(ctx context.Context, id uuid.UUID, projectID uuid.UUID) (string, error) {
+	search, err := s.savedSearchRepo.GetByIDForProject(ctx, id, projectID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return "", errors.NewValidationError("Saved search must exist in the project's organization", "Saved search ID: "+id.String())
+		}
+		return "", err
+	}
+	return strings.TrimSpace(search.Query), nil
+}
+
+// getOwnedSavedSearch retrieves a saved search and verifies it belongs to the organization
+func (s *Service) getOwnedSavedSearch(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.OrganizationID != organizationID {
+		return nil, errors.NewForbiddenError("Unauthorized: saved search doesn't belong to this organization", "Saved search ID: "+id.String())
+	}
+
+	return search, nil
+}
+
+// generateUniqueShareToken generates a short, URL-safe share token
+func (s *Service) generateUniqueShareToken(ctx context.Context) (string, error) {
+	for attempt := 0; attempt < constants.ShareTokenMaxAttempts; attempt++ {
+		bytes := make([]byte, constants.ShareTokenByteSize)
+		if _, err := rand.Read(bytes); err != nil {
+			return "", err
+		}
+
+		token := base64.RawURLEncoding.EncodeToString(bytes)
+
+		exists, err := s.savedSearchRepo.ShareTokenExists(ctx, token)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return token, nil
+		}
+	}
+
+	return "", errors.NewInternalError("Failed to generate unique share token after multiple attempts", "")
+}
+
+// normalizeColumns trims column names and drops empty entries
+func (s *Service) normalizeColumns(columns []string) []string {
+	normalized := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if trimmed := strings.TrimSpace(column); trimmed != "" {
+			normalized = append(normalized, trimmed)
+		}
+	}
+	return normalized
+}
+
+// validateCreateSavedSearch validates the create saved search request
+func (s *Service) validateCreateSavedSearch(req dto.CreateSavedSearchRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.NewValidationError("Saved search name is required")
+	}
+	if len(name) < constants.MinSavedSearchNameLength {
+		return errors.NewValidationError("Saved search name must be at least 2 characters")
+	}
+	if len(name) > constants.MaxSavedSearchNameLength {
+		return errors.NewValidationError("Saved search name must be less than 255 characters")
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return errors.NewValidationError("Saved search query is required")
+	}
+	if !timeRangeRegex.MatchString(req.TimeRange) {
+		return errors.NewValidationError("Invalid time range", "Expected a relative range such as 15m, 24h or 7d")
+	}
+	return nil
+}
+
+// toSavedSearchResponse converts a saved search model to response DTO
+func (s *Service) toSavedSearchResponse(search *models.SavedSearch) *dto.SavedSearchResponse {
+	return &dto.SavedSearchResponse{
+		ID:             search.ID,
+		OrganizationID: search.OrganizationID,
+		Name:           search.Name,
+		Query:          search.Query,
+		TimeRange:      search.TimeRange,
+		Columns:        search.Columns,
+		CreatedByID:    search.CreatedByID,
+		ShareToken:     search.ShareToken,
+		CreatedAt:      search.CreatedAt,
+		UpdatedAt:      search.UpdatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default guardrails used when the corresponding environment variables are not set
+const (
+	defaultQueryTimeout = 30 * time.Second
+	defaultWarnCost     = 1e6
+	defaultMaxCost      = 1e7
+)
+
+// Config holds the guardrails applied to interactive log searches
+// Costs are the log store's estimates: planner cost units for Postgres, events read for the file store
+type Config struct {
+	// QueryTimeout cancels a search that runs longer, along with its database statements
+	QueryTimeout time.Duration
+
+	// WarnCost is the estimated cost above which a search runs but its response carries a warning
+	WarnCost float64
+
+	// MaxCost is the estimated cost above which a search is rejected before it runs
+	MaxCost float64
+}
+
+// ConfigFromEnv reads SEARCH_QUERY_TIMEOUT, SEARCH_WARN_COST and SEARCH_MAX_COST
+func ConfigFromEnv() Config {
+	return Config{
+		QueryTimeout: envDuration("SEARCH_QUERY_TIMEOUT", defaultQueryTimeout),
+		WarnCost:     envFloat("SEARCH_WARN_COST", defaultWarnCost),
+		MaxCost:      envFloat("SEARCH_MAX_COST", defaultMaxCost),
+	}
+}
+
+// envDuration reads a duration such as "30s" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// envFloat reads a positive number from the environment, falling back to the default
+func envFloat(name string, fallback float64) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		log.Printf("Invalid %s %q, using default of %g", name, value, fallback)
+		return fallback
+	}
+
+	return number
+}
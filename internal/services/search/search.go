@@ -0,0 +1,361 @@
+package search
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+	orgRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/organization"
+)
+
+// maxSearchRange caps how far back a single search may reach on each plan
+var maxSearchRange = map[string]time.Duration{
+	constants.PlanFree:       constants.MaxLogSearchRangeSecondsFree * time.Second,
+	constants.PlanTeam:       constants.MaxLogSearchRangeSecondsTeam * time.Second,
+	constants.PlanEnterprise: constants.MaxLogSearchRangeSecondsEnterprise * time.Second,
+}
+
+// Service handles log search business logic
+// Searches are guarded: the time range is capped by the organization's plan, the log store's
+// cost estimate is checked before the search runs, and a search running past the timeout is
+// cancelled along with its database statements
+type Service struct {
+	logStore logstore.LogStore
+	orgRepo  *orgRepo.Repository
+	config   Config
+}
+
+// NewService creates a new search service
+// orgRepo looks up each organization's plan; without one, searches are not limited by plan
+func NewService(logStore logstore.LogStore, orgRepo *orgRepo.Repository, config Config) *Service {
+	return &Service{
+		logStore: logStore,
+		orgRepo:  orgRepo,
+		config:   config,
+	}
+}
+
+// SearchLogs returns a page of a project's events matching the search, newest first by default
+// Without from and to the last 24 hours are searched
+func (s *Service) SearchLogs(ctx context.Context, projectID uuid.UUID, params dto.LogSearchParams) (*dto.LogSearchResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.QueryTimeout)
+	defer cancel()
+
+	query, err := BuildQuery([]uuid.UUID{projectID}, params, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	query.Limit = params.Limit
+	if query.Limit == 0 {
+		query.Limit = constants.DefaultLogSearchLimit
+	}
+	if query.Limit < 1 || query.Limit > constants.MaxLogSearchLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxLogSearchLimit))
+	}
+
+	warning, err := s.CheckProjectQuery(ctx, projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := logstore.Collect(ctx, s.logStore, query)
+	if err != nil {
+		return nil, s.searchError(err)
+	}
+
+	response := s.toSearchResponse(query, events)
+	response.Warning = warning
+	return response, nil
+}
+
+// SearchOrganizationLogs searches several projects of an organization at once, merging their
+// events by timestamp and naming each event's project
+// projects are the organization's projects; projectIDs picks some of them, or all when empty
+func (s *Service) SearchOrganizationLogs(ctx context.Context, orgID uuid.UUID, projects []*dto.ProjectResponse, projectIDs []uuid.UUID, params dto.LogSearchParams) (*dto.LogSearchResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.QueryTimeout)
+	defer cancel()
+
+	names := make(map[uuid.UUID]string, len(projects))
+	for _, project := range projects {
+		names[project.ID] = project.Name
+	}
+
+	if len(projectIDs) == 0 {
+		for _, project := range projects {
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+	for _, id := range projectIDs {
+		if _, ok := names[id]; !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("Project %s is not part of the organization", id))
+		}
+	}
+	if len(projectIDs) == 0 {
+		return nil, errors.NewValidationError("The organization has no projects to search")
+	}
+
+	query, err := BuildQuery(projectIDs, params, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	query.Limit = params.Limit
+	if query.Limit == 0 {
+		query.Limit = constants.DefaultLogSearchLimit
+	}
+	if query.Limit < 1 || query.Limit > constants.MaxLogSearchLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxLogSearchLimit))
+	}
+
+	plan := ""
+	if s.orgRepo != nil {
+		organization, err := s.orgRepo.GetByID(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		plan = organization.Plan
+	}
+	warning, err := s.checkQuery(ctx, query, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := logstore.Collect(ctx, s.logStore, query)
+	if err != nil {
+		return nil, s.searchError(err)
+	}
+
+	response := s.toSearchResponse(query, events)
+	response.Warning = warning
+	for _, event := range response.Events {
+		event.ProjectName = names[event.ProjectID]
+	}
+	return response, nil
+}
+
+// defaultContextGroupBy are the fields an event's context is matched on when none are chosen
+var defaultContextGroupBy = []string{logstore.FieldHost, logstore.FieldService, logstore.FieldStream}
+
+// contextGroupFields are the columns an event's context can be grouped by, besides attributes
+var contextGroupFields = map[string]bool{
+	logstore.FieldHost:    true,
+	logstore.FieldService: true,
+	logstore.FieldStream:  true,
+	logstore.FieldRelease: true,
+}
+
+// GetEventContext returns an event with the events just before and after it from the same source
+// Neighbours are read with two keyset queries starting at the event, one in each direction, and
+// only up to an hour either side, so only the partitions around the event are touched
+func (s *Service) GetEventContext(ctx context.Context, projectID uuid.UUID, eventID uuid.UUID, params dto.LogContextParams) (*dto.LogContextResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.QueryTimeout)
+	defer cancel()
+
+	if params.Before < 0 || params.Before > constants.MaxLogContextLines {
+		return nil, errors.NewValidationError(fmt.Sprintf("Before must be between 0 and %d", constants.MaxLogContextLines))
+	}
+	if params.After < 0 || params.After > constants.MaxLogContextLines {
+		return nil, errors.NewValidationError(fmt.Sprintf("After must be between 0 and %d", constants.MaxLogContextLines))
+	}
+
+	groupBy := params.GroupBy
+	if groupBy == nil {
+		groupBy = defaultContextGroupBy
+	}
+	for _, name := range groupBy {
+		attribute, isAttribute := strings.CutPrefix(name, "attr.")
+		if !contextGroupFields[name] && (!isAttribute || attribute == "") {
+			return nil, errors.NewValidationError(fmt.Sprintf("Cannot group by %q", name), "Group by host, service, stream, release or attr.<name>")
+		}
+	}
+
+	event, err := s.logStore.Get(ctx, projectID, eventID)
+	if err == logstore.ErrNotFound {
+		return nil, errors.NewNotFoundError("Log event", eventID.String())
+	}
+	if err != nil {
+		return nil, errors.NewInternalError("Failed to retrieve log event", err.Error())
+	}
+
+	response := &dto.LogContextResponse{
+		Event:   s.toLogEventResponse(event),
+		Before:  []*dto.LogEventResponse{},
+		After:   []*dto.LogEventResponse{},
+		GroupBy: make(map[string]string),
+	}
+	fields := make(map[string]string)
+	for _, name := range groupBy {
+		field := strings.TrimPrefix(name, "attr.")
+		if value := event.Field(field); value != "" {
+			fields[field] = value
+			response.GroupBy[name] = value
+		}
+	}
+
+	window := constants.LogContextWindowSeconds * time.Second
+	cursor := &logstore.Cursor{Timestamp: event.Timestamp, ID: event.ID}
+	query := logstore.Query{
+		ProjectIDs: []uuid.UUID{projectID},
+		From:       event.Timestamp.Add(-window),
+		To:         event.Timestamp.Add(window),
+		Fields:     fields,
+		After:      cursor,
+	}
+
+	if params.Before > 0 {
+		query.Descending = true
+		query.Limit = params.Before
+		before, err := logstore.Collect(ctx, s.logStore, query)
+		if err != nil {
+			return nil, errors.NewInternalError("Failed to retrieve log context", err.Error())
+		}
+		// Read newest first, returned oldest first
+		for i := len(before) - 1; i >= 0; i-- {
+			response.Before = append(response.Before, s.toLogEventResponse(before[i]))
+		}
+	}
+
+	if params.After > 0 {
+		query.Descending = false
+		query.Limit = params.After
+		after, err := logstore.Collect(ctx, s.logStore, query)
+		if err != nil {
+			return nil, errors.NewInternalError("Failed to retrieve log context", err.Error())
+		}
+		for _, event := range after {
+			response.After = append(response.After, s.toLogEventResponse(event))
+		}
+	}
+
+	return response, nil
+}
+
+// CheckProjectQuery applies the guardrails to a query over one project's events before it runs,
+// using the plan of the project's organization. Exports use it to be held to the same limits as
+// searches. Returns a warning to show with the results, if any
+func (s *Service) CheckProjectQuery(ctx context.Context, projectID uuid.UUID, query logstore.Query) (string, error) {
+	plan := ""
+	if s.orgRepo != nil {
+		organization, err := s.orgRepo.GetByProjectID(ctx, projectID)
+		if err != nil {
+			return "", err
+		}
+		plan = organization.Plan
+	}
+	return s.checkQuery(ctx, query, plan)
+}
+
+// checkQuery applies the guardrails to a search before it runs
+// A range longer than the plan allows, or an estimated cost above MaxCost, rejects the search;
+// a cost above WarnCost returns a warning to show with the results. An empty plan is not limited
+func (s *Service) checkQuery(ctx context.Context, query logstore.Query, plan string) (string, error) {
+	if maxRange, ok := maxSearchRange[plan]; ok && query.To.Sub(query.From) > maxRange {
+		return "", errors.NewValidationError(fmt.Sprintf("The %s plan can search at most %d days at a time", plan, int(maxRange.Hours()/24)))
+	}
+
+	estimate, err := s.logStore.Estimate(ctx, query)
+	if err != nil {
+		return "", s.searchError(err)
+	}
+	if estimate.Cost > s.config.MaxCost {
+		return "", errors.NewBadRequestError("Search is too expensive to run",
+			fmt.Sprintf("It would scan about %d events; narrow the time range or add filters", estimate.Rows))
+	}
+	if estimate.Cost > s.config.WarnCost {
+		return fmt.Sprintf("This search scans about %d events and may be slow; narrow the time range or add filters", estimate.Rows), nil
+	}
+	return "", nil
+}
+
+// searchError explains a failed search, telling timeouts apart from other failures
+func (s *Service) searchError(err error) error {
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewBadRequestError(fmt.Sprintf("Search took longer than %s and was cancelled", s.config.QueryTimeout),
+			"Narrow the time range or add filters")
+	}
+	return errors.NewInternalError("Failed to search logs", err.Error())
+}
+
+// BuildQuery validates search parameters and turns them into a log store query
+// Without from and to the 24 hours before now are searched. The limit is left to the caller,
+// since searches and exports cap results differently
+func BuildQuery(projectIDs []uuid.UUID, params dto.LogSearchParams, now time.Time) (logstore.Query, error) {
+	to := now
+	if params.To != nil {
+		to = params.To.UTC()
+	}
+	from := to.Add(-constants.DefaultLogSearchRangeSeconds * time.Second)
+	if params.From != nil {
+		from = params.From.UTC()
+	}
+	if !from.Before(to) {
+		return logstore.Query{}, errors.NewValidationError("'from' must be before 'to'")
+	}
+
+	query := logstore.Query{
+		ProjectIDs: projectIDs,
+		From:       from,
+		To:         to,
+		Fields:     params.Fields,
+		Text:       params.Query,
+		PatternID:  params.PatternID,
+		Descending: params.Descending,
+	}
+	for _, level := range params.Levels {
+		if level = strings.ToLower(strings.TrimSpace(level)); level != "" {
+			query.Levels = append(query.Levels, level)
+		}
+	}
+	if params.Cursor != "" {
+		cursor, err := logstore.ParseCursor(params.Cursor)
+		if err != nil {
+			return logstore.Query{}, errors.NewValidationError("Invalid cursor", err.Error())
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+// toSearchResponse builds a page of results, with a cursor when the page is full
+func (s *Service) toSearchResponse(query logstore.Query, events []*logstore.Event) *dto.LogSearchResponse {
+	response := &dto.LogSearchResponse{
+		Events: make([]*dto.LogEventResponse, 0, len(events)),
+		From:   query.From,
+		To:     query.To,
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, s.toLogEventResponse(event))
+	}
+	if len(events) == query.Limit {
+		last := events[len(events)-1]
+		response.NextCursor = logstore.Cursor{Timestamp: last.Timestamp, ID: last.ID}.String()
+	}
+	return response
+}
+
+// toLogEventResponse converts a stored event to its API representation
+func (s *Service) toLogEventResponse(event *logstore.Event) *dto.LogEventResponse {
+	return &dto.LogEventResponse{
+		ID:         event.ID,
+		ProjectID:  event.ProjectID,
+		Timestamp:  event.Timestamp,
+		ReceivedAt: event.ReceivedAt,
+		Level:      event.Level,
+		Message:    event.Message,
+		Host:       event.Host,
+		Service:    event.Service,
+		Stream:     event.Stream,
+		Release:    event.Release,
+		PatternID:  event.PatternID,
+		Attributes: event.Attributes,
+	}
+}
@@ -0,0 +1,86 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/logstore"
+)
+
+func TestGetEventContext(t *testing.T) {
+	store, err := logstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	start := time.Now().UTC().Truncate(time.Second)
+	var events []*logstore.Event
+	for i := 0; i < 10; i++ {
+		host := "web-1"
+		if i%2 == 1 {
+			host = "web-2"
+		}
+		events = append(events, &logstore.Event{
+			ID:        uuid.New(),
+			ProjectID: projectID,
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+			Level:     "info",
+			Message:   "event",
+			Host:      host,
+		})
+	}
+	// An event far outside the context window is never returned
+	events = append(events, &logstore.Event{ID: uuid.New(), ProjectID: projectID, Timestamp: start.Add(-2 * time.Hour), Level: "info", Message: "old", Host: "web-1"})
+	if err := store.Append(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+
+	anchor := events[4] // web-1, between events 0-3 and 5-9
+
+	tests := []struct {
+		name       string
+		params     dto.LogContextParams
+		wantBefore []int
+		wantAfter  []int
+	}{
+		{name: "default grouping keeps the same host", params: dto.LogContextParams{Before: 50, After: 50}, wantBefore: []int{0, 2}, wantAfter: []int{6, 8}},
+		{name: "no grouping", params: dto.LogContextParams{Before: 2, After: 3, GroupBy: []string{}}, wantBefore: []int{2, 3}, wantAfter: []int{5, 6, 7}},
+		{name: "zero on one side", params: dto.LogContextParams{Before: 0, After: 1, GroupBy: []string{}}, wantBefore: []int{}, wantAfter: []int{5}},
+	}
+
+	service := NewService(store, nil, Config{QueryTimeout: time.Minute, WarnCost: 1e6, MaxCost: 1e7})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.GetEventContext(context.Background(), projectID, anchor.ID, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Event.ID != anchor.ID {
+				t.Errorf("event = %s, want %s", result.Event.ID, anchor.ID)
+			}
+			check := func(side string, got []*dto.LogEventResponse, want []int) {
+				if len(got) != len(want) {
+					t.Fatalf("%s has %d events, want %d", side, len(got), len(want))
+				}
+				for i, index := range want {
+					if got[i].ID != events[index].ID {
+						t.Errorf("%s[%d] is not event %d", side, i, index)
+					}
+				}
+			}
+			check("before", result.Before, tt.wantBefore)
+			check("after", result.After, tt.wantAfter)
+		})
+	}
+
+	if _, err := service.GetEventContext(context.Background(), projectID, uuid.New(), dto.LogContextParams{}); err == nil {
+		t.Error("expected an error for an unknown event")
+	}
+	if _, err := service.GetEventContext(context.Background(), projectID, anchor.ID, dto.LogContextParams{GroupBy: []string{"message"}}); err == nil {
+		t.Error("expected an error for an unsupported group_by field")
+	}
+}
@@ -0,0 +1,267 @@
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/services/monitor"
+)
+
+// Default notifier settings used when the corresponding environment variables are not set
+const (
+	defaultReleaseInterval = time.Minute
+	defaultReleaseBatch    = 100
+)
+
+// NotifierConfig controls how often held notifications are checked against silences
+type NotifierConfig struct {
+	// ReleaseInterval is how often held notifications are checked
+	ReleaseInterval time.Duration
+
+	// BatchSize caps how many held notifications are checked per batch
+	BatchSize int
+}
+
+// NotifierConfigFromEnv reads SILENCE_RELEASE_INTERVAL and SILENCE_RELEASE_BATCH_SIZE
+func NotifierConfigFromEnv() NotifierConfig {
+	config := NotifierConfig{
+		ReleaseInterval: envDuration("SILENCE_RELEASE_INTERVAL", defaultReleaseInterval),
+		BatchSize:       defaultReleaseBatch,
+	}
+
+	if value := os.Getenv("SILENCE_RELEASE_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Printf("Invalid SILENCE_RELEASE_BATCH_SIZE %q, using default of %d", value, defaultReleaseBatch)
+		} else {
+			config.BatchSize = size
+		}
+	}
+
+	return config
+}
+
+// envDuration reads a duration such as "1m" from the environment, falling back to the default
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using default of %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// Sender delivers the alert and monitor notifications that no silence mutes
+type Sender interface {
+	alert.Notifier
+	monitor.Notifier
+}
+
+// Notifier holds back alert and monitor notifications muted by a silence and passes the rest on
+// Silences only affect notifications; evaluations and check-ins are recorded regardless
+//
+// A muted firing notification is held rather than dropped. Once no silence mutes it any more
+// it is sent, so a rule that starts firing during a maintenance window and is still firing
+// when the window ends is notified. A rule that resolves while its firing notification is
+// held is never notified at all, since receivers never heard that it fired.
+// Missed and failed monitors are held and released the same way.
+type Notifier struct {
+	silenceService *Service
+	next           Sender
+	config         NotifierConfig
+}
+
+// NewNotifier creates a notifier that consults silences before notifying through next
+func NewNotifier(silenceService *Service, next Sender, config NotifierConfig) *Notifier {
+	return &Notifier{
+		silenceService: silenceService,
+		next:           next,
+		config:         config,
+	}
+}
+
+// Notify holds the notification if a silence mutes it and forwards it otherwise
+// If silences cannot be checked the notification is sent, since a missed page costs more than a spurious one
+func (n *Notifier) Notify(ctx context.Context, notification alert.Notification) error {
+	silences, err := n.silenceService.Suppress(ctx, notification, notification.EvaluatedAt)
+	if err != nil {
+		log.Printf("Failed to check silences for alert rule %s, notifying anyway: %v", notification.RuleID, err)
+		return n.next.Notify(ctx, notification)
+	}
+
+	if len(silences) > 0 {
+		ids := make([]string, len(silences))
+		for i, silence := range silences {
+			ids[i] = silence.ID.String()
+		}
+		log.Printf("Alert %q (%s) for project %s is %s; notification suppressed by silence %s",
+			notification.RuleName, notification.RuleID, notification.ProjectID, notification.State, strings.Join(ids, ", "))
+
+		if notification.State == constants.AlertStateFiring {
+			return n.silenceService.HoldAlert(ctx, notification)
+		}
+		_, err := n.silenceService.DropHeldAlert(ctx, notification.RuleID)
+		return err
+	}
+
+	// A newer notification supersedes anything still held for the rule
+	held, err := n.silenceService.DropHeldAlert(ctx, notification.RuleID)
+	if err != nil {
+		log.Printf("Failed to drop held notification of alert rule %s: %v", notification.RuleID, err)
+	}
+	if held && notification.State == constants.AlertStateOK {
+		log.Printf("Alert %q (%s) for project %s resolved before its held firing notification was sent; not notifying",
+			notification.RuleName, notification.RuleID, notification.ProjectID)
+		return nil
+	}
+	return n.next.Notify(ctx, notification)
+}
+
+// NotifyMonitor holds the monitor notification if a silence mutes it and forwards it otherwise
+// Missed and failed notifications are held; a recovery drops the held one, as for alerts
+func (n *Notifier) NotifyMonitor(ctx context.Context, notification monitor.Notification) error {
+	silences, err := n.silenceService.SuppressMonitor(ctx, notification, notification.OccurredAt)
+	if err != nil {
+		log.Printf("Failed to check silences for monitor %s, notifying anyway: %v", notification.MonitorID, err)
+		return n.next.NotifyMonitor(ctx, notification)
+	}
+
+	if len(silences) > 0 {
+		ids := make([]string, len(silences))
+		for i, silence := range silences {
+			ids[i] = silence.ID.String()
+		}
+		log.Printf("Monitor %q (%s) for project %s is %s; notification suppressed by silence %s",
+			notification.MonitorSlug, notification.MonitorID, notification.ProjectID, notification.Status, strings.Join(ids, ", "))
+
+		if notification.Status != constants.MonitorStatusOK {
+			return n.silenceService.HoldMonitor(ctx, notification)
+		}
+		_, err := n.silenceService.DropHeldMonitor(ctx, notification.MonitorID)
+		return err
+	}
+
+	// A newer notification supersedes anything still held for the monitor
+	held, err := n.silenceService.DropHeldMonitor(ctx, notification.MonitorID)
+	if err != nil {
+		log.Printf("Failed to drop held notification of monitor %s: %v", notification.MonitorID, err)
+	}
+	if held && notification.Status == constants.MonitorStatusOK {
+		log.Printf("Monitor %q (%s) for project %s recovered before its held notification was sent; not notifying",
+			notification.MonitorSlug, notification.MonitorID, notification.ProjectID)
+		return nil
+	}
+	return n.next.NotifyMonitor(ctx, notification)
+}
+
+// Start sends held notifications whose silences have ended on every interval until the context is cancelled
+func (n *Notifier) Start(ctx context.Context) {
+	log.Printf("Held notification release started (interval %s, batch size %d)", n.config.ReleaseInterval, n.config.BatchSize)
+
+	ticker := time.NewTicker(n.config.ReleaseInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.ReleaseHeld(ctx, time.Now()); err != nil {
+			log.Printf("Held notification release failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReleaseHeld sends every held notification that no silence mutes at the given time
+// Held notifications still muted stay held; nothing is recorded for them, so polling is cheap
+func (n *Notifier) ReleaseHeld(ctx context.Context, now time.Time) error {
+	held, err := n.silenceService.GetHeldNotifications(ctx, n.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range held {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n.release(ctx, notification, now)
+	}
+	return nil
+}
+
+// release sends one held notification if no silence mutes it any more
+func (n *Notifier) release(ctx context.Context, held *models.HeldNotification, now time.Time) {
+	if held.MonitorID != nil {
+		n.releaseMonitor(ctx, held, now)
+		return
+	}
+
+	var notification alert.Notification
+	if err := json.Unmarshal(held.Payload, &notification); err != nil {
+		log.Printf("Discarding unreadable held notification %s: %v", held.ID, err)
+		n.silenceService.TakeHeldNotification(ctx, held.ID)
+		return
+	}
+
+	if !n.take(ctx, held, notification.ProjectID, notification.Labels, now) {
+		return
+	}
+
+	log.Printf("Alert %q (%s) for project %s is still %s after its silence ended; sending held notification",
+		notification.RuleName, notification.RuleID, notification.ProjectID, notification.State)
+	if err := n.next.Notify(ctx, notification); err != nil {
+		log.Printf("Failed to send held notification for alert rule %s: %v", notification.RuleID, err)
+	}
+}
+
+// releaseMonitor sends one held monitor notification if no silence mutes it any more
+func (n *Notifier) releaseMonitor(ctx context.Context, held *models.HeldNotification, now time.Time) {
+	var notification monitor.Notification
+	if err := json.Unmarshal(held.Payload, &notification); err != nil {
+		log.Printf("Discarding unreadable held notification %s: %v", held.ID, err)
+		n.silenceService.TakeHeldNotification(ctx, held.ID)
+		return
+	}
+
+	if !n.take(ctx, held, notification.ProjectID, notification.Labels, now) {
+		return
+	}
+
+	log.Printf("Monitor %q (%s) for project %s is still %s after its silence ended; sending held notification",
+		notification.MonitorSlug, notification.MonitorID, notification.ProjectID, notification.Status)
+	if err := n.next.NotifyMonitor(ctx, notification); err != nil {
+		log.Printf("Failed to send held notification for monitor %s: %v", notification.MonitorID, err)
+	}
+}
+
+// take removes a held notification that no silence mutes any more, reporting whether the caller should send it
+func (n *Notifier) take(ctx context.Context, held *models.HeldNotification, projectID uuid.UUID, labels map[string]string, now time.Time) bool {
+	muted, err := n.silenceService.IsMuted(ctx, projectID, labels, now)
+	if err != nil {
+		log.Printf("Failed to check silences for held notification %s: %v", held.ID, err)
+		return false
+	}
+	if muted {
+		return false
+	}
+
+	taken, err := n.silenceService.TakeHeldNotification(ctx, held.ID)
+	return err == nil && taken
+}
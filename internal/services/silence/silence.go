@@ -0,0 +1,601 @@
+package silence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed the time zone database so maintenance windows work on hosts without one
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	silenceRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/silence"
+	"github.com/nihar-hegde/valtro-backend/internal/services/alert"
+	"github.com/nihar-hegde/valtro-backend/internal/services/monitor"
+	"github.com/nihar-hegde/valtro-backend/internal/utils/validator"
+)
+
+// weekdays maps weekday names and their three-letter abbreviations to time.Weekday values
+var weekdays = func() map[string]time.Weekday {
+	names := make(map[string]time.Weekday, 14)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		names[name] = day
+		names[name[:3]] = day
+	}
+	return names
+}()
+
+// Service handles silence management and decides which notifications are muted
+type Service struct {
+	silenceRepo *silenceRepo.Repository
+}
+
+// NewService creates a new silence service
+func NewService(silenceRepo *silenceRepo.Repository) *Service {
+	return &Service{
+		silenceRepo: silenceRepo,
+	}
+}
+
+// CreateSilence creates a silence and records it in the audit trail
+func (s *Service) CreateSilence(ctx context.Context, organizationID uuid.UUID, req dto.CreateSilenceRequest, actorID uuid.UUID) (*dto.SilenceResponse, error) {
+	now := time.Now()
+	silence := &models.Silence{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ProjectID:      req.ProjectID,
+		Comment:        strings.TrimSpace(req.Comment),
+		StartsAt:       now,
+		EndsAt:         req.EndsAt,
+		RecurrenceDays: []int{},
+		CreatedByID:    actorID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if req.StartsAt != nil {
+		silence.StartsAt = *req.StartsAt
+	}
+
+	var err error
+	silence.Matchers, err = validator.ValidateLabels("Silence matchers", req.Matchers, constants.MaxSilenceMatchers)
+	if err != nil {
+		return nil, err
+	}
+	if req.Recurrence != nil {
+		if err := s.applyRecurrence(silence, req.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate business rules
+	if err := s.validateSilence(silence, now); err != nil {
+		return nil, err
+	}
+	if silence.ProjectID != nil {
+		exists, err := s.silenceRepo.ProjectExistsInOrganization(ctx, *silence.ProjectID, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.NewValidationError("Project must exist in the organization")
+		}
+	}
+
+	entry := s.newAuditEntry(silence, constants.SilenceActionCreated, &actorID, nil, "Silence created: "+s.describeSilence(silence))
+
+	// Save silence and audit entry together
+	if err := s.silenceRepo.Create(ctx, silence, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toSilenceResponse(silence, now), nil
+}
+
+// GetSilencesByOrganization lists an organization's silences, optionally only those in one state
+func (s *Service) GetSilencesByOrganization(ctx context.Context, organizationID uuid.UUID, state string) ([]*dto.SilenceResponse, error) {
+	switch state {
+	case "", constants.SilenceStateScheduled, constants.SilenceStateActive, constants.SilenceStateExpired:
+	default:
+		return nil, errors.NewValidationError("State must be one of 'scheduled', 'active' or 'expired'")
+	}
+
+	now := time.Now()
+	silences, err := s.silenceRepo.GetByOrganizationID(ctx, organizationID, state, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.SilenceResponse, 0, len(silences))
+	for _, silence := range silences {
+		responses = append(responses, s.toSilenceResponse(silence, now))
+	}
+
+	return responses, nil
+}
+
+// GetSilenceByID retrieves a silence of an organization
+func (s *Service) GetSilenceByID(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*dto.SilenceResponse, error) {
+	silence, err := s.getOrganizationSilence(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSilenceResponse(silence, time.Now()), nil
+}
+
+// UpdateSilence changes the matchers, comment, period or recurrence of a silence that has not expired
+func (s *Service) UpdateSilence(ctx context.Context, id uuid.UUID, req dto.UpdateSilenceRequest, organizationID uuid.UUID, actorID uuid.UUID) (*dto.SilenceResponse, error) {
+	silence, err := s.getUnexpiredSilence(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	before := *silence
+
+	// Update fields if provided
+	if req.Matchers != nil {
+		silence.Matchers, err = validator.ValidateLabels("Silence matchers", req.Matchers, constants.MaxSilenceMatchers)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Comment != nil {
+		silence.Comment = strings.TrimSpace(*req.Comment)
+	}
+	if req.StartsAt != nil {
+		silence.StartsAt = *req.StartsAt
+	}
+	if req.ClearEndsAt {
+		silence.EndsAt = nil
+	} else if req.EndsAt != nil {
+		silence.EndsAt = req.EndsAt
+	}
+	if req.ClearRecurrence {
+		silence.RecurrenceDays = []int{}
+		silence.RecurrenceStartTime = ""
+		silence.RecurrenceDurationSeconds = 0
+		silence.RecurrenceTimezone = ""
+	} else if req.Recurrence != nil {
+		if err := s.applyRecurrence(silence, req.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate business rules
+	now := time.Now()
+	if err := s.validateSilence(silence, now); err != nil {
+		return nil, err
+	}
+
+	// Describe each change for the audit trail
+	var changes []string
+	if formatLabels(before.Matchers) != formatLabels(silence.Matchers) {
+		changes = append(changes, fmt.Sprintf("matchers %s -> %s", formatLabels(before.Matchers), formatLabels(silence.Matchers)))
+	}
+	if before.Comment != silence.Comment {
+		changes = append(changes, fmt.Sprintf("comment %q -> %q", before.Comment, silence.Comment))
+	}
+	if !before.StartsAt.Equal(silence.StartsAt) {
+		changes = append(changes, fmt.Sprintf("starts_at %s -> %s", formatTime(&before.StartsAt), formatTime(&silence.StartsAt)))
+	}
+	if formatTime(before.EndsAt) != formatTime(silence.EndsAt) {
+		changes = append(changes, fmt.Sprintf("ends_at %s -> %s", formatTime(before.EndsAt), formatTime(silence.EndsAt)))
+	}
+	if describeRecurrence(&before) != describeRecurrence(silence) {
+		changes = append(changes, fmt.Sprintf("recurrence %s -> %s", describeRecurrence(&before), describeRecurrence(silence)))
+	}
+
+	if len(changes) == 0 {
+		return s.toSilenceResponse(silence, now), nil
+	}
+
+	silence.UpdatedAt = now
+	entry := s.newAuditEntry(silence, constants.SilenceActionUpdated, &actorID, nil, "Silence updated: "+strings.Join(changes, "; "))
+
+	// Save silence and audit entry together
+	if err := s.silenceRepo.Update(ctx, silence, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toSilenceResponse(silence, now), nil
+}
+
+// ExpireSilence ends a silence early so notifications are sent again
+func (s *Service) ExpireSilence(ctx context.Context, id uuid.UUID, organizationID uuid.UUID, actorID uuid.UUID) (*dto.SilenceResponse, error) {
+	silence, err := s.getUnexpiredSilence(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	silence.ExpiredAt = &now
+	silence.ExpiredByID = &actorID
+	silence.UpdatedAt = now
+
+	entry := s.newAuditEntry(silence, constants.SilenceActionExpired, &actorID, nil, "Silence expired: "+s.describeSilence(silence))
+
+	// Save silence and audit entry together
+	if err := s.silenceRepo.Update(ctx, silence, entry); err != nil {
+		return nil, err
+	}
+
+	return s.toSilenceResponse(silence, now), nil
+}
+
+// GetAuditTrail retrieves an organization's silence audit trail, newest first
+// A non-nil silence ID limits the trail to that silence
+func (s *Service) GetAuditTrail(ctx context.Context, organizationID uuid.UUID, silenceID *uuid.UUID, limit int) ([]*dto.SilenceAuditEntryResponse, error) {
+	if limit == 0 {
+		limit = constants.DefaultSilenceAuditLimit
+	}
+	if limit < 1 || limit > constants.MaxSilenceAuditLimit {
+		return nil, errors.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", constants.MaxSilenceAuditLimit))
+	}
+
+	if silenceID != nil {
+		if _, err := s.getOrganizationSilence(ctx, *silenceID, organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.silenceRepo.GetAuditTrail(ctx, organizationID, silenceID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	responses := make([]*dto.SilenceAuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, &dto.SilenceAuditEntryResponse{
+			ID:             entry.ID,
+			SilenceID:      entry.SilenceID,
+			OrganizationID: entry.OrganizationID,
+			Action:         entry.Action,
+			ActorID:        entry.ActorID,
+			AlertRuleID:    entry.AlertRuleID,
+			MonitorID:      entry.MonitorID,
+			Details:        entry.Details,
+			CreatedAt:      entry.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// Suppress returns the silences muting an alert notification at the given time and records
+// the suppression in each one's audit trail. No silences means the notification should be sent
+func (s *Service) Suppress(ctx context.Context, notification alert.Notification, at time.Time) ([]*models.Silence, error) {
+	silences, err := s.muting(ctx, notification.ProjectID, notification.Labels, at)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.SilenceAuditEntry, 0, len(silences))
+	for _, silence := range silences {
+		entries = append(entries, s.newAuditEntry(silence, constants.SilenceActionSuppressed, nil, &notification.RuleID,
+			fmt.Sprintf("Notification suppressed: alert rule %q is %s", notification.RuleName, notification.State)))
+	}
+
+	if err := s.silenceRepo.CreateAuditEntries(ctx, entries); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// SuppressMonitor returns the silences muting a monitor notification at the given time and
+// records the suppression in each one's audit trail, like Suppress does for alert notifications
+func (s *Service) SuppressMonitor(ctx context.Context, notification monitor.Notification, at time.Time) ([]*models.Silence, error) {
+	silences, err := s.muting(ctx, notification.ProjectID, notification.Labels, at)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.SilenceAuditEntry, 0, len(silences))
+	for _, silence := range silences {
+		entry := s.newAuditEntry(silence, constants.SilenceActionSuppressed, nil, nil,
+			fmt.Sprintf("Notification suppressed: monitor %q is %s", notification.MonitorSlug, notification.Status))
+		entry.MonitorID = &notification.MonitorID
+		entries = append(entries, entry)
+	}
+
+	if err := s.silenceRepo.CreateAuditEntries(ctx, entries); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// IsMuted reports whether any silence mutes notifications with the given labels at the given time
+// Unlike Suppress it records nothing, so it can be polled
+func (s *Service) IsMuted(ctx context.Context, projectID uuid.UUID, labels map[string]string, at time.Time) (bool, error) {
+	silences, err := s.muting(ctx, projectID, labels, at)
+	if err != nil {
+		return false, err
+	}
+	return len(silences) > 0, nil
+}
+
+// HoldAlert keeps a suppressed firing notification so it can be sent when the silence ends
+// Holding again for the same rule replaces the earlier notification
+func (s *Service) HoldAlert(ctx context.Context, notification alert.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return errors.NewInternalError("Failed to encode held notification", err.Error())
+	}
+
+	return s.silenceRepo.HoldNotification(ctx, &models.HeldNotification{
+		ID:          uuid.New(),
+		ProjectID:   notification.ProjectID,
+		AlertRuleID: &notification.RuleID,
+		Payload:     payload,
+		HeldAt:      time.Now(),
+	})
+}
+
+// DropHeldAlert discards the notification held for a rule, reporting whether there was one
+func (s *Service) DropHeldAlert(ctx context.Context, ruleID uuid.UUID) (bool, error) {
+	return s.silenceRepo.DeleteHeldForAlertRule(ctx, ruleID)
+}
+
+// HoldMonitor keeps a suppressed missed or failed monitor notification so it can be sent when the silence ends
+// Holding again for the same monitor replaces the earlier notification
+func (s *Service) HoldMonitor(ctx context.Context, notification monitor.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return errors.NewInternalError("Failed to encode held notification", err.Error())
+	}
+
+	return s.silenceRepo.HoldNotification(ctx, &models.HeldNotification{
+		ID:        uuid.New(),
+		ProjectID: notification.ProjectID,
+		MonitorID: &notification.MonitorID,
+		Payload:   payload,
+		HeldAt:    time.Now(),
+	})
+}
+
+// DropHeldMonitor discards the notification held for a monitor, reporting whether there was one
+func (s *Service) DropHeldMonitor(ctx context.Context, monitorID uuid.UUID) (bool, error) {
+	return s.silenceRepo.DeleteHeldForMonitor(ctx, monitorID)
+}
+
+// GetHeldNotifications retrieves up to limit held notifications, longest held first
+func (s *Service) GetHeldNotifications(ctx context.Context, limit int) ([]*models.HeldNotification, error) {
+	return s.silenceRepo.GetHeld(ctx, limit)
+}
+
+// TakeHeldNotification removes a held notification before it is sent, reporting whether the
+// caller owns it; false means another instance already took it
+func (s *Service) TakeHeldNotification(ctx context.Context, id uuid.UUID) (bool, error) {
+	return s.silenceRepo.TakeHeld(ctx, id)
+}
+
+// muting returns the silences of a project that mute notifications with the given labels at the given time
+func (s *Service) muting(ctx context.Context, projectID uuid.UUID, labels map[string]string, at time.Time) ([]*models.Silence, error) {
+	candidates, err := s.silenceRepo.GetActiveForProject(ctx, projectID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var silences []*models.Silence
+	for _, silence := range candidates {
+		if silence.InEffect(at) && matches(silence.Matchers, labels) {
+			silences = append(silences, silence)
+		}
+	}
+	return silences, nil
+}
+
+// getOrganizationSilence retrieves a silence, reporting silences of other organizations as missing
+func (s *Service) getOrganizationSilence(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*models.Silence, error) {
+	silence, err := s.silenceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if silence.OrganizationID != organizationID {
+		return nil, errors.NewNotFoundError("Silence", id.String())
+	}
+	return silence, nil
+}
+
+// getUnexpiredSilence retrieves a silence of an organization that has not expired yet
+func (s *Service) getUnexpiredSilence(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (*models.Silence, error) {
+	silence, err := s.getOrganizationSilence(ctx, id, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if silence.IsExpired(time.Now()) {
+		return nil, errors.NewConflictError("Silence has already expired", "Silence ID: "+id.String())
+	}
+	return silence, nil
+}
+
+// applyRecurrence validates a weekly window and stores it on the silence
+func (s *Service) applyRecurrence(silence *models.Silence, recurrence *dto.SilenceRecurrence) error {
+	if len(recurrence.Days) == 0 {
+		return errors.NewValidationError("Recurrence needs at least one day")
+	}
+	days := make([]int, 0, len(recurrence.Days))
+	seen := make(map[time.Weekday]bool, len(recurrence.Days))
+	for _, name := range recurrence.Days {
+		day, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return errors.NewValidationError(fmt.Sprintf("Recurrence day %q must be a weekday name such as 'monday'", name))
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, int(day))
+		}
+	}
+	sort.Ints(days)
+
+	startTime := strings.TrimSpace(recurrence.StartTime)
+	if _, err := time.Parse("15:04", startTime); err != nil {
+		return errors.NewValidationError("Recurrence start time must be in HH:MM format, such as 22:00")
+	}
+
+	duration, err := time.ParseDuration(strings.TrimSpace(recurrence.Duration))
+	if err != nil {
+		return errors.NewValidationError("Recurrence duration must be a duration such as 30m or 2h", err.Error())
+	}
+	if duration%time.Minute != 0 || duration < time.Minute || duration > constants.MaxSilenceWindowSeconds*time.Second {
+		return errors.NewValidationError(fmt.Sprintf("Recurrence duration must be a whole number of minutes between 1m and %s",
+			time.Duration(constants.MaxSilenceWindowSeconds)*time.Second))
+	}
+
+	timezone := strings.TrimSpace(recurrence.Timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Recurrence time zone %q is not a known IANA time zone", timezone))
+	}
+
+	silence.RecurrenceDays = days
+	silence.RecurrenceStartTime = startTime
+	silence.RecurrenceDurationSeconds = int(duration / time.Second)
+	silence.RecurrenceTimezone = timezone
+	return nil
+}
+
+// validateSilence validates the comment and period of a silence
+func (s *Service) validateSilence(silence *models.Silence, now time.Time) error {
+	if silence.Comment == "" {
+		return errors.NewValidationError("Silence comment is required")
+	}
+	if len(silence.Comment) > constants.MaxSilenceCommentLength {
+		return errors.NewValidationError(fmt.Sprintf("Silence comment must be at most %d characters", constants.MaxSilenceCommentLength))
+	}
+	if silence.EndsAt == nil {
+		if !silence.IsRecurring() {
+			return errors.NewValidationError("Silence end is required unless the silence recurs")
+		}
+		return nil
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return errors.NewValidationError("Silence end must be after its start")
+	}
+	if !silence.EndsAt.After(now) {
+		return errors.NewValidationError("Silence end must be in the future")
+	}
+	return nil
+}
+
+// newAuditEntry builds an audit entry for a silence
+func (s *Service) newAuditEntry(silence *models.Silence, action string, actorID *uuid.UUID, alertRuleID *uuid.UUID, details string) *models.SilenceAuditEntry {
+	return &models.SilenceAuditEntry{
+		ID:             uuid.New(),
+		SilenceID:      silence.ID,
+		OrganizationID: silence.OrganizationID,
+		Action:         action,
+		ActorID:        actorID,
+		AlertRuleID:    alertRuleID,
+		Details:        details,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// describeSilence summarizes a silence's scope, matchers, period and recurrence for the audit trail
+func (s *Service) describeSilence(silence *models.Silence) string {
+	scope := "organization"
+	if silence.ProjectID != nil {
+		scope = "project " + silence.ProjectID.String()
+	}
+	return fmt.Sprintf("comment %q, scope %s, matchers %s, period %s to %s, recurrence %s",
+		silence.Comment, scope, formatLabels(silence.Matchers), formatTime(&silence.StartsAt), formatTime(silence.EndsAt),
+		describeRecurrence(silence))
+}
+
+// silenceState reports whether a silence is scheduled, active or expired at the given time
+func silenceState(silence *models.Silence, at time.Time) string {
+	if silence.IsExpired(at) {
+		return constants.SilenceStateExpired
+	}
+	if at.Before(silence.StartsAt) {
+		return constants.SilenceStateScheduled
+	}
+	return constants.SilenceStateActive
+}
+
+// matches reports whether the labels carry every matcher with an equal value
+func matches(matchers map[string]string, labels map[string]string) bool {
+	for key, value := range matchers {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// toRecurrence converts a silence's weekly window to its DTO, or nil if it does not recur
+func toRecurrence(silence *models.Silence) *dto.SilenceRecurrence {
+	if !silence.IsRecurring() {
+		return nil
+	}
+	days := make([]string, len(silence.RecurrenceDays))
+	for i, day := range silence.RecurrenceDays {
+		days[i] = strings.ToLower(time.Weekday(day).String())
+	}
+	return &dto.SilenceRecurrence{
+		Days:      days,
+		StartTime: silence.RecurrenceStartTime,
+		Duration:  (time.Duration(silence.RecurrenceDurationSeconds) * time.Second).String(),
+		Timezone:  silence.RecurrenceTimezone,
+	}
+}
+
+// describeRecurrence formats a silence's weekly window for the audit trail
+func describeRecurrence(silence *models.Silence) string {
+	recurrence := toRecurrence(silence)
+	if recurrence == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s at %s for %s (%s)", strings.Join(recurrence.Days, ","),
+		recurrence.StartTime, recurrence.Duration, recurrence.Timezone)
+}
+
+// formatLabels formats labels as sorted key=value pairs for the audit trail
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "none"
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// formatTime formats an optional timestamp for the audit trail
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "none"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// toSilenceResponse converts a silence model to response DTO
+func (s *Service) toSilenceResponse(silence *models.Silence, now time.Time) *dto.SilenceResponse {
+	return &dto.SilenceResponse{
+		ID:             silence.ID,
+		OrganizationID: silence.OrganizationID,
+		ProjectID:      silence.ProjectID,
+		Matchers:       silence.Matchers,
+		Comment:        silence.Comment,
+		StartsAt:       silence.StartsAt,
+		EndsAt:         silence.EndsAt,
+		Recurrence:     toRecurrence(silence),
+		State:          silenceState(silence, now),
+		InEffect:       silence.InEffect(now),
+		CreatedByID:    silence.CreatedByID,
+		ExpiredAt:      silence.ExpiredAt,
+		ExpiredByID:    silence.ExpiredByID,
+		CreatedAt:      silence.CreatedAt,
+		UpdatedAt:      silence.UpdatedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package usage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	"github.com/nihar-hegde/valtro-backend/internal/dto"
+	"github.com/nihar-hegde/valtro-backend/internal/errors"
+	"github.com/nihar-hegde/valtro-backend/internal/models"
+	usageRepo "github.com/nihar-hegde/valtro-backend/internal/repositories/usage"
+)
+
+// Service handles usage accounting business logic
+type Service struct {
+	usageRepo *usageRepo.Repository
+}
+
+// NewService creates a new usage service
+func NewService(usageRepo *usageRepo.Repository) *Service {
+	return &Service{
+		usageRepo: usageRepo,
+	}
+}
+
+// RecordIngest adds ingested events to the project's hourly usage counters
+// The ingestion pipeline calls this once per accepted batch and log level
+func (s *Service) RecordIngest(ctx context.Context, projectID uuid.UUID, level string, events, rawBytes, storedBytes int64, at time.Time) error {
+	if events < 0 || rawBytes < 0 || storedBytes < 0 {
+		return errors.NewValidationError("Usage counters cannot be negative")
+	}
+
+	row := &models.ProjectUsageHourly{
+		ProjectID:   projectID,
+		Hour:        at.UTC().Truncate(time.Hour),
+		Level:       strings.ToLower(level),
+		EventCount:  events,
+		RawBytes:    rawBytes,
+		StoredBytes: storedBytes,
+		UpdatedAt:   time.Now(),
+	}
+
+	return s.usageRepo.Increment(ctx, row)
+}
+
+// GetOrganizationUsage builds a daily or monthly usage report for an organization
+// A nil from or to falls back to the last 30 days (daily) or the last 12 months (monthly)
+func (s *Service) GetOrganizationUsage(ctx context.Context, organizationID uuid.UUID, granularity string, from, to *time.Time) (*dto.OrganizationUsageResponse, error) {
+	if granularity == "" {
+		granularity = constants.UsageGranularityDay
+	}
+	if granularity != constants.UsageGranularityDay && granularity != constants.UsageGranularityMonth {
+		return nil, errors.NewValidationError("Granularity must be either 'day' or 'month'")
+	}
+
+	start, end := s.defaultRange(granularity, time.Now().UTC())
+	if from != nil {
+		start = from.UTC()
+	}
+	if to != nil {
+		end = to.UTC()
+	}
+
+	// Validate business rules
+	if !start.Before(end) {
+		return nil, errors.NewValidationError("Usage range start must be before range end")
+	}
+	if end.Sub(start) > constants.MaxUsageRangeDays*24*time.Hour {
+		return nil, errors.NewValidationError("Usage range cannot exceed 731 days")
+	}
+
+	totals, err := s.usageRepo.GetOrganizationTotals(ctx, organizationID, granularity, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toUsageResponse(organizationID, granularity, start, end, totals), nil
+}
+
+// defaultRange returns the reporting window used when the caller does not specify one
+func (s *Service) defaultRange(granularity string, now time.Time) (time.Time, time.Time) {
+	if granularity == constants.UsageGranularityMonth {
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return monthStart.AddDate(0, -11, 0), now
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return dayStart.AddDate(0, 0, -(constants.DefaultUsageRangeDays - 1)), now
+}
+
+// toUsageResponse groups aggregated rows into periods and projects
+// Rows arrive ordered by period and project, so each group is contiguous
+func (s *Service) toUsageResponse(organizationID uuid.UUID, granularity string, from, to time.Time, totals []*usageRepo.BucketTotal) *dto.OrganizationUsageResponse {
+	resp := &dto.OrganizationUsageResponse{
+		OrganizationID: organizationID,
+		Granularity:    granularity,
+		From:           from,
+		To:             to,
+		Periods:        make([]*dto.UsagePeriod, 0),
+	}
+
+	var period *dto.UsagePeriod
+	var project *dto.ProjectUsage
+	for _, total := range totals {
+		if period == nil || !period.Start.Equal(total.Period) {
+			period = &dto.UsagePeriod{Start: total.Period, Projects: make([]*dto.ProjectUsage, 0)}
+			resp.Periods = append(resp.Periods, period)
+			project = nil
+		}
+		if project == nil || project.ProjectID != total.ProjectID {
+			project = &dto.ProjectUsage{
+				ProjectID:   total.ProjectID,
+				ProjectName: total.ProjectName,
+				Levels:      make(map[string]dto.UsageTotals),
+			}
+			period.Projects = append(period.Projects, project)
+		}
+
+		counters := dto.UsageTotals{
+			Events:      total.EventCount,
+			RawBytes:    total.RawBytes,
+			StoredBytes: total.StoredBytes,
+		}
+		project.Levels[total.Level] = counters
+		addUsage(&project.Totals, counters)
+		addUsage(&period.Totals, counters)
+		addUsage(&resp.Totals, counters)
+	}
+
+	return resp
+}
+
+// addUsage adds counters to a running total
+func addUsage(total *dto.UsageTotals, counters dto.UsageTotals) {
+	total.Events += counters.Events
+	total.RawBytes += counters.RawBytes
+	total.StoredBytes += counters.StoredBytes
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -24,14 +25,14 @@ func NewService(userRepo *userRepo.Repository) *Service {
 }
 
 // CreateUser creates a new user with business logic validation
-func (s *Service) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse, error) {
+func (s *Service) CreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error) {
 	// Validate business rules
 	if err := s.validateCreateUser(req); err != nil {
 		return nil, err
 	}
 
 	// Check if user already exists by Clerk user ID
-	exists, err := s.userRepo.ClerkUserIDExists(req.ClerkUserID)
+	exists, err := s.userRepo.ClerkUserIDExists(ctx, req.ClerkUserID)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -52,7 +53,7 @@ func (s *Service) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse, erro
 	}
 
 	// Save to database
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
@@ -61,8 +62,8 @@ func (s *Service) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse, erro
 }
 
 // GetUserByID retrieves a user by ID
-func (s *Service) GetUserByID(id uuid.UUID) (*dto.UserResponse, error) {
-	user, err := s.userRepo.GetByID(id)
+func (s *Service) GetUserByID(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -70,8 +71,8 @@ func (s *Service) GetUserByID(id uuid.UUID) (*dto.UserResponse, error) {
 }
 
 // GetUserByClerkID retrieves a user by Clerk user ID
-func (s *Service) GetUserByClerkID(clerkUserID string) (*dto.UserResponse, error) {
-	user, err := s.userRepo.GetByClerkUserID(clerkUserID)
+func (s *Service) GetUserByClerkID(ctx context.Context, clerkUserID string) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetByClerkUserID(ctx, clerkUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +80,7 @@ func (s *Service) GetUserByClerkID(clerkUserID string) (*dto.UserResponse, error
 }
 
 // GetAllUsers retrieves all users with pagination
-func (s *Service) GetAllUsers(limit, offset int) ([]*dto.UserResponse, error) {
+func (s *Service) GetAllUsers(ctx context.Context, limit, offset int) ([]*dto.UserResponse, error) {
 	// Set default pagination limits
 	if limit <= 0 || limit > 100 {
 		limit = 20
@@ -88,7 +89,7 @@ func (s *Service) GetAllUsers(limit, offset int) ([]*dto.UserResponse, error) {
 		offset = 0
 	}
 
-	users, err := s.userRepo.GetAll(limit, offset)
+	users, err := s.userRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err // Repository now returns structured errors
 	}
@@ -103,9 +104,9 @@ func (s *Service) GetAllUsers(limit, offset int) ([]*dto.UserResponse, error) {
 }
 
 // UpdateUser updates a user
-func (s *Service) UpdateUser(id uuid.UUID, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
+func (s *Service) UpdateUser(ctx context.Context, id uuid.UUID, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
 	// Get existing user
-	user, err := s.userRepo.GetByID(id)
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +118,7 @@ func (s *Service) UpdateUser(id uuid.UUID, req dto.UpdateUserRequest) (*dto.User
 	if req.Username != nil {
 		// Check if username is already taken by another user
 		if *req.Username != "" {
-			existingUser, err := s.userRepo.GetByUsername(*req.Username)
+			existingUser, err := s.userRepo.GetByUsername(ctx, *req.Username)
 			if err == nil && existingUser.ID != user.ID {
 				return nil, errors.NewConflictError("Username already taken", "Username: "+*req.Username)
 			}
@@ -131,7 +132,7 @@ func (s *Service) UpdateUser(id uuid.UUID, req dto.UpdateUserRequest) (*dto.User
 	user.UpdatedAt = time.Now()
 
 	// Save changes
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err // Repository now returns structured errors
 	}
 
@@ -139,15 +140,15 @@ func (s *Service) UpdateUser(id uuid.UUID, req dto.UpdateUserRequest) (*dto.User
 }
 
 // DeleteUser soft deletes a user
-func (s *Service) DeleteUser(id uuid.UUID) error {
+func (s *Service) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	// Check if user exists
-	_, err := s.userRepo.GetByID(id)
+	_, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	// Soft delete
-	if err := s.userRepo.Delete(id); err != nil {
+	if err := s.userRepo.Delete(ctx, id); err != nil {
 		return err // Repository now returns structured errors
 	}
 
@@ -155,8 +156,8 @@ func (s *Service) DeleteUser(id uuid.UUID) error {
 }
 
 // UpdateLastSignIn updates the user's last sign-in time
-func (s *Service) UpdateLastSignIn(clerkUserID string) error {
-	user, err := s.userRepo.GetByClerkUserID(clerkUserID)
+func (s *Service) UpdateLastSignIn(ctx context.Context, clerkUserID string) error {
+	user, err := s.userRepo.GetByClerkUserID(ctx, clerkUserID)
 	if err != nil {
 		return err
 	}
@@ -165,7 +166,7 @@ func (s *Service) UpdateLastSignIn(clerkUserID string) error {
 	user.LastSignIn = &now
 	user.UpdatedAt = time.Now()
 
-	return s.userRepo.Update(user)
+	return s.userRepo.Update(ctx, user)
 }
 
 // validateCreateUser validates the create user request
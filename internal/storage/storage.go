@@ -0,0 +1,104 @@
+// Package storage stores uploaded files such as release artifacts, either on local
+// disk or in an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+)
+
+// ErrNotFound is returned by Get when no object exists under the key
+var ErrNotFound = errors.New("object not found")
+
+// Store is a flat key/value object store
+// Keys are slash-separated paths such as "artifacts/{projectID}/{artifactID}"
+type Store interface {
+	// Put writes an object, replacing any existing object with the same key
+	Put(ctx context.Context, key string, body io.Reader, size int64) error
+
+	// Get opens an object for reading; the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes an object; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Default storage settings used when the corresponding environment variables are not set
+const (
+	defaultBackend   = "local"
+	defaultLocalPath = "./data/artifacts"
+	defaultS3Region  = "us-east-1"
+)
+
+// NewFromEnv builds the store selected by ARTIFACT_STORAGE ("local" or "s3")
+//
+// Local storage reads ARTIFACT_STORAGE_PATH. S3 storage reads S3_ENDPOINT, S3_BUCKET,
+// S3_REGION, S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY and S3_USE_PATH_STYLE.
+func NewFromEnv() (Store, error) {
+	backend := os.Getenv("ARTIFACT_STORAGE")
+	if backend == "" {
+		backend = defaultBackend
+	}
+
+	switch backend {
+	case "local":
+		path := os.Getenv("ARTIFACT_STORAGE_PATH")
+		if path == "" {
+			path = defaultLocalPath
+		}
+		return NewLocalStore(path)
+	case "s3":
+		config := S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		}
+		if config.Region == "" {
+			config.Region = defaultS3Region
+		}
+		if value := os.Getenv("S3_USE_PATH_STYLE"); value != "" {
+			usePathStyle, err := strconv.ParseBool(value)
+			if err != nil {
+				log.Printf("Invalid S3_USE_PATH_STYLE %q, using default of false", value)
+			} else {
+				config.UsePathStyle = usePathStyle
+			}
+		}
+		return NewS3Store(config)
+	default:
+		return nil, fmt.Errorf("unknown ARTIFACT_STORAGE %q, expected \"local\" or \"s3\"", backend)
+	}
+}
+
+// unavailableStore fails every operation with the error that prevented storage from being configured
+type unavailableStore struct {
+	err error
+}
+
+// NewUnavailableStore returns a store that rejects every call with err
+// It lets the API start without working storage; only features that need it fail
+func NewUnavailableStore(err error) Store {
+	return &unavailableStore{err: err}
+}
+
+// Put always fails
+func (s *unavailableStore) Put(ctx context.Context, key string, body io.Reader, size int64) error {
+	return fmt.Errorf("storage is unavailable: %w", s.err)
+}
+
+// Get always fails
+func (s *unavailableStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("storage is unavailable: %w", s.err)
+}
+
+// Delete always fails
+func (s *unavailableStore) Delete(ctx context.Context, key string) error {
+	return fmt.Errorf("storage is unavailable: %w", s.err)
+}
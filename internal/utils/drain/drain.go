@@ -0,0 +1,200 @@
+// Package drain implements the Drain online log template miner.
+//
+// Messages are tokenized on whitespace and routed through a fixed-depth parse tree
+// keyed by token count and leading tokens. At each leaf the message joins the most
+// similar cluster, and positions where the cluster's messages disagree become the
+// wildcard "<*>". See He et al., "Drain: An Online Log Parsing Approach with Fixed
+// Depth Tree" (ICWS 2017).
+package drain
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Wildcard replaces tokens that vary between messages of the same pattern
+const Wildcard = "<*>"
+
+// Default tuning used when a Config field is zero
+const (
+	defaultDepth               = 4
+	defaultSimilarityThreshold = 0.4
+	defaultMaxChildren         = 100
+)
+
+// Config tunes the parse tree
+type Config struct {
+	// Depth is the number of tree levels, including the root and token-count levels
+	// A depth of 4 routes messages by their first token
+	Depth int
+
+	// SimilarityThreshold is the fraction of matching tokens needed to join a cluster
+	SimilarityThreshold float64
+
+	// MaxChildren caps the branches per tree node; extra tokens share a wildcard branch
+	MaxChildren int
+}
+
+// Cluster is a group of messages that share a template
+type Cluster struct {
+	// ID is assigned by the caller so clusters can be matched to stored patterns
+	ID string
+
+	// Tokens is the template, with Wildcard at positions that vary
+	Tokens []string
+}
+
+// Template returns the cluster's template as a single string
+func (c *Cluster) Template() string {
+	return strings.Join(c.Tokens, " ")
+}
+
+// node is a parse tree node; leaves hold clusters
+type node struct {
+	children map[string]*node
+	clusters []*Cluster
+}
+
+func newNode() *node {
+	return &node{children: make(map[string]*node)}
+}
+
+// Miner groups messages into clusters. It is safe for concurrent use.
+type Miner struct {
+	mu     sync.Mutex
+	config Config
+	root   *node
+}
+
+// New creates an empty miner, filling zero config fields with defaults
+func New(config Config) *Miner {
+	if config.Depth < 3 {
+		config.Depth = defaultDepth
+	}
+	if config.SimilarityThreshold <= 0 {
+		config.SimilarityThreshold = defaultSimilarityThreshold
+	}
+	if config.MaxChildren <= 0 {
+		config.MaxChildren = defaultMaxChildren
+	}
+	return &Miner{config: config, root: newNode()}
+}
+
+// Tokenize splits a message on whitespace and masks tokens containing digits,
+// which are almost always IDs, counters, durations or addresses
+func Tokenize(message string) []string {
+	tokens := strings.Fields(message)
+	for i, token := range tokens {
+		if strings.IndexFunc(token, unicode.IsDigit) >= 0 {
+			tokens[i] = Wildcard
+		}
+	}
+	return tokens
+}
+
+// Load adds an existing cluster (e.g. a stored pattern) to the tree without matching
+func (m *Miner) Load(id, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokens := strings.Fields(template)
+	leaf := m.leaf(tokens)
+	leaf.clusters = append(leaf.clusters, &Cluster{ID: id, Tokens: tokens})
+}
+
+// Add assigns a message to a cluster, creating one with newID() if nothing is similar enough
+// It returns the cluster and whether its template was created or generalized by this message
+// The returned cluster is a copy and can be read without holding the miner's lock
+func (m *Miner) Add(message string, newID func() string) (Cluster, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokens := Tokenize(message)
+	leaf := m.leaf(tokens)
+
+	if cluster := m.bestMatch(leaf.clusters, tokens); cluster != nil {
+		changed := false
+		for i, token := range tokens {
+			if cluster.Tokens[i] != Wildcard && cluster.Tokens[i] != token {
+				cluster.Tokens[i] = Wildcard
+				changed = true
+			}
+		}
+		return copyCluster(cluster), changed
+	}
+
+	cluster := &Cluster{ID: newID(), Tokens: tokens}
+	leaf.clusters = append(leaf.clusters, cluster)
+	return copyCluster(cluster), true
+}
+
+// leaf walks (and grows) the tree to the leaf for the given tokens
+func (m *Miner) leaf(tokens []string) *node {
+	// First level: token count
+	current := m.child(m.root, strconv.Itoa(len(tokens)), true)
+
+	// Following levels: leading tokens, one per remaining level below the leaf
+	for i := 0; i < m.config.Depth-3 && i < len(tokens); i++ {
+		current = m.child(current, tokens[i], false)
+	}
+
+	return current
+}
+
+// child returns the branch for key, creating it if there is room
+// Once a node is full, unseen keys share the wildcard branch
+func (m *Miner) child(parent *node, key string, exact bool) *node {
+	if next, ok := parent.children[key]; ok {
+		return next
+	}
+	if !exact && len(parent.children) >= m.config.MaxChildren {
+		key = Wildcard
+		if next, ok := parent.children[key]; ok {
+			return next
+		}
+	}
+
+	next := newNode()
+	parent.children[key] = next
+	return next
+}
+
+// bestMatch returns the most similar cluster at or above the similarity threshold
+func (m *Miner) bestMatch(clusters []*Cluster, tokens []string) *Cluster {
+	var best *Cluster
+	bestScore := -1.0
+	for _, cluster := range clusters {
+		score := similarity(cluster.Tokens, tokens)
+		if score > bestScore {
+			best, bestScore = cluster, score
+		}
+	}
+	if best == nil || bestScore < m.config.SimilarityThreshold {
+		return nil
+	}
+	return best
+}
+
+// similarity is the fraction of positions where the template matches the message
+func similarity(template, tokens []string) float64 {
+	if len(template) != len(tokens) {
+		return 0
+	}
+	if len(tokens) == 0 {
+		return 1
+	}
+
+	matches := 0
+	for i, token := range template {
+		if token == tokens[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(tokens))
+}
+
+func copyCluster(cluster *Cluster) Cluster {
+	return Cluster{ID: cluster.ID, Tokens: append([]string(nil), cluster.Tokens...)}
+}
@@ -0,0 +1,144 @@
+package drain
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{name: "plain words", message: "user logged in", want: []string{"user", "logged", "in"}},
+		{name: "extra whitespace", message: "  user\tlogged \n in ", want: []string{"user", "logged", "in"}},
+		{name: "tokens with digits are masked", message: "request 123 took 45ms from 10.0.0.1", want: []string{"request", Wildcard, "took", Wildcard, "from", Wildcard}},
+		{name: "empty message", message: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinerAdd(t *testing.T) {
+	type step struct {
+		message  string
+		template string // template of the returned cluster
+		cluster  string // ID of the returned cluster
+		changed  bool
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		steps  []step
+	}{
+		{
+			name: "differing token becomes a wildcard",
+			steps: []step{
+				{message: "user alice logged in", template: "user alice logged in", cluster: "1", changed: true},
+				{message: "user bob logged in", template: "user <*> logged in", cluster: "1", changed: true},
+				{message: "user carol logged in", template: "user <*> logged in", cluster: "1", changed: false},
+			},
+		},
+		{
+			name: "numbers are masked before matching",
+			steps: []step{
+				{message: "request 123 took 45ms", template: "request <*> took <*>", cluster: "1", changed: true},
+				{message: "request 987 took 3ms", template: "request <*> took <*>", cluster: "1", changed: false},
+			},
+		},
+		{
+			name: "messages of different lengths never share a cluster",
+			steps: []step{
+				{message: "cache miss for key", template: "cache miss for key", cluster: "1", changed: true},
+				{message: "cache miss for key again", template: "cache miss for key again", cluster: "2", changed: true},
+			},
+		},
+		{
+			name: "messages with a different first token never share a cluster",
+			steps: []step{
+				{message: "user alice logged in", template: "user alice logged in", cluster: "1", changed: true},
+				{message: "admin alice logged in", template: "admin alice logged in", cluster: "2", changed: true},
+			},
+		},
+		{
+			name: "messages below the similarity threshold start a new cluster",
+			steps: []step{
+				{message: "disk sda is full", template: "disk sda is full", cluster: "1", changed: true},
+				{message: "disk check skipped today", template: "disk check skipped today", cluster: "2", changed: true},
+				{message: "disk sdb is full", template: "disk <*> is full", cluster: "1", changed: true},
+			},
+		},
+		{
+			name:   "a higher threshold keeps similar messages apart",
+			config: Config{SimilarityThreshold: 0.9},
+			steps: []step{
+				{message: "user alice logged in", template: "user alice logged in", cluster: "1", changed: true},
+				{message: "user bob logged in", template: "user bob logged in", cluster: "2", changed: true},
+			},
+		},
+		{
+			name:   "first tokens past MaxChildren share the wildcard branch",
+			config: Config{MaxChildren: 1},
+			steps: []step{
+				{message: "alpha started", template: "alpha started", cluster: "1", changed: true},
+				{message: "beta started", template: "beta started", cluster: "2", changed: true},
+				{message: "gamma started", template: "<*> started", cluster: "2", changed: true},
+				{message: "alpha started", template: "alpha started", cluster: "1", changed: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			miner := New(tt.config)
+			next := 0
+			newID := func() string {
+				next++
+				return strconv.Itoa(next)
+			}
+
+			for _, step := range tt.steps {
+				cluster, changed := miner.Add(step.message, newID)
+				if cluster.ID != step.cluster || cluster.Template() != step.template || changed != step.changed {
+					t.Errorf("Add(%q) = (%s %q, %v), want (%s %q, %v)",
+						step.message, cluster.ID, cluster.Template(), changed, step.cluster, step.template, step.changed)
+				}
+			}
+		})
+	}
+}
+
+func TestMinerLoad(t *testing.T) {
+	miner := New(Config{})
+	miner.Load("stored", "user <*> logged in")
+
+	cluster, changed := miner.Add("user dave logged in", func() string {
+		t.Fatal("a new cluster was created for a message matching a loaded pattern")
+		return ""
+	})
+	if cluster.ID != "stored" || cluster.Template() != "user <*> logged in" || changed {
+		t.Errorf("Add() = (%s %q, %v), want (stored %q, false)", cluster.ID, cluster.Template(), changed, "user <*> logged in")
+	}
+}
+
+func TestAddReturnsCopy(t *testing.T) {
+	miner := New(Config{})
+	newID := func() string { return "1" }
+
+	cluster, _ := miner.Add("user alice logged in", newID)
+	cluster.Tokens[0] = "changed"
+
+	again, _ := miner.Add("user alice logged in", newID)
+	if again.Template() != "user alice logged in" {
+		t.Errorf("template = %q after modifying a returned cluster, want it unchanged", again.Template())
+	}
+}
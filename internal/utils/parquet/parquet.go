@@ -0,0 +1,285 @@
+// Package parquet writes Apache Parquet files row group by row group, so large exports
+// can be streamed without holding every row in memory.
+//
+// Only what log exports need is supported: flat schemas of required UTF-8 string and
+// timestamp columns, PLAIN encoding and gzip-compressed data pages, one page per column
+// chunk. Memory use is bounded by the row group size.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// DefaultRowGroupSize is the number of rows buffered per row group when none is given
+const DefaultRowGroupSize = 10000
+
+// Parquet physical types, converted types, encodings and codecs, as numbered by the format
+const (
+	typeInt64     = 2
+	typeByteArray = 6
+
+	repetitionRequired = 0
+
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecGzip = 2
+
+	pageTypeData = 0
+)
+
+// Type is the type of a column
+type Type int
+
+const (
+	// String columns hold UTF-8 text; values must be strings
+	String Type = iota
+
+	// Timestamp columns hold instants with microsecond precision; values must be time.Time
+	Timestamp
+)
+
+// Column describes one column of the schema
+type Column struct {
+	Name string
+	Type Type
+}
+
+// columnChunk records where a column chunk was written, for the footer
+type columnChunk struct {
+	offset       int64
+	uncompressed int64
+	compressed   int64
+	values       int64
+}
+
+// rowGroup records a written row group, for the footer
+type rowGroup struct {
+	chunks []columnChunk
+	rows   int64
+	size   int64
+}
+
+// Writer writes rows to a Parquet file
+// Rows are buffered until a row group is full; Close writes the last row group and the footer
+type Writer struct {
+	out          io.Writer
+	offset       int64
+	columns      []Column
+	rowGroupSize int
+
+	values    []bytes.Buffer // PLAIN-encoded values of the buffered rows, per column
+	rows      int            // Rows buffered
+	rowGroups []rowGroup
+	total     int64
+	err       error
+}
+
+// NewWriter creates a writer for the schema
+// A row group size of zero uses DefaultRowGroupSize
+func NewWriter(out io.Writer, columns []Column, rowGroupSize int) *Writer {
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+	return &Writer{
+		out:          out,
+		columns:      columns,
+		rowGroupSize: rowGroupSize,
+		values:       make([]bytes.Buffer, len(columns)),
+	}
+}
+
+// write writes to the output, remembering the first error and the offset reached
+func (w *Writer) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.out.Write(data)
+	w.offset += int64(n)
+	w.err = err
+}
+
+// Write adds a row; values must match the schema's columns in number, order and type
+func (w *Writer) Write(row []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, schema has %d columns", len(row), len(w.columns))
+	}
+
+	for i, column := range w.columns {
+		buf := &w.values[i]
+		switch column.Type {
+		case String:
+			value, ok := row[i].(string)
+			if !ok {
+				return fmt.Errorf("column %s expects a string, got %T", column.Name, row[i])
+			}
+			binary.Write(buf, binary.LittleEndian, uint32(len(value)))
+			buf.WriteString(value)
+		case Timestamp:
+			value, ok := row[i].(time.Time)
+			if !ok {
+				return fmt.Errorf("column %s expects a time.Time, got %T", column.Name, row[i])
+			}
+			binary.Write(buf, binary.LittleEndian, value.UnixMicro())
+		}
+	}
+
+	w.rows++
+	if w.rows >= w.rowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush writes the buffered rows as a row group with one data page per column
+func (w *Writer) flush() error {
+	if w.offset == 0 {
+		w.write([]byte(magic))
+	}
+	if w.rows == 0 {
+		return w.err
+	}
+
+	group := rowGroup{rows: int64(w.rows)}
+	for i := range w.columns {
+		data := w.values[i].Bytes()
+
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		gz.Write(data)
+		if err := gz.Close(); err != nil {
+			return err
+		}
+
+		header := pageHeader(len(data), compressed.Len(), w.rows)
+		chunk := columnChunk{
+			offset:       w.offset,
+			uncompressed: int64(len(header) + len(data)),
+			compressed:   int64(len(header) + compressed.Len()),
+			values:       int64(w.rows),
+		}
+		w.write(header)
+		w.write(compressed.Bytes())
+
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.uncompressed
+		w.values[i].Reset()
+	}
+
+	w.rowGroups = append(w.rowGroups, group)
+	w.total += int64(w.rows)
+	w.rows = 0
+	return w.err
+}
+
+// Close writes any buffered rows and the footer; it does not close the underlying writer
+func (w *Writer) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	footer := w.footer()
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(footer)))
+	w.write(footer)
+	w.write(length)
+	w.write([]byte(magic))
+	return w.err
+}
+
+// Rows returns the number of rows written so far
+func (w *Writer) Rows() int64 {
+	return w.total + int64(w.rows)
+}
+
+// pageHeader encodes the header of a PLAIN-encoded data page of required values
+// Required columns of a flat schema have no repetition or definition levels
+func pageHeader(uncompressed, compressed, values int) []byte {
+	c := &compactWriter{}
+	c.i32(1, pageTypeData)
+	c.i32(2, int32(uncompressed))
+	c.i32(3, int32(compressed))
+	c.beginStruct(5) // DataPageHeader
+	c.i32(1, int32(values))
+	c.i32(2, encodingPlain)
+	c.i32(3, encodingRLE) // Definition level encoding; no levels are written
+	c.i32(4, encodingRLE) // Repetition level encoding; no levels are written
+	c.endStruct()
+	return c.message()
+}
+
+// footer encodes the FileMetaData: the schema and where every column chunk is
+func (w *Writer) footer() []byte {
+	c := &compactWriter{}
+	c.i32(1, 1) // Format version
+
+	// The schema is the root element followed by one element per column
+	c.list(2, thriftStruct, len(w.columns)+1)
+	c.beginListStruct()
+	c.binary(4, "schema")
+	c.i32(5, int32(len(w.columns)))
+	c.endStruct()
+	for _, column := range w.columns {
+		physical, converted := column.types()
+		c.beginListStruct()
+		c.i32(1, physical)
+		c.i32(3, repetitionRequired)
+		c.binary(4, column.Name)
+		c.i32(6, converted)
+		c.endStruct()
+	}
+
+	c.i64(3, w.total)
+
+	c.list(4, thriftStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		c.beginListStruct()
+		c.list(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			physical, _ := w.columns[i].types()
+			c.beginListStruct()
+			c.i64(2, chunk.offset)
+			c.beginStruct(3) // ColumnMetaData
+			c.i32(1, physical)
+			c.list(2, thriftI32, 1)
+			c.listI32(encodingPlain)
+			c.list(3, thriftBinary, 1)
+			c.listBinary(w.columns[i].Name)
+			c.i32(4, codecGzip)
+			c.i64(5, chunk.values)
+			c.i64(6, chunk.uncompressed)
+			c.i64(7, chunk.compressed)
+			c.i64(9, chunk.offset)
+			c.endStruct()
+			c.endStruct()
+		}
+		c.i64(2, group.size)
+		c.i64(3, group.rows)
+		c.endStruct()
+	}
+
+	c.binary(6, "valtro-backend")
+	return c.message()
+}
+
+// types returns the physical and converted type of a column
+func (c Column) types() (int32, int32) {
+	if c.Type == Timestamp {
+		return typeInt64, convertedTimestampMicros
+	}
+	return typeByteArray, convertedUTF8
+}
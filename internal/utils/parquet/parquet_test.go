@@ -0,0 +1,212 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// compactReader decodes Thrift compact structs into maps of field ID to value, enough to
+// check what the writer produced
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *compactReader) varint() uint64 {
+	var v uint64
+	for shift := 0; ; shift += 7 {
+		b := r.byte()
+		v |= uint64(b&0x7F) << shift
+		if b < 0x80 {
+			return v
+		}
+	}
+}
+
+func (r *compactReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *compactReader) value(fieldType byte) interface{} {
+	switch fieldType {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.varint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		header := r.byte()
+		size, elementType := int(header>>4), header&0x0F
+		if size == 15 {
+			size = int(r.varint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(elementType)
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	default:
+		panic(fmt.Sprintf("unsupported thrift type %d", fieldType))
+	}
+}
+
+func (r *compactReader) structValue() map[int64]interface{} {
+	fields := make(map[int64]interface{})
+	var lastID int64
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		fieldType := header & 0x0F
+		id := lastID + int64(header>>4)
+		if header>>4 == 0 {
+			id = r.zigzag()
+		}
+		fields[id] = r.value(fieldType)
+		lastID = id
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	columns := []Column{{Name: "timestamp", Type: Timestamp}, {Name: "message", Type: String}}
+	start := time.Date(2026, 3, 1, 12, 0, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		name         string
+		rows         int
+		rowGroupSize int
+		wantGroups   int
+	}{
+		{name: "empty file", rows: 0, rowGroupSize: 10, wantGroups: 0},
+		{name: "single row group", rows: 7, rowGroupSize: 10, wantGroups: 1},
+		{name: "exactly full row groups", rows: 20, rowGroupSize: 10, wantGroups: 2},
+		{name: "partial last row group", rows: 25, rowGroupSize: 10, wantGroups: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer := NewWriter(&out, columns, tt.rowGroupSize)
+			for i := 0; i < tt.rows; i++ {
+				if err := writer.Write([]interface{}{start.Add(time.Duration(i) * time.Second), fmt.Sprintf("event %d", i)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			data := out.Bytes()
+			if !bytes.HasPrefix(data, []byte(magic)) || !bytes.HasSuffix(data, []byte(magic)) {
+				t.Fatal("file does not start and end with PAR1")
+			}
+			footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+			reader := &compactReader{data: data[len(data)-8-footerLength : len(data)-8]}
+			metadata := reader.structValue()
+
+			if got := metadata[3].(int64); got != int64(tt.rows) {
+				t.Errorf("num_rows = %d, want %d", got, tt.rows)
+			}
+			schema := metadata[2].([]interface{})
+			if len(schema) != len(columns)+1 {
+				t.Fatalf("schema has %d elements, want %d", len(schema), len(columns)+1)
+			}
+			for i, column := range columns {
+				if name := schema[i+1].(map[int64]interface{})[4]; name != column.Name {
+					t.Errorf("schema element %d is %v, want %s", i+1, name, column.Name)
+				}
+			}
+
+			rowGroups := metadata[4].([]interface{})
+			if len(rowGroups) != tt.wantGroups {
+				t.Fatalf("got %d row groups, want %d", len(rowGroups), tt.wantGroups)
+			}
+
+			// Decode every page back into values
+			var timestamps []time.Time
+			var messages []string
+			for _, group := range rowGroups {
+				chunks := group.(map[int64]interface{})[1].([]interface{})
+				for i, chunk := range chunks {
+					meta := chunk.(map[int64]interface{})[3].(map[int64]interface{})
+					pageReader := &compactReader{data: data, pos: int(meta[9].(int64))}
+					header := pageReader.structValue()
+					compressed := data[pageReader.pos : pageReader.pos+int(header[3].(int64))]
+
+					gz, err := gzip.NewReader(bytes.NewReader(compressed))
+					if err != nil {
+						t.Fatal(err)
+					}
+					page, err := io.ReadAll(gz)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if int64(len(page)) != header[2].(int64) {
+						t.Errorf("page is %d bytes, header says %d", len(page), header[2])
+					}
+
+					values := int(header[5].(map[int64]interface{})[1].(int64))
+					for v := 0; v < values; v++ {
+						if i == 0 {
+							timestamps = append(timestamps, time.UnixMicro(int64(binary.LittleEndian.Uint64(page))).UTC())
+							page = page[8:]
+						} else {
+							n := binary.LittleEndian.Uint32(page)
+							messages = append(messages, string(page[4:4+n]))
+							page = page[4+n:]
+						}
+					}
+				}
+			}
+
+			if len(timestamps) != tt.rows || len(messages) != tt.rows {
+				t.Fatalf("decoded %d timestamps and %d messages, want %d of each", len(timestamps), len(messages), tt.rows)
+			}
+			for i := 0; i < tt.rows; i++ {
+				if want := start.Add(time.Duration(i) * time.Second); !timestamps[i].Equal(want) {
+					t.Errorf("row %d timestamp = %s, want %s", i, timestamps[i], want)
+				}
+				if want := fmt.Sprintf("event %d", i); messages[i] != want {
+					t.Errorf("row %d message = %q, want %q", i, messages[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterRejectsMismatchedRows(t *testing.T) {
+	writer := NewWriter(io.Discard, []Column{{Name: "message", Type: String}}, 0)
+
+	tests := []struct {
+		name string
+		row  []interface{}
+	}{
+		{name: "too few values", row: []interface{}{}},
+		{name: "wrong type", row: []interface{}{42}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := writer.Write(tt.row); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package parquet
+
+import "bytes"
+
+// Thrift compact protocol type IDs used in Parquet metadata
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// compactWriter encodes Thrift structs with the compact protocol, which Parquet uses for its
+// page headers and file footer. Only the field types Parquet metadata needs are supported
+type compactWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16 // lastID of each enclosing struct
+}
+
+func (c *compactWriter) varint(v uint64) {
+	for v >= 0x80 {
+		c.buf.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	c.buf.WriteByte(byte(v))
+}
+
+func (c *compactWriter) zigzag(v int64) {
+	c.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+// fieldHeader writes a field's ID, as a delta from the previous field when it fits, and its type
+func (c *compactWriter) fieldHeader(id int16, fieldType byte) {
+	if delta := id - c.lastID; delta > 0 && delta <= 15 {
+		c.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		c.buf.WriteByte(fieldType)
+		c.zigzag(int64(id))
+	}
+	c.lastID = id
+}
+
+func (c *compactWriter) i32(id int16, v int32) {
+	c.fieldHeader(id, thriftI32)
+	c.zigzag(int64(v))
+}
+
+func (c *compactWriter) i64(id int16, v int64) {
+	c.fieldHeader(id, thriftI64)
+	c.zigzag(v)
+}
+
+func (c *compactWriter) binary(id int16, v string) {
+	c.fieldHeader(id, thriftBinary)
+	c.varint(uint64(len(v)))
+	c.buf.WriteString(v)
+}
+
+// beginStruct starts a struct-valued field; end it with endStruct
+func (c *compactWriter) beginStruct(id int16) {
+	c.fieldHeader(id, thriftStruct)
+	c.beginListStruct()
+}
+
+// beginListStruct starts a struct that is an element of a list, which has no field header
+func (c *compactWriter) beginListStruct() {
+	c.stack = append(c.stack, c.lastID)
+	c.lastID = 0
+}
+
+// endStruct writes the stop byte of the innermost struct
+func (c *compactWriter) endStruct() {
+	c.buf.WriteByte(0)
+	c.lastID = c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+// list writes the header of a list field; the caller then writes size elements
+func (c *compactWriter) list(id int16, elementType byte, size int) {
+	c.fieldHeader(id, thriftList)
+	if size < 15 {
+		c.buf.WriteByte(byte(size)<<4 | elementType)
+	} else {
+		c.buf.WriteByte(0xF0 | elementType)
+		c.varint(uint64(size))
+	}
+}
+
+// listI32 writes an i32 list element
+func (c *compactWriter) listI32(v int32) {
+	c.zigzag(int64(v))
+}
+
+// listBinary writes a binary list element
+func (c *compactWriter) listBinary(v string) {
+	c.varint(uint64(len(v)))
+	c.buf.WriteString(v)
+}
+
+// message returns the encoded top-level struct, including its stop byte
+func (c *compactWriter) message() []byte {
+	c.buf.WriteByte(0)
+	return c.buf.Bytes()
+}
@@ -0,0 +1,43 @@
+package softdelete
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultGracePeriod is how long soft-deleted records stay restorable when PURGE_GRACE_PERIOD is not set
+const DefaultGracePeriod = 30 * 24 * time.Hour
+
+// gracePeriod reads PURGE_GRACE_PERIOD once so restore and purge always agree on it
+var gracePeriod = sync.OnceValue(func() time.Duration {
+	value := os.Getenv("PURGE_GRACE_PERIOD")
+	if value == "" {
+		return DefaultGracePeriod
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid PURGE_GRACE_PERIOD %q, using default of %s", value, DefaultGracePeriod)
+		return DefaultGracePeriod
+	}
+
+	return duration
+})
+
+// GracePeriod returns how long a soft-deleted record stays restorable before it is purged
+func GracePeriod() time.Duration {
+	return gracePeriod()
+}
+
+// Cutoff returns the deletion time before which records are past their grace period
+// Records deleted before it can no longer be restored and are purged on the next run
+func Cutoff(now time.Time) time.Time {
+	return now.Add(-GracePeriod())
+}
+
+// Expired reports whether a record deleted at the given time is past its grace period
+func Expired(deletedAt time.Time, now time.Time) bool {
+	return deletedAt.Before(Cutoff(now))
+}
@@ -0,0 +1,227 @@
+// Package sourcemap decodes Source Map v3 files and maps generated positions back
+// to original source positions.
+//
+// Only regular maps are supported; index maps with "sections" are rejected.
+// All lines and columns are zero-based, as in the specification.
+package sourcemap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// base64Values maps each base64 character to its 6-bit value, or -1 if invalid
+var base64Values = func() [256]int {
+	var values [256]int
+	for i := range values {
+		values[i] = -1
+	}
+	for i, c := range "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/" {
+		values[c] = i
+	}
+	return values
+}()
+
+// rawMap is the JSON layout of a Source Map v3 file
+type rawMap struct {
+	Version        int               `json:"version"`
+	SourceRoot     string            `json:"sourceRoot"`
+	Sources        []string          `json:"sources"`
+	SourcesContent []*string         `json:"sourcesContent"`
+	Names          []string          `json:"names"`
+	Mappings       string            `json:"mappings"`
+	Sections       []json.RawMessage `json:"sections"`
+}
+
+// segment maps a generated column to an original position
+type segment struct {
+	generatedColumn int
+	source          int
+	line            int
+	column          int
+	name            int
+}
+
+// Map is a decoded source map
+type Map struct {
+	sources  []string
+	contents []*string
+	names    []string
+	lines    [][]segment
+}
+
+// Position is an original source position
+type Position struct {
+	Source string
+	Line   int
+	Column int
+	Name   string
+}
+
+// Parse decodes a Source Map v3 file
+func Parse(data []byte) (*Map, error) {
+	var raw rawMap
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid source map JSON: %w", err)
+	}
+	if raw.Version != 3 {
+		return nil, fmt.Errorf("unsupported source map version %d", raw.Version)
+	}
+	if len(raw.Sections) > 0 {
+		return nil, errors.New("index source maps are not supported")
+	}
+
+	m := &Map{
+		sources:  make([]string, len(raw.Sources)),
+		contents: raw.SourcesContent,
+		names:    raw.Names,
+	}
+	for i, source := range raw.Sources {
+		if raw.SourceRoot != "" && !strings.Contains(source, "://") && !strings.HasPrefix(source, "/") {
+			source = strings.TrimSuffix(raw.SourceRoot, "/") + "/" + source
+		}
+		m.sources[i] = source
+	}
+
+	lines, err := decodeMappings(raw.Mappings, len(raw.Sources), len(raw.Names))
+	if err != nil {
+		return nil, err
+	}
+	m.lines = lines
+
+	return m, nil
+}
+
+// Lookup returns the original position of a generated line and column
+// The closest mapping at or before the column on the same line is used
+func (m *Map) Lookup(line, column int) (Position, bool) {
+	if line < 0 || line >= len(m.lines) {
+		return Position{}, false
+	}
+
+	segments := m.lines[line]
+	i := sort.Search(len(segments), func(i int) bool {
+		return segments[i].generatedColumn > column
+	}) - 1
+	if i < 0 || segments[i].source < 0 {
+		return Position{}, false
+	}
+
+	seg := segments[i]
+	position := Position{
+		Source: m.sources[seg.source],
+		Line:   seg.line,
+		Column: seg.column,
+	}
+	if seg.name >= 0 {
+		position.Name = m.names[seg.name]
+	}
+	return position, true
+}
+
+// SourceLines returns the embedded content of a source split into lines
+// The second result is false when the map does not embed the source
+func (m *Map) SourceLines(source string) ([]string, bool) {
+	for i, s := range m.sources {
+		if s != source {
+			continue
+		}
+		if i >= len(m.contents) || m.contents[i] == nil {
+			return nil, false
+		}
+		return strings.Split(*m.contents[i], "\n"), true
+	}
+	return nil, false
+}
+
+// decodeMappings decodes the VLQ "mappings" string into segments per generated line
+func decodeMappings(mappings string, sourceCount, nameCount int) ([][]segment, error) {
+	var lines [][]segment
+	source, line, column, name := 0, 0, 0, 0
+
+	for _, encodedLine := range strings.Split(mappings, ";") {
+		var segments []segment
+		generatedColumn := 0
+
+		for _, encoded := range strings.Split(encodedLine, ",") {
+			if encoded == "" {
+				continue
+			}
+
+			fields, err := decodeVLQ(encoded)
+			if err != nil {
+				return nil, err
+			}
+
+			generatedColumn += fields[0]
+			seg := segment{generatedColumn: generatedColumn, source: -1, name: -1}
+
+			switch len(fields) {
+			case 1:
+				// Generated column only, no original position
+			case 4, 5:
+				source += fields[1]
+				line += fields[2]
+				column += fields[3]
+				if source < 0 || source >= sourceCount {
+					return nil, fmt.Errorf("source index %d out of range", source)
+				}
+				seg.source, seg.line, seg.column = source, line, column
+
+				if len(fields) == 5 {
+					name += fields[4]
+					if name < 0 || name >= nameCount {
+						return nil, fmt.Errorf("name index %d out of range", name)
+					}
+					seg.name = name
+				}
+			default:
+				return nil, fmt.Errorf("invalid mapping segment %q", encoded)
+			}
+
+			segments = append(segments, seg)
+		}
+
+		sort.SliceStable(segments, func(i, j int) bool {
+			return segments[i].generatedColumn < segments[j].generatedColumn
+		})
+		lines = append(lines, segments)
+	}
+
+	return lines, nil
+}
+
+// decodeVLQ decodes a segment of base64 VLQ values
+func decodeVLQ(encoded string) ([]int, error) {
+	var values []int
+	value, shift := 0, 0
+
+	for i := 0; i < len(encoded); i++ {
+		digit := base64Values[encoded[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base64 character %q in mappings", encoded[i])
+		}
+
+		value += (digit & 31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+
+		// The lowest bit carries the sign
+		if value&1 == 1 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 {
+		return nil, errors.New("truncated VLQ value in mappings")
+	}
+
+	return values, nil
+}
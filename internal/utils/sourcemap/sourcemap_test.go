@@ -0,0 +1,129 @@
+package sourcemap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		want    []int
+		wantErr bool
+	}{
+		{name: "zero", encoded: "A", want: []int{0}},
+		{name: "one", encoded: "C", want: []int{1}},
+		{name: "minus one", encoded: "D", want: []int{-1}},
+		{name: "largest single digit", encoded: "e", want: []int{15}},
+		{name: "continuation digit", encoded: "gB", want: []int{16}},
+		{name: "negative continuation", encoded: "hB", want: []int{-16}},
+		{name: "three digits", encoded: "w+B", want: []int{1000}},
+		{name: "full segment", encoded: "AAgBC", want: []int{0, 0, 16, 1}},
+		{name: "invalid character", encoded: "A!", wantErr: true},
+		{name: "truncated value", encoded: "Ag", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeVLQ(tt.encoded)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeVLQ(%q) = %v, want an error", tt.encoded, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeVLQ(%q) failed: %v", tt.encoded, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeVLQ(%q) = %v, want %v", tt.encoded, got, tt.want)
+			}
+		})
+	}
+}
+
+// testMap maps generated line 0 to a.js and line 1 to b.js; line 2 is empty and
+// line 3 has a segment without an original position
+const testMap = `{
+	"version": 3,
+	"sourceRoot": "src/",
+	"sources": ["a.js", "/abs/b.js"],
+	"sourcesContent": ["first\nsecond", null],
+	"names": ["render"],
+	"mappings": "AAAA,IAAIA;ACCA;;K"
+}`
+
+func TestLookup(t *testing.T) {
+	m, err := Parse([]byte(testMap))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		line, column int
+		want         Position
+		found        bool
+	}{
+		{name: "exact segment start", line: 0, column: 0, want: Position{Source: "src/a.js", Line: 0, Column: 0}, found: true},
+		{name: "between segments uses the earlier one", line: 0, column: 3, want: Position{Source: "src/a.js", Line: 0, Column: 0}, found: true},
+		{name: "segment with a name", line: 0, column: 4, want: Position{Source: "src/a.js", Line: 0, Column: 4, Name: "render"}, found: true},
+		{name: "past the last segment", line: 0, column: 500, want: Position{Source: "src/a.js", Line: 0, Column: 4, Name: "render"}, found: true},
+		{name: "original column carries over lines", line: 1, column: 0, want: Position{Source: "/abs/b.js", Line: 1, Column: 4}, found: true},
+		{name: "empty line", line: 2, column: 0},
+		{name: "segment without original position", line: 3, column: 9},
+		{name: "before the first segment", line: 3, column: 2},
+		{name: "line past the end", line: 4, column: 0},
+		{name: "negative line", line: -1, column: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := m.Lookup(tt.line, tt.column)
+			if found != tt.found || got != tt.want {
+				t.Errorf("Lookup(%d, %d) = (%+v, %v), want (%+v, %v)", tt.line, tt.column, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestSourceLines(t *testing.T) {
+	m, err := Parse([]byte(testMap))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if lines, ok := m.SourceLines("src/a.js"); !ok || !reflect.DeepEqual(lines, []string{"first", "second"}) {
+		t.Errorf("SourceLines(src/a.js) = (%q, %v), want ([first second], true)", lines, ok)
+	}
+	if _, ok := m.SourceLines("/abs/b.js"); ok {
+		t.Error("SourceLines(/abs/b.js) found content, want none")
+	}
+	if _, ok := m.SourceLines("missing.js"); ok {
+		t.Error("SourceLines(missing.js) found content, want none")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "invalid JSON", data: `{"version": 3,`},
+		{name: "unsupported version", data: `{"version": 2, "sources": [], "mappings": ""}`},
+		{name: "index map", data: `{"version": 3, "sections": [{"offset": {"line": 0, "column": 0}}]}`},
+		{name: "source index out of range", data: `{"version": 3, "sources": ["a.js"], "mappings": "ACAA"}`},
+		{name: "name index out of range", data: `{"version": 3, "sources": ["a.js"], "names": [], "mappings": "AAAAA"}`},
+		{name: "segment with two fields", data: `{"version": 3, "sources": ["a.js"], "mappings": "AA"}`},
+		{name: "invalid base64", data: `{"version": 3, "sources": ["a.js"], "mappings": "AA*A"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse(%s) succeeded, want an error", tt.data)
+			}
+		})
+	}
+}
@@ -7,11 +7,25 @@ import (
 	"strings"
 
 	"github.com/nihar-hegde/valtro-backend/internal/constants"
+	appErrors "github.com/nihar-hegde/valtro-backend/internal/errors"
 )
 
 // Email validation regex
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
+// labelKeyPattern matches valid label keys
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// logLevels lists the log levels that retention can be configured for
+var logLevels = map[string]bool{
+	constants.LogLevelTrace: true,
+	constants.LogLevelDebug: true,
+	constants.LogLevelInfo:  true,
+	constants.LogLevelWarn:  true,
+	constants.LogLevelError: true,
+	constants.LogLevelFatal: true,
+}
+
 // ValidationError represents a validation error
 type ValidationError struct {
 	Field   string
@@ -133,6 +147,66 @@ func ValidateCreateProjectRequest(name string) error {
 	return nil
 }
 
+// ValidateRetentionPolicy validates retention durations and their log levels
+// When allowInherit is true a zero default is accepted and means "inherit from the organization"
+func ValidateRetentionPolicy(defaultDays int, levelDays map[string]int, allowInherit bool) error {
+	if !(allowInherit && defaultDays == 0) {
+		if err := validateRetentionDays(defaultDays, "default retention"); err != nil {
+			return err
+		}
+	}
+
+	for level, days := range levelDays {
+		if !logLevels[level] {
+			return ValidationError{Field: "level_days", Message: fmt.Sprintf("unknown log level %q", level)}
+		}
+		if err := validateRetentionDays(days, level+" retention"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRetentionDays checks that a retention duration is within the allowed range
+func validateRetentionDays(days int, fieldName string) error {
+	if days < constants.MinRetentionDays || days > constants.MaxRetentionDays {
+		return ValidationError{
+			Field:   fieldName,
+			Message: fmt.Sprintf("%s must be between %d and %d days", fieldName, constants.MinRetentionDays, constants.MaxRetentionDays),
+		}
+	}
+	return nil
+}
+
+// ValidateLabels checks label keys and values, returning the labels with surrounding whitespace trimmed
+// Keys start with a letter or underscore followed by letters, digits, underscores, dots or dashes.
+// Used for alert rule, monitor and silence labels as well as log event attributes
+func ValidateLabels(field string, labels map[string]string, max int) (map[string]string, error) {
+	if len(labels) > max {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("%s can have at most %d entries", field, max))
+	}
+
+	cleaned := make(map[string]string, len(labels))
+	for key, value := range labels {
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !labelKeyPattern.MatchString(key) || len(key) > constants.MaxAlertLabelKeyLength {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("%s: key %q must start with a letter or underscore, contain only letters, digits, '_', '.' or '-', and be at most %d characters",
+				field, key, constants.MaxAlertLabelKeyLength))
+		}
+		if value == "" || len(value) > constants.MaxAlertLabelValueLength {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("%s: value of %q must be between 1 and %d characters",
+				field, key, constants.MaxAlertLabelValueLength))
+		}
+		if _, exists := cleaned[key]; exists {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("%s: key %q is given more than once", field, key))
+		}
+		cleaned[key] = value
+	}
+	return cleaned, nil
+}
+
 // SanitizeString trims whitespace
 func SanitizeString(s string) string {
 	return strings.TrimSpace(s)
@@ -0,0 +1,2 @@
+-- Drop saved_searches table
+DROP TABLE IF EXISTS saved_searches;
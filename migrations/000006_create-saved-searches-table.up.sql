@@ -0,0 +1,49 @@
+-- Create saved_searches table
+CREATE TABLE IF NOT EXISTS saved_searches (
+    -- Unique identifier for the saved search, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this saved search to the organization that owns it.
+    -- ON DELETE CASCADE means if an organization is deleted, all its saved searches are also deleted.
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+
+    -- The user-provided name for the saved search (e.g., "Checkout errors").
+    name VARCHAR(255) NOT NULL,
+
+    -- The raw search query text.
+    query TEXT NOT NULL,
+
+    -- Relative time range the search runs over (e.g., "24h").
+    time_range VARCHAR(50) NOT NULL,
+
+    -- Ordered list of result columns, stored as a JSON array.
+    columns JSONB NOT NULL DEFAULT '[]',
+
+    -- The user who created the saved search.
+    created_by_id UUID NOT NULL REFERENCES users(id),
+
+    -- Short token used by share links. NULL until the search is shared.
+    share_token VARCHAR(32),
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Soft delete timestamp for GORM soft delete functionality
+    deleted_at TIMESTAMP
+);
+
+-- Create an index on the organization_id for quickly fetching all saved searches for an org.
+CREATE INDEX IF NOT EXISTS idx_saved_searches_organization_id ON saved_searches(organization_id);
+
+-- Share tokens must be unique so that a link always resolves to a single search.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_searches_share_token ON saved_searches(share_token) WHERE share_token IS NOT NULL;
+
+-- Create an index on deleted_at for efficient soft delete filtering
+CREATE INDEX IF NOT EXISTS idx_saved_searches_deleted_at ON saved_searches(deleted_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE saved_searches IS 'Named log queries saved by organizations';
+COMMENT ON COLUMN saved_searches.query IS 'Raw search query text';
+COMMENT ON COLUMN saved_searches.time_range IS 'Relative time range, e.g. 15m, 24h, 7d';
+COMMENT ON COLUMN saved_searches.share_token IS 'Short token resolving a share link to this search';
@@ -0,0 +1,3 @@
+-- Remove log retention settings from organizations and projects
+ALTER TABLE projects DROP COLUMN retention_policy;
+ALTER TABLE organizations DROP COLUMN retention_policy;
@@ -0,0 +1,11 @@
+-- Add log retention settings to organizations and projects
+
+-- Organization-wide default retention, in days, optionally broken down per log level.
+ALTER TABLE organizations ADD COLUMN retention_policy JSONB NOT NULL DEFAULT '{"default_days": 30}';
+
+-- Optional per-project override. NULL means the organization default applies unchanged.
+ALTER TABLE projects ADD COLUMN retention_policy JSONB;
+
+-- Add comments for documentation
+COMMENT ON COLUMN organizations.retention_policy IS 'Default log retention, e.g. {"default_days": 30, "level_days": {"debug": 3}}';
+COMMENT ON COLUMN projects.retention_policy IS 'Project override of the organization retention policy';
@@ -0,0 +1,3 @@
+-- Drop legal hold tables
+DROP TABLE IF EXISTS legal_hold_audit_entries;
+DROP TABLE IF EXISTS legal_holds;
@@ -0,0 +1,66 @@
+-- Create legal_holds table
+CREATE TABLE IF NOT EXISTS legal_holds (
+    -- Unique identifier for the legal hold, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this hold to the project whose logs must be preserved.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Why the hold was placed (e.g., a case or ticket reference).
+    reason TEXT NOT NULL,
+
+    -- Optional time range of held logs. NULL on both ends means all of the project's logs.
+    range_start TIMESTAMPTZ,
+    range_end TIMESTAMPTZ,
+
+    -- When the hold lapses on its own. NULL means until explicitly released.
+    expires_at TIMESTAMPTZ,
+
+    -- The user who placed the hold.
+    created_by_id UUID NOT NULL REFERENCES users(id),
+
+    -- Release details. NULL while the hold is in place.
+    released_at TIMESTAMPTZ,
+    released_by_id UUID REFERENCES users(id),
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_legal_holds_range CHECK (range_start IS NULL OR range_end IS NULL OR range_start <= range_end)
+);
+
+-- Create an index on the project_id for quickly fetching all holds for a project.
+CREATE INDEX IF NOT EXISTS idx_legal_holds_project_id ON legal_holds(project_id);
+
+-- Create legal_hold_audit_entries table
+CREATE TABLE IF NOT EXISTS legal_hold_audit_entries (
+    -- Unique identifier for the audit entry, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The hold that changed.
+    legal_hold_id UUID NOT NULL REFERENCES legal_holds(id) ON DELETE CASCADE,
+
+    -- Denormalized project so a project's full trail can be read in one query.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- What happened: created, updated or released.
+    action VARCHAR(20) NOT NULL,
+
+    -- The user who made the change.
+    actor_id UUID NOT NULL REFERENCES users(id),
+
+    -- Human-readable description of the change.
+    details TEXT NOT NULL,
+
+    -- When the change was made.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for reading the trail of a hold or of a whole project.
+CREATE INDEX IF NOT EXISTS idx_legal_hold_audit_entries_legal_hold_id ON legal_hold_audit_entries(legal_hold_id);
+CREATE INDEX IF NOT EXISTS idx_legal_hold_audit_entries_project_id ON legal_hold_audit_entries(project_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE legal_holds IS 'Preservation orders that override log retention for a project';
+COMMENT ON TABLE legal_hold_audit_entries IS 'Append-only audit trail of legal hold changes';
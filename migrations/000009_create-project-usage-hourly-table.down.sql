@@ -0,0 +1,2 @@
+-- Drop project_usage_hourly table
+DROP TABLE IF EXISTS project_usage_hourly;
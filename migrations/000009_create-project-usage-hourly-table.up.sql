@@ -0,0 +1,29 @@
+-- Create project_usage_hourly table
+CREATE TABLE IF NOT EXISTS project_usage_hourly (
+    -- The project the usage belongs to.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Start of the hourly bucket, truncated to the hour in UTC.
+    hour TIMESTAMPTZ NOT NULL,
+
+    -- Log level the counters apply to.
+    level VARCHAR(10) NOT NULL,
+
+    -- Rolled-up counters for the bucket.
+    event_count BIGINT NOT NULL DEFAULT 0,
+    raw_bytes BIGINT NOT NULL DEFAULT 0,
+    stored_bytes BIGINT NOT NULL DEFAULT 0,
+
+    -- When the bucket was last incremented.
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (project_id, hour, level)
+);
+
+-- Create an index on hour for time-range scans across projects.
+CREATE INDEX IF NOT EXISTS idx_project_usage_hourly_hour ON project_usage_hourly(hour);
+
+-- Add comments for documentation
+COMMENT ON TABLE project_usage_hourly IS 'Hourly ingest and storage counters per project and log level';
+COMMENT ON COLUMN project_usage_hourly.raw_bytes IS 'Event bytes as received from SDKs';
+COMMENT ON COLUMN project_usage_hourly.stored_bytes IS 'Event bytes after encoding and compression';
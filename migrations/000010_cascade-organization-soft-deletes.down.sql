@@ -0,0 +1,2 @@
+-- Nothing to undo: the projects soft deleted by the up migration cannot be told apart
+-- from projects deleted together with their organization afterwards.
@@ -0,0 +1,10 @@
+-- Soft delete projects that were left live under an already soft-deleted organization.
+-- Deleting an organization now soft deletes its projects with the same timestamp;
+-- this brings rows deleted before that change in line so they can be restored together.
+UPDATE projects p
+SET deleted_at = o.deleted_at,
+    updated_at = NOW()
+FROM organizations o
+WHERE p.organization_id = o.id
+  AND o.deleted_at IS NOT NULL
+  AND p.deleted_at IS NULL;
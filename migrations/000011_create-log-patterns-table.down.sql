@@ -0,0 +1,2 @@
+-- Drop log_patterns table
+DROP TABLE IF EXISTS log_patterns;
@@ -0,0 +1,32 @@
+-- Create log_patterns table
+CREATE TABLE IF NOT EXISTS log_patterns (
+    -- Unique identifier for the pattern, assigned by the pattern miner.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this pattern to the project it was mined from.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Message template with variable tokens replaced by <*>.
+    template TEXT NOT NULL,
+
+    -- Number of events that matched the pattern.
+    event_count BIGINT NOT NULL DEFAULT 0,
+
+    -- Timestamps of the first and most recent matching events.
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index on the project_id for loading a project's patterns into the miner.
+CREATE INDEX IF NOT EXISTS idx_log_patterns_project_id ON log_patterns(project_id);
+
+-- Create an index for listing the newest patterns of a project first.
+CREATE INDEX IF NOT EXISTS idx_log_patterns_project_first_seen ON log_patterns(project_id, first_seen_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE log_patterns IS 'Message templates mined from log events with the Drain algorithm';
+COMMENT ON COLUMN log_patterns.template IS 'Message template, e.g. "user <*> logged in from <*>"';
@@ -0,0 +1,2 @@
+-- Drop issues table
+DROP TABLE IF EXISTS issues;
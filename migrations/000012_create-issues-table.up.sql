@@ -0,0 +1,44 @@
+-- Create issues table
+CREATE TABLE IF NOT EXISTS issues (
+    -- Unique identifier for the issue, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this issue to the project the errors came from.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Hex SHA-256 of the grouping components.
+    fingerprint VARCHAR(64) NOT NULL,
+
+    -- Short summary and innermost in-app frame.
+    title TEXT NOT NULL,
+    culprit TEXT,
+
+    -- Log level of the first grouped event.
+    level VARCHAR(10) NOT NULL,
+
+    -- Number of grouped events.
+    event_count BIGINT NOT NULL DEFAULT 0,
+
+    -- Distinct reporting hosts and most recent sample events, both capped in size.
+    hosts JSONB NOT NULL DEFAULT '[]',
+    sample_events JSONB NOT NULL DEFAULT '[]',
+
+    -- Timestamps of the first and most recent grouped events.
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each fingerprint maps to exactly one issue per project.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_project_fingerprint ON issues(project_id, fingerprint);
+
+-- Create indexes for listing a project's issues by recency or frequency.
+CREATE INDEX IF NOT EXISTS idx_issues_project_last_seen ON issues(project_id, last_seen_at DESC);
+CREATE INDEX IF NOT EXISTS idx_issues_project_event_count ON issues(project_id, event_count DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE issues IS 'Error events grouped by fingerprint';
+COMMENT ON COLUMN issues.fingerprint IS 'Hex SHA-256 of the exception type and in-app frames, or of an SDK-supplied fingerprint';
@@ -0,0 +1,10 @@
+-- Remove issue triage state and activity log
+DROP TABLE IF EXISTS issue_activities;
+DROP INDEX IF EXISTS idx_issues_project_status;
+ALTER TABLE issues DROP COLUMN ignore_until;
+ALTER TABLE issues DROP COLUMN ignore_until_count;
+ALTER TABLE issues DROP COLUMN resolved_release;
+ALTER TABLE issues DROP COLUMN resolved_at;
+ALTER TABLE issues DROP COLUMN assignee_id;
+ALTER TABLE issues DROP COLUMN status;
+ALTER TABLE issues DROP COLUMN last_release;
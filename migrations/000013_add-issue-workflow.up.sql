@@ -0,0 +1,50 @@
+-- Add triage state to issues
+ALTER TABLE issues ADD COLUMN last_release VARCHAR(255);
+
+-- Triage status: unresolved, resolved, resolved_in_next_release or ignored.
+ALTER TABLE issues ADD COLUMN status VARCHAR(30) NOT NULL DEFAULT 'unresolved';
+
+-- Organization member handling the issue. Cleared if the user is removed.
+ALTER TABLE issues ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Resolution details used for regression detection.
+ALTER TABLE issues ADD COLUMN resolved_at TIMESTAMPTZ;
+ALTER TABLE issues ADD COLUMN resolved_release VARCHAR(255);
+
+-- Conditions that end an ignore. NULL on both means ignored until changed by hand.
+ALTER TABLE issues ADD COLUMN ignore_until_count BIGINT;
+ALTER TABLE issues ADD COLUMN ignore_until TIMESTAMPTZ;
+
+-- Create an index for filtering a project's issues by status.
+CREATE INDEX IF NOT EXISTS idx_issues_project_status ON issues(project_id, status);
+
+-- Create issue_activities table
+CREATE TABLE IF NOT EXISTS issue_activities (
+    -- Unique identifier for the activity entry, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The issue that changed.
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+
+    -- What happened: status_changed, assigned, unassigned, regressed or unignored.
+    action VARCHAR(20) NOT NULL,
+
+    -- The user who made the change. NULL for automatic transitions.
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Status before and after the change.
+    from_status VARCHAR(30),
+    to_status VARCHAR(30),
+
+    -- Human-readable description of the change.
+    details TEXT NOT NULL,
+
+    -- When the change was made.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index on the issue_id for reading an issue's activity log.
+CREATE INDEX IF NOT EXISTS idx_issue_activities_issue_id ON issue_activities(issue_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE issue_activities IS 'Append-only log of issue status and assignment changes';
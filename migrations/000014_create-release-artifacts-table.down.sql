@@ -0,0 +1,2 @@
+-- Drop release_artifacts table
+DROP TABLE IF EXISTS release_artifacts;
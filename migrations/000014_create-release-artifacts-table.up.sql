@@ -0,0 +1,34 @@
+-- Create release_artifacts table
+CREATE TABLE IF NOT EXISTS release_artifacts (
+    -- Unique identifier for the artifact.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this artifact to its project.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Release identifier reported by SDKs, e.g. "web@1.4.2".
+    release VARCHAR(255) NOT NULL,
+
+    -- URL path of the artifact with "~" standing for any scheme and host.
+    name VARCHAR(500) NOT NULL,
+
+    -- Key of the file contents in artifact storage (local disk or S3).
+    storage_key VARCHAR(255) NOT NULL,
+
+    -- File size in bytes and hex SHA-256 checksum.
+    size BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+
+    -- The user who uploaded the artifact.
+    created_by_id UUID NOT NULL,
+
+    -- Upload timestamp managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each file name appears once per release; uploading it again replaces it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_artifacts_project_release_name ON release_artifacts(project_id, release, name);
+
+-- Add comments for documentation
+COMMENT ON TABLE release_artifacts IS 'Files uploaded per release, such as JavaScript source maps used for symbolication';
+COMMENT ON COLUMN release_artifacts.name IS 'Artifact path, e.g. "~/static/js/app.js.map"';
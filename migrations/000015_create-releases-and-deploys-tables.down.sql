@@ -0,0 +1,7 @@
+-- Remove issue first releases
+DROP INDEX IF EXISTS idx_issues_project_first_release;
+ALTER TABLE issues DROP COLUMN IF EXISTS first_release;
+
+-- Drop deploys and releases tables
+DROP TABLE IF EXISTS deploys;
+DROP TABLE IF EXISTS releases;
@@ -0,0 +1,66 @@
+-- Create releases table
+CREATE TABLE IF NOT EXISTS releases (
+    -- Unique identifier for the release.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this release to its project.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Release identifier reported by SDKs, e.g. "web@1.4.2".
+    version VARCHAR(255) NOT NULL,
+
+    -- Commit the release was built from, if known.
+    commit_sha VARCHAR(64),
+
+    -- Number of events, and of error or fatal events, reporting this release.
+    event_count BIGINT NOT NULL DEFAULT 0,
+    error_count BIGINT NOT NULL DEFAULT 0,
+
+    -- Timestamps of the first and most recent events reporting this release.
+    first_event_at TIMESTAMPTZ,
+    last_event_at TIMESTAMPTZ,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each version appears once per project.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_releases_project_version ON releases(project_id, version);
+
+-- Create an index for listing a project's newest releases first.
+CREATE INDEX IF NOT EXISTS idx_releases_project_created_at ON releases(project_id, created_at DESC);
+
+-- Create deploys table
+CREATE TABLE IF NOT EXISTS deploys (
+    -- Unique identifier for the deploy.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this deploy to the deployed release.
+    release_id UUID NOT NULL REFERENCES releases(id) ON DELETE CASCADE,
+
+    -- Environment the release was deployed to, e.g. "production".
+    environment VARCHAR(64) NOT NULL,
+
+    -- When the deploy finished.
+    deployed_at TIMESTAMPTZ NOT NULL,
+
+    -- The user who recorded the deploy.
+    created_by_id UUID NOT NULL,
+
+    -- Record timestamp managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index on the release_id for listing a release's deploys.
+CREATE INDEX IF NOT EXISTS idx_deploys_release_id ON deploys(release_id);
+
+-- Track the release each issue first appeared in, so releases can report their new issues.
+-- Issues created before this migration have no first release.
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS first_release VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_issues_project_first_release ON issues(project_id, first_release);
+
+-- Add comments for documentation
+COMMENT ON TABLE releases IS 'Versions of project code, with event and error counts per release';
+COMMENT ON TABLE deploys IS 'Deploys of a release to an environment';
+COMMENT ON COLUMN issues.first_release IS 'Release reported by the event that created the issue';
@@ -0,0 +1,3 @@
+-- Drop alert_evaluations and alert_rules tables
+DROP TABLE IF EXISTS alert_evaluations;
+DROP TABLE IF EXISTS alert_rules;
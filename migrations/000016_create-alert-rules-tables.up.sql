@@ -0,0 +1,86 @@
+-- Create alert_rules table
+CREATE TABLE IF NOT EXISTS alert_rules (
+    -- Unique identifier for the rule.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this rule to the project it watches.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Display name, unique per project (enforced by the service).
+    name VARCHAR(255) NOT NULL,
+
+    -- Log query selecting the events to aggregate; empty means every event.
+    query TEXT NOT NULL DEFAULT '',
+
+    -- Aggregation ("count" or "rate") and comparison ("gt", "gte", "lt" or "lte").
+    aggregation VARCHAR(20) NOT NULL,
+    comparison VARCHAR(5) NOT NULL,
+
+    -- Value that fires the rule, and the value that resolves it (NULL means the same).
+    threshold DOUBLE PRECISION NOT NULL,
+    resolve_threshold DOUBLE PRECISION,
+
+    -- Aggregation window and evaluation interval in seconds.
+    window_seconds INTEGER NOT NULL,
+    evaluation_interval_seconds INTEGER NOT NULL,
+
+    -- Whether the scheduler evaluates the rule.
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Current state ("ok" or "firing") and when it last changed.
+    state VARCHAR(10) NOT NULL DEFAULT 'ok',
+    state_changed_at TIMESTAMPTZ,
+
+    -- Most recent successful evaluation.
+    last_evaluated_at TIMESTAMPTZ,
+    last_value DOUBLE PRECISION,
+
+    -- When the scheduler should next evaluate the rule.
+    next_evaluation_at TIMESTAMPTZ NOT NULL,
+
+    -- The user who created the rule.
+    created_by_id UUID NOT NULL,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index on the project_id for listing a project's rules.
+CREATE INDEX IF NOT EXISTS idx_alert_rules_project_id ON alert_rules(project_id);
+
+-- Create a partial index for the scheduler's due-rule query.
+CREATE INDEX IF NOT EXISTS idx_alert_rules_next_evaluation_at ON alert_rules(next_evaluation_at) WHERE enabled;
+
+-- Create alert_evaluations table
+CREATE TABLE IF NOT EXISTS alert_evaluations (
+    -- Unique identifier for the evaluation.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this evaluation to its rule.
+    alert_rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+
+    -- Aggregation window; the evaluation ran at its end.
+    evaluated_at TIMESTAMPTZ NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+
+    -- Aggregated value; NULL when the evaluation failed.
+    value DOUBLE PRECISION,
+
+    -- Rule state before and after the evaluation.
+    previous_state VARCHAR(10) NOT NULL,
+    state VARCHAR(10) NOT NULL,
+
+    -- Failure reason, if any.
+    error TEXT
+);
+
+-- Create an index for reading a rule's history, newest first.
+CREATE INDEX IF NOT EXISTS idx_alert_evaluations_rule_evaluated_at ON alert_evaluations(alert_rule_id, evaluated_at DESC);
+
+-- Create an index for pruning old evaluations.
+CREATE INDEX IF NOT EXISTS idx_alert_evaluations_evaluated_at ON alert_evaluations(evaluated_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE alert_rules IS 'Threshold alert rules evaluated periodically by the alert scheduler';
+COMMENT ON TABLE alert_evaluations IS 'Evaluation history of alert rules';
@@ -0,0 +1,6 @@
+-- Remove channel selection from alert rules
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS channel_ids;
+
+-- Drop notification_deliveries and notification_channels tables
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TABLE IF EXISTS notification_channels;
@@ -0,0 +1,94 @@
+-- Create notification_channels table
+CREATE TABLE IF NOT EXISTS notification_channels (
+    -- Unique identifier for the channel.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this channel to the organization that owns it.
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+
+    -- Display name, unique per organization.
+    name VARCHAR(255) NOT NULL,
+
+    -- Delivery type ("webhook", "slack" or "email").
+    type VARCHAR(20) NOT NULL,
+
+    -- Endpoint for webhook and slack channels.
+    url TEXT NOT NULL DEFAULT '',
+
+    -- HMAC-SHA256 signing key for webhook channels.
+    secret VARCHAR(64) NOT NULL DEFAULT '',
+
+    -- Email addresses of email channels, as a JSON array.
+    recipients JSONB NOT NULL DEFAULT '[]',
+
+    -- Custom message templates; empty means the default.
+    subject_template TEXT NOT NULL DEFAULT '',
+    body_template TEXT NOT NULL DEFAULT '',
+
+    -- Whether notifications are sent to the channel.
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- The user who created the channel.
+    created_by_id UUID NOT NULL,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create a unique index so channel names are unique per organization.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_channels_organization_name ON notification_channels(organization_id, name);
+
+-- Create notification_deliveries table
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    -- Unique identifier for the delivery, sent to webhook receivers for deduplication.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this delivery to its channel.
+    channel_id UUID NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+
+    -- The alert rule that triggered the notification; NULL for test notifications.
+    alert_rule_id UUID REFERENCES alert_rules(id) ON DELETE SET NULL,
+
+    -- What the notification is about ("alert.firing", "alert.resolved" or "test").
+    event VARCHAR(30) NOT NULL,
+
+    -- Rendered message, so retries send exactly the same content.
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+
+    -- Delivery status ("pending", "succeeded" or "failed") and attempts made.
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    -- When a pending delivery is next attempted.
+    next_attempt_at TIMESTAMPTZ,
+
+    -- Outcome of the latest attempt.
+    last_error TEXT,
+    response_status INTEGER,
+
+    -- When the notification was delivered.
+    delivered_at TIMESTAMPTZ,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for reading a channel's delivery log, newest first.
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_channel_created_at ON notification_deliveries(channel_id, created_at DESC);
+
+-- Create a partial index for the dispatcher's due-delivery query.
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_next_attempt_at ON notification_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- Create an index for pruning old deliveries.
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_created_at ON notification_deliveries(created_at);
+
+-- Let alert rules pick the channels they notify.
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS channel_ids JSONB NOT NULL DEFAULT '[]';
+
+-- Add comments for documentation
+COMMENT ON TABLE notification_channels IS 'Organization-level destinations for alert notifications';
+COMMENT ON TABLE notification_deliveries IS 'Delivery log of notifications, including pending retries';
+COMMENT ON COLUMN alert_rules.channel_ids IS 'Notification channels the rule notifies on state changes';
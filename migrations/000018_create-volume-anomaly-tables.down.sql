@@ -0,0 +1,4 @@
+-- Drop anomaly detection tables
+DROP TABLE IF EXISTS anomaly_settings;
+DROP TABLE IF EXISTS volume_anomalies;
+DROP TABLE IF EXISTS volume_baselines;
@@ -0,0 +1,84 @@
+-- Create volume_baselines table
+CREATE TABLE IF NOT EXISTS volume_baselines (
+    -- The project and log level the baseline belongs to.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    level VARCHAR(10) NOT NULL,
+
+    -- Hour of the week, 0 for Sunday 00:00 UTC to 167.
+    hour_of_week SMALLINT NOT NULL,
+
+    -- Exponentially weighted mean and variance of the hourly event count.
+    mean DOUBLE PRECISION NOT NULL,
+    variance DOUBLE PRECISION NOT NULL,
+
+    -- Number of hours learned, and the most recent one.
+    samples INTEGER NOT NULL,
+    last_hour TIMESTAMPTZ NOT NULL,
+
+    -- When the baseline was last learned from.
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (project_id, level, hour_of_week),
+    CONSTRAINT chk_volume_baselines_hour_of_week CHECK (hour_of_week BETWEEN 0 AND 167)
+);
+
+-- Create an index for loading every project's baselines for one hour of the week.
+CREATE INDEX IF NOT EXISTS idx_volume_baselines_hour_of_week ON volume_baselines(hour_of_week);
+
+-- Create volume_anomalies table
+CREATE TABLE IF NOT EXISTS volume_anomalies (
+    -- Unique identifier for the anomaly.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this anomaly to its project.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Log level whose rate deviated; empty for silence, which covers every level.
+    level VARCHAR(10) NOT NULL DEFAULT '',
+
+    -- How the rate deviated ("spike", "drop" or "silence").
+    kind VARCHAR(10) NOT NULL,
+
+    -- Counts and score at the strongest deviation.
+    observed BIGINT NOT NULL,
+    expected DOUBLE PRECISION NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+
+    -- When the deviation was first seen, last seen and found to be over.
+    started_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- Create an index for listing a project's anomalies, newest first.
+CREATE INDEX IF NOT EXISTS idx_volume_anomalies_project_started_at ON volume_anomalies(project_id, started_at DESC);
+
+-- Create a partial unique index so a project and level have at most one open anomaly,
+-- even with several detectors running.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volume_anomalies_open ON volume_anomalies(project_id, level) WHERE resolved_at IS NULL;
+
+-- Create anomaly_settings table
+CREATE TABLE IF NOT EXISTS anomaly_settings (
+    -- The project the settings apply to.
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Whether anomalies are detected for the project.
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Standard deviations from the baseline that count as anomalous.
+    sensitivity DOUBLE PRECISION NOT NULL,
+
+    -- Deviations smaller than this many events are ignored.
+    minimum_events INTEGER NOT NULL,
+
+    -- Whether a project going quiet raises an anomaly.
+    detect_silence BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- When the settings were last changed.
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE volume_baselines IS 'Learned hour-of-week event volume per project and log level';
+COMMENT ON TABLE volume_anomalies IS 'Periods in which a project''s event rate deviated from its baseline';
+COMMENT ON TABLE anomaly_settings IS 'Per-project anomaly detection sensitivity; projects without a row use the defaults';
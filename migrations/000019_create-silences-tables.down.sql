@@ -0,0 +1,6 @@
+-- Drop silence_audit_entries and silences tables
+DROP TABLE IF EXISTS silence_audit_entries;
+DROP TABLE IF EXISTS silences;
+
+-- Remove labels from alert rules
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,83 @@
+-- Let alert rules carry labels that silences can match on.
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+
+-- Create silences table
+CREATE TABLE IF NOT EXISTS silences (
+    -- Unique identifier for the silence.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this silence to the organization it belongs to.
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+
+    -- Optional project the silence is limited to. NULL means every project of the organization.
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Labels a rule must carry to be silenced, as a JSON object. Empty means every rule in scope.
+    matchers JSONB NOT NULL DEFAULT '{}',
+
+    -- Why the silence was created (e.g., a maintenance ticket reference).
+    comment TEXT NOT NULL,
+
+    -- Period the silence applies in. ends_at may be NULL for recurring silences.
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+
+    -- Weekly maintenance window: weekdays (0 = Sunday), opening time, length and time zone.
+    -- No weekdays means the silence applies for its whole period.
+    recurrence_days JSONB NOT NULL DEFAULT '[]',
+    recurrence_start_time VARCHAR(5) NOT NULL DEFAULT '',
+    recurrence_duration_seconds INTEGER NOT NULL DEFAULT 0,
+    recurrence_timezone VARCHAR(64) NOT NULL DEFAULT '',
+
+    -- The user who created the silence.
+    created_by_id UUID NOT NULL REFERENCES users(id),
+
+    -- Early expiry details. NULL while the silence runs its course.
+    expired_at TIMESTAMPTZ,
+    expired_by_id UUID REFERENCES users(id),
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_silences_period CHECK (ends_at IS NULL OR starts_at < ends_at)
+);
+
+-- Create an index on the organization_id for listing and matching an organization's silences.
+CREATE INDEX IF NOT EXISTS idx_silences_organization_id ON silences(organization_id);
+
+-- Create silence_audit_entries table
+CREATE TABLE IF NOT EXISTS silence_audit_entries (
+    -- Unique identifier for the audit entry.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The silence the entry is about.
+    silence_id UUID NOT NULL REFERENCES silences(id) ON DELETE CASCADE,
+
+    -- Denormalized organization so an organization's full trail can be read in one query.
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+
+    -- What happened: created, updated, expired or suppressed.
+    action VARCHAR(20) NOT NULL,
+
+    -- The user who made the change. NULL for notifications suppressed by the scheduler.
+    actor_id UUID REFERENCES users(id),
+
+    -- The alert rule whose notification was suppressed.
+    alert_rule_id UUID REFERENCES alert_rules(id) ON DELETE SET NULL,
+
+    -- Human-readable description of the change.
+    details TEXT NOT NULL,
+
+    -- When the change was made.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for reading the trail of a silence or of a whole organization, newest first.
+CREATE INDEX IF NOT EXISTS idx_silence_audit_entries_silence_id ON silence_audit_entries(silence_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_silence_audit_entries_organization_id ON silence_audit_entries(organization_id, created_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE silences IS 'Silences and maintenance windows that mute alert notifications';
+COMMENT ON TABLE silence_audit_entries IS 'Append-only audit trail of silence changes and suppressed notifications';
+COMMENT ON COLUMN alert_rules.labels IS 'Key/value labels silences match on';
@@ -0,0 +1,5 @@
+-- Drop incident tables
+DROP TABLE IF EXISTS incident_timeline_entries;
+DROP TABLE IF EXISTS incident_issues;
+DROP TABLE IF EXISTS incident_alert_evaluations;
+DROP TABLE IF EXISTS incidents;
@@ -0,0 +1,115 @@
+-- Create incidents table
+CREATE TABLE IF NOT EXISTS incidents (
+    -- Unique identifier for the incident.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this incident to the project it affects.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- The alert rule that opened the incident. NULL for incidents opened by hand.
+    alert_rule_id UUID REFERENCES alert_rules(id) ON DELETE SET NULL,
+
+    -- Short description of the incident.
+    title VARCHAR(255) NOT NULL,
+
+    -- Lifecycle status ("open", "acknowledged" or "resolved").
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+
+    -- Pinned log query and time range. log_query_to is NULL while the alert is still firing.
+    log_query TEXT NOT NULL DEFAULT '',
+    log_query_from TIMESTAMPTZ NOT NULL,
+    log_query_to TIMESTAMPTZ,
+
+    -- When the incident started.
+    started_at TIMESTAMPTZ NOT NULL,
+
+    -- Acknowledgement and resolution details. NULL until they happen.
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by_id UUID REFERENCES users(id),
+    resolved_at TIMESTAMPTZ,
+    resolved_by_id UUID REFERENCES users(id),
+
+    -- The user who opened the incident. NULL for incidents opened by an alert rule.
+    created_by_id UUID REFERENCES users(id),
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for listing a project's incidents, most recent first.
+CREATE INDEX IF NOT EXISTS idx_incidents_project_started_at ON incidents(project_id, started_at DESC);
+
+-- Create a partial unique index so an alert rule has at most one unresolved incident.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_open_alert_rule ON incidents(alert_rule_id) WHERE resolved_at IS NULL;
+
+-- Create incident_alert_evaluations table
+CREATE TABLE IF NOT EXISTS incident_alert_evaluations (
+    -- Unique identifier for the record.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The incident the evaluation belongs to.
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+
+    -- The original evaluation. Not a foreign key, as evaluation history is pruned.
+    alert_evaluation_id UUID NOT NULL,
+
+    -- Evaluated window, value and resulting state.
+    evaluated_at TIMESTAMPTZ NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    state VARCHAR(10) NOT NULL,
+
+    -- How the value was judged at the time.
+    aggregation VARCHAR(20) NOT NULL,
+    comparison VARCHAR(5) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL
+);
+
+-- Create an index for reading an incident's evaluations.
+CREATE INDEX IF NOT EXISTS idx_incident_alert_evaluations_incident_id ON incident_alert_evaluations(incident_id);
+
+-- Create incident_issues table
+CREATE TABLE IF NOT EXISTS incident_issues (
+    -- The incident and the linked issue.
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+
+    -- The user who linked the issue. NULL for issues linked automatically.
+    linked_by_id UUID REFERENCES users(id),
+
+    -- When the issue was linked.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (incident_id, issue_id)
+);
+
+-- Create incident_timeline_entries table
+CREATE TABLE IF NOT EXISTS incident_timeline_entries (
+    -- Unique identifier for the entry.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The incident the entry belongs to.
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+
+    -- What happened, e.g. "alert_firing", "acknowledged" or "note".
+    kind VARCHAR(20) NOT NULL,
+
+    -- The member who acted. NULL for entries recorded by the alert scheduler.
+    actor_id UUID REFERENCES users(id),
+
+    -- The note, or a description of the event.
+    message TEXT NOT NULL,
+
+    -- When it happened.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for reading an incident's timeline in order.
+CREATE INDEX IF NOT EXISTS idx_incident_timeline_entries_incident_id ON incident_timeline_entries(incident_id, created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE incidents IS 'Incidents grouping alert evaluations, issues and a pinned log query';
+COMMENT ON TABLE incident_alert_evaluations IS 'Copies of the alert evaluations that changed an incident''s rule state';
+COMMENT ON TABLE incident_issues IS 'Issues linked to incidents';
+COMMENT ON TABLE incident_timeline_entries IS 'Append-only timeline of incident events and notes';
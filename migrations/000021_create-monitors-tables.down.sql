@@ -0,0 +1,4 @@
+-- Drop monitor tables
+ALTER TABLE notification_deliveries DROP COLUMN IF EXISTS monitor_id;
+DROP TABLE IF EXISTS monitor_check_ins;
+DROP TABLE IF EXISTS monitors;
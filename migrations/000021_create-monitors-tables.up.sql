@@ -0,0 +1,105 @@
+-- Create monitors table
+CREATE TABLE IF NOT EXISTS monitors (
+    -- Unique identifier for the monitor.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this monitor to the project it belongs to.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Display name, and the slug jobs check in with, unique per project.
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(64) NOT NULL,
+
+    -- How runs are expected ("cron" or "interval").
+    schedule_type VARCHAR(10) NOT NULL,
+
+    -- Five-field cron schedule and the IANA time zone it is evaluated in, for "cron" monitors.
+    cron_expression VARCHAR(255) NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+
+    -- Expected seconds between check-ins, for "interval" monitors.
+    interval_seconds INTEGER NOT NULL DEFAULT 0,
+
+    -- Grace period after the expected time before a run is missed.
+    margin_seconds INTEGER NOT NULL,
+
+    -- How long a started run may take before it times out; 0 means no limit.
+    max_runtime_seconds INTEGER NOT NULL DEFAULT 0,
+
+    -- Notification channels notified when the monitor changes status, as a JSON array.
+    channel_ids JSONB NOT NULL DEFAULT '[]',
+
+    -- Whether missed and failed runs are detected and notified.
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Current status ("pending", "ok", "missed" or "failed") and when it last changed.
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    status_changed_at TIMESTAMPTZ,
+
+    -- When the monitor last received a check-in.
+    last_check_in_at TIMESTAMPTZ,
+
+    -- When the next run is expected, and when it is missed; NULL while disabled.
+    next_check_in_at TIMESTAMPTZ,
+    deadline_at TIMESTAMPTZ,
+
+    -- The user who created the monitor.
+    created_by_id UUID NOT NULL,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create a unique index so slugs are unique per project.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_monitors_project_slug ON monitors(project_id, slug);
+
+-- Create a partial index for the scheduler's missed-run query.
+CREATE INDEX IF NOT EXISTS idx_monitors_deadline_at ON monitors(deadline_at) WHERE enabled;
+
+-- Create monitor_check_ins table
+CREATE TABLE IF NOT EXISTS monitor_check_ins (
+    -- Unique identifier for the run, returned to the job so it can close the run.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Foreign key linking this run to its monitor.
+    monitor_id UUID NOT NULL REFERENCES monitors(id) ON DELETE CASCADE,
+
+    -- Outcome of the run ("in_progress", "ok", "error", "missed" or "timeout").
+    status VARCHAR(20) NOT NULL,
+
+    -- The scheduled time the run was for; NULL for runs outside the schedule.
+    expected_at TIMESTAMPTZ,
+
+    -- When the run started and finished, and how long it took in milliseconds.
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    duration_ms BIGINT,
+
+    -- When a run still in progress times out.
+    timeout_at TIMESTAMPTZ,
+
+    -- Optional note sent by the job, such as an error message.
+    message TEXT NOT NULL DEFAULT '',
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for reading a monitor's run history, newest first.
+CREATE INDEX IF NOT EXISTS idx_monitor_check_ins_monitor_created_at ON monitor_check_ins(monitor_id, created_at DESC);
+
+-- Create a partial index for the scheduler's timed-out run query.
+CREATE INDEX IF NOT EXISTS idx_monitor_check_ins_timeout_at ON monitor_check_ins(timeout_at) WHERE status = 'in_progress';
+
+-- Create an index for pruning old runs.
+CREATE INDEX IF NOT EXISTS idx_monitor_check_ins_created_at ON monitor_check_ins(created_at);
+
+-- Record which monitor triggered a notification.
+ALTER TABLE notification_deliveries ADD COLUMN IF NOT EXISTS monitor_id UUID REFERENCES monitors(id) ON DELETE SET NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE monitors IS 'Scheduled jobs and heartbeats watched through the check-ins they send';
+COMMENT ON TABLE monitor_check_ins IS 'Run history of monitors, including missed and timed-out runs';
+COMMENT ON COLUMN notification_deliveries.monitor_id IS 'Monitor that triggered the notification, if any';
@@ -0,0 +1 @@
+-- Nothing to undo: the redacted response bodies cannot be recovered.
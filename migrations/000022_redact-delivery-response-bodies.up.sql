@@ -0,0 +1,6 @@
+-- Strip receiver response bodies from the delivery log.
+-- Failed webhook and Slack deliveries used to record up to 512 bytes of the response,
+-- which were returned through the API; only the status is kept from now on.
+UPDATE notification_deliveries
+SET last_error = substring(last_error FROM '^endpoint responded with status [0-9]+')
+WHERE last_error LIKE 'endpoint responded with status %';
@@ -0,0 +1,2 @@
+-- Drop held_notifications table
+DROP TABLE IF EXISTS held_notifications;
@@ -0,0 +1,25 @@
+-- Create held_notifications table
+-- A firing notification muted by a silence is kept here and sent once no silence mutes it,
+-- unless the alert resolves first.
+CREATE TABLE IF NOT EXISTS held_notifications (
+    -- Unique identifier for the held notification, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The project the notification is about.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- The alert rule whose firing notification is held. At most one per rule.
+    alert_rule_id UUID REFERENCES alert_rules(id) ON DELETE CASCADE,
+
+    -- The notification exactly as it would have been sent.
+    payload JSONB NOT NULL,
+
+    -- When the notification was first held back.
+    held_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One held notification per rule; also used to upsert when a rule fires again while muted.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_held_notifications_alert_rule_id ON held_notifications(alert_rule_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE held_notifications IS 'Firing notifications held back by silences, sent when the silence ends';
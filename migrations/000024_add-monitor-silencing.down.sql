@@ -0,0 +1,5 @@
+-- Remove monitor silencing
+DROP INDEX IF EXISTS idx_held_notifications_monitor_id;
+ALTER TABLE held_notifications DROP COLUMN IF EXISTS monitor_id;
+ALTER TABLE silence_audit_entries DROP COLUMN IF EXISTS monitor_id;
+ALTER TABLE monitors DROP COLUMN IF EXISTS labels;
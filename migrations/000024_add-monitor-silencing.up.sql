@@ -0,0 +1,13 @@
+-- Let silences mute monitor notifications
+-- Monitors get labels for silence matchers to select them by, as alert rules have.
+ALTER TABLE monitors ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+
+-- The monitor whose notification a silence suppressed. Cleared if the monitor is deleted.
+ALTER TABLE silence_audit_entries ADD COLUMN IF NOT EXISTS monitor_id UUID REFERENCES monitors(id) ON DELETE SET NULL;
+
+-- The monitor whose missed or failed notification is held. At most one per monitor.
+ALTER TABLE held_notifications ADD COLUMN IF NOT EXISTS monitor_id UUID REFERENCES monitors(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_held_notifications_monitor_id ON held_notifications(monitor_id);
+
+-- Add comments for documentation
+COMMENT ON COLUMN monitors.labels IS 'Key/value labels that silence matchers select monitors by';
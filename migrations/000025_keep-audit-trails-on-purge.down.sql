@@ -0,0 +1,46 @@
+-- Restore the original foreign keys
+-- Legal hold audit entries whose hold was purged cannot reference it again and are removed.
+-- Other rows whose creator was purged must be removed by hand before NOT NULL can be restored.
+DELETE FROM legal_hold_audit_entries WHERE legal_hold_id IS NULL OR project_id IS NULL OR actor_id IS NULL;
+
+ALTER TABLE saved_searches DROP CONSTRAINT IF EXISTS saved_searches_created_by_id_fkey;
+ALTER TABLE saved_searches ADD CONSTRAINT saved_searches_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id);
+ALTER TABLE saved_searches ALTER COLUMN created_by_id SET NOT NULL;
+
+ALTER TABLE legal_holds DROP CONSTRAINT IF EXISTS legal_holds_created_by_id_fkey;
+ALTER TABLE legal_holds ADD CONSTRAINT legal_holds_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id);
+ALTER TABLE legal_holds ALTER COLUMN created_by_id SET NOT NULL;
+ALTER TABLE legal_holds DROP CONSTRAINT IF EXISTS legal_holds_released_by_id_fkey;
+ALTER TABLE legal_holds ADD CONSTRAINT legal_holds_released_by_id_fkey FOREIGN KEY (released_by_id) REFERENCES users(id);
+
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_legal_hold_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_legal_hold_id_fkey FOREIGN KEY (legal_hold_id) REFERENCES legal_holds(id) ON DELETE CASCADE;
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN legal_hold_id SET NOT NULL;
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_project_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_project_id_fkey FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN project_id SET NOT NULL;
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_actor_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id);
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN actor_id SET NOT NULL;
+
+ALTER TABLE silences DROP CONSTRAINT IF EXISTS silences_created_by_id_fkey;
+ALTER TABLE silences ADD CONSTRAINT silences_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id);
+ALTER TABLE silences ALTER COLUMN created_by_id SET NOT NULL;
+ALTER TABLE silences DROP CONSTRAINT IF EXISTS silences_expired_by_id_fkey;
+ALTER TABLE silences ADD CONSTRAINT silences_expired_by_id_fkey FOREIGN KEY (expired_by_id) REFERENCES users(id);
+
+ALTER TABLE silence_audit_entries DROP CONSTRAINT IF EXISTS silence_audit_entries_actor_id_fkey;
+ALTER TABLE silence_audit_entries ADD CONSTRAINT silence_audit_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id);
+
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_acknowledged_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_acknowledged_by_id_fkey FOREIGN KEY (acknowledged_by_id) REFERENCES users(id);
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_resolved_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_resolved_by_id_fkey FOREIGN KEY (resolved_by_id) REFERENCES users(id);
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_created_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id);
+
+ALTER TABLE incident_issues DROP CONSTRAINT IF EXISTS incident_issues_linked_by_id_fkey;
+ALTER TABLE incident_issues ADD CONSTRAINT incident_issues_linked_by_id_fkey FOREIGN KEY (linked_by_id) REFERENCES users(id);
+
+ALTER TABLE incident_timeline_entries DROP CONSTRAINT IF EXISTS incident_timeline_entries_actor_id_fkey;
+ALTER TABLE incident_timeline_entries ADD CONSTRAINT incident_timeline_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id);
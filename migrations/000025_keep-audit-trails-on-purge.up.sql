@@ -0,0 +1,46 @@
+-- Keep audit trails when the records they mention are purged
+-- References to users become ON DELETE SET NULL so purging a user is never blocked by what they
+-- created or changed. Legal hold audit entries also outlive their hold and project: the purge job
+-- deletes those with the project, and the trail is kept as evidence with the references cleared.
+
+ALTER TABLE saved_searches ALTER COLUMN created_by_id DROP NOT NULL;
+ALTER TABLE saved_searches DROP CONSTRAINT IF EXISTS saved_searches_created_by_id_fkey;
+ALTER TABLE saved_searches ADD CONSTRAINT saved_searches_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE legal_holds ALTER COLUMN created_by_id DROP NOT NULL;
+ALTER TABLE legal_holds DROP CONSTRAINT IF EXISTS legal_holds_created_by_id_fkey;
+ALTER TABLE legal_holds ADD CONSTRAINT legal_holds_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE legal_holds DROP CONSTRAINT IF EXISTS legal_holds_released_by_id_fkey;
+ALTER TABLE legal_holds ADD CONSTRAINT legal_holds_released_by_id_fkey FOREIGN KEY (released_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN legal_hold_id DROP NOT NULL;
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_legal_hold_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_legal_hold_id_fkey FOREIGN KEY (legal_hold_id) REFERENCES legal_holds(id) ON DELETE SET NULL;
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN project_id DROP NOT NULL;
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_project_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_project_id_fkey FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL;
+ALTER TABLE legal_hold_audit_entries ALTER COLUMN actor_id DROP NOT NULL;
+ALTER TABLE legal_hold_audit_entries DROP CONSTRAINT IF EXISTS legal_hold_audit_entries_actor_id_fkey;
+ALTER TABLE legal_hold_audit_entries ADD CONSTRAINT legal_hold_audit_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE silences ALTER COLUMN created_by_id DROP NOT NULL;
+ALTER TABLE silences DROP CONSTRAINT IF EXISTS silences_created_by_id_fkey;
+ALTER TABLE silences ADD CONSTRAINT silences_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE silences DROP CONSTRAINT IF EXISTS silences_expired_by_id_fkey;
+ALTER TABLE silences ADD CONSTRAINT silences_expired_by_id_fkey FOREIGN KEY (expired_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE silence_audit_entries DROP CONSTRAINT IF EXISTS silence_audit_entries_actor_id_fkey;
+ALTER TABLE silence_audit_entries ADD CONSTRAINT silence_audit_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_acknowledged_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_acknowledged_by_id_fkey FOREIGN KEY (acknowledged_by_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_resolved_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_resolved_by_id_fkey FOREIGN KEY (resolved_by_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_created_by_id_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE incident_issues DROP CONSTRAINT IF EXISTS incident_issues_linked_by_id_fkey;
+ALTER TABLE incident_issues ADD CONSTRAINT incident_issues_linked_by_id_fkey FOREIGN KEY (linked_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE incident_timeline_entries DROP CONSTRAINT IF EXISTS incident_timeline_entries_actor_id_fkey;
+ALTER TABLE incident_timeline_entries ADD CONSTRAINT incident_timeline_entries_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
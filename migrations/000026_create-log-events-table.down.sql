@@ -0,0 +1,2 @@
+-- Drop log_events table and all of its daily partitions
+DROP TABLE IF EXISTS log_events;
@@ -0,0 +1,50 @@
+-- Create log_events table, partitioned by day on the event timestamp.
+-- Partitions (log_events_pYYYYMMDD) are created by the application the first time an event for
+-- a day is stored, and a whole day can be dropped or detached without touching other days.
+CREATE TABLE IF NOT EXISTS log_events (
+    -- Unique identifier of the event, assigned at ingest.
+    id UUID NOT NULL,
+
+    -- The project the event was sent to.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- When the event happened, as reported by the sender, and when it was received.
+    timestamp TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Log level of the event (trace, debug, info, warn, error, fatal).
+    level VARCHAR(10) NOT NULL,
+
+    -- The log message.
+    message TEXT NOT NULL,
+
+    -- Where the event came from and which release produced it; empty when not sent.
+    host VARCHAR(255) NOT NULL DEFAULT '',
+    service VARCHAR(255) NOT NULL DEFAULT '',
+    stream VARCHAR(255) NOT NULL DEFAULT '',
+    release VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- The mined message pattern the event matched, if any.
+    pattern_id UUID,
+
+    -- Free-form key/value attributes sent with the event.
+    attributes JSONB NOT NULL DEFAULT '{}',
+
+    -- Estimated size of the event, summed up when events are deleted.
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+
+    -- Words of the message for full-text search.
+    search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', message)) STORED,
+
+    PRIMARY KEY (project_id, timestamp, id)
+) PARTITION BY RANGE (timestamp);
+
+-- Create an index for fetching a single event by ID.
+CREATE INDEX IF NOT EXISTS idx_log_events_project_id_id ON log_events(project_id, id);
+
+-- Create an index for word and phrase searches.
+CREATE INDEX IF NOT EXISTS idx_log_events_search_vector ON log_events USING GIN (search_vector);
+
+-- Add comments for documentation
+COMMENT ON TABLE log_events IS 'Ingested log events, partitioned by day on timestamp';
+COMMENT ON COLUMN log_events.search_vector IS 'Words of the message, matched by word and phrase searches';
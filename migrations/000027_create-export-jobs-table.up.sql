@@ -0,0 +1,54 @@
+-- Create export_jobs table
+-- Large log exports run in the background; the finished file is kept in object storage
+-- until expires_at and then deleted.
+CREATE TABLE IF NOT EXISTS export_jobs (
+    -- Unique identifier for the export job, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The project whose logs are exported.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- The user who requested the export; cleared if the user is purged.
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- File format (csv, ndjson or parquet), exported columns and the search to run.
+    format VARCHAR(10) NOT NULL,
+    columns JSONB NOT NULL DEFAULT '[]',
+    filter JSONB NOT NULL,
+
+    -- Maximum number of events to export.
+    max_rows INTEGER NOT NULL,
+
+    -- pending, running, succeeded, failed or expired.
+    status VARCHAR(20) NOT NULL,
+
+    -- How often a worker has started the job, and until when the current worker holds it.
+    attempts INTEGER NOT NULL DEFAULT 0,
+    lease_expires_at TIMESTAMPTZ,
+
+    -- The finished file.
+    row_count BIGINT NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    storage_key TEXT NOT NULL DEFAULT '',
+
+    -- Why a failed job failed.
+    error TEXT NOT NULL DEFAULT '',
+
+    -- When the job finished and when its file expires.
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for listing a project's exports, newest first.
+CREATE INDEX IF NOT EXISTS idx_export_jobs_project_created_at ON export_jobs(project_id, created_at DESC);
+
+-- Create an index for workers looking for jobs to run or files to expire.
+CREATE INDEX IF NOT EXISTS idx_export_jobs_status ON export_jobs(status);
+
+-- Add comments for documentation
+COMMENT ON TABLE export_jobs IS 'Background exports of log search results to downloadable files';
+COMMENT ON COLUMN export_jobs.filter IS 'Search whose results are exported: text, time range, levels and fields';
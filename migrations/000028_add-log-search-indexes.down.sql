@@ -0,0 +1,17 @@
+-- Drop the per-project indexed attributes setting.
+ALTER TABLE projects DROP COLUMN IF EXISTS indexed_attributes;
+
+-- Drop the trigram indexes.
+DROP INDEX IF EXISTS idx_log_events_search_text_trgm;
+DROP INDEX IF EXISTS idx_log_events_message_trgm;
+
+-- Restore the search vector over the message alone.
+DROP INDEX IF EXISTS idx_log_events_search_vector;
+ALTER TABLE log_events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE log_events ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', message)) STORED;
+CREATE INDEX IF NOT EXISTS idx_log_events_search_vector ON log_events USING GIN (search_vector);
+
+-- Drop the search text column. The pg_trgm extension is left installed, since other
+-- objects may depend on it.
+ALTER TABLE log_events DROP COLUMN IF EXISTS search_text;
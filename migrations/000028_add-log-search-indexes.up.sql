@@ -0,0 +1,27 @@
+-- Enable trigram matching, used to index substring searches on log messages.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Add the text of each event's full-text indexed attributes, filled at ingest from the
+-- project's indexed_attributes setting.
+ALTER TABLE log_events ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+
+-- Regenerate the search vector so word and phrase searches cover the indexed attributes too.
+DROP INDEX IF EXISTS idx_log_events_search_vector;
+ALTER TABLE log_events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE log_events ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', message || ' ' || search_text)) STORED;
+
+-- Create an index for word and phrase searches.
+CREATE INDEX IF NOT EXISTS idx_log_events_search_vector ON log_events USING GIN (search_vector);
+
+-- Create trigram indexes for substring searches, which match anywhere in the text.
+CREATE INDEX IF NOT EXISTS idx_log_events_message_trgm ON log_events USING GIN (message gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_log_events_search_text_trgm ON log_events USING GIN (search_text gin_trgm_ops);
+
+-- Add the per-project list of attributes whose values are full-text indexed.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS indexed_attributes JSONB NOT NULL DEFAULT '[]';
+
+-- Add comments for documentation
+COMMENT ON COLUMN log_events.search_text IS 'Values of the project''s indexed attributes, matched by full-text searches';
+COMMENT ON COLUMN log_events.search_vector IS 'Words of the message and search text, matched by word and phrase searches';
+COMMENT ON COLUMN projects.indexed_attributes IS 'Attributes whose values are full-text indexed for new events';
@@ -0,0 +1,3 @@
+-- Drop log_rehydrations and log_archives tables
+DROP TABLE IF EXISTS log_rehydrations;
+DROP TABLE IF EXISTS log_archives;
@@ -0,0 +1,78 @@
+-- Create log_archives table
+-- Before retention deletes expired log events they are archived to object storage, one
+-- gzip-compressed NDJSON file per project and UTC day; this table indexes those files.
+CREATE TABLE IF NOT EXISTS log_archives (
+    -- Unique identifier for the archive, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The project whose events are archived.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- The UTC day of the archived events, and the timestamps of the oldest and newest of them.
+    day DATE NOT NULL,
+    first_timestamp TIMESTAMPTZ NOT NULL,
+    last_timestamp TIMESTAMPTZ NOT NULL,
+
+    -- The log levels the archive was taken for.
+    levels JSONB NOT NULL DEFAULT '[]',
+
+    -- The archived file.
+    event_count BIGINT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+
+    -- When the events were archived.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for finding a project's archives that overlap a time range.
+CREATE INDEX IF NOT EXISTS idx_log_archives_project_range ON log_archives(project_id, first_timestamp, last_timestamp);
+
+-- Create log_rehydrations table
+-- A rehydration loads archived events back into a temporary store where they can be searched
+-- until expires_at.
+CREATE TABLE IF NOT EXISTS log_rehydrations (
+    -- Unique identifier for the rehydration, using UUID.
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The project whose archives are loaded.
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- The user who requested the rehydration; cleared if the user is purged.
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- The time range loaded, from inclusive and to exclusive.
+    from_time TIMESTAMPTZ NOT NULL,
+    to_time TIMESTAMPTZ NOT NULL,
+
+    -- pending, running, ready, failed or expired.
+    status VARCHAR(20) NOT NULL,
+
+    -- How often a worker has started loading the events, and until when the current worker holds it.
+    attempts INTEGER NOT NULL DEFAULT 0,
+    lease_expires_at TIMESTAMPTZ,
+
+    -- How many events were loaded.
+    event_count BIGINT NOT NULL DEFAULT 0,
+
+    -- Why a failed rehydration failed.
+    error TEXT NOT NULL DEFAULT '',
+
+    -- When loading finished and when the loaded events are removed.
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+
+    -- Standard timestamps managed by PostgreSQL.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create an index for listing a project's rehydrations, newest first.
+CREATE INDEX IF NOT EXISTS idx_log_rehydrations_project_created_at ON log_rehydrations(project_id, created_at DESC);
+
+-- Create an index for workers looking for rehydrations to load or expire.
+CREATE INDEX IF NOT EXISTS idx_log_rehydrations_status ON log_rehydrations(status);
+
+-- Add comments for documentation
+COMMENT ON TABLE log_archives IS 'Files of expired log events archived to object storage, one per project and UTC day';
+COMMENT ON TABLE log_rehydrations IS 'Archived log events loaded back into a temporary searchable store';
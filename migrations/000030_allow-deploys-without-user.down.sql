@@ -0,0 +1,5 @@
+-- Deploys without a user cannot satisfy the constraint again, so they are removed.
+DELETE FROM deploys WHERE created_by_id IS NULL;
+ALTER TABLE deploys ALTER COLUMN created_by_id SET NOT NULL;
+
+COMMENT ON COLUMN deploys.created_by_id IS NULL;
@@ -0,0 +1,4 @@
+-- Deploys recorded with the project API key, e.g. by the CLI, have no user.
+ALTER TABLE deploys ALTER COLUMN created_by_id DROP NOT NULL;
+
+COMMENT ON COLUMN deploys.created_by_id IS 'User who recorded the deploy; NULL when recorded with the project API key';
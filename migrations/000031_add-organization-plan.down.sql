@@ -0,0 +1,2 @@
+-- Remove the organization's plan
+ALTER TABLE organizations DROP COLUMN IF EXISTS plan;
@@ -0,0 +1,5 @@
+-- Add the organization's plan, which caps how far back a single log search may reach.
+-- Existing organizations start on the free plan.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS plan VARCHAR(20) NOT NULL DEFAULT 'free';
+
+COMMENT ON COLUMN organizations.plan IS 'Plan of the organization: free, team or enterprise';
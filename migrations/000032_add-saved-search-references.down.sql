@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP COLUMN IF EXISTS saved_search_id;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS saved_search_id;
@@ -0,0 +1,7 @@
+-- Let alert rules and incidents reference the organization saved search their log query
+-- was taken from. The reference is cleared if the saved search row is removed.
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS saved_search_id UUID REFERENCES saved_searches(id) ON DELETE SET NULL;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS saved_search_id UUID REFERENCES saved_searches(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN alert_rules.saved_search_id IS 'Saved search the rule''s query was copied from, if any';
+COMMENT ON COLUMN incidents.saved_search_id IS 'Saved search the pinned log query was copied from, if any';
@@ -0,0 +1,5 @@
+UPDATE alert_rules SET query = saved_searches.query
+FROM saved_searches
+WHERE alert_rules.saved_search_id = saved_searches.id;
+
+COMMENT ON COLUMN alert_rules.saved_search_id IS 'Saved search the rule''s query was copied from, if any';
@@ -0,0 +1,5 @@
+-- Alert rules that reference a saved search now read its query at every evaluation instead of
+-- keeping a copy, so clear the copies taken when the rules were saved.
+UPDATE alert_rules SET query = '' WHERE saved_search_id IS NOT NULL;
+
+COMMENT ON COLUMN alert_rules.saved_search_id IS 'Saved search whose current query the rule uses, if any';